@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// subcommand is a non-interactive mode run instead of the UI
+type subcommand struct {
+	usage string
+	run   func(app *AppConfig, args []string) error
+}
+
+// subcommands maps the first command line argument to its mode
+var subcommands = map[string]subcommand{
+	"dns":    {usage: dnsUsage, run: runDNS},
+	"dyndns": {usage: dyndnsUsage, run: runDynDNS},
+}
+
+// runSubcommand runs the mode named by the first argument
+func runSubcommand(app *AppConfig, args []string) error {
+	cmd, ok := subcommands[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown command %q, expected one of: %s", args[0], strings.Join(subcommandNames(), ", "))
+	}
+	return cmd.run(app, args[1:])
+}
+
+// subcommandNames returns the subcommand names in order
+func subcommandNames() []string {
+	names := make([]string, 0, len(subcommands))
+	for name := range subcommands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// usage prints the global flags and the subcommands
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "Usage: %s [flags] [command]\n\nWithout a command, the terminal UI starts.\n\nFlags:\n",
+		os.Args[0])
+	flag.PrintDefaults()
+	fmt.Fprintln(out, "\nCommands:")
+	for _, name := range subcommandNames() {
+		fmt.Fprintf(out, "  %s\n", subcommands[name].usage)
+	}
+}
+
+// confirm asks a yes/no question on the terminal, defaulting to no
+func confirm(question string) (bool, error) {
+	fmt.Printf("%s [y/N] ", question)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && answer == "" {
+		return false, fmt.Errorf("failed to read the answer: %w", err)
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	}
+	return false, nil
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+
+	"ovh-terminal/internal/commands"
+)
+
+const dnsUsage = "dns plan|apply [-file dns.toml] [-ignore-unmanaged] [-yes]"
+
+// runDNS shows or applies the changes between a records file and the
+// live zones
+func runDNS(app *AppConfig, args []string) error {
+	if len(args) == 0 || (args[0] != "plan" && args[0] != "apply") {
+		return fmt.Errorf("usage: ovh-terminal %s", dnsUsage)
+	}
+	action := args[0]
+
+	flags := flag.NewFlagSet("dns "+action, flag.ContinueOnError)
+	file := flags.String("file", "dns.toml", "records file, in TOML or YAML")
+	ignoreUnmanaged := flags.Bool("ignore-unmanaged", false,
+		"leave live records alone when their subdomain and type are not in the file")
+	yes := flags.Bool("yes", false, "apply without asking for confirmation")
+	if err := flags.Parse(args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	if action == "plan" {
+		plan := commands.NewPlanDNSCommand(app.APIClient, *ignoreUnmanaged)
+		if err := plan.SetInput("file", *file); err != nil {
+			return err
+		}
+		output, err := plan.Execute()
+		if err != nil {
+			return err
+		}
+		fmt.Println(output)
+		return nil
+	}
+
+	apply := commands.NewApplyDNSCommand(app.APIClient, *ignoreUnmanaged)
+	if err := apply.SetInput("file", *file); err != nil {
+		return err
+	}
+	plan, err := apply.Plan()
+	if err != nil {
+		return err
+	}
+	fmt.Println(plan.String())
+	if plan.Empty() {
+		return nil
+	}
+
+	if !*yes {
+		prompt, err := apply.NextPrompt()
+		if err != nil {
+			return err
+		}
+		ok, err := confirm(prompt.Label)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Nothing applied.")
+			return nil
+		}
+	}
+
+	output, err := apply.Execute()
+	if err != nil {
+		return err
+	}
+	fmt.Println(output)
+	return nil
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"ovh-terminal/internal/dyndns"
+)
+
+const dyndnsUsage = "dyndns [-once]"
+
+// runDynDNS keeps the records of the [dyndns] section pointing to the
+// public address until interrupted
+func runDynDNS(app *AppConfig, args []string) error {
+	flags := flag.NewFlagSet("dyndns", flag.ContinueOnError)
+	once := flags.Bool("once", false, "check the address once and exit")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	// The updater runs unattended, its log goes to stderr as well
+	app.Logger.SetConsole(true)
+	log := app.Logger.With(map[string]interface{}{"command": "dyndns"})
+
+	updater, err := dyndns.NewUpdater(app.APIClient, app.Config.DynDNS, log)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if *once {
+		return updater.Check(ctx)
+	}
+	return updater.Run(ctx)
+}
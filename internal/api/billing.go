@@ -0,0 +1,224 @@
+// internal/api/billing.go
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Price is an amount in the account currency
+type Price struct {
+	CurrencyCode string  `json:"currencyCode"`
+	Text         string  `json:"text"`
+	Value        float64 `json:"value"`
+}
+
+// Bill is an invoice of the account
+type Bill struct {
+	BillID          string     `json:"billId"`
+	Date            *time.Time `json:"date"`
+	OrderID         int64      `json:"orderId"`
+	Category        string     `json:"category"`
+	Password        string     `json:"password"`
+	PdfURL          string     `json:"pdfUrl"`
+	URL             string     `json:"url"`
+	PriceWithTax    Price      `json:"priceWithTax"`
+	PriceWithoutTax Price      `json:"priceWithoutTax"`
+	Tax             Price      `json:"tax"`
+}
+
+// BillDetail is a line of an invoice. Periods are plain dates.
+type BillDetail struct {
+	BillDetailID string `json:"billDetailId"`
+	Description  string `json:"description"`
+	Domain       string `json:"domain"`
+	PeriodStart  string `json:"periodStart"`
+	PeriodEnd    string `json:"periodEnd"`
+	Quantity     string `json:"quantity"`
+	TotalPrice   Price  `json:"totalPrice"`
+	UnitPrice    Price  `json:"unitPrice"`
+}
+
+// BillPayment is the payment of a settled invoice
+type BillPayment struct {
+	PaymentDate       *time.Time `json:"paymentDate"`
+	PaymentIdentifier string     `json:"paymentIdentifier"`
+	PaymentType       string     `json:"paymentType"`
+}
+
+// ListBills returns the IDs of the bills issued between from and to.
+// Zero times leave the range open.
+func (c *Client) ListBills(from, to time.Time) ([]string, error) {
+	endpoint := NewEndpointBuilder(ResourceBilling)
+	if !from.IsZero() {
+		endpoint.WithParameter("date.from", from.Format("2006-01-02"))
+	}
+	if !to.IsZero() {
+		endpoint.WithParameter("date.to", to.Format("2006-01-02"))
+	}
+
+	var ids []string
+	if err := c.Get(endpoint.Build(), &ids); err != nil {
+		return nil, fmt.Errorf("failed to list bills: %w", err)
+	}
+	return ids, nil
+}
+
+// GetBill fetches a bill
+func (c *Client) GetBill(billID string) (*Bill, error) {
+	var bill Bill
+	if err := c.Get(GetBillingEndpoint(billID), &bill); err != nil {
+		return nil, fmt.Errorf("failed to get bill %s: %w", billID, err)
+	}
+	return &bill, nil
+}
+
+// ListBillDetails returns the line IDs of a bill
+func (c *Client) ListBillDetails(billID string) ([]string, error) {
+	var ids []string
+	endpoint := NewEndpointBuilder(ResourceBilling).WithID(billID).WithSegment("details").Build()
+	if err := c.Get(endpoint, &ids); err != nil {
+		return nil, fmt.Errorf("failed to list details of bill %s: %w", billID, err)
+	}
+	return ids, nil
+}
+
+// GetBillDetail fetches a line of a bill
+func (c *Client) GetBillDetail(billID, detailID string) (*BillDetail, error) {
+	var detail BillDetail
+	endpoint := NewEndpointBuilder(ResourceBilling).
+		WithID(billID).
+		WithSegment("details").
+		WithID(detailID).
+		Build()
+	if err := c.Get(endpoint, &detail); err != nil {
+		return nil, fmt.Errorf("failed to get detail %s of bill %s: %w", detailID, billID, err)
+	}
+	return &detail, nil
+}
+
+// GetBillPayment fetches the payment of a bill. Unpaid bills have no
+// payment and return nil without error.
+func (c *Client) GetBillPayment(billID string) (*BillPayment, error) {
+	var payment BillPayment
+	endpoint := NewEndpointBuilder(ResourceBilling).WithID(billID).WithSegment("payment").Build()
+	if err := c.Get(endpoint, &payment); err != nil {
+		if IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get payment of bill %s: %w", billID, err)
+	}
+	if payment.PaymentDate == nil {
+		return nil, nil
+	}
+	return &payment, nil
+}
+
+// Download fetches a document linked from the API, such as an invoice.
+// These links carry their own access token and are not signed, so errors
+// only name the scheme, host and path.
+func (c *Client) Download(link string) ([]byte, error) {
+	client := &http.Client{Transport: c.downloadTransport(), Timeout: c.timeout}
+	resp, err := openDownload(context.Background(), client, link)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", displayURL(link), err)
+	}
+	return data, nil
+}
+
+// DownloadTo streams a linked document, such as a database dump, into w
+// and returns its size. It is bounded by ctx only, large files outlast the
+// API timeout. progress receives the bytes written so far and the expected
+// size, -1 when unknown.
+func (c *Client) DownloadTo(
+	ctx context.Context,
+	link string,
+	w io.Writer,
+	progress func(written, total int64),
+) (int64, error) {
+	client := &http.Client{Transport: c.downloadTransport()}
+	resp, err := openDownload(ctx, client, link)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if progress != nil {
+		w = &progressWriter{w: w, total: resp.ContentLength, report: progress}
+	}
+	written, err := io.Copy(w, resp.Body)
+	if err != nil {
+		return written, fmt.Errorf("failed to download %s: %w", displayURL(link), err)
+	}
+	return written, nil
+}
+
+// downloadTransport returns the transport of linked documents. They are
+// never recorded: cassettes hold API exchanges, not invoices or dumps, and
+// recording would buffer the whole file.
+func (c *Client) downloadTransport() http.RoundTripper {
+	if recorder, ok := c.transport.(*Recorder); ok {
+		return recorder.next
+	}
+	return c.transport
+}
+
+// openDownload requests a linked document and checks the response status
+func openDownload(ctx context.Context, client *http.Client, link string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid download link %s", displayURL(link))
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		// The client error quotes the whole link
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return nil, fmt.Errorf("failed to download %s: %w", displayURL(link), err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to download %s: %s", displayURL(link), resp.Status)
+	}
+	return resp, nil
+}
+
+// progressWriter reports the bytes written through it
+type progressWriter struct {
+	w       io.Writer
+	written int64
+	total   int64
+	report  func(written, total int64)
+}
+
+// Write implements io.Writer
+func (p *progressWriter) Write(data []byte) (int, error) {
+	n, err := p.w.Write(data)
+	p.written += int64(n)
+	p.report(p.written, p.total)
+	return n, err
+}
+
+// displayURL strips the query and credentials of a link for messages
+func displayURL(link string) string {
+	u, err := url.Parse(link)
+	if err != nil {
+		return "link"
+	}
+	return (&url.URL{Scheme: u.Scheme, Host: u.Host, Path: u.Path}).String()
+}
@@ -0,0 +1,456 @@
+// internal/api/cassette.go
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"ovh-terminal/internal/logger"
+)
+
+// Cassette files are stored one interaction per file, named after the
+// request method and path so that a directory can be reviewed and diffed.
+const (
+	cassetteExt   = ".json"
+	redactedValue = "REDACTED"
+	authTimePath  = "/auth/time"
+)
+
+// sensitiveHeaders are replaced before a request is written to disk
+var sensitiveHeaders = map[string]bool{
+	"Authorization":     true,
+	"Cookie":            true,
+	"Set-Cookie":        true,
+	"X-Ovh-Application": true,
+	"X-Ovh-Consumer":    true,
+	"X-Ovh-Signature":   true,
+	"X-Ovh-Timestamp":   true,
+}
+
+// transientHeaders are dropped since they no longer match a scrubbed body
+var transientHeaders = map[string]bool{
+	"Connection":        true,
+	"Content-Encoding":  true,
+	"Content-Length":    true,
+	"Transfer-Encoding": true,
+}
+
+// sensitiveFields are JSON keys whose values are replaced in recorded bodies
+var sensitiveFields = map[string]bool{
+	"accesstoken":       true,
+	"access_token":      true,
+	"applicationkey":    true,
+	"applicationsecret": true,
+	"appkey":            true,
+	"appsecret":         true,
+	"clientsecret":      true,
+	"client_secret":     true,
+	"consumerkey":       true,
+	"password":          true,
+	"refresh_token":     true,
+	"secret":            true,
+}
+
+// sensitiveParams are query parameters whose values are replaced in
+// recorded queries and links, download links carry their access token
+var sensitiveParams = map[string]bool{
+	"access_token": true,
+	"passwd":       true,
+	"password":     true,
+	"signature":    true,
+	"temp_url_sig": true,
+	"token":        true,
+}
+
+// linkFields are JSON keys holding download links, such as those of bills
+// and database dumps
+var linkFields = map[string]bool{
+	"pdfurl": true,
+	"url":    true,
+}
+
+// pathFields returns the JSON keys redacted in the bodies of a path on top
+// of sensitiveFields, for endpoints returning secrets under generic names
+func pathFields(path string) map[string]bool {
+	switch {
+	case strings.HasPrefix(path, "/cloud/project/") && strings.HasSuffix(path, "/kubeconfig"):
+		// The admin kubeconfig embeds client keys and tokens
+		return map[string]bool{"content": true}
+	}
+	return nil
+}
+
+// Interaction is a single recorded request/response pair
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+	Recorded time.Time        `json:"recorded"`
+}
+
+// RecordedRequest holds the scrubbed parts of an API request
+type RecordedRequest struct {
+	Method  string            `json:"method"`
+	Path    string            `json:"path"`
+	Query   string            `json:"query,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"`
+}
+
+// RecordedResponse holds the scrubbed parts of an API response
+type RecordedResponse struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"`
+}
+
+// Recorder is an http.RoundTripper that forwards requests to the real
+// transport and writes every exchange to a cassette directory
+type Recorder struct {
+	dir  string
+	next http.RoundTripper
+	log  *logger.Logger
+	mu   sync.Mutex
+}
+
+// NewRecorder creates a recorder writing to dir. A nil next transport
+// falls back to http.DefaultTransport.
+func NewRecorder(dir string, next http.RoundTripper) *Recorder {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Recorder{
+		dir:  dir,
+		next: next,
+		log:  logger.Log.With(map[string]interface{}{"component": "recorder"}),
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	path := cassettePath(req)
+	interaction := Interaction{
+		Request: RecordedRequest{
+			Method:  req.Method,
+			Path:    path,
+			Query:   scrubQuery(req.URL.RawQuery),
+			Headers: scrubHeaders(req.Header),
+			Body:    scrubBody(path, req.Header.Get("Content-Type"), reqBody),
+		},
+		Response: RecordedResponse{
+			Status:  resp.StatusCode,
+			Headers: scrubHeaders(resp.Header),
+			Body:    scrubBody(path, resp.Header.Get("Content-Type"), respBody),
+		},
+		Recorded: time.Now().UTC(),
+	}
+
+	if err := r.save(&interaction); err != nil {
+		// A failed write must not break the session being recorded
+		r.log.Error("Failed to write cassette",
+			"path", interaction.Request.Path,
+			"error", err)
+	}
+
+	return resp, nil
+}
+
+// save writes an interaction to its cassette file
+func (r *Recorder) save(interaction *Interaction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := os.MkdirAll(r.dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(interaction, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode interaction: %w", err)
+	}
+
+	name := cassetteName(interaction.Request.Method,
+		interaction.Request.Path, interaction.Request.Query, interaction.Request.Body)
+	return os.WriteFile(filepath.Join(r.dir, name), data, 0o600)
+}
+
+// Replayer is an http.RoundTripper that serves responses from a cassette
+// directory without any network access
+type Replayer struct {
+	dir string
+	log *logger.Logger
+}
+
+// NewReplayer creates a replayer reading from dir
+func NewReplayer(dir string) (*Replayer, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open cassette directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("cassette path %s is not a directory", dir)
+	}
+
+	return &Replayer{
+		dir: dir,
+		log: logger.Log.With(map[string]interface{}{"component": "replayer"}),
+	}, nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	path := cassettePath(req)
+
+	// Recorded server times are stale, answer with the local clock so
+	// request signing keeps a zero delta
+	if req.Method == http.MethodGet && path == authTimePath {
+		body := strconv.FormatInt(time.Now().Unix(), 10)
+		return newResponse(req, http.StatusOK, nil, []byte(body)), nil
+	}
+
+	name := cassetteName(req.Method, path, scrubQuery(req.URL.RawQuery),
+		scrubBody(path, req.Header.Get("Content-Type"), reqBody))
+	data, err := os.ReadFile(filepath.Join(r.dir, name))
+	if err != nil {
+		r.log.Warn("No recorded interaction", "method", req.Method, "path", path)
+		body, _ := json.Marshal(map[string]string{
+			"message": fmt.Sprintf("no recorded interaction for %s %s", req.Method, path),
+		})
+		return newResponse(req, http.StatusNotFound, nil, body), nil
+	}
+
+	var interaction Interaction
+	if err := json.Unmarshal(data, &interaction); err != nil {
+		return nil, fmt.Errorf("invalid cassette %s: %w", name, err)
+	}
+
+	r.log.Debug("Replaying interaction", "method", req.Method, "path", path)
+	return newResponse(req, interaction.Response.Status,
+		interaction.Response.Headers, interaction.Response.Body), nil
+}
+
+// readBody drains a body and replaces it with an in-memory copy
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+
+	data, err := io.ReadAll(*body)
+	(*body).Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body: %w", err)
+	}
+
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+// newResponse builds an HTTP response for a replayed request
+func newResponse(
+	req *http.Request,
+	status int,
+	headers map[string]string,
+	body []byte,
+) *http.Response {
+	header := make(http.Header)
+	header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		header.Set(k, v)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// cassettePath returns the request path without the API version prefix
+// so cassettes do not depend on the endpoint they were recorded against
+func cassettePath(req *http.Request) string {
+	return strings.TrimPrefix(req.URL.Path, "/1.0")
+}
+
+// cassetteName builds a readable, collision-free file name for a request.
+// The scrubbed body is part of the key, so requests to the same path with
+// different payloads are recorded apart.
+func cassetteName(method, path, query string, body json.RawMessage) string {
+	key := method + " " + path
+	if query != "" {
+		key += "?" + query
+	}
+	if len(body) > 0 {
+		key += " " + string(body)
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(key))
+
+	readable := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9',
+			r == '.', r == '-':
+			return r
+		default:
+			return '_'
+		}
+	}, strings.Trim(path, "/"))
+
+	return fmt.Sprintf("%s_%s-%08x%s", strings.ToLower(method), readable, h.Sum32(), cassetteExt)
+}
+
+// scrubHeaders flattens headers and redacts credentials and signatures
+func scrubHeaders(headers http.Header) map[string]string {
+	if len(headers) == 0 {
+		return nil
+	}
+
+	result := make(map[string]string, len(headers))
+	for key, values := range headers {
+		key = http.CanonicalHeaderKey(key)
+		if transientHeaders[key] {
+			continue
+		}
+		if sensitiveHeaders[key] {
+			result[key] = redactedValue
+			continue
+		}
+		result[key] = strings.Join(values, ", ")
+	}
+	return result
+}
+
+// scrubBody redacts secret values from the JSON or form body of a path.
+// Other bodies are stored as a JSON string.
+func scrubBody(path, contentType string, body []byte) json.RawMessage {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	// OAuth2 token requests may send the client secret as a form field
+	if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType == "application/x-www-form-urlencoded" {
+		if values, err := url.ParseQuery(string(body)); err == nil {
+			for key := range values {
+				lower := strings.ToLower(key)
+				if sensitiveParams[lower] || sensitiveFields[lower] {
+					values[key] = []string{redactedValue}
+				}
+			}
+			encoded, _ := json.Marshal(values.Encode())
+			return encoded
+		}
+	}
+
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		encoded, _ := json.Marshal(string(body))
+		return encoded
+	}
+
+	scrubbed, err := json.Marshal(scrubValue(value, pathFields(path)))
+	if err != nil {
+		return nil
+	}
+	return scrubbed
+}
+
+// scrubValue walks a decoded JSON value and redacts sensitive fields and
+// the extra fields of its path
+func scrubValue(value interface{}, extra map[string]bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			lower := strings.ToLower(key)
+			if (sensitiveFields[lower] || extra[lower]) && field != nil {
+				v[key] = redactedValue
+				continue
+			}
+			if link, ok := field.(string); ok && linkFields[lower] {
+				v[key] = scrubLink(link)
+				continue
+			}
+			v[key] = scrubValue(field, extra)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = scrubValue(item, extra)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// scrubQuery redacts the access tokens of a raw query. Queries without
+// one are kept as sent.
+func scrubQuery(raw string) string {
+	if raw == "" {
+		return ""
+	}
+
+	values, err := url.ParseQuery(raw)
+	if err != nil {
+		return redactedValue
+	}
+	redacted := false
+	for key := range values {
+		if sensitiveParams[strings.ToLower(key)] {
+			values[key] = []string{redactedValue}
+			redacted = true
+		}
+	}
+	if !redacted {
+		return raw
+	}
+	return values.Encode()
+}
+
+// scrubLink redacts the access tokens in the query of a link
+func scrubLink(link string) string {
+	u, err := url.Parse(link)
+	if err != nil || u.RawQuery == "" {
+		return link
+	}
+	u.RawQuery = scrubQuery(u.RawQuery)
+	return u.String()
+}
@@ -0,0 +1,311 @@
+// internal/api/cassette_test.go
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ovh-terminal/internal/logger"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+
+	mock := &mockTransport{
+		responses: map[string]interface{}{
+			"/me": mockAccountInfo,
+			"/me/api/application/1": map[string]interface{}{
+				"applicationId":     1,
+				"applicationKey":    "very-secret-key",
+				"applicationSecret": "even-more-secret",
+				"name":              "terminal",
+			},
+		},
+		errors: make(map[string]int),
+	}
+
+	recording, err := NewClient(testAccount, logger.NewLogger(),
+		WithTransport(mock), WithRecorder(dir))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	if _, err := recording.GetAccountInfo(); err != nil {
+		t.Fatalf("GetAccountInfo failed while recording: %v", err)
+	}
+	var app map[string]interface{}
+	if err := recording.Get("/me/api/application/1", &app); err != nil {
+		t.Fatalf("Get failed while recording: %v", err)
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir failed: %v", err)
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			t.Fatalf("ReadFile failed: %v", err)
+		}
+		for _, secret := range []string{
+			testAccount.AppKey, testAccount.ConsumerKey,
+			"very-secret-key", "even-more-secret", "$1$",
+		} {
+			if strings.Contains(string(data), secret) {
+				t.Errorf("Cassette %s leaks %q", file.Name(), secret)
+			}
+		}
+	}
+
+	replayer, err := NewReplayer(dir)
+	if err != nil {
+		t.Fatalf("NewReplayer failed: %v", err)
+	}
+	replaying, err := NewClient(testAccount, logger.NewLogger(), WithTransport(replayer))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	info, err := replaying.GetAccountInfo()
+	if err != nil {
+		t.Fatalf("GetAccountInfo failed while replaying: %v", err)
+	}
+	if info.Email != mockAccountInfo.Email {
+		t.Errorf("Expected email %s, got %s", mockAccountInfo.Email, info.Email)
+	}
+
+	if _, err := replaying.ListDomains(); err == nil {
+		t.Error("Expected error for unrecorded request but got nil")
+	}
+}
+
+func TestRecordScrubsKubeconfig(t *testing.T) {
+	dir := t.TempDir()
+
+	mock := &mockTransport{
+		responses: map[string]interface{}{
+			"/cloud/project/p1/kube/k1/kubeconfig": map[string]string{
+				"content": "users:\n- user:\n    client-key-data: c2VjcmV0LWtleQ==\n",
+			},
+		},
+		errors: make(map[string]int),
+	}
+
+	client, err := NewClient(testAccount, logger.NewLogger(),
+		WithTransport(mock), WithRecorder(dir))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	config, err := client.GetKubeconfig("p1", "k1")
+	if err != nil {
+		t.Fatalf("GetKubeconfig failed while recording: %v", err)
+	}
+	if !strings.Contains(config.Content, "client-key-data") {
+		t.Errorf("Expected the caller to get the kubeconfig, got %q", config.Content)
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir failed: %v", err)
+	}
+	found := false
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			t.Fatalf("ReadFile failed: %v", err)
+		}
+		if strings.Contains(string(data), "client-key-data") {
+			t.Errorf("Cassette %s leaks the kubeconfig", file.Name())
+		}
+		if strings.Contains(file.Name(), "kubeconfig") {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected a cassette for the kubeconfig request")
+	}
+}
+
+func TestRecordScrubsDownloadLinks(t *testing.T) {
+	dir := t.TempDir()
+
+	link := "https://www.ovh.com/cgi-bin/order/facture.pdf?reference=FR1&passwd=topsecret"
+	mock := &mockTransport{
+		responses: map[string]interface{}{
+			"/me/bill/FR1": map[string]interface{}{
+				"billId": "FR1",
+				"pdfUrl": link,
+				"url":    "https://www.ovh.com/cgi-bin/order/facture.html?reference=FR1&passwd=topsecret",
+			},
+			"/cgi-bin/order/facture.pdf": "pdf",
+		},
+		errors: map[string]int{"/cgi-bin/order/facture.html": 403},
+	}
+
+	recording, err := NewClient(testAccount, logger.NewLogger(),
+		WithTransport(mock), WithRecorder(dir))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	bill, err := recording.GetBill("FR1")
+	if err != nil {
+		t.Fatalf("GetBill failed while recording: %v", err)
+	}
+	if bill.PdfURL != link {
+		t.Errorf("Expected the caller to get %s, got %s", link, bill.PdfURL)
+	}
+	if _, err := recording.Download(bill.PdfURL); err != nil {
+		t.Fatalf("Download failed while recording: %v", err)
+	}
+
+	_, err = recording.Download(bill.URL)
+	if err == nil {
+		t.Fatal("Expected error for forbidden download but got nil")
+	}
+	if strings.Contains(err.Error(), "topsecret") {
+		t.Errorf("Download error leaks the link token: %v", err)
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir failed: %v", err)
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			t.Fatalf("ReadFile failed: %v", err)
+		}
+		if strings.Contains(string(data), "topsecret") {
+			t.Errorf("Cassette %s leaks the link token", file.Name())
+		}
+	}
+
+	replayer, err := NewReplayer(dir)
+	if err != nil {
+		t.Fatalf("NewReplayer failed: %v", err)
+	}
+	replaying, err := NewClient(testAccount, logger.NewLogger(), WithTransport(replayer))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	bill, err = replaying.GetBill("FR1")
+	if err != nil {
+		t.Fatalf("GetBill failed while replaying: %v", err)
+	}
+	if !strings.Contains(bill.PdfURL, "passwd=REDACTED") {
+		t.Errorf("Expected a scrubbed link, got %s", bill.PdfURL)
+	}
+}
+
+func TestRecordSkipsDownloads(t *testing.T) {
+	dir := t.TempDir()
+
+	mock := &mockTransport{
+		responses: map[string]interface{}{"/cgi-bin/order/facture.pdf": "pdf"},
+		errors:    make(map[string]int),
+	}
+	client, err := NewClient(testAccount, logger.NewLogger(),
+		WithTransport(mock), WithRecorder(dir))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	var buf strings.Builder
+	link := "https://www.ovh.com/cgi-bin/order/facture.pdf?reference=FR1&passwd=topsecret"
+	if _, err := client.DownloadTo(context.Background(), link, &buf, nil); err != nil {
+		t.Fatalf("DownloadTo failed while recording: %v", err)
+	}
+	if buf.Len() == 0 {
+		t.Error("Expected the document content")
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatalf("ReadDir failed: %v", err)
+	}
+	for _, file := range files {
+		if strings.Contains(file.Name(), "facture") {
+			t.Errorf("Expected downloads not to be recorded, got %s", file.Name())
+		}
+	}
+}
+
+// echoTransport answers every request with its own body
+type echoTransport struct{}
+
+func (echoTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, _ := io.ReadAll(req.Body)
+	headers := map[string]string{"Content-Type": req.Header.Get("Content-Type")}
+	return newResponse(req, http.StatusOK, headers, body), nil
+}
+
+func TestRecordScrubsFormBodies(t *testing.T) {
+	dir := t.TempDir()
+	recorder := NewRecorder(dir, echoTransport{})
+
+	form := url.Values{"grant_type": {"client_credentials"}, "client_secret": {"form-secret"}}
+	req, _ := http.NewRequest(http.MethodPost, "https://www.ovh.com/auth/oauth2/token",
+		strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := recorder.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip failed: %v", err)
+	}
+	resp.Body.Close()
+
+	files, err := os.ReadDir(dir)
+	if err != nil || len(files) != 1 {
+		t.Fatalf("Expected one cassette, got %v (err %v)", files, err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, files[0].Name()))
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if strings.Contains(string(data), "form-secret") {
+		t.Errorf("Cassette leaks the client secret:\n%s", data)
+	}
+	if !strings.Contains(string(data), "client_credentials") {
+		t.Errorf("Expected other form fields to be kept:\n%s", data)
+	}
+}
+
+func TestReplayKeysOnBody(t *testing.T) {
+	dir := t.TempDir()
+	recorder := NewRecorder(dir, echoTransport{})
+
+	send := func(rt http.RoundTripper, body string) string {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodPost, "https://eu.api.ovh.com/1.0/domain/zone/example.com/record",
+			strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := rt.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("RoundTrip failed: %v", err)
+		}
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+		return string(data)
+	}
+
+	send(recorder, `{"subDomain":"www"}`)
+	send(recorder, `{"subDomain":"mail"}`)
+
+	replayer, err := NewReplayer(dir)
+	if err != nil {
+		t.Fatalf("NewReplayer failed: %v", err)
+	}
+	for _, name := range []string{"www", "mail"} {
+		if got := send(replayer, `{"subDomain":"`+name+`"}`); !strings.Contains(got, `"`+name+`"`) {
+			t.Errorf("Expected the response recorded for %s, got %s", name, got)
+		}
+	}
+}
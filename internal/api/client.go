@@ -2,13 +2,17 @@
 package api
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
+	"sync"
 	"time"
 
 	"ovh-terminal/internal/config"
 	"ovh-terminal/internal/logger"
 
 	ovh "github.com/ovh/go-ovh/ovh"
+	"golang.org/x/oauth2"
 )
 
 // ClientOption defines a function type for configuring the client
@@ -24,10 +28,15 @@ type RetryConfig struct {
 
 // Client wraps the OVH API client with additional functionality
 type Client struct {
-	client  *ovh.Client
-	logger  *logger.Logger
-	retry   RetryConfig
-	timeout time.Duration
+	mu        sync.RWMutex
+	client    *ovh.Client
+	account   *config.AccountConfig
+	transport http.RoundTripper
+	logger    *logger.Logger
+	retry     RetryConfig
+	timeout   time.Duration
+	authMode  string
+	clockSkew time.Duration
 }
 
 // Default configuration values
@@ -52,6 +61,20 @@ func WithTimeout(timeout time.Duration) ClientOption {
 	}
 }
 
+// WithTransport replaces the HTTP transport used for API requests
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(c *Client) {
+		c.transport = transport
+	}
+}
+
+// WithRecorder records every API exchange to cassette files in dir
+func WithRecorder(dir string) ClientOption {
+	return func(c *Client) {
+		c.transport = NewRecorder(dir, c.transport)
+	}
+}
+
 // NewClient creates a new OVH API client
 func NewClient(
 	cfg *config.AccountConfig,
@@ -62,23 +85,20 @@ func NewClient(
 		return nil, fmt.Errorf("account configuration is required")
 	}
 
-	// Create client with configuration
-	client, err := ovh.NewClient(
-		cfg.Endpoint,
-		cfg.AppKey,
-		cfg.AppSecret,
-		cfg.ConsumerKey,
-	)
+	// Apply CA bundle and proxy settings
+	transport, err := newTransport(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create OVH client: %w", err)
+		return nil, fmt.Errorf("failed to configure HTTP transport: %w", err)
 	}
 
 	// Create wrapped client with default settings
 	c := &Client{
-		client:  client,
-		logger:  log,
-		retry:   defaultRetryConfig,
-		timeout: time.Second * 30,
+		account:   cfg,
+		transport: transport,
+		logger:    log,
+		retry:     defaultRetryConfig,
+		timeout:   time.Second * 30,
+		authMode:  cfg.AuthMode(),
 	}
 
 	// Apply options
@@ -86,9 +106,37 @@ func NewClient(
 		opt(c)
 	}
 
+	// Create client for the configured authentication mode
+	switch c.authMode {
+	case config.AuthOAuth2:
+		c.client, err = newOAuth2Client(cfg, c.transport)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		c.client, err = c.newSignedClient()
+		if err != nil {
+			return nil, err
+		}
+
+		// Signatures embed a timestamp, apply the API time delta up front.
+		// Requests will report the failure if the API is unreachable.
+		if err := c.syncClock(); err != nil {
+			c.logger.Debug("Clock synchronization failed", "error", err)
+		}
+	}
+
 	return c, nil
 }
 
+// api returns the current OVH client, which is replaced when the local
+// clock is resynchronized
+func (c *Client) api() *ovh.Client {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.client
+}
+
 // shouldRetry determines if a request should be retried
 func (c *Client) shouldRetry(err error, attempt int) bool {
 	if attempt >= c.retry.MaxRetries {
@@ -116,7 +164,7 @@ func (c *Client) calculateDelay(attempt int) time.Duration {
 }
 
 // executeWithRetry handles request execution with retry logic
-func (c *Client) executeWithRetry(operation string, fn func() error) error {
+func (c *Client) executeWithRetry(method, operation string, fn func() error) error {
 	var lastErr error
 
 	for attempt := 0; attempt < c.retry.MaxRetries; attempt++ {
@@ -140,15 +188,22 @@ func (c *Client) executeWithRetry(operation string, fn func() error) error {
 		}
 	}
 
-	return c.handleAPIError("GET", operation, lastErr)
+	// Retry once with a fresh time delta if the local clock moved
+	if c.isClockError(lastErr) && c.resyncClock() {
+		if lastErr = fn(); lastErr == nil {
+			return nil
+		}
+	}
+
+	return c.handleAPIError(method, operation, lastErr)
 }
 
 // Get performs a GET request to the OVH API
 func (c *Client) Get(path string, result interface{}) error {
 	c.logger.Debug("Making GET request", "path", path)
 
-	return c.executeWithRetry(path, func() error {
-		return c.client.Get(path, result)
+	return c.executeWithRetry("GET", path, func() error {
+		return c.api().Get(path, result)
 	})
 }
 
@@ -156,8 +211,26 @@ func (c *Client) Get(path string, result interface{}) error {
 func (c *Client) Post(path string, payload interface{}, result interface{}) error {
 	c.logger.Debug("Making POST request", "path", path)
 
-	return c.executeWithRetry(path, func() error {
-		return c.client.Post(path, payload, result)
+	return c.executeWithRetry("POST", path, func() error {
+		return c.api().Post(path, payload, result)
+	})
+}
+
+// Put performs a PUT request to the OVH API
+func (c *Client) Put(path string, payload interface{}, result interface{}) error {
+	c.logger.Debug("Making PUT request", "path", path)
+
+	return c.executeWithRetry("PUT", path, func() error {
+		return c.api().Put(path, payload, result)
+	})
+}
+
+// Delete performs a DELETE request to the OVH API
+func (c *Client) Delete(path string, result interface{}) error {
+	c.logger.Debug("Making DELETE request", "path", path)
+
+	return c.executeWithRetry("DELETE", path, func() error {
+		return c.api().Delete(path, result)
 	})
 }
 
@@ -167,10 +240,36 @@ func (c *Client) handleAPIError(method, path string, err error) error {
 		return nil
 	}
 
+	// Token refresh failures surface from the oauth2 transport
+	var tokenErr *oauth2.RetrieveError
+	if errors.As(err, &tokenErr) {
+		authErr := NewAuthError("Failed to refresh OAuth2 access token", err)
+		authErr.AuthMode = c.authMode
+		return authErr
+	}
+
+	if c.isClockError(err) {
+		authErr := NewAuthError("Request signature rejected", err)
+		authErr.AuthMode = c.authMode
+		if skew, skewErr := c.measureClockSkew(); skewErr == nil {
+			// A stable offset is already corrected, only blame the clock
+			// when it moved since the delta was computed
+			c.mu.RLock()
+			applied := c.clockSkew
+			c.mu.RUnlock()
+			if absDuration(skew-applied) >= clockTolerance {
+				authErr.ClockSkew = skew
+			}
+		}
+		return authErr
+	}
+
 	if ovhErr, ok := err.(*ovh.APIError); ok {
 		switch ovhErr.Code {
 		case 401, 403:
-			return NewAuthError("Authentication failed", err)
+			authErr := NewAuthError("Authentication failed", err)
+			authErr.AuthMode = c.authMode
+			return authErr
 		default:
 			return NewAPIError("API request failed", err, map[string]interface{}{
 				"status": ovhErr.Code,
@@ -0,0 +1,128 @@
+// internal/api/clock.go
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"ovh-terminal/internal/config"
+
+	ovh "github.com/ovh/go-ovh/ovh"
+)
+
+// clockTolerance is the skew below which the local clock is considered
+// in sync with the API, /auth/time only has a one second resolution
+const clockTolerance = 5 * time.Second
+
+// clockErrorMessages identify signature rejections caused by the request
+// timestamp rather than by the credentials
+var clockErrorMessages = []string{"timestamp", "signature", "out of time"}
+
+// newSignedClient creates an OVH client signing requests with the
+// account application and consumer keys
+func (c *Client) newSignedClient() (*ovh.Client, error) {
+	client, err := ovh.NewClient(
+		c.account.Endpoint,
+		c.account.AppKey,
+		c.account.AppSecret,
+		c.account.ConsumerKey,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OVH client: %w", err)
+	}
+	client.Client.Transport = c.transport
+	return client, nil
+}
+
+// syncClock queries /auth/time and stores the delta the client applies
+// to request timestamps
+func (c *Client) syncClock() error {
+	delta, err := c.api().TimeDelta()
+	if err != nil {
+		return fmt.Errorf("failed to query API time: %w", err)
+	}
+
+	c.mu.Lock()
+	c.clockSkew = delta
+	c.mu.Unlock()
+
+	if absDuration(delta) >= clockTolerance {
+		c.logger.Warn("Local clock differs from API time, correcting request timestamps",
+			"offset", delta.Round(time.Second).String())
+	}
+	return nil
+}
+
+// measureClockSkew returns how far the local clock is ahead of the API
+func (c *Client) measureClockSkew() (time.Duration, error) {
+	serverTime, err := c.api().Time()
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(time.Now().Unix()-serverTime.Unix()) * time.Second, nil
+}
+
+// resyncClock recreates the signed client when the local clock moved
+// since the delta was computed. It reports whether requests should be
+// retried.
+func (c *Client) resyncClock() bool {
+	skew, err := c.measureClockSkew()
+	if err != nil {
+		return false
+	}
+
+	c.mu.RLock()
+	applied := c.clockSkew
+	c.mu.RUnlock()
+	if absDuration(skew-applied) < clockTolerance {
+		return false
+	}
+
+	client, err := c.newSignedClient()
+	if err != nil {
+		return false
+	}
+	c.mu.Lock()
+	c.client = client
+	c.mu.Unlock()
+
+	c.logger.Warn("Local clock changed, resynchronizing with API time",
+		"previous", applied.Round(time.Second).String(),
+		"offset", skew.String())
+	return c.syncClock() == nil
+}
+
+// isClockError reports whether the API rejected a signed request because
+// of its timestamp or signature
+func (c *Client) isClockError(err error) bool {
+	if c.authMode != config.AuthConsumerKey {
+		return false
+	}
+
+	var ovhErr *ovh.APIError
+	if !errors.As(err, &ovhErr) {
+		return false
+	}
+	if ovhErr.Code != http.StatusUnauthorized && ovhErr.Code != http.StatusBadRequest {
+		return false
+	}
+
+	msg := strings.ToLower(ovhErr.Message)
+	for _, pattern := range clockErrorMessages {
+		if strings.Contains(msg, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// absDuration returns the absolute value of d
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
@@ -0,0 +1,343 @@
+// internal/api/cloud.go
+package api
+
+import (
+	"fmt"
+	"time"
+)
+
+// CloudProject represents a Public Cloud project
+type CloudProject struct {
+	ProjectID    string     `json:"project_id"`
+	Description  string     `json:"description"`
+	Status       string     `json:"status"`
+	PlanCode     string     `json:"planCode"`
+	CreationDate *time.Time `json:"creationDate"`
+	Expiration   *time.Time `json:"expiration"`
+}
+
+// GetDisplayName returns the description, falling back to the project ID
+func (p *CloudProject) GetDisplayName() string {
+	if p.Description != "" {
+		return p.Description
+	}
+	return p.ProjectID
+}
+
+// CloudIPAddress is an address attached to an instance
+type CloudIPAddress struct {
+	IP        string `json:"ip"`
+	Type      string `json:"type"`
+	Version   int    `json:"version"`
+	NetworkID string `json:"networkId"`
+}
+
+// CloudFlavor is an instance model
+type CloudFlavor struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Region    string `json:"region"`
+	VCPUs     int    `json:"vcpus"`
+	RAM       int    `json:"ram"`
+	Disk      int    `json:"disk"`
+	Type      string `json:"type"`
+	OSType    string `json:"osType"`
+	Available bool   `json:"available"`
+}
+
+// CloudImage is the image an instance was installed from
+type CloudImage struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Region string `json:"region"`
+}
+
+// CloudInstance represents a Public Cloud instance
+type CloudInstance struct {
+	ID          string           `json:"id"`
+	Name        string           `json:"name"`
+	Status      string           `json:"status"`
+	Region      string           `json:"region"`
+	FlavorID    string           `json:"flavorId"`
+	ImageID     string           `json:"imageId"`
+	SSHKeyID    string           `json:"sshKeyId"`
+	Created     *time.Time       `json:"created"`
+	IPAddresses []CloudIPAddress `json:"ipAddresses"`
+	PlanCode    string           `json:"planCode"`
+	Flavor      *CloudFlavor     `json:"flavor,omitempty"`
+	Image       *CloudImage      `json:"image,omitempty"`
+}
+
+// PublicIPs returns the public addresses of the instance
+func (i *CloudInstance) PublicIPs() []string {
+	var ips []string
+	for _, addr := range i.IPAddresses {
+		if addr.Type == "public" {
+			ips = append(ips, addr.IP)
+		}
+	}
+	return ips
+}
+
+// CloudVolume represents a block storage volume
+type CloudVolume struct {
+	ID           string     `json:"id"`
+	Name         string     `json:"name"`
+	Description  string     `json:"description"`
+	Size         int        `json:"size"`
+	Region       string     `json:"region"`
+	Status       string     `json:"status"`
+	Type         string     `json:"type"`
+	Bootable     bool       `json:"bootable"`
+	AttachedTo   []string   `json:"attachedTo"`
+	CreationDate *time.Time `json:"creationDate"`
+}
+
+// CloudSnapshot represents an instance snapshot
+type CloudSnapshot struct {
+	ID           string     `json:"id"`
+	Name         string     `json:"name"`
+	Region       string     `json:"region"`
+	Status       string     `json:"status"`
+	Size         float64    `json:"size"`
+	MinDisk      int        `json:"minDisk"`
+	Type         string     `json:"type"`
+	Visibility   string     `json:"visibility"`
+	CreationDate *time.Time `json:"creationDate"`
+}
+
+// CloudNetworkRegion is the state of a private network in one region
+type CloudNetworkRegion struct {
+	Region      string `json:"region"`
+	Status      string `json:"status"`
+	OpenstackID string `json:"openstackId"`
+}
+
+// CloudNetwork represents a private network (vRack)
+type CloudNetwork struct {
+	ID      string               `json:"id"`
+	Name    string               `json:"name"`
+	Status  string               `json:"status"`
+	Type    string               `json:"type"`
+	VlanID  int                  `json:"vlanId"`
+	Regions []CloudNetworkRegion `json:"regions"`
+}
+
+// CloudSSHKey represents an SSH key stored in a project
+type CloudSSHKey struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	PublicKey   string   `json:"publicKey"`
+	Fingerprint string   `json:"fingerPrint"`
+	Regions     []string `json:"regions"`
+}
+
+// cloudProjectEndpoint builds an endpoint below a project
+func cloudProjectEndpoint(projectID string, segments ...string) string {
+	eb := NewEndpointBuilder(ResourceCloud).WithID(projectID)
+	for _, segment := range segments {
+		eb.WithSegment(segment)
+	}
+	return eb.Build()
+}
+
+// GetCloudProject retrieves a Public Cloud project
+func (c *Client) GetCloudProject(projectID string) (*CloudProject, error) {
+	var project CloudProject
+	err := c.Get(GetCloudProjectEndpoint(projectID), &project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cloud project %s: %w", projectID, err)
+	}
+	return &project, nil
+}
+
+// ListCloudInstances retrieves the instances of a project
+func (c *Client) ListCloudInstances(projectID string) ([]CloudInstance, error) {
+	var instances []CloudInstance
+	err := c.Get(GetCloudProjectActionEndpoint(projectID, "instance"), &instances)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list instances of %s: %w", projectID, err)
+	}
+	return instances, nil
+}
+
+// GetCloudInstance retrieves an instance with its flavor and image
+func (c *Client) GetCloudInstance(projectID, instanceID string) (*CloudInstance, error) {
+	var instance CloudInstance
+	endpoint := cloudProjectEndpoint(projectID, "instance", instanceID)
+	err := c.Get(endpoint, &instance)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get instance %s: %w", instanceID, err)
+	}
+	return &instance, nil
+}
+
+// ListCloudFlavors retrieves the flavors available to a project
+func (c *Client) ListCloudFlavors(projectID string) ([]CloudFlavor, error) {
+	var flavors []CloudFlavor
+	err := c.Get(GetCloudProjectActionEndpoint(projectID, "flavor"), &flavors)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list flavors of %s: %w", projectID, err)
+	}
+	return flavors, nil
+}
+
+// ListCloudVolumes retrieves the volumes of a project
+func (c *Client) ListCloudVolumes(projectID string) ([]CloudVolume, error) {
+	var volumes []CloudVolume
+	err := c.Get(GetCloudProjectActionEndpoint(projectID, "volume"), &volumes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list volumes of %s: %w", projectID, err)
+	}
+	return volumes, nil
+}
+
+// GetCloudVolume retrieves a volume
+func (c *Client) GetCloudVolume(projectID, volumeID string) (*CloudVolume, error) {
+	var volume CloudVolume
+	endpoint := cloudProjectEndpoint(projectID, "volume", volumeID)
+	err := c.Get(endpoint, &volume)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get volume %s: %w", volumeID, err)
+	}
+	return &volume, nil
+}
+
+// ListCloudSnapshots retrieves the instance snapshots of a project
+func (c *Client) ListCloudSnapshots(projectID string) ([]CloudSnapshot, error) {
+	var snapshots []CloudSnapshot
+	err := c.Get(GetCloudProjectActionEndpoint(projectID, "snapshot"), &snapshots)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots of %s: %w", projectID, err)
+	}
+	return snapshots, nil
+}
+
+// GetCloudSnapshot retrieves an instance snapshot
+func (c *Client) GetCloudSnapshot(projectID, snapshotID string) (*CloudSnapshot, error) {
+	var snapshot CloudSnapshot
+	endpoint := cloudProjectEndpoint(projectID, "snapshot", snapshotID)
+	err := c.Get(endpoint, &snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get snapshot %s: %w", snapshotID, err)
+	}
+	return &snapshot, nil
+}
+
+// ListCloudNetworks retrieves the private networks of a project
+func (c *Client) ListCloudNetworks(projectID string) ([]CloudNetwork, error) {
+	var networks []CloudNetwork
+	endpoint := cloudProjectEndpoint(projectID, "network", "private")
+	err := c.Get(endpoint, &networks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list networks of %s: %w", projectID, err)
+	}
+	return networks, nil
+}
+
+// GetCloudNetwork retrieves a private network
+func (c *Client) GetCloudNetwork(projectID, networkID string) (*CloudNetwork, error) {
+	var network CloudNetwork
+	endpoint := cloudProjectEndpoint(projectID, "network", "private", networkID)
+	err := c.Get(endpoint, &network)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network %s: %w", networkID, err)
+	}
+	return &network, nil
+}
+
+// ListCloudSSHKeys retrieves the SSH keys of a project
+func (c *Client) ListCloudSSHKeys(projectID string) ([]CloudSSHKey, error) {
+	var keys []CloudSSHKey
+	err := c.Get(GetCloudProjectActionEndpoint(projectID, "sshkey"), &keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list SSH keys of %s: %w", projectID, err)
+	}
+	return keys, nil
+}
+
+// GetCloudSSHKey retrieves an SSH key
+func (c *Client) GetCloudSSHKey(projectID, keyID string) (*CloudSSHKey, error) {
+	var key CloudSSHKey
+	endpoint := cloudProjectEndpoint(projectID, "sshkey", keyID)
+	err := c.Get(endpoint, &key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get SSH key %s: %w", keyID, err)
+	}
+	return &key, nil
+}
+
+// Instance statuses reported by OpenStack
+const (
+	CloudInstanceActive           = "ACTIVE"
+	CloudInstanceShutoff          = "SHUTOFF"
+	CloudInstanceShelved          = "SHELVED"
+	CloudInstanceShelvedOffloaded = "SHELVED_OFFLOADED"
+	CloudInstanceRescue           = "RESCUE"
+	CloudInstanceError            = "ERROR"
+)
+
+// Reboot types accepted by RebootCloudInstance
+const (
+	CloudRebootSoft = "soft"
+	CloudRebootHard = "hard"
+)
+
+// CloudRescue is returned when an instance enters rescue mode
+type CloudRescue struct {
+	AdminPassword string `json:"adminPassword"`
+}
+
+// cloudInstanceAction posts an action on an instance
+func (c *Client) cloudInstanceAction(projectID, instanceID, action string, payload, result interface{}) error {
+	endpoint := cloudProjectEndpoint(projectID, "instance", instanceID, action)
+	err := c.Post(endpoint, payload, result)
+	if err != nil {
+		return fmt.Errorf("failed to %s instance %s: %w", action, instanceID, err)
+	}
+	return nil
+}
+
+// StartCloudInstance starts a stopped instance
+func (c *Client) StartCloudInstance(projectID, instanceID string) error {
+	return c.cloudInstanceAction(projectID, instanceID, "start", nil, nil)
+}
+
+// StopCloudInstance stops an instance
+func (c *Client) StopCloudInstance(projectID, instanceID string) error {
+	return c.cloudInstanceAction(projectID, instanceID, "stop", nil, nil)
+}
+
+// RebootCloudInstance reboots an instance, rebootType is soft or hard
+func (c *Client) RebootCloudInstance(projectID, instanceID, rebootType string) error {
+	payload := map[string]string{"type": rebootType}
+	return c.cloudInstanceAction(projectID, instanceID, "reboot", payload, nil)
+}
+
+// ShelveCloudInstance shelves an instance, releasing its compute resources
+func (c *Client) ShelveCloudInstance(projectID, instanceID string) error {
+	return c.cloudInstanceAction(projectID, instanceID, "shelve", nil, nil)
+}
+
+// UnshelveCloudInstance restores a shelved instance
+func (c *Client) UnshelveCloudInstance(projectID, instanceID string) error {
+	return c.cloudInstanceAction(projectID, instanceID, "unshelve", nil, nil)
+}
+
+// SetCloudInstanceRescue enters or leaves rescue mode. The admin password
+// of the rescue system is only returned when entering it.
+func (c *Client) SetCloudInstanceRescue(projectID, instanceID string, rescue bool) (*CloudRescue, error) {
+	payload := map[string]bool{"rescue": rescue}
+	var result CloudRescue
+	if err := c.cloudInstanceAction(projectID, instanceID, "rescueMode", payload, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CreateCloudInstanceSnapshot snapshots an instance under a name
+func (c *Client) CreateCloudInstanceSnapshot(projectID, instanceID, name string) error {
+	payload := map[string]string{"snapshotName": name}
+	return c.cloudInstanceAction(projectID, instanceID, "snapshot", payload, nil)
+}
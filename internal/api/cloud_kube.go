@@ -0,0 +1,86 @@
+// internal/api/cloud_kube.go
+package api
+
+import (
+	"fmt"
+	"time"
+)
+
+// KubeCluster is a Managed Kubernetes cluster
+type KubeCluster struct {
+	ID                     string     `json:"id"`
+	Name                   string     `json:"name"`
+	Region                 string     `json:"region"`
+	Version                string     `json:"version"`
+	Status                 string     `json:"status"`
+	URL                    string     `json:"url"`
+	UpdatePolicy           string     `json:"updatePolicy"`
+	IsUpToDate             bool       `json:"isUpToDate"`
+	ControlPlaneIsUpToDate bool       `json:"controlPlaneIsUpToDate"`
+	NextUpgradeVersions    []string   `json:"nextUpgradeVersions"`
+	PrivateNetworkID       string     `json:"privateNetworkId"`
+	CreatedAt              *time.Time `json:"createdAt"`
+	UpdatedAt              *time.Time `json:"updatedAt"`
+}
+
+// KubeNodePool is a group of identical nodes of a cluster
+type KubeNodePool struct {
+	ID             string     `json:"id"`
+	Name           string     `json:"name"`
+	Flavor         string     `json:"flavor"`
+	Status         string     `json:"status"`
+	DesiredNodes   int        `json:"desiredNodes"`
+	CurrentNodes   int        `json:"currentNodes"`
+	AvailableNodes int        `json:"availableNodes"`
+	UpToDateNodes  int        `json:"upToDateNodes"`
+	MinNodes       int        `json:"minNodes"`
+	MaxNodes       int        `json:"maxNodes"`
+	Autoscale      bool       `json:"autoscale"`
+	MonthlyBilled  bool       `json:"monthlyBilled"`
+	CreatedAt      *time.Time `json:"createdAt"`
+}
+
+// Kubeconfig is the client configuration of a cluster
+type Kubeconfig struct {
+	Content string `json:"content"`
+}
+
+// ListKubeClusters returns the Managed Kubernetes cluster IDs of a project
+func (c *Client) ListKubeClusters(projectID string) ([]string, error) {
+	var ids []string
+	err := c.Get(cloudProjectEndpoint(projectID, "kube"), &ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list kubernetes clusters: %w", err)
+	}
+	return ids, nil
+}
+
+// GetKubeCluster fetches a Managed Kubernetes cluster
+func (c *Client) GetKubeCluster(projectID, kubeID string) (*KubeCluster, error) {
+	var cluster KubeCluster
+	err := c.Get(cloudProjectEndpoint(projectID, "kube", kubeID), &cluster)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kubernetes cluster %s: %w", kubeID, err)
+	}
+	return &cluster, nil
+}
+
+// ListKubeNodePools returns the node pools of a cluster
+func (c *Client) ListKubeNodePools(projectID, kubeID string) ([]KubeNodePool, error) {
+	var pools []KubeNodePool
+	err := c.Get(cloudProjectEndpoint(projectID, "kube", kubeID, "nodepool"), &pools)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list node pools of %s: %w", kubeID, err)
+	}
+	return pools, nil
+}
+
+// GetKubeconfig generates the admin kubeconfig of a cluster
+func (c *Client) GetKubeconfig(projectID, kubeID string) (*Kubeconfig, error) {
+	var config Kubeconfig
+	err := c.Post(cloudProjectEndpoint(projectID, "kube", kubeID, "kubeconfig"), nil, &config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kubeconfig of %s: %w", kubeID, err)
+	}
+	return &config, nil
+}
@@ -0,0 +1,70 @@
+// internal/api/cloud_quota.go
+package api
+
+import (
+	"fmt"
+)
+
+// CloudInstanceQuota is the compute quota of a region, RAM is in MB
+type CloudInstanceQuota struct {
+	MaxCores      int `json:"maxCores"`
+	MaxInstances  int `json:"maxInstances"`
+	MaxRAM        int `json:"maxRam"`
+	UsedCores     int `json:"usedCores"`
+	UsedInstances int `json:"usedInstances"`
+	UsedRAM       int `json:"usedRAM"`
+}
+
+// CloudVolumeQuota is the block storage quota of a region
+type CloudVolumeQuota struct {
+	MaxGigabytes   int `json:"maxGigabytes"`
+	UsedGigabytes  int `json:"usedGigabytes"`
+	MaxVolumeCount int `json:"maxVolumeCount"`
+	VolumeCount    int `json:"volumeCount"`
+}
+
+// CloudQuota is the quota of a project in one region
+type CloudQuota struct {
+	Region   string              `json:"region"`
+	Instance *CloudInstanceQuota `json:"instance"`
+	Volume   *CloudVolumeQuota   `json:"volume"`
+}
+
+// CloudRegion is a region of a project
+type CloudRegion struct {
+	Name               string `json:"name"`
+	Status             string `json:"status"`
+	ContinentCode      string `json:"continentCode"`
+	DatacenterLocation string `json:"datacenterLocation"`
+}
+
+// ListCloudQuotas returns the quota of a project in each region
+func (c *Client) ListCloudQuotas(projectID string) ([]CloudQuota, error) {
+	var quotas []CloudQuota
+	err := c.Get(cloudProjectEndpoint(projectID, "quota"), &quotas)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list quotas: %w", err)
+	}
+	return quotas, nil
+}
+
+// GetCloudRegion fetches a region enabled on a project
+func (c *Client) GetCloudRegion(projectID, region string) (*CloudRegion, error) {
+	var result CloudRegion
+	err := c.Get(cloudProjectEndpoint(projectID, "region", region), &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get region %s: %w", region, err)
+	}
+	return &result, nil
+}
+
+// ListAvailableCloudRegions returns the regions that can still be enabled
+// on a project
+func (c *Client) ListAvailableCloudRegions(projectID string) ([]CloudRegion, error) {
+	var regions []CloudRegion
+	err := c.Get(cloudProjectEndpoint(projectID, "regionAvailable"), &regions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list available regions: %w", err)
+	}
+	return regions, nil
+}
@@ -0,0 +1,153 @@
+// internal/api/cloud_storage.go
+package api
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// StorageObject is an object of an S3 container
+type StorageObject struct {
+	Key          string     `json:"key"`
+	Size         int64      `json:"size"`
+	LastModified *time.Time `json:"lastModified"`
+	StorageClass string     `json:"storageClass"`
+}
+
+// StorageContainer is an S3 compatible Object Storage container
+type StorageContainer struct {
+	Name         string          `json:"name"`
+	Region       string          `json:"region"`
+	OwnerID      int             `json:"ownerId"`
+	ObjectsCount int64           `json:"objectsCount"`
+	ObjectsSize  int64           `json:"objectsSize"`
+	CreatedAt    *time.Time      `json:"createdAt"`
+	Objects      []StorageObject `json:"objects,omitempty"`
+}
+
+// CloudUserRole is a role granted to a project user
+type CloudUserRole struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// CloudUser is an OpenStack user of a project
+type CloudUser struct {
+	ID           int             `json:"id"`
+	Username     string          `json:"username"`
+	Description  string          `json:"description"`
+	Status       string          `json:"status"`
+	Roles        []CloudUserRole `json:"roles"`
+	CreationDate *time.Time      `json:"creationDate"`
+}
+
+// S3Credentials is an S3 access key of a user. The secret is only set
+// when the credentials are created or their secret is requested.
+type S3Credentials struct {
+	Access   string `json:"access"`
+	Secret   string `json:"secret,omitempty"`
+	TenantID string `json:"tenantId"`
+	UserID   string `json:"userId"`
+}
+
+// S3Endpoint returns the S3 endpoint of an Object Storage region
+func S3Endpoint(region string) string {
+	return fmt.Sprintf("https://s3.%s.io.cloud.ovh.net", S3Region(region))
+}
+
+// S3Region returns the S3 name of a region, "GRA" or "GRA11" become "gra"
+func S3Region(region string) string {
+	return strings.ToLower(strings.TrimRight(region, "0123456789"))
+}
+
+// ListCloudRegions returns the regions enabled on a project
+func (c *Client) ListCloudRegions(projectID string) ([]string, error) {
+	var regions []string
+	err := c.Get(cloudProjectEndpoint(projectID, "region"), &regions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list regions: %w", err)
+	}
+	return regions, nil
+}
+
+// ListStorageContainers returns the S3 containers of a region
+func (c *Client) ListStorageContainers(projectID, region string) ([]StorageContainer, error) {
+	var containers []StorageContainer
+	err := c.Get(cloudProjectEndpoint(projectID, "region", region, "storage"), &containers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers in %s: %w", region, err)
+	}
+	return containers, nil
+}
+
+// GetStorageContainer fetches an S3 container with its first objects
+func (c *Client) GetStorageContainer(projectID, region, name string) (*StorageContainer, error) {
+	var container StorageContainer
+	err := c.Get(cloudProjectEndpoint(projectID, "region", region, "storage", name), &container)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get container %s: %w", name, err)
+	}
+	return &container, nil
+}
+
+// ListCloudUsers returns the users of a project
+func (c *Client) ListCloudUsers(projectID string) ([]CloudUser, error) {
+	var users []CloudUser
+	err := c.Get(cloudProjectEndpoint(projectID, "user"), &users)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	return users, nil
+}
+
+// GetCloudUser fetches a user of a project
+func (c *Client) GetCloudUser(projectID, userID string) (*CloudUser, error) {
+	var user CloudUser
+	err := c.Get(cloudProjectEndpoint(projectID, "user", userID), &user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user %s: %w", userID, err)
+	}
+	return &user, nil
+}
+
+// ListS3Credentials returns the S3 access keys of a user
+func (c *Client) ListS3Credentials(projectID, userID string) ([]S3Credentials, error) {
+	var credentials []S3Credentials
+	err := c.Get(cloudProjectEndpoint(projectID, "user", userID, "s3Credentials"), &credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list S3 credentials: %w", err)
+	}
+	return credentials, nil
+}
+
+// CreateS3Credentials generates a new S3 access key for a user
+func (c *Client) CreateS3Credentials(projectID, userID string) (*S3Credentials, error) {
+	var credentials S3Credentials
+	err := c.Post(cloudProjectEndpoint(projectID, "user", userID, "s3Credentials"), nil, &credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 credentials: %w", err)
+	}
+	return &credentials, nil
+}
+
+// GetS3Secret fetches the secret of an S3 access key
+func (c *Client) GetS3Secret(projectID, userID, access string) (string, error) {
+	var result struct {
+		Secret string `json:"secret"`
+	}
+	endpoint := cloudProjectEndpoint(projectID, "user", userID, "s3Credentials", access, "secret")
+	if err := c.Post(endpoint, nil, &result); err != nil {
+		return "", fmt.Errorf("failed to get S3 secret: %w", err)
+	}
+	return result.Secret, nil
+}
+
+// DeleteS3Credentials revokes an S3 access key
+func (c *Client) DeleteS3Credentials(projectID, userID, access string) error {
+	endpoint := cloudProjectEndpoint(projectID, "user", userID, "s3Credentials", access)
+	if err := c.Delete(endpoint, nil); err != nil {
+		return fmt.Errorf("failed to delete S3 credentials: %w", err)
+	}
+	return nil
+}
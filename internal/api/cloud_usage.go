@@ -0,0 +1,120 @@
+// internal/api/cloud_usage.go
+package api
+
+import (
+	"fmt"
+	"time"
+)
+
+// Billing modes of usage items
+const (
+	CloudBillingHourly  = "hourly"
+	CloudBillingMonthly = "monthly"
+)
+
+// CloudUsageQuantity is an amount of consumed resource
+type CloudUsageQuantity struct {
+	Unit  string  `json:"unit"`
+	Value float64 `json:"value"`
+}
+
+// CloudUsageEntry is the consumption of a resource reference in a region
+type CloudUsageEntry struct {
+	Region     string              `json:"region"`
+	Reference  string              `json:"reference"`
+	Quantity   *CloudUsageQuantity `json:"quantity"`
+	TotalPrice float64             `json:"totalPrice"`
+}
+
+// CloudHourlyUsage lists hourly billed consumption by resource type
+type CloudHourlyUsage struct {
+	Instance          []CloudUsageEntry `json:"instance"`
+	InstanceBandwidth []CloudUsageEntry `json:"instanceBandwidth"`
+	InstanceOption    []CloudUsageEntry `json:"instanceOption"`
+	Snapshot          []CloudUsageEntry `json:"snapshot"`
+	Storage           []CloudUsageEntry `json:"storage"`
+	Volume            []CloudUsageEntry `json:"volume"`
+}
+
+// CloudMonthlyUsage lists monthly billed consumption by resource type
+type CloudMonthlyUsage struct {
+	Instance       []CloudUsageEntry `json:"instance"`
+	InstanceOption []CloudUsageEntry `json:"instanceOption"`
+	Certification  []CloudUsageEntry `json:"certification"`
+}
+
+// CloudUsagePeriod is the time range covered by a usage report
+type CloudUsagePeriod struct {
+	From *time.Time `json:"from"`
+	To   *time.Time `json:"to"`
+}
+
+// CloudUsage is the current or forecast consumption of a project
+type CloudUsage struct {
+	HourlyUsage  *CloudHourlyUsage  `json:"hourlyUsage"`
+	MonthlyUsage *CloudMonthlyUsage `json:"monthlyUsage"`
+	Period       CloudUsagePeriod   `json:"period"`
+	LastUpdate   *time.Time         `json:"lastUpdate"`
+}
+
+// CloudUsageItem is a usage entry with its billing mode and resource type
+type CloudUsageItem struct {
+	CloudUsageEntry
+	Billing string
+	Type    string
+}
+
+// Items flattens the report into one item per entry
+func (u *CloudUsage) Items() []CloudUsageItem {
+	var items []CloudUsageItem
+	add := func(billing, resource string, entries []CloudUsageEntry) {
+		for _, entry := range entries {
+			items = append(items, CloudUsageItem{CloudUsageEntry: entry, Billing: billing, Type: resource})
+		}
+	}
+
+	if h := u.HourlyUsage; h != nil {
+		add(CloudBillingHourly, "instance", h.Instance)
+		add(CloudBillingHourly, "instanceBandwidth", h.InstanceBandwidth)
+		add(CloudBillingHourly, "instanceOption", h.InstanceOption)
+		add(CloudBillingHourly, "snapshot", h.Snapshot)
+		add(CloudBillingHourly, "storage", h.Storage)
+		add(CloudBillingHourly, "volume", h.Volume)
+	}
+	if m := u.MonthlyUsage; m != nil {
+		add(CloudBillingMonthly, "instance", m.Instance)
+		add(CloudBillingMonthly, "instanceOption", m.InstanceOption)
+		add(CloudBillingMonthly, "certification", m.Certification)
+	}
+	return items
+}
+
+// Total returns the price of all items of the report
+func (u *CloudUsage) Total() float64 {
+	var total float64
+	for _, item := range u.Items() {
+		total += item.TotalPrice
+	}
+	return total
+}
+
+// GetCloudUsage fetches the consumption of the current month so far
+func (c *Client) GetCloudUsage(projectID string) (*CloudUsage, error) {
+	return c.getCloudUsage(projectID, "current")
+}
+
+// GetCloudUsageForecast fetches the consumption expected at the end of
+// the month
+func (c *Client) GetCloudUsageForecast(projectID string) (*CloudUsage, error) {
+	return c.getCloudUsage(projectID, "forecast")
+}
+
+// getCloudUsage fetches a usage report of a project
+func (c *Client) getCloudUsage(projectID, report string) (*CloudUsage, error) {
+	var usage CloudUsage
+	err := c.Get(cloudProjectEndpoint(projectID, "usage", report), &usage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s usage: %w", report, err)
+	}
+	return &usage, nil
+}
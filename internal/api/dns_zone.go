@@ -0,0 +1,305 @@
+// internal/api/dns_zone.go
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Zone DNSSEC status values
+const (
+	DNSSECEnabled           = "enabled"
+	DNSSECDisabled          = "disabled"
+	DNSSECEnableInProgress  = "enableInProgress"
+	DNSSECDisableInProgress = "disableInProgress"
+)
+
+// Web redirection types: visible redirects answer 302 or 301, invisible
+// ones frame the target
+const (
+	RedirectionVisible          = "visible"
+	RedirectionVisiblePermanent = "visiblePermanent"
+	RedirectionInvisible        = "invisible"
+)
+
+// DNSZone is a DNS zone served by OVHcloud
+type DNSZone struct {
+	Name            string     `json:"name"`
+	NameServers     []string   `json:"nameServers"`
+	DnssecSupported bool       `json:"dnssecSupported"`
+	HasDNSAnycast   bool       `json:"hasDnsAnycast"`
+	LastUpdate      *time.Time `json:"lastUpdate"`
+}
+
+// ZoneDNSSEC is the DNSSEC state of a hosted zone
+type ZoneDNSSEC struct {
+	Status string `json:"status"`
+}
+
+// WebRedirection redirects HTTP requests for a subdomain to a URL
+type WebRedirection struct {
+	ID          int64  `json:"id,omitempty"`
+	Zone        string `json:"zone,omitempty"`
+	SubDomain   string `json:"subDomain"`
+	Target      string `json:"target"`
+	Type        string `json:"type"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Keywords    string `json:"keywords,omitempty"`
+}
+
+// Host returns the redirected host name
+func (r *WebRedirection) Host() string {
+	if r.SubDomain == "" {
+		return r.Zone
+	}
+	return r.SubDomain + "." + r.Zone
+}
+
+// zoneEndpoint builds the path of a DNS zone sub-resource
+func zoneEndpoint(zone string, segments ...string) string {
+	builder := NewEndpointBuilder(ResourceDomain).WithSegment("zone").WithID(zone)
+	for _, segment := range segments {
+		builder.WithSegment(segment)
+	}
+	return builder.Build()
+}
+
+// GetDNSZone fetches a DNS zone
+func (c *Client) GetDNSZone(zone string) (*DNSZone, error) {
+	var info DNSZone
+	if err := c.Get(zoneEndpoint(zone), &info); err != nil {
+		return nil, fmt.Errorf("failed to get zone %s: %w", zone, err)
+	}
+	return &info, nil
+}
+
+// RefreshDNSZone applies pending changes of a zone to the nameservers
+func (c *Client) RefreshDNSZone(zone string) error {
+	if err := c.Post(zoneEndpoint(zone, "refresh"), nil, nil); err != nil {
+		return fmt.Errorf("failed to refresh zone %s: %w", zone, err)
+	}
+	return nil
+}
+
+// GetZoneDNSSEC fetches the DNSSEC state of a hosted zone
+func (c *Client) GetZoneDNSSEC(zone string) (*ZoneDNSSEC, error) {
+	var state ZoneDNSSEC
+	if err := c.Get(zoneEndpoint(zone, "dnssec"), &state); err != nil {
+		return nil, fmt.Errorf("failed to get DNSSEC state of %s: %w", zone, err)
+	}
+	return &state, nil
+}
+
+// EnableZoneDNSSEC signs a hosted zone and publishes its keys
+func (c *Client) EnableZoneDNSSEC(zone string) error {
+	if err := c.Post(zoneEndpoint(zone, "dnssec"), nil, nil); err != nil {
+		return fmt.Errorf("failed to enable DNSSEC on %s: %w", zone, err)
+	}
+	return nil
+}
+
+// DisableZoneDNSSEC stops signing a hosted zone
+func (c *Client) DisableZoneDNSSEC(zone string) error {
+	if err := c.Delete(zoneEndpoint(zone, "dnssec"), nil); err != nil {
+		return fmt.Errorf("failed to disable DNSSEC on %s: %w", zone, err)
+	}
+	return nil
+}
+
+// ListZoneRedirections returns the web redirection IDs of a zone
+func (c *Client) ListZoneRedirections(zone string) ([]int64, error) {
+	var ids []int64
+	if err := c.Get(zoneEndpoint(zone, "redirection"), &ids); err != nil {
+		return nil, fmt.Errorf("failed to list redirections of %s: %w", zone, err)
+	}
+	return ids, nil
+}
+
+// GetZoneRedirection fetches a web redirection
+func (c *Client) GetZoneRedirection(zone string, id int64) (*WebRedirection, error) {
+	var redirection WebRedirection
+	if err := c.Get(zoneEndpoint(zone, "redirection", strconv.FormatInt(id, 10)), &redirection); err != nil {
+		return nil, fmt.Errorf("failed to get redirection %d of %s: %w", id, zone, err)
+	}
+	return &redirection, nil
+}
+
+// CreateZoneRedirection adds a web redirection. The zone must be
+// refreshed for it to take effect.
+func (c *Client) CreateZoneRedirection(zone string, redirection WebRedirection) (*WebRedirection, error) {
+	var created WebRedirection
+	if err := c.Post(zoneEndpoint(zone, "redirection"), redirection, &created); err != nil {
+		redirection.Zone = zone
+		return nil, fmt.Errorf("failed to redirect %s: %w", redirection.Host(), err)
+	}
+	return &created, nil
+}
+
+// DeleteZoneRedirection removes a web redirection. The zone must be
+// refreshed for it to take effect.
+func (c *Client) DeleteZoneRedirection(zone string, id int64) error {
+	if err := c.Delete(zoneEndpoint(zone, "redirection", strconv.FormatInt(id, 10)), nil); err != nil {
+		return fmt.Errorf("failed to delete redirection %d of %s: %w", id, zone, err)
+	}
+	return nil
+}
+
+// DNSRecord is a record of a hosted zone
+type DNSRecord struct {
+	ID        int64  `json:"id,omitempty"`
+	Zone      string `json:"zone,omitempty"`
+	SubDomain string `json:"subDomain"`
+	FieldType string `json:"fieldType"`
+	Target    string `json:"target"`
+	TTL       int    `json:"ttl"`
+}
+
+// ListZoneRecords returns the record IDs of a zone, optionally filtered
+// by type and subdomain
+func (c *Client) ListZoneRecords(zone, fieldType, subDomain string) ([]int64, error) {
+	endpoint := NewEndpointBuilder(ResourceDomain).
+		WithSegment("zone").
+		WithID(zone).
+		WithSegment("record").
+		WithParameter("fieldType", fieldType).
+		WithParameter("subDomain", subDomain).
+		Build()
+
+	var ids []int64
+	if err := c.Get(endpoint, &ids); err != nil {
+		return nil, fmt.Errorf("failed to list records of %s: %w", zone, err)
+	}
+	return ids, nil
+}
+
+// GetZoneRecord fetches a record of a zone
+func (c *Client) GetZoneRecord(zone string, id int64) (*DNSRecord, error) {
+	var record DNSRecord
+	if err := c.Get(zoneEndpoint(zone, "record", strconv.FormatInt(id, 10)), &record); err != nil {
+		return nil, fmt.Errorf("failed to get record %d of %s: %w", id, zone, err)
+	}
+	return &record, nil
+}
+
+// CreateZoneRecord adds a record to a zone. The zone must be refreshed
+// for it to take effect.
+func (c *Client) CreateZoneRecord(zone string, record DNSRecord) (*DNSRecord, error) {
+	var created DNSRecord
+	if err := c.Post(zoneEndpoint(zone, "record"), record, &created); err != nil {
+		return nil, fmt.Errorf("failed to add %s record to %s: %w", record.FieldType, zone, err)
+	}
+	return &created, nil
+}
+
+// UpdateZoneRecord changes the subdomain, value and TTL of a record. The
+// zone must be refreshed for it to take effect.
+func (c *Client) UpdateZoneRecord(zone string, id int64, record DNSRecord) error {
+	payload := map[string]interface{}{
+		"subDomain": record.SubDomain,
+		"target":    record.Target,
+		"ttl":       record.TTL,
+	}
+	if err := c.Put(zoneEndpoint(zone, "record", strconv.FormatInt(id, 10)), payload, nil); err != nil {
+		return fmt.Errorf("failed to update record %d of %s: %w", id, zone, err)
+	}
+	return nil
+}
+
+// DeleteZoneRecord removes a record from a zone. The zone must be
+// refreshed for it to take effect.
+func (c *Client) DeleteZoneRecord(zone string, id int64) error {
+	if err := c.Delete(zoneEndpoint(zone, "record", strconv.FormatInt(id, 10)), nil); err != nil {
+		return fmt.Errorf("failed to delete record %d of %s: %w", id, zone, err)
+	}
+	return nil
+}
+
+// ExportDNSZone returns the zone in BIND format
+func (c *Client) ExportDNSZone(zone string) (string, error) {
+	var content string
+	if err := c.Get(zoneEndpoint(zone, "export"), &content); err != nil {
+		return "", fmt.Errorf("failed to export zone %s: %w", zone, err)
+	}
+	return content, nil
+}
+
+// ImportDNSZone replaces the records of a zone with a BIND zone file
+func (c *Client) ImportDNSZone(zone, zoneFile string) (*DomainTask, error) {
+	payload := map[string]string{"zoneFile": zoneFile}
+
+	var task DomainTask
+	if err := c.Post(zoneEndpoint(zone, "import"), payload, &task); err != nil {
+		return nil, fmt.Errorf("failed to import zone %s: %w", zone, err)
+	}
+	return &task, nil
+}
+
+// GetZoneTask fetches a task of a zone. Zone tasks share the layout of
+// domain tasks.
+func (c *Client) GetZoneTask(zone string, id int64) (*DomainTask, error) {
+	var task DomainTask
+	if err := c.Get(zoneEndpoint(zone, "task", strconv.FormatInt(id, 10)), &task); err != nil {
+		return nil, fmt.Errorf("failed to get task %d of %s: %w", id, zone, err)
+	}
+	return &task, nil
+}
+
+// DynHostRecord is an A record meant to be updated by dynamic DNS clients
+type DynHostRecord struct {
+	ID        int64  `json:"id,omitempty"`
+	Zone      string `json:"zone,omitempty"`
+	SubDomain string `json:"subDomain"`
+	IP        string `json:"ip"`
+	TTL       int    `json:"ttl,omitempty"`
+}
+
+// ListDynHostRecords returns the DynHost record IDs of a zone, optionally
+// filtered by subdomain
+func (c *Client) ListDynHostRecords(zone, subDomain string) ([]int64, error) {
+	endpoint := NewEndpointBuilder(ResourceDomain).
+		WithSegment("zone").
+		WithID(zone).
+		WithSegment("dynHost").
+		WithSegment("record").
+		WithParameter("subDomain", subDomain).
+		Build()
+
+	var ids []int64
+	if err := c.Get(endpoint, &ids); err != nil {
+		return nil, fmt.Errorf("failed to list DynHost records of %s: %w", zone, err)
+	}
+	return ids, nil
+}
+
+// GetDynHostRecord fetches a DynHost record
+func (c *Client) GetDynHostRecord(zone string, id int64) (*DynHostRecord, error) {
+	var record DynHostRecord
+	if err := c.Get(zoneEndpoint(zone, "dynHost", "record", strconv.FormatInt(id, 10)), &record); err != nil {
+		return nil, fmt.Errorf("failed to get DynHost record %d of %s: %w", id, zone, err)
+	}
+	return &record, nil
+}
+
+// CreateDynHostRecord adds a DynHost record. The zone must be refreshed
+// for it to take effect.
+func (c *Client) CreateDynHostRecord(zone, subDomain, ip string) (*DynHostRecord, error) {
+	payload := map[string]string{"subDomain": subDomain, "ip": ip}
+
+	var created DynHostRecord
+	if err := c.Post(zoneEndpoint(zone, "dynHost", "record"), payload, &created); err != nil {
+		return nil, fmt.Errorf("failed to add DynHost record to %s: %w", zone, err)
+	}
+	return &created, nil
+}
+
+// UpdateDynHostRecord points a DynHost record to a new address. The zone
+// must be refreshed for it to take effect.
+func (c *Client) UpdateDynHostRecord(zone string, id int64, ip string) error {
+	payload := map[string]string{"ip": ip}
+	if err := c.Put(zoneEndpoint(zone, "dynHost", "record", strconv.FormatInt(id, 10)), payload, nil); err != nil {
+		return fmt.Errorf("failed to update DynHost record %d of %s: %w", id, zone, err)
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+// internal/api/domain.go
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Nameserver types of a domain: served by OVHcloud DNS or by servers
+// managed elsewhere
+const (
+	NameServerHosted   = "hosted"
+	NameServerExternal = "external"
+)
+
+// Transfer lock states. Locking and unlocking are transitions.
+const (
+	TransferLocked    = "locked"
+	TransferLocking   = "locking"
+	TransferUnlocked  = "unlocked"
+	TransferUnlocking = "unlocking"
+)
+
+// Domain task status values
+const (
+	DomainTaskTodo      = "todo"
+	DomainTaskDoing     = "doing"
+	DomainTaskDone      = "done"
+	DomainTaskError     = "error"
+	DomainTaskCancelled = "cancelled"
+)
+
+// DomainNameServer is a nameserver declared at the registry
+type DomainNameServer struct {
+	ID       int64  `json:"id"`
+	Host     string `json:"host"`
+	IP       string `json:"ip,omitempty"`
+	IsUsed   bool   `json:"isUsed"`
+	ToDelete bool   `json:"toDelete"`
+}
+
+// NameServerInput is a nameserver to declare. IP is only needed for glue
+// records, when the host is inside the domain itself.
+type NameServerInput struct {
+	Host string `json:"host"`
+	IP   string `json:"ip,omitempty"`
+}
+
+// DSKey is a DNSSEC key published at the registry
+type DSKey struct {
+	Algorithm int    `json:"algorithm"`
+	Flags     int    `json:"flags"`
+	PublicKey string `json:"publicKey"`
+	Tag       int    `json:"tag"`
+}
+
+// DomainDSRecord is a DNSSEC key declared for a domain
+type DomainDSRecord struct {
+	DSKey
+	ID     int64  `json:"id"`
+	Status string `json:"status"`
+}
+
+// DomainTask is an asynchronous operation at the registry
+type DomainTask struct {
+	ID       int64      `json:"id"`
+	Function string     `json:"function"`
+	Status   string     `json:"status"`
+	Comment  string     `json:"comment"`
+	TodoDate *time.Time `json:"todoDate"`
+	DoneDate *time.Time `json:"doneDate"`
+}
+
+// IsDone checks if the task completed successfully
+func (t *DomainTask) IsDone() bool {
+	return t.Status == DomainTaskDone
+}
+
+// IsFailed checks if the task ended without completing
+func (t *DomainTask) IsFailed() bool {
+	return t.Status == DomainTaskError || t.Status == DomainTaskCancelled
+}
+
+// IsTransferLocked reports whether the domain is locked, or being locked
+func (d *DomainInfo) IsTransferLocked() bool {
+	return d.TransferLockStatus == TransferLocked || d.TransferLockStatus == TransferLocking
+}
+
+// domainEndpoint builds the path of a domain sub-resource
+func domainEndpoint(domain string, segments ...string) string {
+	builder := NewEndpointBuilder(ResourceDomain).WithID(domain)
+	for _, segment := range segments {
+		builder.WithSegment(segment)
+	}
+	return builder.Build()
+}
+
+// updateDomain changes properties of a domain
+func (c *Client) updateDomain(domain string, payload map[string]interface{}) error {
+	return c.Put(GetDomainEndpoint(domain), payload, nil)
+}
+
+// SetDomainTransferLock locks or unlocks the transfer of a domain
+func (c *Client) SetDomainTransferLock(domain string, locked bool) error {
+	status := TransferUnlocked
+	if locked {
+		status = TransferLocked
+	}
+	if err := c.updateDomain(domain, map[string]interface{}{"transferLockStatus": status}); err != nil {
+		return fmt.Errorf("failed to set transfer lock of %s to %s: %w", domain, status, err)
+	}
+	return nil
+}
+
+// SetDomainNameServerType switches a domain between hosted and external
+// nameservers
+func (c *Client) SetDomainNameServerType(domain, nameServerType string) error {
+	if err := c.updateDomain(domain, map[string]interface{}{"nameServerType": nameServerType}); err != nil {
+		return fmt.Errorf("failed to set nameserver type of %s: %w", domain, err)
+	}
+	return nil
+}
+
+// ListDomainNameServers returns the nameserver IDs of a domain
+func (c *Client) ListDomainNameServers(domain string) ([]int64, error) {
+	var ids []int64
+	if err := c.Get(domainEndpoint(domain, "nameServer"), &ids); err != nil {
+		return nil, fmt.Errorf("failed to list nameservers of %s: %w", domain, err)
+	}
+	return ids, nil
+}
+
+// GetDomainNameServer fetches a nameserver of a domain
+func (c *Client) GetDomainNameServer(domain string, id int64) (*DomainNameServer, error) {
+	var server DomainNameServer
+	if err := c.Get(domainEndpoint(domain, "nameServer", strconv.FormatInt(id, 10)), &server); err != nil {
+		return nil, fmt.Errorf("failed to get nameserver %d of %s: %w", id, domain, err)
+	}
+	return &server, nil
+}
+
+// UpdateDomainNameServers replaces the nameservers of a domain
+func (c *Client) UpdateDomainNameServers(domain string, servers []NameServerInput) (*DomainTask, error) {
+	payload := map[string]interface{}{"nameServers": servers}
+
+	var task DomainTask
+	if err := c.Post(domainEndpoint(domain, "nameServers", "update"), payload, &task); err != nil {
+		return nil, fmt.Errorf("failed to update nameservers of %s: %w", domain, err)
+	}
+	return &task, nil
+}
+
+// ListDomainDSRecords returns the DNSSEC key IDs of a domain
+func (c *Client) ListDomainDSRecords(domain string) ([]int64, error) {
+	var ids []int64
+	if err := c.Get(domainEndpoint(domain, "dsRecord"), &ids); err != nil {
+		return nil, fmt.Errorf("failed to list DS records of %s: %w", domain, err)
+	}
+	return ids, nil
+}
+
+// GetDomainDSRecord fetches a DNSSEC key of a domain
+func (c *Client) GetDomainDSRecord(domain string, id int64) (*DomainDSRecord, error) {
+	var record DomainDSRecord
+	if err := c.Get(domainEndpoint(domain, "dsRecord", strconv.FormatInt(id, 10)), &record); err != nil {
+		return nil, fmt.Errorf("failed to get DS record %d of %s: %w", id, domain, err)
+	}
+	return &record, nil
+}
+
+// UpdateDomainDSRecords replaces the DNSSEC keys of a domain. No keys
+// disables DNSSEC.
+func (c *Client) UpdateDomainDSRecords(domain string, keys []DSKey) (*DomainTask, error) {
+	if keys == nil {
+		keys = []DSKey{}
+	}
+	payload := map[string]interface{}{"keys": keys}
+
+	var task DomainTask
+	if err := c.Post(domainEndpoint(domain, "dsRecord"), payload, &task); err != nil {
+		return nil, fmt.Errorf("failed to update DS records of %s: %w", domain, err)
+	}
+	return &task, nil
+}
+
+// GetDomainTask retrieves the status of a task on a domain
+func (c *Client) GetDomainTask(domain string, taskID int64) (*DomainTask, error) {
+	var task DomainTask
+	if err := c.Get(domainEndpoint(domain, "task", strconv.FormatInt(taskID, 10)), &task); err != nil {
+		return nil, fmt.Errorf("failed to get task %d of %s: %w", taskID, domain, err)
+	}
+	return &task, nil
+}
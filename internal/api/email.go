@@ -0,0 +1,225 @@
+// internal/api/email.go
+package api
+
+import (
+	"fmt"
+	"time"
+)
+
+// EmailDomain is an MX Plan email service attached to a domain
+type EmailDomain struct {
+	Domain             string     `json:"domain"`
+	Offer              string     `json:"offer"`
+	Status             string     `json:"status"`
+	AllowedAccountSize []int64    `json:"allowedAccountSize"`
+	CreationDate       *time.Time `json:"creationDate"`
+}
+
+// EmailAccount is a mailbox of an email domain. Size is in bytes.
+type EmailAccount struct {
+	AccountName string `json:"accountName"`
+	Domain      string `json:"domain"`
+	Email       string `json:"email"`
+	Description string `json:"description"`
+	Size        int64  `json:"size"`
+	IsBlocked   bool   `json:"isBlocked"`
+	State       string `json:"state"`
+}
+
+// EmailUsage is the storage used by a mailbox. Quota is in bytes.
+type EmailUsage struct {
+	Quota      int64      `json:"quota"`
+	EmailCount int64      `json:"emailCount"`
+	Date       *time.Time `json:"date"`
+}
+
+// EmailRedirection forwards the messages of an address
+type EmailRedirection struct {
+	ID   string `json:"id"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// EmailMailingList is a mailing list of an email domain
+type EmailMailingList struct {
+	ID            int64  `json:"id"`
+	Name          string `json:"name"`
+	Language      string `json:"language"`
+	OwnerEmail    string `json:"ownerEmail"`
+	ReplyTo       string `json:"replyTo"`
+	NbSubscribers int    `json:"nbSubscribers"`
+}
+
+// EmailResponder is the automatic reply of a mailbox
+type EmailResponder struct {
+	Account string     `json:"account"`
+	Content string     `json:"content"`
+	Copy    bool       `json:"copy"`
+	CopyTo  string     `json:"copyTo"`
+	From    *time.Time `json:"from"`
+	To      *time.Time `json:"to"`
+}
+
+// EmailTask is a pending change of an email domain
+type EmailTask struct {
+	ID     int64      `json:"id"`
+	Action string     `json:"action"`
+	Domain string     `json:"domain"`
+	Name   string     `json:"name"`
+	Date   *time.Time `json:"date"`
+}
+
+// emailEndpoint builds the path of an email domain sub-resource
+func emailEndpoint(domain string, segments ...string) string {
+	builder := NewEndpointBuilder(ResourceEmail).WithID(domain)
+	for _, segment := range segments {
+		builder.WithSegment(segment)
+	}
+	return builder.Build()
+}
+
+// ListEmailDomains returns the email domains of the account
+func (c *Client) ListEmailDomains() ([]string, error) {
+	var domains []string
+	if err := c.Get(NewEndpointBuilder(ResourceEmail).Build(), &domains); err != nil {
+		return nil, fmt.Errorf("failed to list email domains: %w", err)
+	}
+	return domains, nil
+}
+
+// GetEmailDomain fetches an email domain
+func (c *Client) GetEmailDomain(domain string) (*EmailDomain, error) {
+	var info EmailDomain
+	if err := c.Get(emailEndpoint(domain), &info); err != nil {
+		return nil, fmt.Errorf("failed to get email domain %s: %w", domain, err)
+	}
+	return &info, nil
+}
+
+// ListEmailAccounts returns the mailbox names of a domain
+func (c *Client) ListEmailAccounts(domain string) ([]string, error) {
+	var names []string
+	if err := c.Get(emailEndpoint(domain, "account"), &names); err != nil {
+		return nil, fmt.Errorf("failed to list mailboxes of %s: %w", domain, err)
+	}
+	return names, nil
+}
+
+// GetEmailAccount fetches a mailbox
+func (c *Client) GetEmailAccount(domain, account string) (*EmailAccount, error) {
+	var info EmailAccount
+	if err := c.Get(emailEndpoint(domain, "account", account), &info); err != nil {
+		return nil, fmt.Errorf("failed to get mailbox %s@%s: %w", account, domain, err)
+	}
+	return &info, nil
+}
+
+// GetEmailAccountUsage fetches the storage used by a mailbox
+func (c *Client) GetEmailAccountUsage(domain, account string) (*EmailUsage, error) {
+	var usage EmailUsage
+	if err := c.Get(emailEndpoint(domain, "account", account, "usage"), &usage); err != nil {
+		return nil, fmt.Errorf("failed to get usage of %s@%s: %w", account, domain, err)
+	}
+	return &usage, nil
+}
+
+// CreateEmailAccount creates a mailbox of size bytes
+func (c *Client) CreateEmailAccount(domain, account, password string, size int64) (*EmailTask, error) {
+	payload := map[string]interface{}{
+		"accountName": account,
+		"password":    password,
+		"size":        size,
+	}
+
+	var task EmailTask
+	if err := c.Post(emailEndpoint(domain, "account"), payload, &task); err != nil {
+		return nil, fmt.Errorf("failed to create mailbox %s@%s: %w", account, domain, err)
+	}
+	return &task, nil
+}
+
+// DeleteEmailAccount deletes a mailbox and its messages
+func (c *Client) DeleteEmailAccount(domain, account string) (*EmailTask, error) {
+	var task EmailTask
+	if err := c.Delete(emailEndpoint(domain, "account", account), &task); err != nil {
+		return nil, fmt.Errorf("failed to delete mailbox %s@%s: %w", account, domain, err)
+	}
+	return &task, nil
+}
+
+// ChangeEmailPassword sets the password of a mailbox
+func (c *Client) ChangeEmailPassword(domain, account, password string) (*EmailTask, error) {
+	payload := map[string]string{"password": password}
+
+	var task EmailTask
+	if err := c.Post(emailEndpoint(domain, "account", account, "changePassword"), payload, &task); err != nil {
+		return nil, fmt.Errorf("failed to change password of %s@%s: %w", account, domain, err)
+	}
+	return &task, nil
+}
+
+// ListEmailRedirections returns the redirection IDs of a domain
+func (c *Client) ListEmailRedirections(domain string) ([]string, error) {
+	var ids []string
+	if err := c.Get(emailEndpoint(domain, "redirection"), &ids); err != nil {
+		return nil, fmt.Errorf("failed to list redirections of %s: %w", domain, err)
+	}
+	return ids, nil
+}
+
+// GetEmailRedirection fetches a redirection
+func (c *Client) GetEmailRedirection(domain, id string) (*EmailRedirection, error) {
+	var redirection EmailRedirection
+	if err := c.Get(emailEndpoint(domain, "redirection", id), &redirection); err != nil {
+		return nil, fmt.Errorf("failed to get redirection %s of %s: %w", id, domain, err)
+	}
+	return &redirection, nil
+}
+
+// CreateEmailRedirection forwards from to another address. With
+// localCopy, messages are also kept in the mailbox of from.
+func (c *Client) CreateEmailRedirection(domain, from, to string, localCopy bool) (*EmailTask, error) {
+	payload := map[string]interface{}{"from": from, "to": to, "localCopy": localCopy}
+
+	var task EmailTask
+	if err := c.Post(emailEndpoint(domain, "redirection"), payload, &task); err != nil {
+		return nil, fmt.Errorf("failed to redirect %s to %s: %w", from, to, err)
+	}
+	return &task, nil
+}
+
+// ListEmailMailingLists returns the mailing list names of a domain
+func (c *Client) ListEmailMailingLists(domain string) ([]string, error) {
+	var names []string
+	if err := c.Get(emailEndpoint(domain, "mailingList"), &names); err != nil {
+		return nil, fmt.Errorf("failed to list mailing lists of %s: %w", domain, err)
+	}
+	return names, nil
+}
+
+// GetEmailMailingList fetches a mailing list
+func (c *Client) GetEmailMailingList(domain, name string) (*EmailMailingList, error) {
+	var list EmailMailingList
+	if err := c.Get(emailEndpoint(domain, "mailingList", name), &list); err != nil {
+		return nil, fmt.Errorf("failed to get mailing list %s of %s: %w", name, domain, err)
+	}
+	return &list, nil
+}
+
+// ListEmailResponders returns the accounts of a domain with a responder
+func (c *Client) ListEmailResponders(domain string) ([]string, error) {
+	var accounts []string
+	if err := c.Get(emailEndpoint(domain, "responder"), &accounts); err != nil {
+		return nil, fmt.Errorf("failed to list responders of %s: %w", domain, err)
+	}
+	return accounts, nil
+}
+
+// GetEmailResponder fetches the responder of an account
+func (c *Client) GetEmailResponder(domain, account string) (*EmailResponder, error) {
+	var responder EmailResponder
+	if err := c.Get(emailEndpoint(domain, "responder", account), &responder); err != nil {
+		return nil, fmt.Errorf("failed to get responder of %s@%s: %w", account, domain, err)
+	}
+	return &responder, nil
+}
@@ -3,6 +3,7 @@ package api
 
 import (
 	"fmt"
+	"net/url"
 	"path"
 	"strings"
 )
@@ -19,6 +20,8 @@ const (
 	ResourceBilling ResourceType = "billing"
 	ResourceSupport ResourceType = "support"
 	ResourceVPS     ResourceType = "vps"
+	ResourceHosting ResourceType = "hosting"
+	ResourceEmail   ResourceType = "email"
 )
 
 // Endpoint definitions for OVH API
@@ -32,6 +35,8 @@ const (
 	endpointBilling         = "/me/bill"
 	endpointSupport         = "/support"
 	endpointVPS             = "/vps"
+	endpointHosting         = "/hosting/web"
+	endpointEmail           = "/email/domain"
 )
 
 // EndpointMap maps resource types to their base endpoints
@@ -43,6 +48,8 @@ var EndpointMap = map[ResourceType]string{
 	ResourceIP:      endpointIP,
 	ResourceBilling: endpointBilling,
 	ResourceSupport: endpointSupport,
+	ResourceHosting: endpointHosting,
+	ResourceEmail:   endpointEmail,
 }
 
 // EndpointBuilder helps construct endpoint paths
@@ -164,8 +171,9 @@ func GetCloudProjectActionEndpoint(projectID, action string) string {
 		Build()
 }
 
+// GetIPEndpoint escapes the block since IP blocks contain a slash
 func GetIPEndpoint(ip string) string {
-	return NewEndpointBuilder(ResourceIP).WithID(ip).Build()
+	return NewEndpointBuilder(ResourceIP).WithID(url.PathEscape(ip)).Build()
 }
 
 func GetBillingEndpoint(billID string) string {
@@ -173,5 +181,8 @@ func GetBillingEndpoint(billID string) string {
 }
 
 func GetSupportTicketEndpoint(ticketID string) string {
-	return NewEndpointBuilder(ResourceSupport).WithID(ticketID).Build()
+	return NewEndpointBuilder(ResourceSupport).
+		WithSegment("tickets").
+		WithID(ticketID).
+		Build()
 }
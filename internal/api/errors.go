@@ -2,8 +2,15 @@
 package api
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
+	"time"
+
+	"ovh-terminal/internal/config"
+
+	ovh "github.com/ovh/go-ovh/ovh"
 )
 
 // ErrorType represents different categories of API errors
@@ -24,6 +31,14 @@ var errorMessages = map[ErrorType]string{
 	ErrorTypeValidation: "Invalid request. This might be a bug in the application.",
 }
 
+// authHints tells the user which settings to check for each auth mode
+var authHints = map[string]string{
+	config.AuthConsumerKey: "Check app_key, app_secret and consumer_key, " +
+		"or create new ones at https://api.ovh.com/createToken/.",
+	config.AuthOAuth2: "Check client_id and client_secret of the service account " +
+		"and that its IAM policies allow this call.",
+}
+
 // UserMessage returns a user-friendly message for each error type
 func (et ErrorType) UserMessage() string {
 	if msg, ok := errorMessages[et]; ok {
@@ -34,10 +49,12 @@ func (et ErrorType) UserMessage() string {
 
 // APIError wraps API-related errors with additional context
 type APIError struct {
-	Type    ErrorType
-	Message string
-	Details interface{}
-	Err     error
+	Type      ErrorType
+	Message   string
+	Details   interface{}
+	Err       error
+	AuthMode  string
+	ClockSkew time.Duration
 }
 
 func (e *APIError) Error() string {
@@ -84,13 +101,20 @@ func (e *APIError) UserError() string {
 
 	switch e.Type {
 	case ErrorTypeAuth:
+		if e.ClockSkew != 0 {
+			return fmt.Sprintf("The OVH API rejected the request signature: your clock is off by %d seconds. "+
+				"Please synchronize your system clock and try again.", clockSkewSeconds(e.ClockSkew))
+		}
+		msg := baseMsg
 		if strings.Contains(strings.ToLower(e.Error()), "invalid") {
-			return fmt.Sprintf("%s The credentials appear to be invalid.", baseMsg)
+			msg = fmt.Sprintf("%s The credentials appear to be invalid.", baseMsg)
+		} else if strings.Contains(strings.ToLower(e.Error()), "expired") {
+			msg = fmt.Sprintf("%s Your authentication token may have expired.", baseMsg)
 		}
-		if strings.Contains(strings.ToLower(e.Error()), "expired") {
-			return fmt.Sprintf("%s Your authentication token may have expired.", baseMsg)
+		if hint, ok := authHints[e.AuthMode]; ok {
+			msg += " " + hint
 		}
-		return baseMsg
+		return msg
 
 	case ErrorTypeAPI:
 		details := detailsToString(e.Details)
@@ -101,10 +125,25 @@ func (e *APIError) UserError() string {
 	}
 }
 
+// clockSkewSeconds returns the absolute skew in whole seconds
+func clockSkewSeconds(skew time.Duration) int64 {
+	seconds := int64(skew.Round(time.Second) / time.Second)
+	if seconds < 0 {
+		return -seconds
+	}
+	return seconds
+}
+
 func (e *APIError) Unwrap() error {
 	return e.Err
 }
 
+// IsNotFound reports whether err comes from an API 404 response
+func IsNotFound(err error) bool {
+	var ovhErr *ovh.APIError
+	return errors.As(err, &ovhErr) && ovhErr.Code == http.StatusNotFound
+}
+
 // Error constructors
 func NewAuthError(message string, err error) *APIError {
 	return &APIError{
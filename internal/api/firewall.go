@@ -0,0 +1,141 @@
+// internal/api/firewall.go
+package api
+
+import (
+	"fmt"
+	"time"
+)
+
+// Firewall rule states
+const (
+	FirewallRuleCreationPending = "creationPending"
+	FirewallRuleOK              = "ok"
+	FirewallRuleRemovalPending  = "removalPending"
+)
+
+// FirewallIP represents the edge firewall of a single address
+type FirewallIP struct {
+	IPOnFirewall string `json:"ipOnFirewall"`
+	Enabled      bool   `json:"enabled"`
+	State        string `json:"state"`
+}
+
+// FirewallRule represents an edge firewall rule as returned by the API.
+// Ports are expressions such as "eq 22".
+type FirewallRule struct {
+	Sequence        int        `json:"sequence"`
+	Action          string     `json:"action"`
+	Protocol        string     `json:"protocol"`
+	Source          string     `json:"source"`
+	Destination     string     `json:"destination"`
+	SourcePort      string     `json:"sourcePort"`
+	DestinationPort string     `json:"destinationPort"`
+	TCPOption       string     `json:"tcpOption"`
+	Fragments       *bool      `json:"fragments"`
+	State           string     `json:"state"`
+	Rule            string     `json:"rule"`
+	CreationDate    *time.Time `json:"creationDate"`
+}
+
+// FirewallRuleCreate is the payload creating a firewall rule
+type FirewallRuleCreate struct {
+	Sequence        int                `json:"sequence"`
+	Action          string             `json:"action"`
+	Protocol        string             `json:"protocol"`
+	Source          string             `json:"source,omitempty"`
+	SourcePort      int                `json:"sourcePort,omitempty"`
+	DestinationPort int                `json:"destinationPort,omitempty"`
+	TCPOption       *FirewallOptionTCP `json:"tcpOption,omitempty"`
+}
+
+// FirewallOptionTCP holds the TCP options of a rule being created. Rules
+// read back report them as the flat tcpOption and fragments fields.
+type FirewallOptionTCP struct {
+	Option    string `json:"option,omitempty"`
+	Fragments bool   `json:"fragments,omitempty"`
+}
+
+// firewallEndpoint builds an endpoint below the firewall of an address
+func firewallEndpoint(block, ip string, segments ...string) string {
+	return ipSubresourceEndpoint(block, "firewall", ip, segments...)
+}
+
+// ListFirewallIPs retrieves the addresses of a block with a firewall
+func (c *Client) ListFirewallIPs(block string) ([]string, error) {
+	var ips []string
+	err := c.Get(GetIPActionEndpoint(block, "firewall"), &ips)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list firewalls of %s: %w", block, err)
+	}
+	return ips, nil
+}
+
+// GetFirewall retrieves the firewall of an address
+func (c *Client) GetFirewall(block, ip string) (*FirewallIP, error) {
+	var firewall FirewallIP
+	err := c.Get(firewallEndpoint(block, ip), &firewall)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get firewall of %s: %w", ip, err)
+	}
+	return &firewall, nil
+}
+
+// AddFirewallIP creates the firewall of an address, initially disabled
+func (c *Client) AddFirewallIP(block, ip string) (*FirewallIP, error) {
+	var firewall FirewallIP
+	payload := map[string]string{"ipOnFirewall": ip}
+	err := c.Post(GetIPActionEndpoint(block, "firewall"), payload, &firewall)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create firewall of %s: %w", ip, err)
+	}
+	return &firewall, nil
+}
+
+// SetFirewallEnabled enables or disables the firewall of an address
+func (c *Client) SetFirewallEnabled(block, ip string, enabled bool) error {
+	payload := map[string]bool{"enabled": enabled}
+	err := c.Put(firewallEndpoint(block, ip), payload, nil)
+	if err != nil {
+		return fmt.Errorf("failed to update firewall of %s: %w", ip, err)
+	}
+	return nil
+}
+
+// ListFirewallRules retrieves the rule sequences of an address
+func (c *Client) ListFirewallRules(block, ip string) ([]int, error) {
+	var sequences []int
+	err := c.Get(firewallEndpoint(block, ip, "rule"), &sequences)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list firewall rules of %s: %w", ip, err)
+	}
+	return sequences, nil
+}
+
+// GetFirewallRule retrieves a firewall rule by sequence
+func (c *Client) GetFirewallRule(block, ip string, sequence int) (*FirewallRule, error) {
+	var rule FirewallRule
+	err := c.Get(firewallEndpoint(block, ip, "rule", fmt.Sprint(sequence)), &rule)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get firewall rule %d of %s: %w", sequence, ip, err)
+	}
+	return &rule, nil
+}
+
+// CreateFirewallRule adds a firewall rule to an address
+func (c *Client) CreateFirewallRule(block, ip string, rule FirewallRuleCreate) (*FirewallRule, error) {
+	var created FirewallRule
+	err := c.Post(firewallEndpoint(block, ip, "rule"), rule, &created)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create firewall rule %d of %s: %w", rule.Sequence, ip, err)
+	}
+	return &created, nil
+}
+
+// DeleteFirewallRule removes a firewall rule by sequence
+func (c *Client) DeleteFirewallRule(block, ip string, sequence int) error {
+	err := c.Delete(firewallEndpoint(block, ip, "rule", fmt.Sprint(sequence)), nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete firewall rule %d of %s: %w", sequence, ip, err)
+	}
+	return nil
+}
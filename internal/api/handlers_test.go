@@ -3,41 +3,68 @@ package api
 
 import (
 	"encoding/json"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"ovh-terminal/internal/config"
 	"ovh-terminal/internal/logger"
+
+	ovh "github.com/ovh/go-ovh/ovh"
 )
 
-// mockClient simulates API responses for testing
-type mockClient struct {
-	responses map[string]interface{}
-	errors    map[string]error
+// mockTransport simulates API responses for testing
+type mockTransport struct {
+	responses    map[string]interface{}
+	errors       map[string]int
+	errorMessage string
+	timeOffset   time.Duration
 }
 
-func (m *mockClient) Get(path string, result interface{}) error {
-	if err, exists := m.errors[path]; exists && err != nil {
-		return err
+func (m *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	path := strings.TrimPrefix(req.URL.Path, "/1.0")
+
+	if path == "/auth/time" {
+		return m.respond(req, http.StatusOK, time.Now().Add(m.timeOffset).Unix())
 	}
 
-	if response, exists := m.responses[path]; exists {
-		// Simulate JSON marshaling/unmarshaling to match real behavior
-		data, err := json.Marshal(response)
-		if err != nil {
-			return err
+	if status, exists := m.errors[path]; exists {
+		message := m.errorMessage
+		if message == "" {
+			message = "mock error"
 		}
-		return json.Unmarshal(data, result)
+		return m.respond(req, status, map[string]string{"message": message})
+	}
+
+	if response, exists := m.responses[path]; exists {
+		return m.respond(req, http.StatusOK, response)
 	}
 
-	return nil
+	return m.respond(req, http.StatusNotFound, map[string]string{"message": "not found"})
 }
 
-func (m *mockClient) Post(path string, payload interface{}, result interface{}) error {
-	// Similar to Get, but for POST requests (to be implemented when needed)
-	return nil
+// respond encodes a value as a JSON response to match real behavior
+func (m *mockTransport) respond(
+	req *http.Request,
+	status int,
+	value interface{},
+) (*http.Response, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return newResponse(req, status, nil, data), nil
 }
 
 // Test data
+var testAccount = &config.AccountConfig{
+	Endpoint:    "https://api.ovh.test/1.0",
+	AppKey:      "test-app-key",
+	AppSecret:   "test-app-secret",
+	ConsumerKey: "test-consumer-key",
+}
+
 var mockAccountInfo = &AccountInfo{
 	Email:        "test@example.com",
 	FirstName:    "Test",
@@ -49,7 +76,7 @@ var mockAccountInfo = &AccountInfo{
 
 var mockServerInfo = &ServerInfo{
 	Name:         "ns123456.ip-1-2-3.eu",
-	DisplayName:  "My Server",
+	IAM:          &IAMInfo{DisplayName: "My Server"},
 	IP:           "1.2.3.4",
 	State:        "active",
 	Datacenter:   "rbx1",
@@ -64,7 +91,7 @@ var mockDomainInfo = &DomainInfo{
 }
 
 func setupMockClient() *Client {
-	mock := &mockClient{
+	mock := &mockTransport{
 		responses: map[string]interface{}{
 			"/me":                       mockAccountInfo,
 			"/dedicated/server":         []string{"server1", "server2"},
@@ -75,13 +102,15 @@ func setupMockClient() *Client {
 			"/ip":                       []string{"1.2.3.4", "5.6.7.8"},
 			"/ip/1.2.3.4":               &IPInfo{IP: "1.2.3.4", Type: "failover"},
 		},
-		errors: make(map[string]error),
+		errors: make(map[string]int),
 	}
 
-	return &Client{
-		client: mock,
-		logger: logger.NewLogger(),
+	client, err := NewClient(testAccount, logger.NewLogger(), WithTransport(mock))
+	if err != nil {
+		panic(err)
 	}
+
+	return client
 }
 
 func TestGetAccountInfo(t *testing.T) {
@@ -169,8 +198,8 @@ func TestErrorHandling(t *testing.T) {
 	client := setupMockClient()
 
 	// Add an error for a specific path
-	mock := client.client.(*mockClient)
-	mock.errors["/me"] = NewAPIError("Test error", nil, nil)
+	mock := client.transport.(*mockTransport)
+	mock.errors["/me"] = http.StatusBadRequest
 
 	_, err := client.GetAccountInfo()
 	if err == nil {
@@ -182,3 +211,28 @@ func TestErrorHandling(t *testing.T) {
 		t.Errorf("Expected APIError but got %T", err)
 	}
 }
+
+func TestClockSkewError(t *testing.T) {
+	client := setupMockClient()
+
+	// The local clock jumps 90 seconds ahead after the client started
+	mock := client.transport.(*mockTransport)
+	mock.timeOffset = -90 * time.Second
+	rejected := &ovh.APIError{Code: http.StatusUnauthorized, Message: "Invalid signature"}
+
+	err := client.handleAPIError(http.MethodGet, "/me", rejected)
+	apiErr, ok := err.(*APIError)
+	if !ok || apiErr.Type != ErrorTypeAuth {
+		t.Fatalf("Expected auth error but got %v", err)
+	}
+	if !strings.Contains(apiErr.UserError(), "your clock is off by 90 seconds") {
+		t.Errorf("Expected clock skew in user error, got %q", apiErr.UserError())
+	}
+
+	// Once the offset is corrected, a rejection is a credential problem
+	client.clockSkew = 90 * time.Second
+	err = client.handleAPIError(http.MethodGet, "/me", rejected)
+	if apiErr, ok := err.(*APIError); !ok || apiErr.ClockSkew != 0 {
+		t.Errorf("Expected no clock skew for a corrected offset, got %v", err)
+	}
+}
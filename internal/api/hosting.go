@@ -0,0 +1,269 @@
+// internal/api/hosting.go
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// UnitValue is a size with its unit, as the hosting API reports quotas
+type UnitValue struct {
+	Unit  string  `json:"unit"`
+	Value float64 `json:"value"`
+}
+
+// unitSizes maps the units of the hosting API to bytes
+var unitSizes = map[string]float64{
+	"B":  1,
+	"KB": 1 << 10,
+	"MB": 1 << 20,
+	"GB": 1 << 30,
+	"TB": 1 << 40,
+}
+
+// Bytes converts the size to bytes. Unknown units count as bytes.
+func (u *UnitValue) Bytes() int64 {
+	if u == nil {
+		return 0
+	}
+	size, ok := unitSizes[strings.ToUpper(u.Unit)]
+	if !ok {
+		size = 1
+	}
+	return int64(u.Value * size)
+}
+
+// Hosting is a web hosting plan
+type Hosting struct {
+	ServiceName     string     `json:"serviceName"`
+	DisplayName     string     `json:"displayName"`
+	Offer           string     `json:"offer"`
+	Cluster         string     `json:"cluster"`
+	ClusterIP       string     `json:"clusterIp"`
+	Datacenter      string     `json:"datacenter"`
+	OperatingSystem string     `json:"operatingSystem"`
+	Home            string     `json:"home"`
+	PrimaryLogin    string     `json:"primaryLogin"`
+	State           string     `json:"state"`
+	HasCDN          bool       `json:"hasCdn"`
+	HasHostedSSL    bool       `json:"hasHostedSsl"`
+	QuotaSize       *UnitValue `json:"quotaSize"`
+	QuotaUsed       *UnitValue `json:"quotaUsed"`
+}
+
+// GetDisplayName returns the display name, falling back to the service name
+func (h *Hosting) GetDisplayName() string {
+	if h.DisplayName != "" {
+		return h.DisplayName
+	}
+	return h.ServiceName
+}
+
+// HostingConfig is the runtime configuration (.ovhconfig) of a folder
+type HostingConfig struct {
+	ID            int64  `json:"id"`
+	Path          string `json:"path"`
+	EngineName    string `json:"engineName"`
+	EngineVersion string `json:"engineVersion"`
+	Environment   string `json:"environment"`
+	Container     string `json:"container"`
+	HTTPFirewall  string `json:"httpFirewall"`
+	Status        string `json:"status"`
+}
+
+// HostingDomain is a domain attached to a hosting plan
+type HostingDomain struct {
+	Domain   string `json:"domain"`
+	Path     string `json:"path"`
+	SSL      bool   `json:"ssl"`
+	CDN      string `json:"cdn"`
+	Firewall string `json:"firewall"`
+	OwnLog   string `json:"ownLog"`
+	Status   string `json:"status"`
+}
+
+// HostingUser is an FTP/SSH user of a hosting plan
+type HostingUser struct {
+	Login          string `json:"login"`
+	Home           string `json:"home"`
+	SSHState       string `json:"sshState"`
+	State          string `json:"state"`
+	PrimaryAccount bool   `json:"isPrimaryAccount"`
+	Status         string `json:"status"`
+}
+
+// HostingDatabase is a database of a hosting plan
+type HostingDatabase struct {
+	Name      string     `json:"name"`
+	User      string     `json:"user"`
+	Server    string     `json:"server"`
+	Port      int        `json:"port"`
+	Type      string     `json:"type"`
+	Version   string     `json:"version"`
+	Mode      string     `json:"mode"`
+	State     string     `json:"state"`
+	Status    string     `json:"status"`
+	QuotaSize *UnitValue `json:"quotaSize"`
+	QuotaUsed *UnitValue `json:"quotaUsed"`
+}
+
+// HostingCron is a scheduled task of a hosting plan
+type HostingCron struct {
+	ID          int64  `json:"id"`
+	Command     string `json:"command"`
+	Description string `json:"description"`
+	Email       string `json:"email"`
+	Frequency   string `json:"frequency"`
+	Language    string `json:"language"`
+	State       string `json:"state"`
+	Status      string `json:"status"`
+}
+
+// HostingSSL is the SSL certificate of a hosting plan
+type HostingSSL struct {
+	Provider     string `json:"provider"`
+	Type         string `json:"type"`
+	Status       string `json:"status"`
+	Regenerable  bool   `json:"regenerable"`
+	IsReportable bool   `json:"isReportable"`
+}
+
+// hostingEndpoint builds the path of a hosting sub-resource
+func hostingEndpoint(service string, segments ...string) string {
+	builder := NewEndpointBuilder(ResourceHosting).WithID(service)
+	for _, segment := range segments {
+		builder.WithSegment(segment)
+	}
+	return builder.Build()
+}
+
+// ListHostings returns the web hosting plans of the account
+func (c *Client) ListHostings() ([]string, error) {
+	var names []string
+	if err := c.Get(NewEndpointBuilder(ResourceHosting).Build(), &names); err != nil {
+		return nil, fmt.Errorf("failed to list hosting plans: %w", err)
+	}
+	return names, nil
+}
+
+// GetHosting fetches a web hosting plan
+func (c *Client) GetHosting(name string) (*Hosting, error) {
+	var hosting Hosting
+	if err := c.Get(hostingEndpoint(name), &hosting); err != nil {
+		return nil, fmt.Errorf("failed to get hosting plan %s: %w", name, err)
+	}
+	return &hosting, nil
+}
+
+// ListHostingConfigs returns the runtime configurations of a hosting plan
+func (c *Client) ListHostingConfigs(service string) ([]HostingConfig, error) {
+	var ids []int64
+	if err := c.Get(hostingEndpoint(service, "ovhConfig"), &ids); err != nil {
+		return nil, fmt.Errorf("failed to list configurations of %s: %w", service, err)
+	}
+
+	configs := make([]HostingConfig, 0, len(ids))
+	for _, id := range ids {
+		var config HostingConfig
+		if err := c.Get(hostingEndpoint(service, "ovhConfig", strconv.FormatInt(id, 10)), &config); err != nil {
+			return nil, fmt.Errorf("failed to get configuration %d of %s: %w", id, service, err)
+		}
+		configs = append(configs, config)
+	}
+	return configs, nil
+}
+
+// ListHostingDomains returns the domains attached to a hosting plan
+func (c *Client) ListHostingDomains(service string) ([]string, error) {
+	var domains []string
+	if err := c.Get(hostingEndpoint(service, "attachedDomain"), &domains); err != nil {
+		return nil, fmt.Errorf("failed to list domains of %s: %w", service, err)
+	}
+	return domains, nil
+}
+
+// GetHostingDomain fetches a domain attached to a hosting plan
+func (c *Client) GetHostingDomain(service, domain string) (*HostingDomain, error) {
+	var attached HostingDomain
+	if err := c.Get(hostingEndpoint(service, "attachedDomain", domain), &attached); err != nil {
+		return nil, fmt.Errorf("failed to get domain %s of %s: %w", domain, service, err)
+	}
+	return &attached, nil
+}
+
+// ListHostingUsers returns the FTP/SSH logins of a hosting plan
+func (c *Client) ListHostingUsers(service string) ([]string, error) {
+	var logins []string
+	if err := c.Get(hostingEndpoint(service, "user"), &logins); err != nil {
+		return nil, fmt.Errorf("failed to list users of %s: %w", service, err)
+	}
+	return logins, nil
+}
+
+// GetHostingUser fetches an FTP/SSH user of a hosting plan
+func (c *Client) GetHostingUser(service, login string) (*HostingUser, error) {
+	var user HostingUser
+	if err := c.Get(hostingEndpoint(service, "user", login), &user); err != nil {
+		return nil, fmt.Errorf("failed to get user %s of %s: %w", login, service, err)
+	}
+	return &user, nil
+}
+
+// ListHostingDatabases returns the database names of a hosting plan
+func (c *Client) ListHostingDatabases(service string) ([]string, error) {
+	var names []string
+	if err := c.Get(hostingEndpoint(service, "database"), &names); err != nil {
+		return nil, fmt.Errorf("failed to list databases of %s: %w", service, err)
+	}
+	return names, nil
+}
+
+// GetHostingDatabase fetches a database of a hosting plan
+func (c *Client) GetHostingDatabase(service, name string) (*HostingDatabase, error) {
+	var database HostingDatabase
+	if err := c.Get(hostingEndpoint(service, "database", name), &database); err != nil {
+		return nil, fmt.Errorf("failed to get database %s of %s: %w", name, service, err)
+	}
+	return &database, nil
+}
+
+// ListHostingCrons returns the scheduled task IDs of a hosting plan
+func (c *Client) ListHostingCrons(service string) ([]int64, error) {
+	var ids []int64
+	if err := c.Get(hostingEndpoint(service, "cron"), &ids); err != nil {
+		return nil, fmt.Errorf("failed to list cron jobs of %s: %w", service, err)
+	}
+	return ids, nil
+}
+
+// GetHostingCron fetches a scheduled task of a hosting plan
+func (c *Client) GetHostingCron(service string, id int64) (*HostingCron, error) {
+	var cron HostingCron
+	if err := c.Get(hostingEndpoint(service, "cron", strconv.FormatInt(id, 10)), &cron); err != nil {
+		return nil, fmt.Errorf("failed to get cron job %d of %s: %w", id, service, err)
+	}
+	return &cron, nil
+}
+
+// GetHostingSSL fetches the SSL certificate of a hosting plan. Plans
+// without a certificate return nil without error.
+func (c *Client) GetHostingSSL(service string) (*HostingSSL, error) {
+	var ssl HostingSSL
+	if err := c.Get(hostingEndpoint(service, "ssl"), &ssl); err != nil {
+		if IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get SSL certificate of %s: %w", service, err)
+	}
+	return &ssl, nil
+}
+
+// ListHostingSSLDomains returns the domains covered by the certificate
+func (c *Client) ListHostingSSLDomains(service string) ([]string, error) {
+	var domains []string
+	if err := c.Get(hostingEndpoint(service, "ssl", "domains"), &domains); err != nil {
+		return nil, fmt.Errorf("failed to list SSL domains of %s: %w", service, err)
+	}
+	return domains, nil
+}
@@ -0,0 +1,113 @@
+// internal/api/hosting_database.go
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// HostingTask status values
+const (
+	HostingTaskTodo      = "todo"
+	HostingTaskInit      = "init"
+	HostingTaskDoing     = "doing"
+	HostingTaskDone      = "done"
+	HostingTaskError     = "error"
+	HostingTaskCancelled = "cancelled"
+)
+
+// Dump dates: a fresh export, or the automatic backup of the previous day
+// or week
+const (
+	DumpNow       = "now"
+	DumpYesterday = "daily.1"
+	DumpLastWeek  = "weekly.1"
+)
+
+// HostingTask is an asynchronous operation on a hosting plan
+type HostingTask struct {
+	ID         int64      `json:"id"`
+	Function   string     `json:"function"`
+	Status     string     `json:"status"`
+	ObjectID   string     `json:"objectId"`
+	ObjectType string     `json:"objectType"`
+	StartDate  *time.Time `json:"startDate"`
+	DoneDate   *time.Time `json:"doneDate"`
+}
+
+// IsDone checks if the task completed successfully
+func (t *HostingTask) IsDone() bool {
+	return t.Status == HostingTaskDone
+}
+
+// IsFailed checks if the task ended without completing
+func (t *HostingTask) IsFailed() bool {
+	return t.Status == HostingTaskError || t.Status == HostingTaskCancelled
+}
+
+// HostingDump is an export of a hosting database
+type HostingDump struct {
+	ID           int64      `json:"id"`
+	DatabaseName string     `json:"databaseName"`
+	Type         string     `json:"type"`
+	Status       string     `json:"status"`
+	URL          string     `json:"url"`
+	Orphan       bool       `json:"orphan"`
+	CreationDate *time.Time `json:"creationDate"`
+	DeletionDate *time.Time `json:"deletionDate"`
+}
+
+// dumpEndpoint builds the path of a database dump
+func dumpEndpoint(service, database string, dumpID int64, segments ...string) string {
+	segments = append([]string{"database", database, "dump", strconv.FormatInt(dumpID, 10)}, segments...)
+	return hostingEndpoint(service, segments...)
+}
+
+// GetHostingTask retrieves the status of a task on a hosting plan
+func (c *Client) GetHostingTask(service string, taskID int64) (*HostingTask, error) {
+	var task HostingTask
+	if err := c.Get(hostingEndpoint(service, "tasks", strconv.FormatInt(taskID, 10)), &task); err != nil {
+		return nil, fmt.Errorf("failed to get task %d of %s: %w", taskID, service, err)
+	}
+	return &task, nil
+}
+
+// CreateHostingDatabaseDump exports a database. date is DumpNow or one of
+// the automatic backups.
+func (c *Client) CreateHostingDatabaseDump(service, database, date string) (*HostingTask, error) {
+	payload := map[string]interface{}{"date": date, "sendEmail": false}
+
+	var task HostingTask
+	if err := c.Post(hostingEndpoint(service, "database", database, "dump"), payload, &task); err != nil {
+		return nil, fmt.Errorf("failed to dump database %s of %s: %w", database, service, err)
+	}
+	return &task, nil
+}
+
+// ListHostingDatabaseDumps returns the dump IDs of a database
+func (c *Client) ListHostingDatabaseDumps(service, database string) ([]int64, error) {
+	var ids []int64
+	if err := c.Get(hostingEndpoint(service, "database", database, "dump"), &ids); err != nil {
+		return nil, fmt.Errorf("failed to list dumps of %s: %w", database, err)
+	}
+	return ids, nil
+}
+
+// GetHostingDatabaseDump fetches a dump with its download link
+func (c *Client) GetHostingDatabaseDump(service, database string, dumpID int64) (*HostingDump, error) {
+	var dump HostingDump
+	if err := c.Get(dumpEndpoint(service, database, dumpID), &dump); err != nil {
+		return nil, fmt.Errorf("failed to get dump %d of %s: %w", dumpID, database, err)
+	}
+	return &dump, nil
+}
+
+// RestoreHostingDatabaseDump replaces the content of a database with a dump
+func (c *Client) RestoreHostingDatabaseDump(service, database string, dumpID int64) (*HostingTask, error) {
+	var task HostingTask
+	if err := c.Post(dumpEndpoint(service, database, dumpID, "restore"), nil, &task); err != nil {
+		return nil, fmt.Errorf("failed to restore dump %d of %s: %w", dumpID, database, err)
+	}
+	return &task, nil
+}
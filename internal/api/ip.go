@@ -0,0 +1,162 @@
+// internal/api/ip.go
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// IPReverse represents the reverse DNS entry of a single address
+type IPReverse struct {
+	IPReverse string `json:"ipReverse"`
+	Reverse   string `json:"reverse"`
+}
+
+// IPDestination is a service an IP block can be moved to
+type IPDestination struct {
+	Service string   `json:"service"`
+	Nexthop []string `json:"nexthop"`
+}
+
+// IPDestinations lists eligible move destinations by service type
+type IPDestinations struct {
+	DedicatedServer []IPDestination `json:"dedicatedServer"`
+	VPS             []IPDestination `json:"vps"`
+	CloudProject    []IPDestination `json:"cloudProject"`
+	DedicatedCloud  []IPDestination `json:"dedicatedCloud"`
+	IPLoadbalancing []IPDestination `json:"ipLoadbalancing"`
+}
+
+// IPTask status values
+const (
+	IPTaskTodo          = "todo"
+	IPTaskInit          = "init"
+	IPTaskDoing         = "doing"
+	IPTaskDone          = "done"
+	IPTaskCancelled     = "cancelled"
+	IPTaskCustomerError = "customerError"
+	IPTaskOVHError      = "ovhError"
+)
+
+// IPTask represents an asynchronous operation on an IP block
+type IPTask struct {
+	TaskID      int         `json:"taskId"`
+	Function    string      `json:"function"`
+	Status      string      `json:"status"`
+	Comment     string      `json:"comment"`
+	Destination *IPRoutedTo `json:"destination"`
+	StartDate   *time.Time  `json:"startDate"`
+	DoneDate    *time.Time  `json:"doneDate"`
+}
+
+// IsDone checks if the task completed successfully
+func (t *IPTask) IsDone() bool {
+	return t.Status == IPTaskDone
+}
+
+// IsFailed checks if the task ended without completing
+func (t *IPTask) IsFailed() bool {
+	switch t.Status {
+	case IPTaskCancelled, IPTaskCustomerError, IPTaskOVHError:
+		return true
+	}
+	return false
+}
+
+// GetIPActionEndpoint builds an endpoint below an IP block
+func GetIPActionEndpoint(block, action string) string {
+	return NewEndpointBuilder(ResourceIP).
+		WithID(url.PathEscape(block)).
+		WithAction(action).
+		Build()
+}
+
+// getIPReverseEndpoint builds the endpoint of a single reverse entry
+func getIPReverseEndpoint(block, ip string) string {
+	return NewEndpointBuilder(ResourceIP).
+		WithID(url.PathEscape(block)).
+		WithAction("reverse").
+		WithID(ip).
+		Build()
+}
+
+// ListIPReverses retrieves the addresses of a block that have a reverse
+func (c *Client) ListIPReverses(block string) ([]string, error) {
+	var ips []string
+	err := c.Get(GetIPActionEndpoint(block, "reverse"), &ips)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reverses for %s: %w", block, err)
+	}
+	return ips, nil
+}
+
+// GetIPReverse retrieves the reverse of an address in a block
+func (c *Client) GetIPReverse(block, ip string) (*IPReverse, error) {
+	var reverse IPReverse
+	err := c.Get(getIPReverseEndpoint(block, ip), &reverse)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reverse for %s: %w", ip, err)
+	}
+	return &reverse, nil
+}
+
+// SetIPReverse creates or replaces the reverse of an address in a block
+func (c *Client) SetIPReverse(block, ip, reverse string) (*IPReverse, error) {
+	payload := IPReverse{IPReverse: ip, Reverse: reverse}
+	var result IPReverse
+	err := c.Post(GetIPActionEndpoint(block, "reverse"), payload, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set reverse for %s: %w", ip, err)
+	}
+	return &result, nil
+}
+
+// DeleteIPReverse removes the reverse of an address in a block
+func (c *Client) DeleteIPReverse(block, ip string) error {
+	err := c.Delete(getIPReverseEndpoint(block, ip), nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete reverse for %s: %w", ip, err)
+	}
+	return nil
+}
+
+// GetIPMoveDestinations retrieves the services a block can be moved to
+func (c *Client) GetIPMoveDestinations(block string) (*IPDestinations, error) {
+	var destinations IPDestinations
+	err := c.Get(GetIPActionEndpoint(block, "move"), &destinations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get move destinations for %s: %w", block, err)
+	}
+	return &destinations, nil
+}
+
+// MoveIP routes a block to another service, nexthop is optional
+func (c *Client) MoveIP(block, to, nexthop string) (*IPTask, error) {
+	payload := map[string]string{"to": to}
+	if nexthop != "" {
+		payload["nexthop"] = nexthop
+	}
+
+	var task IPTask
+	err := c.Post(GetIPActionEndpoint(block, "move"), payload, &task)
+	if err != nil {
+		return nil, fmt.Errorf("failed to move %s to %s: %w", block, to, err)
+	}
+	return &task, nil
+}
+
+// GetIPTask retrieves the status of a task on a block
+func (c *Client) GetIPTask(block string, taskID int) (*IPTask, error) {
+	var task IPTask
+	endpoint := NewEndpointBuilder(ResourceIP).
+		WithID(url.PathEscape(block)).
+		WithAction("task").
+		WithID(fmt.Sprint(taskID)).
+		Build()
+	err := c.Get(endpoint, &task)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task %d of %s: %w", taskID, block, err)
+	}
+	return &task, nil
+}
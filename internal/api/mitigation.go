@@ -0,0 +1,174 @@
+// internal/api/mitigation.go
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// IPMitigationStateOK is the state of settled mitigations and profiles
+const IPMitigationStateOK = "ok"
+
+// Blocked address states reported by the antihack and spam endpoints
+const (
+	IPBlockedStateBlocked    = "blocked"
+	IPBlockedStateUnblocking = "unblocking"
+	IPBlockedStateUnblocked  = "unblocked"
+	IPSpamStateBlocked       = "blockedForSpam"
+)
+
+// IPMitigation is the anti-DDoS mitigation of an address. Auto means
+// mitigation was triggered by an attack, permanent that it was forced.
+type IPMitigation struct {
+	IPOnMitigation string `json:"ipOnMitigation"`
+	Permanent      bool   `json:"permanent"`
+	Auto           bool   `json:"auto"`
+	State          string `json:"state"`
+}
+
+// IPMitigationProfile sets how long auto mitigation stays on after an attack
+type IPMitigationProfile struct {
+	IPMitigationProfile   string `json:"ipMitigationProfile"`
+	AutoMitigationTimeOut int    `json:"autoMitigationTimeOut"`
+	State                 string `json:"state"`
+}
+
+// IPAntihack is an address blocked for hacking activity
+type IPAntihack struct {
+	IPBlocked string `json:"ipBlocked"`
+	Logs      string `json:"logs"`
+	State     string `json:"state"`
+	Time      int    `json:"time"`
+}
+
+// IPSpam is an address blocked for sending spam
+type IPSpam struct {
+	IPSpamming string     `json:"ipSpamming"`
+	Date       *time.Time `json:"date"`
+	State      string     `json:"state"`
+	Time       int        `json:"time"`
+}
+
+// ipSubresourceEndpoint builds an endpoint below an address of a block
+func ipSubresourceEndpoint(block, resource, ip string, segments ...string) string {
+	eb := NewEndpointBuilder(ResourceIP).
+		WithID(url.PathEscape(block)).
+		WithAction(resource).
+		WithID(ip)
+	for _, segment := range segments {
+		eb.WithSegment(segment)
+	}
+	return eb.Build()
+}
+
+// ListIPMitigations retrieves the addresses of a block under mitigation
+func (c *Client) ListIPMitigations(block string) ([]string, error) {
+	var ips []string
+	err := c.Get(GetIPActionEndpoint(block, "mitigation"), &ips)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list mitigations of %s: %w", block, err)
+	}
+	return ips, nil
+}
+
+// GetIPMitigation retrieves the mitigation of an address
+func (c *Client) GetIPMitigation(block, ip string) (*IPMitigation, error) {
+	var mitigation IPMitigation
+	err := c.Get(ipSubresourceEndpoint(block, "mitigation", ip), &mitigation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mitigation of %s: %w", ip, err)
+	}
+	return &mitigation, nil
+}
+
+// ListMitigationProfiles retrieves the addresses of a block with a profile
+func (c *Client) ListMitigationProfiles(block string) ([]string, error) {
+	var ips []string
+	err := c.Get(GetIPActionEndpoint(block, "mitigationProfiles"), &ips)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list mitigation profiles of %s: %w", block, err)
+	}
+	return ips, nil
+}
+
+// GetMitigationProfile retrieves the mitigation profile of an address
+func (c *Client) GetMitigationProfile(block, ip string) (*IPMitigationProfile, error) {
+	var profile IPMitigationProfile
+	err := c.Get(ipSubresourceEndpoint(block, "mitigationProfiles", ip), &profile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mitigation profile of %s: %w", ip, err)
+	}
+	return &profile, nil
+}
+
+// ListAntihack retrieves the addresses of a block blocked for hacking,
+// optionally filtered by state
+func (c *Client) ListAntihack(block, state string) ([]string, error) {
+	var ips []string
+	endpoint := NewEndpointBuilder(ResourceIP).
+		WithID(url.PathEscape(block)).
+		WithAction("antihack").
+		WithParameter("state", state).
+		Build()
+	err := c.Get(endpoint, &ips)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list anti-hack blocks of %s: %w", block, err)
+	}
+	return ips, nil
+}
+
+// GetAntihack retrieves the anti-hack block of an address
+func (c *Client) GetAntihack(block, ip string) (*IPAntihack, error) {
+	var blocked IPAntihack
+	err := c.Get(ipSubresourceEndpoint(block, "antihack", ip), &blocked)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get anti-hack block of %s: %w", ip, err)
+	}
+	return &blocked, nil
+}
+
+// UnblockAntihack requests the anti-hack block of an address to be lifted
+func (c *Client) UnblockAntihack(block, ip string) error {
+	err := c.Post(ipSubresourceEndpoint(block, "antihack", ip, "unblock"), nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to unblock %s: %w", ip, err)
+	}
+	return nil
+}
+
+// ListSpam retrieves the addresses of a block blocked for spam,
+// optionally filtered by state
+func (c *Client) ListSpam(block, state string) ([]string, error) {
+	var ips []string
+	endpoint := NewEndpointBuilder(ResourceIP).
+		WithID(url.PathEscape(block)).
+		WithAction("spam").
+		WithParameter("state", state).
+		Build()
+	err := c.Get(endpoint, &ips)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list spam blocks of %s: %w", block, err)
+	}
+	return ips, nil
+}
+
+// GetSpam retrieves the spam block of an address
+func (c *Client) GetSpam(block, ip string) (*IPSpam, error) {
+	var spam IPSpam
+	err := c.Get(ipSubresourceEndpoint(block, "spam", ip), &spam)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spam block of %s: %w", ip, err)
+	}
+	return &spam, nil
+}
+
+// UnblockSpam requests the spam block of an address to be lifted
+func (c *Client) UnblockSpam(block, ip string) (*IPSpam, error) {
+	var spam IPSpam
+	err := c.Post(ipSubresourceEndpoint(block, "spam", ip, "unblock"), nil, &spam)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unblock %s: %w", ip, err)
+	}
+	return &spam, nil
+}
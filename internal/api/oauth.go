@@ -0,0 +1,57 @@
+// internal/api/oauth.go
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"ovh-terminal/internal/config"
+
+	ovh "github.com/ovh/go-ovh/ovh"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// tokenTimeout bounds a single OAuth2 token request
+const tokenTimeout = 30 * time.Second
+
+// newOAuth2Client creates an OVH client authenticated with the client
+// credentials of an IAM service account. A first token is acquired up
+// front so bad credentials fail at startup, later requests go through an
+// oauth2.Transport that refreshes the token whenever it expires.
+func newOAuth2Client(cfg *config.AccountConfig, base http.RoundTripper) (*ovh.Client, error) {
+	conf := &clientcredentials.Config{
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		TokenURL:     cfg.OAuth2TokenURL(),
+		Scopes:       []string{"all"},
+	}
+
+	// Token requests share the account transport (CA bundle, proxy)
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{
+		Transport: base,
+		Timeout:   tokenTimeout,
+	})
+	tokens := conf.TokenSource(ctx)
+
+	token, err := tokens.Token()
+	if err != nil {
+		authErr := NewAuthError("Failed to obtain OAuth2 access token", err)
+		authErr.AuthMode = config.AuthOAuth2
+		return nil, authErr
+	}
+
+	client, err := ovh.NewAccessTokenClient(cfg.Endpoint, token.AccessToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OVH client: %w", err)
+	}
+
+	client.Client.Transport = &oauth2.Transport{
+		Source: tokens,
+		Base:   base,
+	}
+
+	return client, nil
+}
@@ -0,0 +1,82 @@
+// internal/api/services.go
+package api
+
+import (
+	"fmt"
+	"time"
+)
+
+// ServiceRenew is the renewal configuration of a service
+type ServiceRenew struct {
+	Automatic          bool `json:"automatic"`
+	DeleteAtExpiration bool `json:"deleteAtExpiration"`
+	Forced             bool `json:"forced"`
+	ManualPayment      bool `json:"manualPayment,omitempty"`
+	Period             int  `json:"period,omitempty"`
+}
+
+// ServiceInfos is the subscription of a service. Dates are plain dates.
+type ServiceInfos struct {
+	ServiceID             int64         `json:"serviceId"`
+	Domain                string        `json:"domain"`
+	Status                string        `json:"status"`
+	Creation              string        `json:"creation"`
+	Expiration            string        `json:"expiration"`
+	EngagedUpTo           string        `json:"engagedUpTo,omitempty"`
+	RenewalType           string        `json:"renewalType"`
+	Renew                 *ServiceRenew `json:"renew"`
+	CanDeleteAtExpiration bool          `json:"canDeleteAtExpiration"`
+	PossibleRenewPeriod   []int         `json:"possibleRenewPeriod,omitempty"`
+}
+
+// serviceDateLayout is the format of service dates
+const serviceDateLayout = "2006-01-02"
+
+// ExpirationDate parses the expiration date, false when it is unset
+func (s *ServiceInfos) ExpirationDate() (time.Time, bool) {
+	return parseServiceDate(s.Expiration)
+}
+
+// EngagementDate parses the end of the engagement, false when the service
+// has none
+func (s *ServiceInfos) EngagementDate() (time.Time, bool) {
+	return parseServiceDate(s.EngagedUpTo)
+}
+
+// parseServiceDate parses a service date, ignoring a time part
+func parseServiceDate(value string) (time.Time, bool) {
+	if len(value) > len(serviceDateLayout) {
+		value = value[:len(serviceDateLayout)]
+	}
+	date, err := time.Parse(serviceDateLayout, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+// serviceInfosEndpoint builds the subscription path of a service
+func serviceInfosEndpoint(resource ResourceType, name string) string {
+	return NewEndpointBuilder(resource).WithID(name).WithSegment("serviceInfos").Build()
+}
+
+// GetServiceInfos fetches the subscription of a service, such as a
+// dedicated server, VPS, domain or hosting plan
+func (c *Client) GetServiceInfos(resource ResourceType, name string) (*ServiceInfos, error) {
+	var infos ServiceInfos
+	if err := c.Get(serviceInfosEndpoint(resource, name), &infos); err != nil {
+		return nil, fmt.Errorf("failed to get service infos of %s: %w", name, err)
+	}
+	return &infos, nil
+}
+
+// UpdateServiceRenew changes the renewal configuration of a service
+func (c *Client) UpdateServiceRenew(resource ResourceType, name string, renew ServiceRenew) error {
+	payload := struct {
+		Renew ServiceRenew `json:"renew"`
+	}{Renew: renew}
+	if err := c.Put(serviceInfosEndpoint(resource, name), payload, nil); err != nil {
+		return fmt.Errorf("failed to update renewal of %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// internal/api/support.go
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Support ticket states
+const (
+	TicketOpen   = "open"
+	TicketClosed = "closed"
+)
+
+// Authors of support messages
+const (
+	MessageFromCustomer = "customer"
+	MessageFromSupport  = "support"
+)
+
+// SupportTicket is a support request of the account
+type SupportTicket struct {
+	TicketID        int64      `json:"ticketId"`
+	TicketNumber    int64      `json:"ticketNumber"`
+	Subject         string     `json:"subject"`
+	State           string     `json:"state"`
+	Product         string     `json:"product"`
+	Category        string     `json:"category"`
+	Type            string     `json:"type"`
+	ServiceName     string     `json:"serviceName"`
+	LastMessageFrom string     `json:"lastMessageFrom"`
+	CanBeClosed     bool       `json:"canBeClosed"`
+	CreationDate    *time.Time `json:"creationDate"`
+	UpdateDate      *time.Time `json:"updateDate"`
+}
+
+// SupportMessage is a message of a support ticket
+type SupportMessage struct {
+	MessageID    int64      `json:"messageId"`
+	TicketID     int64      `json:"ticketId"`
+	From         string     `json:"from"`
+	Body         string     `json:"body"`
+	CreationDate *time.Time `json:"creationDate"`
+	UpdateDate   *time.Time `json:"updateDate"`
+}
+
+// supportMessageBody is the payload of replies and reopenings
+type supportMessageBody struct {
+	Body string `json:"body"`
+}
+
+// supportTicketEndpoint builds the path of a ticket sub-resource
+func supportTicketEndpoint(ticketID int64, segments ...string) string {
+	endpoint := GetSupportTicketEndpoint(strconv.FormatInt(ticketID, 10))
+	for _, segment := range segments {
+		endpoint += "/" + segment
+	}
+	return endpoint
+}
+
+// ListSupportTickets returns the support ticket IDs of the account
+func (c *Client) ListSupportTickets() ([]int64, error) {
+	var ids []int64
+	endpoint := NewEndpointBuilder(ResourceSupport).WithSegment("tickets").Build()
+	if err := c.Get(endpoint, &ids); err != nil {
+		return nil, fmt.Errorf("failed to list support tickets: %w", err)
+	}
+	return ids, nil
+}
+
+// GetSupportTicket fetches a support ticket
+func (c *Client) GetSupportTicket(ticketID int64) (*SupportTicket, error) {
+	var ticket SupportTicket
+	if err := c.Get(supportTicketEndpoint(ticketID), &ticket); err != nil {
+		return nil, fmt.Errorf("failed to get support ticket %d: %w", ticketID, err)
+	}
+	return &ticket, nil
+}
+
+// ListSupportMessages returns the messages of a ticket
+func (c *Client) ListSupportMessages(ticketID int64) ([]SupportMessage, error) {
+	var messages []SupportMessage
+	if err := c.Get(supportTicketEndpoint(ticketID, "messages"), &messages); err != nil {
+		return nil, fmt.Errorf("failed to list messages of ticket %d: %w", ticketID, err)
+	}
+	return messages, nil
+}
+
+// ReplySupportTicket adds a message to an open ticket
+func (c *Client) ReplySupportTicket(ticketID int64, body string) error {
+	err := c.Post(supportTicketEndpoint(ticketID, "reply"), supportMessageBody{Body: body}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to reply to ticket %d: %w", ticketID, err)
+	}
+	return nil
+}
+
+// CloseSupportTicket closes a ticket
+func (c *Client) CloseSupportTicket(ticketID int64) error {
+	if err := c.Post(supportTicketEndpoint(ticketID, "close"), nil, nil); err != nil {
+		return fmt.Errorf("failed to close ticket %d: %w", ticketID, err)
+	}
+	return nil
+}
+
+// ReopenSupportTicket reopens a closed ticket with a message
+func (c *Client) ReopenSupportTicket(ticketID int64, body string) error {
+	err := c.Post(supportTicketEndpoint(ticketID, "reopen"), supportMessageBody{Body: body}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to reopen ticket %d: %w", ticketID, err)
+	}
+	return nil
+}
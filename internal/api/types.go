@@ -134,12 +134,15 @@ func (s *ServerInfo) GetDisplayTitle() string {
 
 // DomainInfo represents domain information
 type DomainInfo struct {
-	Domain       string    `json:"domain"`
-	NameServers  []string  `json:"nameServers"`
-	DnssecStatus string    `json:"dnssecStatus"`
-	LastUpdate   string    `json:"lastUpdate"`
-	WhoisOwner   string    `json:"whoisOwner"`
-	Expiration   time.Time `json:"expiration"`
+	Domain             string    `json:"domain"`
+	NameServers        []string  `json:"nameServers"`
+	NameServerType     string    `json:"nameServerType"`
+	DnssecStatus       string    `json:"dnssecStatus"`
+	DnssecSupported    bool      `json:"dnssecSupported"`
+	TransferLockStatus string    `json:"transferLockStatus"`
+	LastUpdate         string    `json:"lastUpdate"`
+	WhoisOwner         string    `json:"whoisOwner"`
+	Expiration         time.Time `json:"expiration"`
 }
 
 // IsExpired checks if the domain has expired
@@ -161,18 +164,25 @@ func (d *DomainInfo) GetFormattedNameServers() string {
 type IPType string
 
 const (
-	IPTypeFailover IPType = "failover"
-	IPTypeCloud    IPType = "cloud"
-	IPTypeVPS      IPType = "vps"
+	IPTypeDedicated IPType = "dedicated"
+	IPTypeFailover  IPType = "failover"
+	IPTypeCloud     IPType = "cloud"
+	IPTypeVPS       IPType = "vps"
 )
 
+// IPRoutedTo identifies the service an IP block is routed to
+type IPRoutedTo struct {
+	ServiceName string `json:"serviceName"`
+}
+
 // IPInfo represents IP information
 type IPInfo struct {
-	IP          string   `json:"ip"`
-	Type        IPType   `json:"type"`
-	Description string   `json:"description"`
-	RoutedTo    string   `json:"routedTo"`
-	IPBlocks    []string `json:"ipBlock"`
+	IP          string      `json:"ip"`
+	Type        IPType      `json:"type"`
+	Description string      `json:"description"`
+	RoutedTo    *IPRoutedTo `json:"routedTo"`
+	Country     string      `json:"country"`
+	IPBlocks    []string    `json:"ipBlock"`
 }
 
 // IsFailover checks if this is a failover IP
@@ -183,6 +193,8 @@ func (i *IPInfo) IsFailover() bool {
 // GetFormattedType returns a human-readable IP type
 func (i *IPInfo) GetFormattedType() string {
 	switch i.Type {
+	case IPTypeDedicated:
+		return "Dedicated IP"
 	case IPTypeFailover:
 		return "Failover IP"
 	case IPTypeCloud:
@@ -194,6 +206,14 @@ func (i *IPInfo) GetFormattedType() string {
 	}
 }
 
+// GetRoutedService returns the service the block is routed to, if any
+func (i *IPInfo) GetRoutedService() string {
+	if i.RoutedTo == nil {
+		return ""
+	}
+	return i.RoutedTo.ServiceName
+}
+
 // GetFormattedDescription returns a description or default text
 func (i *IPInfo) GetFormattedDescription() string {
 	if i.Description != "" {
@@ -0,0 +1,572 @@
+// internal/commands/billing.go
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"ovh-terminal/internal/api"
+	"ovh-terminal/internal/format"
+	"ovh-terminal/internal/logger"
+)
+
+// Invoice document formats offered by DownloadBillCommand
+const (
+	BillPDF  = "pdf"
+	BillHTML = "html"
+)
+
+// Spending summary periods
+const (
+	SpendingMonthly = "monthly"
+	SpendingYearly  = "yearly"
+)
+
+// invoiceMonths is how far back the invoices list goes
+const invoiceMonths = 12
+
+// Invoice is a bill with its payment, nil when unpaid
+type Invoice struct {
+	Bill    *api.Bill
+	Payment *api.BillPayment
+}
+
+// Status describes the payment status of the invoice
+func (i Invoice) Status() string {
+	if i.Payment == nil {
+		return "unpaid"
+	}
+	return "paid " + format.Date(i.Payment.PaymentDate)
+}
+
+// Summary describes the invoice on one line, for menus
+func (i Invoice) Summary() string {
+	return strings.Join([]string{
+		format.Date(i.Bill.Date),
+		i.Bill.PriceWithTax.Text,
+		i.Status(),
+	}, " · ")
+}
+
+// loadInvoices fetches the bills issued since from, newest first
+func loadInvoices(client *api.Client, log *logger.Logger, from time.Time) ([]Invoice, error) {
+	ids, err := client.ListBills(from, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	invoices := make([]Invoice, 0, len(ids))
+	for _, id := range ids {
+		bill, err := client.GetBill(id)
+		if err != nil {
+			return nil, err
+		}
+		payment, err := client.GetBillPayment(id)
+		if err != nil {
+			log.Error("Failed to get bill payment", "bill", id, "error", err)
+		}
+		invoices = append(invoices, Invoice{Bill: bill, Payment: payment})
+	}
+
+	sort.SliceStable(invoices, func(i, j int) bool {
+		a, b := invoices[i].Bill.Date, invoices[j].Bill.Date
+		if a == nil || b == nil {
+			return b == nil && a != nil
+		}
+		return a.After(*b)
+	})
+	return invoices, nil
+}
+
+// invoicesSince returns the start of the invoices list
+func invoicesSince(now time.Time) time.Time {
+	return time.Date(now.Year(), now.Month()-invoiceMonths+1, 1, 0, 0, 0, 0, now.Location())
+}
+
+// InvoicesCommand lists the invoices of the last twelve months
+type InvoicesCommand struct {
+	BaseCommand
+	client *api.Client
+	log    *logger.Logger
+}
+
+// NewInvoicesCommand creates a new invoices command instance
+func NewInvoicesCommand(client *api.Client) *InvoicesCommand {
+	return &InvoicesCommand{
+		BaseCommand: NewBaseCommand(TypeInfo),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "invoices"}),
+	}
+}
+
+// Execute implements the Command interface
+func (c *InvoicesCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *InvoicesCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *InvoicesCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// Invoices returns the invoices of the last twelve months, newest first
+func (c *InvoicesCommand) Invoices() ([]Invoice, error) {
+	return loadInvoices(c.client, c.log, invoicesSince(time.Now()))
+}
+
+// executeCommand handles the actual command execution
+func (c *InvoicesCommand) executeCommand() (string, error) {
+	c.log.Debug("Executing invoices command")
+
+	invoices, err := c.Invoices()
+	if err != nil {
+		return "", err
+	}
+
+	output := format.NewOutputFormatter(
+		format.WithMaxWidth(maxWidth),
+		format.WithSeparator("\n"),
+	)
+	section := output.AddSection("Invoices")
+	section.SetConfig(format.SectionConfig{
+		KeyValueSpacing: keyValueSpacing,
+		TitleDecorator:  "=",
+	})
+	if len(invoices) == 0 {
+		section.AddField("Invoices", fmt.Sprintf("No invoices in the last %d months", invoiceMonths))
+	}
+	unpaid := 0
+	for _, invoice := range invoices {
+		if invoice.Payment == nil {
+			unpaid++
+		}
+		section.AddField(invoice.Bill.BillID, invoice.Summary())
+	}
+	if unpaid > 0 {
+		section.AddField("Unpaid", fmt.Sprintf("%d of %d", unpaid, len(invoices)))
+	}
+
+	return output.String(), nil
+}
+
+// BillCommand shows an invoice with its lines and payment
+type BillCommand struct {
+	BaseCommand
+	client *api.Client
+	log    *logger.Logger
+	billID string
+}
+
+// NewBillCommand creates a new bill command instance
+func NewBillCommand(client *api.Client, billID string) *BillCommand {
+	return &BillCommand{
+		BaseCommand: NewBaseCommand(TypeInfo),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "bill"}),
+		billID:      billID,
+	}
+}
+
+// Execute implements the Command interface
+func (c *BillCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *BillCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *BillCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// executeCommand handles the actual command execution
+func (c *BillCommand) executeCommand() (string, error) {
+	c.log.Debug("Executing bill command", "bill", c.billID)
+
+	bill, err := c.client.GetBill(c.billID)
+	if err != nil {
+		return "", err
+	}
+	payment, err := c.client.GetBillPayment(c.billID)
+	if err != nil {
+		return "", err
+	}
+	details, err := loadBillDetails(c.client, c.billID)
+	if err != nil {
+		return "", err
+	}
+
+	output := format.NewOutputFormatter(
+		format.WithMaxWidth(maxWidth),
+		format.WithSeparator("\n"),
+	)
+	config := format.SectionConfig{
+		KeyValueSpacing: keyValueSpacing,
+		TitleDecorator:  "=",
+	}
+
+	invoice := Invoice{Bill: bill, Payment: payment}
+	section := output.AddSection("Invoice")
+	section.SetConfig(config)
+	section.AddField("Invoice", bill.BillID)
+	section.AddField("Date", format.Date(bill.Date))
+	if bill.OrderID != 0 {
+		section.AddField("Order", fmt.Sprintf("%d", bill.OrderID))
+	}
+	if bill.Category != "" {
+		section.AddField("Category", bill.Category)
+	}
+	section.AddField("Without tax", bill.PriceWithoutTax.Text)
+	section.AddField("Tax", bill.Tax.Text)
+	section.AddField("Total", bill.PriceWithTax.Text)
+	section.AddField("Status", invoice.Status())
+	if payment != nil && payment.PaymentType != "" {
+		section.AddField("Payment", payment.PaymentType)
+	}
+
+	section = output.AddSection("Details")
+	section.SetConfig(config)
+	if len(details) == 0 {
+		section.AddField("Lines", "None")
+	}
+	for _, detail := range details {
+		lines := []string{fmt.Sprintf("%s × %s = %s",
+			detail.Quantity, detail.UnitPrice.Text, detail.TotalPrice.Text)}
+		if detail.Domain != "" {
+			lines = append(lines, detail.Domain)
+		}
+		if detail.PeriodStart != "" {
+			lines = append(lines, fmt.Sprintf("%s to %s", detail.PeriodStart, detail.PeriodEnd))
+		}
+		section.AddLines(detail.Description, lines)
+	}
+
+	return output.String(), nil
+}
+
+// loadBillDetails fetches the lines of a bill
+func loadBillDetails(client *api.Client, billID string) ([]api.BillDetail, error) {
+	ids, err := client.ListBillDetails(billID)
+	if err != nil {
+		return nil, err
+	}
+	details := make([]api.BillDetail, 0, len(ids))
+	for _, id := range ids {
+		detail, err := client.GetBillDetail(billID, id)
+		if err != nil {
+			return nil, err
+		}
+		details = append(details, *detail)
+	}
+	return details, nil
+}
+
+// DownloadBillCommand saves the PDF or HTML document of an invoice
+type DownloadBillCommand struct {
+	BaseCommand
+	client *api.Client
+	log    *logger.Logger
+	billID string
+	kind   string
+}
+
+// NewDownloadBillCommand creates a new invoice download command instance
+func NewDownloadBillCommand(client *api.Client, billID, kind string) *DownloadBillCommand {
+	return &DownloadBillCommand{
+		BaseCommand: NewBaseCommand(TypeAction),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "download_bill"}),
+		billID:      billID,
+		kind:        kind,
+	}
+}
+
+// Execute implements the Command interface
+func (c *DownloadBillCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *DownloadBillCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *DownloadBillCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// NextPrompt implements the InteractiveCommand interface
+func (c *DownloadBillCommand) NextPrompt() (*Prompt, error) {
+	path, ok := c.input("file")
+	if !ok {
+		return &Prompt{Key: "file", Label: "Invoice file", Kind: PromptText, Default: c.defaultPath()}, nil
+	}
+	if _, err := os.Stat(expandPath(path)); err == nil {
+		return c.confirmPrompt(fmt.Sprintf("Overwrite %s?", path)), nil
+	}
+	return nil, nil
+}
+
+// SetInput implements the InteractiveCommand interface
+func (c *DownloadBillCommand) SetInput(key, value string) error {
+	if key == "file" && strings.TrimSpace(value) == "" {
+		value = c.defaultPath()
+	}
+	return c.BaseCommand.SetInput(key, value)
+}
+
+// defaultPath names the document after the invoice
+func (c *DownloadBillCommand) defaultPath() string {
+	return fmt.Sprintf("%s.%s", c.billID, c.kind)
+}
+
+// executeCommand handles the actual command execution
+func (c *DownloadBillCommand) executeCommand() (string, error) {
+	bill, err := c.client.GetBill(c.billID)
+	if err != nil {
+		return "", err
+	}
+
+	var url string
+	switch c.kind {
+	case BillPDF:
+		url = bill.PdfURL
+	case BillHTML:
+		url = bill.URL
+	default:
+		return "", fmt.Errorf("unknown invoice format %q", c.kind)
+	}
+	if url == "" {
+		return "", fmt.Errorf("invoice %s has no %s document", c.billID, strings.ToUpper(c.kind))
+	}
+
+	data, err := c.client.Download(url)
+	if err != nil {
+		return "", err
+	}
+
+	path, ok := c.input("file")
+	if !ok {
+		path = c.defaultPath()
+	}
+	path = expandPath(path)
+	if err := writePrivateFile(path, data); err != nil {
+		return "", err
+	}
+
+	c.log.Info("Downloaded invoice", "bill", c.billID, "format", c.kind, "path", path)
+	return fmt.Sprintf("Saved invoice %s to %s (%s).", c.billID, path, format.Bytes(int64(len(data)))), nil
+}
+
+// BillLines is a bill with its lines
+type BillLines struct {
+	Bill    api.Bill
+	Details []api.BillDetail
+}
+
+// ServiceSpending is the amount billed for a service, without tax
+type ServiceSpending struct {
+	Service string
+	Amount  float64
+}
+
+// SpendingPeriod is the amount billed over a month or a year
+type SpendingPeriod struct {
+	Label    string
+	Bills    int
+	Total    float64
+	Services []ServiceSpending
+}
+
+// SummarizeSpending groups bills by month or year, newest first. Totals
+// include tax; services are broken down from bill lines, without tax,
+// largest first.
+func SummarizeSpending(bills []BillLines, period string) []SpendingPeriod {
+	layout := "2006-01"
+	if period == SpendingYearly {
+		layout = "2006"
+	}
+
+	periods := make(map[string]*SpendingPeriod)
+	services := make(map[string]map[string]float64)
+	for _, bill := range bills {
+		if bill.Bill.Date == nil {
+			continue
+		}
+		label := bill.Bill.Date.Format(layout)
+		summary, ok := periods[label]
+		if !ok {
+			summary = &SpendingPeriod{Label: label}
+			periods[label] = summary
+			services[label] = make(map[string]float64)
+		}
+		summary.Bills++
+		summary.Total += bill.Bill.PriceWithTax.Value
+		for _, detail := range bill.Details {
+			services[label][detailService(detail)] += detail.TotalPrice.Value
+		}
+	}
+
+	result := make([]SpendingPeriod, 0, len(periods))
+	for label, summary := range periods {
+		for service, amount := range services[label] {
+			summary.Services = append(summary.Services, ServiceSpending{Service: service, Amount: amount})
+		}
+		sort.Slice(summary.Services, func(i, j int) bool {
+			a, b := summary.Services[i], summary.Services[j]
+			if a.Amount != b.Amount {
+				return a.Amount > b.Amount
+			}
+			return a.Service < b.Service
+		})
+		result = append(result, *summary)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Label > result[j].Label })
+	return result
+}
+
+// detailService names the service a bill line is for
+func detailService(detail api.BillDetail) string {
+	if detail.Domain != "" {
+		return detail.Domain
+	}
+	if detail.Description != "" {
+		return detail.Description
+	}
+	return "Other"
+}
+
+// spendingSince returns the start of a spending summary: twelve months
+// for monthly summaries, the current and two previous years otherwise
+func spendingSince(now time.Time, period string) time.Time {
+	if period == SpendingYearly {
+		return time.Date(now.Year()-2, time.January, 1, 0, 0, 0, 0, now.Location())
+	}
+	return invoicesSince(now)
+}
+
+// SpendingCommand summarizes the amounts billed per month or per year,
+// with a breakdown by service
+type SpendingCommand struct {
+	BaseCommand
+	client *api.Client
+	log    *logger.Logger
+	period string
+}
+
+// NewSpendingCommand creates a new spending summary command instance
+func NewSpendingCommand(client *api.Client, period string) *SpendingCommand {
+	return &SpendingCommand{
+		BaseCommand: NewBaseCommand(TypeInfo),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "spending"}),
+		period:      period,
+	}
+}
+
+// Execute implements the Command interface
+func (c *SpendingCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *SpendingCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *SpendingCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// executeCommand handles the actual command execution
+func (c *SpendingCommand) executeCommand() (string, error) {
+	c.log.Debug("Executing spending command", "period", c.period)
+
+	if c.period != SpendingMonthly && c.period != SpendingYearly {
+		return "", fmt.Errorf("unknown spending period %q", c.period)
+	}
+
+	since := spendingSince(time.Now(), c.period)
+	ids, err := c.client.ListBills(since, time.Time{})
+	if err != nil {
+		return "", err
+	}
+	bills := make([]BillLines, 0, len(ids))
+	for _, id := range ids {
+		bill, err := c.client.GetBill(id)
+		if err != nil {
+			return "", err
+		}
+		details, err := loadBillDetails(c.client, id)
+		if err != nil {
+			return "", err
+		}
+		bills = append(bills, BillLines{Bill: *bill, Details: details})
+	}
+
+	currency, _ := accountCurrency(c.client, c.log)
+	periods := SummarizeSpending(bills, c.period)
+
+	output := format.NewOutputFormatter(
+		format.WithMaxWidth(maxWidth),
+		format.WithSeparator("\n"),
+	)
+	config := format.SectionConfig{
+		KeyValueSpacing: keyValueSpacing,
+		TitleDecorator:  "=",
+	}
+
+	total := 0.0
+	for _, period := range periods {
+		total += period.Total
+	}
+	section := output.AddSection("Spending Summary")
+	section.SetConfig(config)
+	section.AddField("Since", format.Date(&since))
+	section.AddField("Invoices", fmt.Sprintf("%d", len(bills)))
+	section.AddField("Total", format.Amount(total, currency))
+
+	for _, period := range periods {
+		section := output.AddSection(period.Label)
+		section.SetConfig(config)
+		invoices := "invoices"
+		if period.Bills == 1 {
+			invoices = "invoice"
+		}
+		section.AddField("Total", fmt.Sprintf("%s in %d %s",
+			format.Amount(period.Total, currency), period.Bills, invoices))
+		lines := make([]string, len(period.Services))
+		for i, service := range period.Services {
+			lines[i] = fmt.Sprintf("%10s  %s", format.Amount(service.Amount, currency), service.Service)
+		}
+		section.AddLines("By service", lines)
+	}
+
+	return output.String(), nil
+}
@@ -0,0 +1,499 @@
+// internal/commands/cloud.go
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"ovh-terminal/internal/api"
+	"ovh-terminal/internal/format"
+	"ovh-terminal/internal/logger"
+)
+
+// Public Cloud resource kinds shown by CloudResourceCommand
+const (
+	CloudResourceInstance  = "instance"
+	CloudResourceVolume    = "volume"
+	CloudResourceSnapshot  = "snapshot"
+	CloudResourceNetwork   = "network"
+	CloudResourceSSHKey    = "sshkey"
+	CloudResourceKube      = "kube"
+	CloudResourceContainer = "container"
+	CloudResourceUser      = "user"
+)
+
+// CloudProjectsCommand lists the Public Cloud projects of the account
+type CloudProjectsCommand struct {
+	BaseCommand
+	client *api.Client
+	log    *logger.Logger
+}
+
+// NewCloudProjectsCommand creates a new cloud projects command instance
+func NewCloudProjectsCommand(client *api.Client) *CloudProjectsCommand {
+	return &CloudProjectsCommand{
+		BaseCommand: NewBaseCommand(TypeInfo),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "cloud_projects"}),
+	}
+}
+
+// Execute implements the Command interface
+func (c *CloudProjectsCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *CloudProjectsCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *CloudProjectsCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// Projects returns the projects sorted by description
+func (c *CloudProjectsCommand) Projects() ([]*api.CloudProject, error) {
+	ids, err := c.client.ListCloudProjects()
+	if err != nil {
+		return nil, err
+	}
+
+	projects := make([]*api.CloudProject, 0, len(ids))
+	for _, id := range ids {
+		project, err := c.client.GetCloudProject(id)
+		if err != nil {
+			c.log.Error("Failed to get cloud project", "id", id, "error", err)
+			project = &api.CloudProject{ProjectID: id}
+		}
+		projects = append(projects, project)
+	}
+
+	sort.Slice(projects, func(i, j int) bool {
+		a, b := projects[i].GetDisplayName(), projects[j].GetDisplayName()
+		if strings.EqualFold(a, b) {
+			return projects[i].ProjectID < projects[j].ProjectID
+		}
+		return strings.ToLower(a) < strings.ToLower(b)
+	})
+	return projects, nil
+}
+
+// executeCommand handles the actual command execution
+func (c *CloudProjectsCommand) executeCommand() (string, error) {
+	c.log.Debug("Executing cloud projects command")
+
+	projects, err := c.Projects()
+	if err != nil {
+		return "", err
+	}
+
+	output := format.NewOutputFormatter(
+		format.WithMaxWidth(maxWidth),
+		format.WithSeparator("\n"),
+	)
+	section := output.AddSection("Public Cloud Projects")
+	section.SetConfig(format.SectionConfig{
+		KeyValueSpacing: keyValueSpacing,
+		TitleDecorator:  "=",
+	})
+	if len(projects) == 0 {
+		section.AddField("Projects", "No Public Cloud projects")
+	}
+	for _, project := range projects {
+		section.AddField(project.GetDisplayName(),
+			fmt.Sprintf("%s (%s)", project.ProjectID, project.Status))
+	}
+
+	return output.String(), nil
+}
+
+// CloudProjectCommand shows a Public Cloud project and its resource counts
+type CloudProjectCommand struct {
+	BaseCommand
+	client    *api.Client
+	log       *logger.Logger
+	projectID string
+}
+
+// NewCloudProjectCommand creates a new cloud project command instance
+func NewCloudProjectCommand(client *api.Client, projectID string) *CloudProjectCommand {
+	return &CloudProjectCommand{
+		BaseCommand: NewBaseCommand(TypeInfo),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "cloud_project"}),
+		projectID:   projectID,
+	}
+}
+
+// Execute implements the Command interface
+func (c *CloudProjectCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *CloudProjectCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *CloudProjectCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// executeCommand handles the actual command execution
+func (c *CloudProjectCommand) executeCommand() (string, error) {
+	c.log.Debug("Executing cloud project command", "project", c.projectID)
+
+	project, err := c.client.GetCloudProject(c.projectID)
+	if err != nil {
+		return "", err
+	}
+
+	output := format.NewOutputFormatter(
+		format.WithMaxWidth(maxWidth),
+		format.WithSeparator("\n"),
+	)
+	config := format.SectionConfig{
+		KeyValueSpacing: keyValueSpacing,
+		TitleDecorator:  "=",
+	}
+
+	section := output.AddSection("Public Cloud Project")
+	section.SetConfig(config)
+	section.AddField("Description", project.GetDisplayName())
+	section.AddField("Project ID", project.ProjectID)
+	section.AddField("Status", project.Status)
+	section.AddField("Plan", project.PlanCode)
+	section.AddField("Created", format.Date(project.CreationDate))
+
+	section = output.AddSection("Resources")
+	section.SetConfig(config)
+	count := func(title string, list func() (int, error)) {
+		n, err := list()
+		if err != nil {
+			c.log.Error("Failed to count resources", "resource", title, "error", err)
+			section.AddField(title, "(unavailable)")
+			return
+		}
+		section.AddField(title, fmt.Sprint(n))
+	}
+	count("Instances", func() (int, error) {
+		items, err := c.client.ListCloudInstances(c.projectID)
+		return len(items), err
+	})
+	count("Volumes", func() (int, error) {
+		items, err := c.client.ListCloudVolumes(c.projectID)
+		return len(items), err
+	})
+	count("Snapshots", func() (int, error) {
+		items, err := c.client.ListCloudSnapshots(c.projectID)
+		return len(items), err
+	})
+	count("Networks", func() (int, error) {
+		items, err := c.client.ListCloudNetworks(c.projectID)
+		return len(items), err
+	})
+	count("SSH keys", func() (int, error) {
+		items, err := c.client.ListCloudSSHKeys(c.projectID)
+		return len(items), err
+	})
+
+	return output.String(), nil
+}
+
+// CloudResourceCommand shows the details of a resource in a project
+type CloudResourceCommand struct {
+	BaseCommand
+	client    *api.Client
+	log       *logger.Logger
+	kind      string
+	projectID string
+	id        string
+}
+
+// NewCloudResourceCommand creates a detail command for a resource of kind
+func NewCloudResourceCommand(client *api.Client, kind, projectID, id string) *CloudResourceCommand {
+	return &CloudResourceCommand{
+		BaseCommand: NewBaseCommand(TypeInfo),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "cloud_" + kind}),
+		kind:        kind,
+		projectID:   projectID,
+		id:          id,
+	}
+}
+
+// Execute implements the Command interface
+func (c *CloudResourceCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *CloudResourceCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *CloudResourceCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// executeCommand handles the actual command execution
+func (c *CloudResourceCommand) executeCommand() (string, error) {
+	c.log.Debug("Executing cloud resource command", "project", c.projectID, "id", c.id)
+
+	output := format.NewOutputFormatter(
+		format.WithMaxWidth(maxWidth),
+		format.WithSeparator("\n"),
+	)
+	config := format.SectionConfig{
+		KeyValueSpacing: keyValueSpacing,
+		TitleDecorator:  "=",
+	}
+
+	var err error
+	switch c.kind {
+	case CloudResourceInstance:
+		err = c.renderInstance(output, config)
+	case CloudResourceVolume:
+		err = c.renderVolume(output, config)
+	case CloudResourceSnapshot:
+		err = c.renderSnapshot(output, config)
+	case CloudResourceNetwork:
+		err = c.renderNetwork(output, config)
+	case CloudResourceSSHKey:
+		err = c.renderSSHKey(output, config)
+	case CloudResourceKube:
+		err = c.renderKube(output, config)
+	case CloudResourceContainer:
+		err = c.renderContainer(output, config)
+	case CloudResourceUser:
+		err = c.renderUser(output, config)
+	default:
+		err = fmt.Errorf("unknown cloud resource kind %q", c.kind)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return output.String(), nil
+}
+
+// renderInstance adds the sections of an instance
+func (c *CloudResourceCommand) renderInstance(
+	output *format.OutputFormatter,
+	config format.SectionConfig,
+) error {
+	instance, err := c.client.GetCloudInstance(c.projectID, c.id)
+	if err != nil {
+		return err
+	}
+
+	section := output.AddSection("Instance")
+	section.SetConfig(config)
+	section.AddField("Name", instance.Name)
+	section.AddField("ID", instance.ID)
+	section.AddField("Status", instance.Status)
+	section.AddField("Region", instance.Region)
+	section.AddField("Created", format.DateTime(instance.Created))
+	if instance.Image != nil {
+		section.AddField("Image", instance.Image.Name)
+	}
+
+	section = output.AddSection("Flavor")
+	section.SetConfig(config)
+	if flavor := instance.Flavor; flavor != nil {
+		section.AddField("Name", flavor.Name)
+		section.AddField("vCPUs", fmt.Sprint(flavor.VCPUs))
+		section.AddField("Memory", fmt.Sprintf("%d MB", flavor.RAM))
+		section.AddField("Disk", fmt.Sprintf("%d GB", flavor.Disk))
+	} else {
+		section.AddField("ID", instance.FlavorID)
+	}
+
+	section = output.AddSection("Addresses")
+	section.SetConfig(config)
+	if len(instance.IPAddresses) == 0 {
+		section.AddField("Addresses", "None")
+	}
+	for _, addr := range instance.IPAddresses {
+		section.AddField(fmt.Sprintf("IPv%d %s", addr.Version, addr.Type), addr.IP)
+	}
+	return nil
+}
+
+// renderVolume adds the sections of a volume
+func (c *CloudResourceCommand) renderVolume(
+	output *format.OutputFormatter,
+	config format.SectionConfig,
+) error {
+	volume, err := c.client.GetCloudVolume(c.projectID, c.id)
+	if err != nil {
+		return err
+	}
+
+	section := output.AddSection("Volume")
+	section.SetConfig(config)
+	section.AddField("Name", volume.Name)
+	section.AddField("ID", volume.ID)
+	section.AddField("Description", volume.Description)
+	section.AddField("Size", fmt.Sprintf("%d GB", volume.Size))
+	section.AddField("Type", volume.Type)
+	section.AddField("Region", volume.Region)
+	section.AddField("Status", volume.Status)
+	section.AddField("Bootable", formatYesNo(volume.Bootable))
+	section.AddField("Created", format.DateTime(volume.CreationDate))
+
+	attached := c.instanceNames(volume.AttachedTo)
+	if len(attached) == 0 {
+		attached = []string{"Not attached"}
+	}
+	section.AddLines("Attached To", attached)
+	return nil
+}
+
+// renderSnapshot adds the sections of a snapshot
+func (c *CloudResourceCommand) renderSnapshot(
+	output *format.OutputFormatter,
+	config format.SectionConfig,
+) error {
+	snapshot, err := c.client.GetCloudSnapshot(c.projectID, c.id)
+	if err != nil {
+		return err
+	}
+
+	section := output.AddSection("Snapshot")
+	section.SetConfig(config)
+	section.AddField("Name", snapshot.Name)
+	section.AddField("ID", snapshot.ID)
+	section.AddField("Status", snapshot.Status)
+	section.AddField("Region", snapshot.Region)
+	section.AddField("Size", fmt.Sprintf("%.2f GB", snapshot.Size))
+	section.AddField("Minimum Disk", fmt.Sprintf("%d GB", snapshot.MinDisk))
+	section.AddField("Visibility", snapshot.Visibility)
+	section.AddField("Created", format.DateTime(snapshot.CreationDate))
+	return nil
+}
+
+// renderNetwork adds the sections of a private network
+func (c *CloudResourceCommand) renderNetwork(
+	output *format.OutputFormatter,
+	config format.SectionConfig,
+) error {
+	network, err := c.client.GetCloudNetwork(c.projectID, c.id)
+	if err != nil {
+		return err
+	}
+
+	section := output.AddSection("Private Network")
+	section.SetConfig(config)
+	section.AddField("Name", network.Name)
+	section.AddField("ID", network.ID)
+	section.AddField("VLAN", fmt.Sprint(network.VlanID))
+	section.AddField("Type", network.Type)
+	section.AddField("Status", network.Status)
+
+	section = output.AddSection("Regions")
+	section.SetConfig(config)
+	if len(network.Regions) == 0 {
+		section.AddField("Regions", "None")
+	}
+	for _, region := range network.Regions {
+		section.AddField(region.Region, region.Status)
+	}
+	return nil
+}
+
+// renderSSHKey adds the sections of an SSH key
+func (c *CloudResourceCommand) renderSSHKey(
+	output *format.OutputFormatter,
+	config format.SectionConfig,
+) error {
+	key, err := c.client.GetCloudSSHKey(c.projectID, c.id)
+	if err != nil {
+		return err
+	}
+
+	section := output.AddSection("SSH Key")
+	section.SetConfig(config)
+	section.AddField("Name", key.Name)
+	section.AddField("ID", key.ID)
+	section.AddField("Fingerprint", key.Fingerprint)
+	section.AddField("Regions", strings.Join(key.Regions, ", "))
+	section.AddLines("Public Key", wrapText(key.PublicKey, maxWidth-20))
+	return nil
+}
+
+// instanceNames resolves instance IDs to names, keeping unknown IDs
+func (c *CloudResourceCommand) instanceNames(ids []string) []string {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	names := make(map[string]string)
+	if instances, err := c.client.ListCloudInstances(c.projectID); err == nil {
+		for _, instance := range instances {
+			names[instance.ID] = instance.Name
+		}
+	}
+
+	result := make([]string, len(ids))
+	for i, id := range ids {
+		if name, ok := names[id]; ok {
+			result[i] = fmt.Sprintf("%s (%s)", name, id)
+		} else {
+			result[i] = id
+		}
+	}
+	return result
+}
+
+// CloudInstanceSummary describes an instance on one line, for menus
+func CloudInstanceSummary(instance api.CloudInstance, flavor string) string {
+	if flavor == "" {
+		flavor = instance.FlavorID
+	}
+	parts := []string{flavor, instance.Region, instance.Status}
+	if ips := instance.PublicIPs(); len(ips) > 0 {
+		parts = append(parts, strings.Join(ips, ", "))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// formatYesNo formats a boolean for display
+func formatYesNo(value bool) string {
+	if value {
+		return "Yes"
+	}
+	return "No"
+}
+
+// wrapText splits text into lines of at most width characters
+func wrapText(text string, width int) []string {
+	if width <= 0 || len(text) <= width {
+		return []string{text}
+	}
+
+	var lines []string
+	for len(text) > width {
+		lines = append(lines, text[:width])
+		text = text[width:]
+	}
+	if text != "" {
+		lines = append(lines, text)
+	}
+	return lines
+}
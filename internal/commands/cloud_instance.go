@@ -0,0 +1,337 @@
+// internal/commands/cloud_instance.go
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"ovh-terminal/internal/api"
+	"ovh-terminal/internal/logger"
+)
+
+// Lifecycle actions run by CloudInstanceActionCommand
+const (
+	InstanceStart      = "start"
+	InstanceStop       = "stop"
+	InstanceSoftReboot = "soft-reboot"
+	InstanceHardReboot = "hard-reboot"
+	InstanceShelve     = "shelve"
+	InstanceUnshelve   = "unshelve"
+	InstanceRescue     = "rescue"
+	InstanceUnrescue   = "unrescue"
+	InstanceSnapshot   = "snapshot"
+)
+
+// settleGrace is the number of polls after which an instance already in
+// its target status is considered settled, for actions that end in the
+// status they started from
+const settleGrace = 3
+
+// instanceAction describes a lifecycle action
+type instanceAction struct {
+	verb     string
+	progress string
+	from     []string
+	target   string
+}
+
+var instanceActions = map[string]instanceAction{
+	InstanceStart: {
+		verb: "Start", progress: "Starting",
+		from:   []string{api.CloudInstanceShutoff},
+		target: api.CloudInstanceActive,
+	},
+	InstanceStop: {
+		verb: "Stop", progress: "Stopping",
+		from:   []string{api.CloudInstanceActive},
+		target: api.CloudInstanceShutoff,
+	},
+	InstanceSoftReboot: {
+		verb: "Soft reboot", progress: "Rebooting",
+		from:   []string{api.CloudInstanceActive},
+		target: api.CloudInstanceActive,
+	},
+	InstanceHardReboot: {
+		verb: "Hard reboot", progress: "Rebooting",
+		from:   []string{api.CloudInstanceActive, api.CloudInstanceShutoff, api.CloudInstanceError},
+		target: api.CloudInstanceActive,
+	},
+	InstanceShelve: {
+		verb: "Shelve", progress: "Shelving",
+		from:   []string{api.CloudInstanceActive, api.CloudInstanceShutoff},
+		target: api.CloudInstanceShelvedOffloaded,
+	},
+	InstanceUnshelve: {
+		verb: "Unshelve", progress: "Unshelving",
+		from:   []string{api.CloudInstanceShelved, api.CloudInstanceShelvedOffloaded},
+		target: api.CloudInstanceActive,
+	},
+	InstanceRescue: {
+		verb: "Boot in rescue mode", progress: "Entering rescue mode",
+		from:   []string{api.CloudInstanceActive, api.CloudInstanceShutoff},
+		target: api.CloudInstanceRescue,
+	},
+	InstanceUnrescue: {
+		verb: "Leave rescue mode", progress: "Leaving rescue mode",
+		from:   []string{api.CloudInstanceRescue},
+		target: api.CloudInstanceActive,
+	},
+	InstanceSnapshot: {
+		verb: "Snapshot", progress: "Creating snapshot",
+		from: []string{api.CloudInstanceActive, api.CloudInstanceShutoff},
+	},
+}
+
+// CloudInstanceActionCommand runs a lifecycle action on a Public Cloud
+// instance and waits for its status to settle
+type CloudInstanceActionCommand struct {
+	BaseCommand
+	client     *api.Client
+	log        *logger.Logger
+	projectID  string
+	instanceID string
+	kind       string
+	instance   *api.CloudInstance
+}
+
+// NewCloudInstanceActionCommand creates a new instance action command instance
+func NewCloudInstanceActionCommand(
+	client *api.Client,
+	projectID, instanceID, kind string,
+) *CloudInstanceActionCommand {
+	return &CloudInstanceActionCommand{
+		BaseCommand: NewBaseCommand(TypeAction, WithTimeout(taskTimeout)),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "cloud_instance_" + kind}),
+		projectID:   projectID,
+		instanceID:  instanceID,
+		kind:        kind,
+	}
+}
+
+// Execute implements the Command interface
+func (c *CloudInstanceActionCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *CloudInstanceActionCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeTask(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *CloudInstanceActionCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(func() (string, error) {
+		return c.executeTask(ctx, c.executeCommand)
+	})
+}
+
+// NextPrompt implements the InteractiveCommand interface
+func (c *CloudInstanceActionCommand) NextPrompt() (*Prompt, error) {
+	action, err := c.action()
+	if err != nil {
+		return nil, err
+	}
+	if err := c.loadInstance(); err != nil {
+		return nil, err
+	}
+
+	if c.kind == InstanceSnapshot {
+		if _, ok := c.input("name"); !ok {
+			return &Prompt{
+				Key:     "name",
+				Label:   "Snapshot name",
+				Kind:    PromptText,
+				Default: c.defaultSnapshotName(),
+			}, nil
+		}
+	}
+
+	return c.confirmPrompt(fmt.Sprintf("%s %s (%s)?",
+		action.verb, c.instance.Name, c.instance.Status)), nil
+}
+
+// SetInput implements the InteractiveCommand interface
+func (c *CloudInstanceActionCommand) SetInput(key, value string) error {
+	if key == "name" {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			value = c.defaultSnapshotName()
+		}
+	}
+	return c.BaseCommand.SetInput(key, value)
+}
+
+// action returns the description of the command action
+func (c *CloudInstanceActionCommand) action() (instanceAction, error) {
+	action, ok := instanceActions[c.kind]
+	if !ok {
+		return instanceAction{}, fmt.Errorf("unknown instance action %q", c.kind)
+	}
+	return action, nil
+}
+
+// loadInstance fetches the instance once and checks the action applies
+// to its current status
+func (c *CloudInstanceActionCommand) loadInstance() error {
+	if c.instance != nil {
+		return nil
+	}
+
+	action, err := c.action()
+	if err != nil {
+		return err
+	}
+	instance, err := c.client.GetCloudInstance(c.projectID, c.instanceID)
+	if err != nil {
+		return err
+	}
+	if !containsString(action.from, instance.Status) {
+		return fmt.Errorf("cannot %s %s while it is %s",
+			strings.ToLower(action.verb), instance.Name, instance.Status)
+	}
+
+	c.instance = instance
+	return nil
+}
+
+// defaultSnapshotName names a snapshot after the instance and the date
+func (c *CloudInstanceActionCommand) defaultSnapshotName() string {
+	name := c.instanceID
+	if c.instance != nil {
+		name = c.instance.Name
+	}
+	return fmt.Sprintf("%s-%s", name, time.Now().Format("20060102-1504"))
+}
+
+// executeCommand handles the actual command execution
+func (c *CloudInstanceActionCommand) executeCommand() (string, error) {
+	action, err := c.action()
+	if err != nil {
+		return "", err
+	}
+	if err := c.loadInstance(); err != nil {
+		return "", err
+	}
+
+	c.log.Info("Running instance action", "project", c.projectID,
+		"instance", c.instanceID, "action", c.kind)
+
+	var rescue *api.CloudRescue
+	switch c.kind {
+	case InstanceStart:
+		err = c.client.StartCloudInstance(c.projectID, c.instanceID)
+	case InstanceStop:
+		err = c.client.StopCloudInstance(c.projectID, c.instanceID)
+	case InstanceSoftReboot:
+		err = c.client.RebootCloudInstance(c.projectID, c.instanceID, api.CloudRebootSoft)
+	case InstanceHardReboot:
+		err = c.client.RebootCloudInstance(c.projectID, c.instanceID, api.CloudRebootHard)
+	case InstanceShelve:
+		err = c.client.ShelveCloudInstance(c.projectID, c.instanceID)
+	case InstanceUnshelve:
+		err = c.client.UnshelveCloudInstance(c.projectID, c.instanceID)
+	case InstanceRescue:
+		rescue, err = c.client.SetCloudInstanceRescue(c.projectID, c.instanceID, true)
+	case InstanceUnrescue:
+		_, err = c.client.SetCloudInstanceRescue(c.projectID, c.instanceID, false)
+	case InstanceSnapshot:
+		return c.snapshot(action)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	name := fmt.Sprintf("%s %s", action.progress, c.instance.Name)
+	if err := c.waitStatus(name, action.target); err != nil {
+		return "", err
+	}
+
+	result := fmt.Sprintf("%s is now %s.", c.instance.Name, action.target)
+	if rescue != nil && rescue.AdminPassword != "" {
+		result += fmt.Sprintf("\nRescue system password: %s", rescue.AdminPassword)
+	}
+	return result, nil
+}
+
+// waitStatus polls the instance until it reaches the target status. When
+// the instance starts in that status, a transition must be seen first, or
+// settleGrace polls must pass without one. An instance starting in ERROR
+// keeps reporting it until the action starts, so ERROR only fails the
+// wait once the instance left its initial status.
+func (c *CloudInstanceActionCommand) waitStatus(name, target string) error {
+	initial := c.instance.Status
+	moved := initial != target
+	left := false
+	polls := 0
+
+	return c.pollTask(name, func() (string, bool, error) {
+		instance, err := c.client.GetCloudInstance(c.projectID, c.instanceID)
+		if err != nil {
+			return "", false, err
+		}
+		polls++
+		left = left || instance.Status != initial
+		if instance.Status == api.CloudInstanceError && (left || initial != api.CloudInstanceError) {
+			return instance.Status, false, fmt.Errorf("%s ended in status %s", c.instance.Name, instance.Status)
+		}
+		if instance.Status != target {
+			moved = true
+			return instance.Status, false, nil
+		}
+		return instance.Status, moved || polls >= settleGrace, nil
+	})
+}
+
+// snapshot creates a snapshot and waits for the image to become active
+func (c *CloudInstanceActionCommand) snapshot(action instanceAction) (string, error) {
+	name, _ := c.input("name")
+	if name == "" {
+		name = c.defaultSnapshotName()
+	}
+
+	// Names can be reused and the API does not return the new image ID,
+	// so the snapshots that already exist are left out of the poll
+	before, err := c.client.ListCloudSnapshots(c.projectID)
+	if err != nil {
+		return "", err
+	}
+	existing := make(map[string]bool, len(before))
+	for _, snapshot := range before {
+		existing[snapshot.ID] = true
+	}
+
+	if err := c.client.CreateCloudInstanceSnapshot(c.projectID, c.instanceID, name); err != nil {
+		return "", err
+	}
+
+	err = c.pollTask(fmt.Sprintf("%s %s", action.progress, name), func() (string, bool, error) {
+		snapshots, err := c.client.ListCloudSnapshots(c.projectID)
+		if err != nil {
+			return "", false, err
+		}
+		for _, snapshot := range snapshots {
+			if snapshot.Name != name || existing[snapshot.ID] {
+				continue
+			}
+			switch snapshot.Status {
+			case "active":
+				return snapshot.Status, true, nil
+			case "killed", "deleted", "error":
+				return snapshot.Status, false, fmt.Errorf("snapshot %s ended in status %s", name, snapshot.Status)
+			}
+			return snapshot.Status, false, nil
+		}
+		return "queued", false, nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Snapshot %s of %s is available.", name, c.instance.Name), nil
+}
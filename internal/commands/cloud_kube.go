@@ -0,0 +1,257 @@
+// internal/commands/cloud_kube.go
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"ovh-terminal/internal/api"
+	"ovh-terminal/internal/format"
+	"ovh-terminal/internal/logger"
+)
+
+// Kubeconfig destinations offered by KubeconfigCommand
+const (
+	KubeconfigToFile = "Write to a file"
+	KubeconfigMerge  = "Merge into ~/.kube/config"
+)
+
+// defaultKubeconfig is the kubeconfig merged into by KubeconfigCommand
+const defaultKubeconfig = "~/.kube/config"
+
+// KubeNodeCount sums the current and desired nodes of node pools
+func KubeNodeCount(pools []api.KubeNodePool) (current, desired int) {
+	for _, pool := range pools {
+		current += pool.CurrentNodes
+		desired += pool.DesiredNodes
+	}
+	return current, desired
+}
+
+// KubeClusterSummary describes a cluster on one line, for menus
+func KubeClusterSummary(cluster *api.KubeCluster, pools []api.KubeNodePool) string {
+	parts := []string{cluster.Version, cluster.Region, cluster.Status}
+	if pools != nil {
+		current, _ := KubeNodeCount(pools)
+		parts = append(parts, fmt.Sprintf("%d nodes in %d pools", current, len(pools)))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// renderKube adds the sections of a Managed Kubernetes cluster
+func (c *CloudResourceCommand) renderKube(
+	output *format.OutputFormatter,
+	config format.SectionConfig,
+) error {
+	cluster, err := c.client.GetKubeCluster(c.projectID, c.id)
+	if err != nil {
+		return err
+	}
+
+	section := output.AddSection("Kubernetes Cluster")
+	section.SetConfig(config)
+	section.AddField("Name", cluster.Name)
+	section.AddField("ID", cluster.ID)
+	section.AddField("Region", cluster.Region)
+	section.AddField("Version", cluster.Version)
+	section.AddField("Status", cluster.Status)
+	section.AddField("Up to date", formatYesNo(cluster.IsUpToDate))
+	if len(cluster.NextUpgradeVersions) > 0 {
+		section.AddField("Upgrades", strings.Join(cluster.NextUpgradeVersions, ", "))
+	}
+	section.AddField("Update policy", cluster.UpdatePolicy)
+	section.AddField("API server", cluster.URL)
+	section.AddField("Created", format.Date(cluster.CreatedAt))
+
+	pools, err := c.client.ListKubeNodePools(c.projectID, c.id)
+	if err != nil {
+		return err
+	}
+
+	current, desired := KubeNodeCount(pools)
+	section = output.AddSection("Node Pools")
+	section.SetConfig(config)
+	section.AddField("Nodes", fmt.Sprintf("%d of %d", current, desired))
+	if len(pools) == 0 {
+		section.AddField("Pools", "None")
+	}
+	for _, pool := range pools {
+		lines := []string{
+			fmt.Sprintf("%s, %s", pool.Flavor, pool.Status),
+			fmt.Sprintf("%d nodes, %d available, %d up to date",
+				pool.CurrentNodes, pool.AvailableNodes, pool.UpToDateNodes),
+		}
+		if pool.Autoscale {
+			lines = append(lines, fmt.Sprintf("autoscaling %d to %d nodes", pool.MinNodes, pool.MaxNodes))
+		} else {
+			lines = append(lines, fmt.Sprintf("fixed at %d nodes", pool.DesiredNodes))
+		}
+		section.AddLines(pool.Name, lines)
+	}
+	return nil
+}
+
+// KubeconfigCommand downloads the kubeconfig of a cluster to a file or
+// merges it into ~/.kube/config
+type KubeconfigCommand struct {
+	BaseCommand
+	client    *api.Client
+	log       *logger.Logger
+	projectID string
+	kubeID    string
+	cluster   *api.KubeCluster
+}
+
+// NewKubeconfigCommand creates a new kubeconfig command instance
+func NewKubeconfigCommand(client *api.Client, projectID, kubeID string) *KubeconfigCommand {
+	return &KubeconfigCommand{
+		BaseCommand: NewBaseCommand(TypeAction),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "kubeconfig"}),
+		projectID:   projectID,
+		kubeID:      kubeID,
+	}
+}
+
+// Execute implements the Command interface
+func (c *KubeconfigCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *KubeconfigCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *KubeconfigCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// NextPrompt implements the InteractiveCommand interface
+func (c *KubeconfigCommand) NextPrompt() (*Prompt, error) {
+	cluster, err := c.loadCluster()
+	if err != nil {
+		return nil, err
+	}
+
+	target, ok := c.input("target")
+	if !ok {
+		return &Prompt{
+			Key:     "target",
+			Label:   "Kubeconfig destination",
+			Kind:    PromptChoice,
+			Choices: []string{KubeconfigToFile, KubeconfigMerge},
+		}, nil
+	}
+
+	if target == KubeconfigMerge {
+		return c.confirmPrompt(fmt.Sprintf("Merge %s into %s?", cluster.Name, defaultKubeconfig)), nil
+	}
+
+	path, ok := c.input("file")
+	if !ok {
+		return &Prompt{Key: "file", Label: "Kubeconfig file", Kind: PromptText, Default: c.defaultPath()}, nil
+	}
+	if _, err := os.Stat(expandPath(path)); err == nil {
+		return c.confirmPrompt(fmt.Sprintf("Overwrite %s?", path)), nil
+	}
+	return nil, nil
+}
+
+// SetInput implements the InteractiveCommand interface
+func (c *KubeconfigCommand) SetInput(key, value string) error {
+	switch key {
+	case "target":
+		switch strings.ToLower(strings.TrimSpace(value)) {
+		case strings.ToLower(KubeconfigToFile), "file":
+			value = KubeconfigToFile
+		case strings.ToLower(KubeconfigMerge), "merge":
+			value = KubeconfigMerge
+		default:
+			return fmt.Errorf("unknown kubeconfig destination %q", value)
+		}
+	case "file":
+		if strings.TrimSpace(value) == "" {
+			value = c.defaultPath()
+		}
+	}
+	return c.BaseCommand.SetInput(key, value)
+}
+
+// loadCluster fetches the cluster once
+func (c *KubeconfigCommand) loadCluster() (*api.KubeCluster, error) {
+	if c.cluster != nil {
+		return c.cluster, nil
+	}
+	cluster, err := c.client.GetKubeCluster(c.projectID, c.kubeID)
+	if err != nil {
+		return nil, err
+	}
+	c.cluster = cluster
+	return cluster, nil
+}
+
+// defaultPath names the kubeconfig file after the cluster
+func (c *KubeconfigCommand) defaultPath() string {
+	name := c.kubeID
+	if c.cluster != nil {
+		name = c.cluster.Name
+	}
+	return fmt.Sprintf("~/.kube/%s.yml", name)
+}
+
+// executeCommand handles the actual command execution
+func (c *KubeconfigCommand) executeCommand() (string, error) {
+	cluster, err := c.loadCluster()
+	if err != nil {
+		return "", err
+	}
+
+	target, ok := c.input("target")
+	if !ok {
+		return "", fmt.Errorf("kubeconfig destination is required")
+	}
+
+	kubeconfig, err := c.client.GetKubeconfig(c.projectID, c.kubeID)
+	if err != nil {
+		return "", err
+	}
+
+	if target == KubeconfigMerge {
+		path := expandPath(defaultKubeconfig)
+		existing, err := os.ReadFile(path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		merged, contexts, err := MergeKubeconfig(existing, []byte(kubeconfig.Content))
+		if err != nil {
+			return "", err
+		}
+		if err := writePrivateFile(path, merged); err != nil {
+			return "", err
+		}
+
+		c.log.Info("Merged kubeconfig", "cluster", c.kubeID, "path", path)
+		return fmt.Sprintf("Merged %s into %s, context: %s.",
+			cluster.Name, defaultKubeconfig, strings.Join(contexts, ", ")), nil
+	}
+
+	path, ok := c.input("file")
+	if !ok {
+		path = c.defaultPath()
+	}
+	path = expandPath(path)
+	if err := writePrivateFile(path, []byte(kubeconfig.Content)); err != nil {
+		return "", err
+	}
+
+	c.log.Info("Wrote kubeconfig", "cluster", c.kubeID, "path", path)
+	return fmt.Sprintf("Wrote the kubeconfig of %s to %s.", cluster.Name, path), nil
+}
@@ -0,0 +1,270 @@
+// internal/commands/cloud_quota.go
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"ovh-terminal/internal/api"
+	"ovh-terminal/internal/format"
+	"ovh-terminal/internal/logger"
+)
+
+// DefaultQuotaWarning is the quota usage percentage warned about when
+// none is configured
+const DefaultQuotaWarning = 80
+
+// quotaBarWidth is the number of cells of quota bars
+const quotaBarWidth = 20
+
+// quotaWarning is the usage percentage above which quotas are flagged
+var quotaWarning = DefaultQuotaWarning
+
+// SetQuotaWarning sets the quota usage percentage above which quotas are
+// flagged, zero restores the default
+func SetQuotaWarning(percent int) {
+	if percent <= 0 {
+		percent = DefaultQuotaWarning
+	}
+	quotaWarning = percent
+}
+
+// QuotaUsage is the usage of one limit of a regional quota
+type QuotaUsage struct {
+	Region string
+	Name   string
+	Unit   string
+	Used   int
+	Max    int
+}
+
+// Percent returns the used share of the limit
+func (q QuotaUsage) Percent() float64 {
+	if q.Max <= 0 {
+		return 0
+	}
+	return float64(q.Used) * 100 / float64(q.Max)
+}
+
+// Exceeds checks if usage is above a percentage of the limit
+func (q QuotaUsage) Exceeds(percent int) bool {
+	return usageAbove(float64(q.Used), float64(q.Max), percent)
+}
+
+// usageAbove checks if used is above a percentage of limit. Every quota
+// warning uses it so that they flag at the same point.
+func usageAbove(used, limit float64, percent int) bool {
+	return limit > 0 && used*100/limit > float64(percent)
+}
+
+// String renders the usage with a bar, "██████░░░░ 6/10 (60%)"
+func (q QuotaUsage) String() string {
+	unit := ""
+	if q.Unit != "" {
+		unit = " " + q.Unit
+	}
+	line := fmt.Sprintf("%s %d/%d%s (%.0f%%)",
+		format.Bar(float64(q.Used), float64(q.Max), quotaBarWidth), q.Used, q.Max, unit, q.Percent())
+	if q.Exceeds(quotaWarning) {
+		line += " ⚠"
+	}
+	return line
+}
+
+// QuotaUsages lists the limits of a regional quota
+func QuotaUsages(quota api.CloudQuota) []QuotaUsage {
+	var usages []QuotaUsage
+	add := func(name, unit string, used, limit int) {
+		usages = append(usages, QuotaUsage{
+			Region: quota.Region, Name: name, Unit: unit, Used: used, Max: limit,
+		})
+	}
+	if i := quota.Instance; i != nil {
+		add("Instances", "", i.UsedInstances, i.MaxInstances)
+		add("Cores", "", i.UsedCores, i.MaxCores)
+		add("RAM", "GB", i.UsedRAM/1000, i.MaxRAM/1000)
+	}
+	if v := quota.Volume; v != nil {
+		add("Volumes", "", v.VolumeCount, v.MaxVolumeCount)
+		add("Volume storage", "GB", v.UsedGigabytes, v.MaxGigabytes)
+	}
+	return usages
+}
+
+// CloudQuotaCommand shows the quota of a project per region with the
+// available regions and flavors
+type CloudQuotaCommand struct {
+	BaseCommand
+	client    *api.Client
+	log       *logger.Logger
+	projectID string
+}
+
+// NewCloudQuotaCommand creates a new cloud quota command instance
+func NewCloudQuotaCommand(client *api.Client, projectID string) *CloudQuotaCommand {
+	return &CloudQuotaCommand{
+		BaseCommand: NewBaseCommand(TypeInfo),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "cloud_quota"}),
+		projectID:   projectID,
+	}
+}
+
+// Execute implements the Command interface
+func (c *CloudQuotaCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *CloudQuotaCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *CloudQuotaCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// executeCommand handles the actual command execution
+func (c *CloudQuotaCommand) executeCommand() (string, error) {
+	c.log.Debug("Executing cloud quota command", "project", c.projectID)
+
+	quotas, err := c.client.ListCloudQuotas(c.projectID)
+	if err != nil {
+		return "", err
+	}
+	sort.Slice(quotas, func(i, j int) bool { return quotas[i].Region < quotas[j].Region })
+
+	output := format.NewOutputFormatter(
+		format.WithMaxWidth(maxWidth),
+		format.WithSeparator("\n"),
+	)
+	config := format.SectionConfig{
+		KeyValueSpacing: keyValueSpacing,
+		TitleDecorator:  "=",
+	}
+
+	var warnings []string
+	for _, quota := range quotas {
+		for _, usage := range QuotaUsages(quota) {
+			if usage.Exceeds(quotaWarning) {
+				warnings = append(warnings, fmt.Sprintf("%s %s at %.0f%% (%d/%d)",
+					usage.Region, strings.ToLower(usage.Name), usage.Percent(), usage.Used, usage.Max))
+			}
+		}
+	}
+	if len(warnings) > 0 {
+		section := output.AddSection("Quota Warnings")
+		section.SetConfig(config)
+		section.AddLines(fmt.Sprintf("Above %d%%", quotaWarning), warnings)
+	}
+
+	for _, quota := range quotas {
+		section := output.AddSection("Quota " + quota.Region)
+		section.SetConfig(config)
+		for _, usage := range QuotaUsages(quota) {
+			section.AddField(usage.Name, usage.String())
+		}
+	}
+
+	c.renderRegions(output, config)
+	c.renderFlavors(output, config)
+
+	return output.String(), nil
+}
+
+// renderRegions adds the enabled regions and those that can be added
+func (c *CloudQuotaCommand) renderRegions(output *format.OutputFormatter, config format.SectionConfig) {
+	section := output.AddSection("Regions")
+	section.SetConfig(config)
+
+	names, err := c.client.ListCloudRegions(c.projectID)
+	if err != nil {
+		c.log.Error("Failed to list regions", "error", err)
+		section.AddField("Regions", "(unavailable)")
+		return
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		region, err := c.client.GetCloudRegion(c.projectID, name)
+		if err != nil {
+			c.log.Error("Failed to get region", "region", name, "error", err)
+			section.AddField(name, "(unavailable)")
+			continue
+		}
+		section.AddField(name, fmt.Sprintf("%s, %s", region.DatacenterLocation, region.Status))
+	}
+
+	available, err := c.client.ListAvailableCloudRegions(c.projectID)
+	if err != nil {
+		c.log.Error("Failed to list available regions", "error", err)
+		return
+	}
+	addable := make([]string, len(available))
+	for i, region := range available {
+		addable[i] = region.Name
+	}
+	sort.Strings(addable)
+	if len(addable) > 0 {
+		section.AddLines("Can be added", wrapWords(addable, maxWidth-20))
+	}
+}
+
+// renderFlavors adds the flavors available in each region
+func (c *CloudQuotaCommand) renderFlavors(output *format.OutputFormatter, config format.SectionConfig) {
+	section := output.AddSection("Available Flavors")
+	section.SetConfig(config)
+
+	flavors, err := c.client.ListCloudFlavors(c.projectID)
+	if err != nil {
+		c.log.Error("Failed to list flavors", "error", err)
+		section.AddField("Flavors", "(unavailable)")
+		return
+	}
+
+	byRegion := make(map[string][]string)
+	for _, flavor := range flavors {
+		if flavor.Available && !containsString(byRegion[flavor.Region], flavor.Name) {
+			byRegion[flavor.Region] = append(byRegion[flavor.Region], flavor.Name)
+		}
+	}
+	if len(byRegion) == 0 {
+		section.AddField("Flavors", "None available")
+	}
+	regions := make([]string, 0, len(byRegion))
+	for region := range byRegion {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+	for _, region := range regions {
+		names := byRegion[region]
+		sort.Strings(names)
+		section.AddLines(region, wrapWords(names, maxWidth-20))
+	}
+}
+
+// wrapWords joins words with commas into lines of at most width characters
+func wrapWords(words []string, width int) []string {
+	var lines []string
+	line := ""
+	for _, word := range words {
+		switch {
+		case line == "":
+			line = word
+		case len(line)+2+len(word) > width:
+			lines = append(lines, line+",")
+			line = word
+		default:
+			line += ", " + word
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -0,0 +1,434 @@
+// internal/commands/cloud_storage.go
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"ovh-terminal/internal/api"
+	"ovh-terminal/internal/format"
+	"ovh-terminal/internal/logger"
+)
+
+// maxListedObjects is the number of objects shown in container details
+const maxListedObjects = 20
+
+// ContainerID identifies a container by region and name
+func ContainerID(region, name string) string {
+	return region + "/" + name
+}
+
+// ContainerSummary describes a container on one line, for menus
+func ContainerSummary(container api.StorageContainer) string {
+	return fmt.Sprintf("%s · %d objects · %s",
+		container.Region, container.ObjectsCount, format.Bytes(container.ObjectsSize))
+}
+
+// CloudUserSummary describes a project user on one line, for menus
+func CloudUserSummary(user api.CloudUser) string {
+	roles := make([]string, len(user.Roles))
+	for i, role := range user.Roles {
+		roles[i] = role.Name
+	}
+	parts := []string{user.Status}
+	if len(roles) > 0 {
+		parts = append(parts, strings.Join(roles, ", "))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// renderContainer adds the sections of an S3 container
+func (c *CloudResourceCommand) renderContainer(
+	output *format.OutputFormatter,
+	config format.SectionConfig,
+) error {
+	region, name, ok := strings.Cut(c.id, "/")
+	if !ok {
+		return fmt.Errorf("invalid container %q", c.id)
+	}
+	container, err := c.client.GetStorageContainer(c.projectID, region, name)
+	if err != nil {
+		return err
+	}
+
+	section := output.AddSection("Object Storage Container")
+	section.SetConfig(config)
+	section.AddField("Name", container.Name)
+	section.AddField("Region", container.Region)
+	section.AddField("Objects", fmt.Sprint(container.ObjectsCount))
+	section.AddField("Size", format.Bytes(container.ObjectsSize))
+	section.AddField("Endpoint", api.S3Endpoint(container.Region))
+	section.AddField("Created", format.Date(container.CreatedAt))
+
+	section = output.AddSection("Objects")
+	section.SetConfig(config)
+	if len(container.Objects) == 0 {
+		section.AddField("Objects", "Empty container")
+		return nil
+	}
+	objects := container.Objects
+	if len(objects) > maxListedObjects {
+		objects = objects[:maxListedObjects]
+	}
+	for _, object := range objects {
+		section.AddField(object.Key, fmt.Sprintf("%s, %s",
+			format.Bytes(object.Size), format.DateTime(object.LastModified)))
+	}
+	if more := int64(len(container.Objects) - len(objects)); more > 0 {
+		section.AddField("...", fmt.Sprintf("%d more objects", more))
+	}
+	return nil
+}
+
+// renderUser adds the sections of a project user and its S3 access keys
+func (c *CloudResourceCommand) renderUser(
+	output *format.OutputFormatter,
+	config format.SectionConfig,
+) error {
+	user, err := c.client.GetCloudUser(c.projectID, c.id)
+	if err != nil {
+		return err
+	}
+
+	section := output.AddSection("User")
+	section.SetConfig(config)
+	section.AddField("Username", user.Username)
+	section.AddField("ID", strconv.Itoa(user.ID))
+	section.AddField("Description", user.Description)
+	section.AddField("Status", user.Status)
+	section.AddField("Created", format.Date(user.CreationDate))
+	roles := make([]string, len(user.Roles))
+	for i, role := range user.Roles {
+		roles[i] = role.Name
+	}
+	section.AddLines("Roles", roles)
+
+	credentials, err := c.client.ListS3Credentials(c.projectID, c.id)
+	if err != nil {
+		return err
+	}
+	section = output.AddSection("S3 Credentials")
+	section.SetConfig(config)
+	if len(credentials) == 0 {
+		section.AddField("Access keys", "None")
+	}
+	for _, credential := range credentials {
+		section.AddField("Access key", credential.Access)
+	}
+	return nil
+}
+
+// S3ConfigSnippets renders credentials as aws and rclone configuration
+func S3ConfigSnippets(credentials *api.S3Credentials, region string) string {
+	name := "ovh-" + api.S3Region(region)
+	endpoint := api.S3Endpoint(region)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# ~/.aws/credentials\n[%s]\n", name)
+	fmt.Fprintf(&b, "aws_access_key_id = %s\n", credentials.Access)
+	fmt.Fprintf(&b, "aws_secret_access_key = %s\n\n", credentials.Secret)
+
+	fmt.Fprintf(&b, "# ~/.aws/config\n[profile %s]\n", name)
+	fmt.Fprintf(&b, "region = %s\n", api.S3Region(region))
+	fmt.Fprintf(&b, "endpoint_url = %s\n", endpoint)
+	b.WriteString("s3 =\n  signature_version = s3v4\n\n")
+
+	fmt.Fprintf(&b, "# ~/.config/rclone/rclone.conf\n[%s]\n", name)
+	b.WriteString("type = s3\nprovider = Other\nenv_auth = false\n")
+	fmt.Fprintf(&b, "access_key_id = %s\n", credentials.Access)
+	fmt.Fprintf(&b, "secret_access_key = %s\n", credentials.Secret)
+	fmt.Fprintf(&b, "region = %s\n", api.S3Region(region))
+	fmt.Fprintf(&b, "endpoint = %s\n", endpoint)
+	fmt.Fprintf(&b, "location_constraint = %s\n", api.S3Region(region))
+	b.WriteString("acl = private")
+	return b.String()
+}
+
+// s3Base holds what the S3 credential commands share
+type s3Base struct {
+	BaseCommand
+	client    *api.Client
+	log       *logger.Logger
+	projectID string
+	userID    string
+	user      *api.CloudUser
+	regions   []string
+	keys      []string
+}
+
+// load fetches the user, the S3 regions of the project and the access
+// keys of the user once
+func (c *s3Base) load() error {
+	if c.user != nil {
+		return nil
+	}
+
+	user, err := c.client.GetCloudUser(c.projectID, c.userID)
+	if err != nil {
+		return err
+	}
+	regions, err := c.client.ListCloudRegions(c.projectID)
+	if err != nil {
+		return err
+	}
+	credentials, err := c.client.ListS3Credentials(c.projectID, c.userID)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool)
+	for _, region := range regions {
+		if s3 := api.S3Region(region); !seen[s3] {
+			seen[s3] = true
+			c.regions = append(c.regions, s3)
+		}
+	}
+	sort.Strings(c.regions)
+	if len(c.regions) == 0 {
+		return fmt.Errorf("no regions enabled on project %s", c.projectID)
+	}
+	for _, credential := range credentials {
+		c.keys = append(c.keys, credential.Access)
+	}
+	c.user = user
+	return nil
+}
+
+// regionPrompt asks for the region of the configuration snippets,
+// skipped when the project has a single one
+func (c *s3Base) regionPrompt() *Prompt {
+	if _, ok := c.input("region"); ok || len(c.regions) == 1 {
+		return nil
+	}
+	return &Prompt{Key: "region", Label: "Region", Kind: PromptChoice, Choices: c.regions}
+}
+
+// region returns the chosen region
+func (c *s3Base) region() string {
+	if region, ok := c.input("region"); ok {
+		return region
+	}
+	return c.regions[0]
+}
+
+// keyPrompt asks for an access key of the user
+func (c *s3Base) keyPrompt() (*Prompt, error) {
+	if _, ok := c.input("access"); ok {
+		return nil, nil
+	}
+	if len(c.keys) == 0 {
+		return nil, fmt.Errorf("%s has no S3 credentials", c.user.Username)
+	}
+	return &Prompt{Key: "access", Label: "Access key", Kind: PromptChoice, Choices: c.keys}, nil
+}
+
+// SetInput implements the InteractiveCommand interface
+func (c *s3Base) SetInput(key, value string) error {
+	if err := c.load(); err != nil {
+		return err
+	}
+	switch key {
+	case "region":
+		value = api.S3Region(strings.TrimSpace(value))
+		if !containsString(c.regions, value) {
+			return fmt.Errorf("region must be one of %s", strings.Join(c.regions, ", "))
+		}
+	case "access":
+		if !containsString(c.keys, value) {
+			return fmt.Errorf("%s is not an access key of %s", value, c.user.Username)
+		}
+	}
+	return c.BaseCommand.SetInput(key, value)
+}
+
+// newS3Base creates the shared part of the S3 credential commands
+func newS3Base(client *api.Client, name, projectID, userID string) s3Base {
+	return s3Base{
+		BaseCommand: NewBaseCommand(TypeAction),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": name}),
+		projectID:   projectID,
+		userID:      userID,
+	}
+}
+
+// CreateS3CredentialsCommand generates S3 credentials for a user and
+// prints them as configuration snippets
+type CreateS3CredentialsCommand struct {
+	s3Base
+}
+
+// NewCreateS3CredentialsCommand creates a new S3 credentials command instance
+func NewCreateS3CredentialsCommand(client *api.Client, projectID, userID string) *CreateS3CredentialsCommand {
+	return &CreateS3CredentialsCommand{
+		s3Base: newS3Base(client, "create_s3_credentials", projectID, userID),
+	}
+}
+
+// Execute implements the Command interface
+func (c *CreateS3CredentialsCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *CreateS3CredentialsCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *CreateS3CredentialsCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// NextPrompt implements the InteractiveCommand interface
+func (c *CreateS3CredentialsCommand) NextPrompt() (*Prompt, error) {
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+	if prompt := c.regionPrompt(); prompt != nil {
+		return prompt, nil
+	}
+	return c.confirmPrompt(fmt.Sprintf("Generate S3 credentials for %s?", c.user.Username)), nil
+}
+
+// executeCommand handles the actual command execution
+func (c *CreateS3CredentialsCommand) executeCommand() (string, error) {
+	if err := c.load(); err != nil {
+		return "", err
+	}
+
+	c.log.Info("Creating S3 credentials", "project", c.projectID, "user", c.userID)
+	credentials, err := c.client.CreateS3Credentials(c.projectID, c.userID)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Generated S3 credentials %s for %s. Keep the secret safe, "+
+		"it can be shown again with \"Show S3 configuration\".\n\n%s",
+		credentials.Access, c.user.Username, S3ConfigSnippets(credentials, c.region())), nil
+}
+
+// S3ConfigCommand prints existing S3 credentials as configuration snippets
+type S3ConfigCommand struct {
+	s3Base
+}
+
+// NewS3ConfigCommand creates a new S3 configuration command instance
+func NewS3ConfigCommand(client *api.Client, projectID, userID string) *S3ConfigCommand {
+	return &S3ConfigCommand{
+		s3Base: newS3Base(client, "s3_config", projectID, userID),
+	}
+}
+
+// Execute implements the Command interface
+func (c *S3ConfigCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *S3ConfigCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *S3ConfigCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// NextPrompt implements the InteractiveCommand interface
+func (c *S3ConfigCommand) NextPrompt() (*Prompt, error) {
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+	if prompt, err := c.keyPrompt(); prompt != nil || err != nil {
+		return prompt, err
+	}
+	return c.regionPrompt(), nil
+}
+
+// executeCommand handles the actual command execution
+func (c *S3ConfigCommand) executeCommand() (string, error) {
+	if err := c.load(); err != nil {
+		return "", err
+	}
+	access, ok := c.input("access")
+	if !ok {
+		if len(c.keys) != 1 {
+			return "", fmt.Errorf("access key is required")
+		}
+		access = c.keys[0]
+	}
+
+	secret, err := c.client.GetS3Secret(c.projectID, c.userID, access)
+	if err != nil {
+		return "", err
+	}
+
+	credentials := &api.S3Credentials{Access: access, Secret: secret}
+	return S3ConfigSnippets(credentials, c.region()), nil
+}
+
+// DeleteS3CredentialsCommand revokes an S3 access key of a user
+type DeleteS3CredentialsCommand struct {
+	s3Base
+}
+
+// NewDeleteS3CredentialsCommand creates a new S3 credentials removal command instance
+func NewDeleteS3CredentialsCommand(client *api.Client, projectID, userID string) *DeleteS3CredentialsCommand {
+	return &DeleteS3CredentialsCommand{
+		s3Base: newS3Base(client, "delete_s3_credentials", projectID, userID),
+	}
+}
+
+// Execute implements the Command interface
+func (c *DeleteS3CredentialsCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *DeleteS3CredentialsCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *DeleteS3CredentialsCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// NextPrompt implements the InteractiveCommand interface
+func (c *DeleteS3CredentialsCommand) NextPrompt() (*Prompt, error) {
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+	if prompt, err := c.keyPrompt(); prompt != nil || err != nil {
+		return prompt, err
+	}
+	access, _ := c.input("access")
+	return c.confirmPrompt(fmt.Sprintf("Revoke S3 access key %s of %s?", access, c.user.Username)), nil
+}
+
+// executeCommand handles the actual command execution
+func (c *DeleteS3CredentialsCommand) executeCommand() (string, error) {
+	access, ok := c.input("access")
+	if !ok {
+		return "", fmt.Errorf("access key is required")
+	}
+
+	c.log.Info("Deleting S3 credentials", "project", c.projectID, "user", c.userID, "access", access)
+	if err := c.client.DeleteS3Credentials(c.projectID, c.userID, access); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Revoked S3 access key %s.", access), nil
+}
@@ -0,0 +1,328 @@
+// internal/commands/cloud_usage.go
+package commands
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"ovh-terminal/internal/api"
+	"ovh-terminal/internal/format"
+	"ovh-terminal/internal/logger"
+)
+
+// usageCategory groups usage resource types under a display title
+type usageCategory struct {
+	title string
+	types []string
+}
+
+// usageCategories are the cost categories, in display order. Resource
+// types not listed are counted as "Other".
+var usageCategories = []usageCategory{
+	{title: "Instances", types: []string{"instance", "instanceOption"}},
+	{title: "Volumes", types: []string{"volume"}},
+	{title: "Snapshots", types: []string{"snapshot"}},
+	{title: "Object storage", types: []string{"storage"}},
+	{title: "Bandwidth", types: []string{"instanceBandwidth"}},
+}
+
+// UsageTotal is the cost of a usage category
+type UsageTotal struct {
+	Category string
+	Price    float64
+}
+
+// usageCategoryOf returns the category title of a resource type
+func usageCategoryOf(resource string) string {
+	for _, category := range usageCategories {
+		if containsString(category.types, resource) {
+			return category.title
+		}
+	}
+	return "Other"
+}
+
+// UsageTotals sums usage items by category. Categories are always listed,
+// "Other" only when it has items.
+func UsageTotals(usage *api.CloudUsage) []UsageTotal {
+	sums := make(map[string]float64)
+	for _, item := range usage.Items() {
+		sums[usageCategoryOf(item.Type)] += item.TotalPrice
+	}
+
+	totals := make([]UsageTotal, 0, len(usageCategories)+1)
+	for _, category := range usageCategories {
+		totals = append(totals, UsageTotal{Category: category.title, Price: sums[category.title]})
+	}
+	if other, ok := sums["Other"]; ok {
+		totals = append(totals, UsageTotal{Category: "Other", Price: other})
+	}
+	return totals
+}
+
+// usageReports holds the reports of a project and the account currency
+type usageReports struct {
+	current  *api.CloudUsage
+	forecast *api.CloudUsage
+	currency string
+	code     string
+}
+
+// loadUsageReports fetches the current and forecast usage of a project.
+// Prices are in the account currency, shown by its symbol.
+func loadUsageReports(client *api.Client, log *logger.Logger, projectID string) (*usageReports, error) {
+	current, err := client.GetCloudUsage(projectID)
+	if err != nil {
+		return nil, err
+	}
+	forecast, err := client.GetCloudUsageForecast(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := &usageReports{current: current, forecast: forecast}
+	reports.currency, reports.code = accountCurrency(client, log)
+	return reports, nil
+}
+
+// accountCurrency returns the symbol and code of the account currency.
+// Prices are shown without currency when it cannot be fetched.
+func accountCurrency(client *api.Client, log *logger.Logger) (symbol, code string) {
+	info, err := client.GetAccountInfo()
+	if err != nil {
+		log.Error("Failed to get account currency", "error", err)
+		return "", ""
+	}
+	if info.Currency == nil {
+		return "", ""
+	}
+	symbol = info.Currency.Symbol
+	if symbol == "" {
+		symbol = info.Currency.Code
+	}
+	return symbol, info.Currency.Code
+}
+
+// CloudUsageCommand shows the costs of a project for the current month
+type CloudUsageCommand struct {
+	BaseCommand
+	client    *api.Client
+	log       *logger.Logger
+	projectID string
+}
+
+// NewCloudUsageCommand creates a new cloud usage command instance
+func NewCloudUsageCommand(client *api.Client, projectID string) *CloudUsageCommand {
+	return &CloudUsageCommand{
+		BaseCommand: NewBaseCommand(TypeInfo),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "cloud_usage"}),
+		projectID:   projectID,
+	}
+}
+
+// Execute implements the Command interface
+func (c *CloudUsageCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *CloudUsageCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *CloudUsageCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// executeCommand handles the actual command execution
+func (c *CloudUsageCommand) executeCommand() (string, error) {
+	c.log.Debug("Executing cloud usage command", "project", c.projectID)
+
+	reports, err := loadUsageReports(c.client, c.log, c.projectID)
+	if err != nil {
+		return "", err
+	}
+
+	output := format.NewOutputFormatter(
+		format.WithMaxWidth(maxWidth),
+		format.WithSeparator("\n"),
+	)
+	config := format.SectionConfig{
+		KeyValueSpacing: keyValueSpacing,
+		TitleDecorator:  "=",
+	}
+
+	section := output.AddSection("Public Cloud Usage")
+	section.SetConfig(config)
+	section.AddField("Project ID", c.projectID)
+	section.AddField("Period", fmt.Sprintf("%s to %s",
+		format.Date(reports.current.Period.From), format.Date(reports.forecast.Period.To)))
+	section.AddField("Last update", format.DateTime(reports.current.LastUpdate))
+
+	addTotals := func(title string, usage *api.CloudUsage) {
+		section := output.AddSection(title)
+		section.SetConfig(config)
+		for _, total := range UsageTotals(usage) {
+			section.AddField(total.Category, format.Amount(total.Price, reports.currency))
+		}
+		section.AddField("Total", format.Amount(usage.Total(), reports.currency))
+	}
+	addTotals("Current Month", reports.current)
+	addTotals("Forecast (End of Month)", reports.forecast)
+
+	items := reports.current.Items()
+	sort.SliceStable(items, func(i, j int) bool { return items[i].TotalPrice > items[j].TotalPrice })
+	section = output.AddSection("Current Usage Details")
+	section.SetConfig(config)
+	if len(items) == 0 {
+		section.AddField("Usage", "Nothing billed yet this month")
+	}
+	lines := make(map[string][]string)
+	for _, item := range items {
+		category := usageCategoryOf(item.Type)
+		lines[category] = append(lines[category], fmt.Sprintf("%10s  %s %s, %s",
+			format.Amount(item.TotalPrice, reports.currency),
+			item.Reference, item.Region, usageQuantity(item)))
+	}
+	for _, total := range UsageTotals(reports.current) {
+		section.AddLines(total.Category, lines[total.Category])
+	}
+
+	return output.String(), nil
+}
+
+// usageQuantity formats the consumed quantity of an item
+func usageQuantity(item api.CloudUsageItem) string {
+	if item.Quantity == nil {
+		return item.Billing
+	}
+	return fmt.Sprintf("%s %s (%s)", strconv.FormatFloat(item.Quantity.Value, 'f', -1, 64),
+		item.Quantity.Unit, item.Billing)
+}
+
+// ExportCloudUsageCommand writes the current and forecast usage of a
+// project to a CSV file
+type ExportCloudUsageCommand struct {
+	BaseCommand
+	client    *api.Client
+	log       *logger.Logger
+	projectID string
+}
+
+// NewExportCloudUsageCommand creates a new usage export command instance
+func NewExportCloudUsageCommand(client *api.Client, projectID string) *ExportCloudUsageCommand {
+	return &ExportCloudUsageCommand{
+		BaseCommand: NewBaseCommand(TypeAction),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "export_cloud_usage"}),
+		projectID:   projectID,
+	}
+}
+
+// Execute implements the Command interface
+func (c *ExportCloudUsageCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *ExportCloudUsageCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *ExportCloudUsageCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// NextPrompt implements the InteractiveCommand interface
+func (c *ExportCloudUsageCommand) NextPrompt() (*Prompt, error) {
+	path, ok := c.input("file")
+	if !ok {
+		return &Prompt{Key: "file", Label: "CSV file", Kind: PromptText, Default: c.defaultPath()}, nil
+	}
+	if _, err := os.Stat(expandPath(path)); err == nil {
+		return c.confirmPrompt(fmt.Sprintf("Overwrite %s?", path)), nil
+	}
+	return nil, nil
+}
+
+// SetInput implements the InteractiveCommand interface
+func (c *ExportCloudUsageCommand) SetInput(key, value string) error {
+	if key == "file" && strings.TrimSpace(value) == "" {
+		value = c.defaultPath()
+	}
+	return c.BaseCommand.SetInput(key, value)
+}
+
+// defaultPath names the export after the project and the month
+func (c *ExportCloudUsageCommand) defaultPath() string {
+	return fmt.Sprintf("cloud-usage-%s-%s.csv", c.projectID, time.Now().Format("2006-01"))
+}
+
+// executeCommand handles the actual command execution
+func (c *ExportCloudUsageCommand) executeCommand() (string, error) {
+	path, ok := c.input("file")
+	if !ok {
+		path = c.defaultPath()
+	}
+	path = expandPath(path)
+
+	reports, err := loadUsageReports(c.client, c.log, c.projectID)
+	if err != nil {
+		return "", err
+	}
+
+	rows := UsageCSV(reports.current, reports.forecast, reports.code)
+	err = streamFile(path, 0o644, func(w io.Writer) error {
+		if err := csv.NewWriter(w).WriteAll(rows); err != nil {
+			return fmt.Errorf("failed to write export file: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	c.log.Info("Exported cloud usage", "project", c.projectID, "path", path, "rows", len(rows)-1)
+	return fmt.Sprintf("Wrote %d usage lines to %s.", len(rows)-1, path), nil
+}
+
+// UsageCSV converts usage reports to CSV records, starting with a header
+func UsageCSV(current, forecast *api.CloudUsage, currency string) [][]string {
+	rows := [][]string{{
+		"report", "billing", "category", "type", "region", "reference",
+		"quantity", "unit", "price", "currency",
+	}}
+	add := func(report string, usage *api.CloudUsage) {
+		for _, item := range usage.Items() {
+			quantity, unit := "", ""
+			if item.Quantity != nil {
+				quantity = strconv.FormatFloat(item.Quantity.Value, 'f', -1, 64)
+				unit = item.Quantity.Unit
+			}
+			rows = append(rows, []string{
+				report, item.Billing, usageCategoryOf(item.Type), item.Type,
+				item.Region, item.Reference, quantity, unit,
+				strconv.FormatFloat(item.TotalPrice, 'f', 2, 64), currency,
+			})
+		}
+	}
+	add("current", current)
+	add("forecast", forecast)
+	return rows
+}
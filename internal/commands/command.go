@@ -51,19 +51,24 @@ type CommandOption func(*CommandConfig)
 
 // CommandConfig holds configuration for command execution
 type CommandConfig struct {
-	Timeout     time.Duration
-	RetryCount  int
-	RetryDelay  time.Duration
-	Interactive bool
+	Timeout      time.Duration
+	RetryCount   int
+	RetryDelay   time.Duration
+	Interactive  bool
+	PollInterval time.Duration
 }
 
 var defaultConfig = CommandConfig{
-	Timeout:     30 * time.Second,
-	RetryCount:  3,
-	RetryDelay:  time.Second,
-	Interactive: false,
+	Timeout:      30 * time.Second,
+	RetryCount:   3,
+	RetryDelay:   time.Second,
+	Interactive:  false,
+	PollInterval: 5 * time.Second,
 }
 
+// taskTimeout is the timeout of commands that wait for API tasks
+const taskTimeout = 15 * time.Minute
+
 // WithTimeout sets a command timeout
 func WithTimeout(d time.Duration) CommandOption {
 	return func(c *CommandConfig) {
@@ -79,6 +84,13 @@ func WithRetry(count int, delay time.Duration) CommandOption {
 	}
 }
 
+// WithPollInterval sets the delay between task status checks
+func WithPollInterval(d time.Duration) CommandOption {
+	return func(c *CommandConfig) {
+		c.PollInterval = d
+	}
+}
+
 // WithInteractive enables interactive mode
 func WithInteractive(interactive bool) CommandOption {
 	return func(c *CommandConfig) {
@@ -101,11 +113,61 @@ type Command interface {
 	ExecuteAsync(ctx context.Context) (<-chan CommandResult, error)
 }
 
+// PromptKind represents the kind of input a prompt collects
+type PromptKind int
+
+const (
+	// PromptText collects a line of text
+	PromptText PromptKind = iota
+
+	// PromptSecret collects text without echoing it
+	PromptSecret
+
+	// PromptConfirm asks for a yes/no confirmation
+	PromptConfirm
+
+	// PromptChoice selects one of a fixed set of values
+	PromptChoice
+
+	// PromptEditor collects text that may span several lines. A short
+	// answer can be typed in place, an empty line opens $EDITOR.
+	PromptEditor
+)
+
+// Prompt describes a single input requested from the user
+type Prompt struct {
+	Key     string
+	Label   string
+	Kind    PromptKind
+	Choices []string
+	Default string
+}
+
+// InteractiveCommand is implemented by commands that collect input
+// before they run
+type InteractiveCommand interface {
+	Command
+
+	// NextPrompt returns the next input to collect, or nil once the
+	// command has everything it needs
+	NextPrompt() (*Prompt, error)
+
+	// SetInput stores the answer to a prompt. An error means the value
+	// was rejected and the prompt should be asked again.
+	SetInput(key, value string) error
+}
+
+// confirmKey is the input key of confirmation prompts
+const confirmKey = "confirm"
+
 // BaseCommand provides common functionality for commands
 type BaseCommand struct {
-	cmdType CommandType
-	config  CommandConfig
-	state   CommandState
+	cmdType  CommandType
+	config   CommandConfig
+	state    CommandState
+	inputs   map[string]string
+	progress ProgressReporter
+	ctx      context.Context
 }
 
 // NewBaseCommand creates a new base command
@@ -120,6 +182,7 @@ func NewBaseCommand(cmdType CommandType, opts ...CommandOption) BaseCommand {
 		cmdType: cmdType,
 		config:  config,
 		state:   StateNew,
+		inputs:  make(map[string]string),
 	}
 }
 
@@ -128,6 +191,70 @@ func (b *BaseCommand) GetType() CommandType {
 	return b.cmdType
 }
 
+// SetInput stores the answer to a prompt without validation
+func (b *BaseCommand) SetInput(key, value string) error {
+	b.inputs[key] = value
+	return nil
+}
+
+// input returns a previously collected value
+func (b *BaseCommand) input(key string) (string, bool) {
+	value, ok := b.inputs[key]
+	return value, ok
+}
+
+// confirmPrompt returns a confirmation prompt until it was answered
+func (b *BaseCommand) confirmPrompt(label string) *Prompt {
+	if _, ok := b.inputs[confirmKey]; ok {
+		return nil
+	}
+	return &Prompt{Key: confirmKey, Label: label, Kind: PromptConfirm}
+}
+
+// SetProgressReporter sets where long running commands report progress
+func (b *BaseCommand) SetProgressReporter(reporter ProgressReporter) {
+	b.progress = reporter
+}
+
+// reportProgress reports progress if a reporter is set
+func (b *BaseCommand) reportProgress(step, total int, message string) {
+	if b.progress == nil {
+		return
+	}
+	b.progress.ReportProgress(CommandProgress{
+		Step:       step,
+		TotalSteps: total,
+		Message:    message,
+	})
+}
+
+// executeAsync runs fn in the background and delivers its result
+func (b *BaseCommand) executeAsync(fn func() (string, error)) (<-chan CommandResult, error) {
+	resultCh := make(chan CommandResult, 1)
+
+	go func() {
+		defer close(resultCh)
+
+		start := time.Now()
+		output, err := fn()
+		duration := time.Since(start)
+
+		state := StateCompleted
+		if err != nil {
+			state = StateFailed
+		}
+
+		resultCh <- CommandResult{
+			Output:   output,
+			Error:    err,
+			Duration: duration,
+			State:    state,
+		}
+	}()
+
+	return resultCh, nil
+}
+
 // executeWithTimeout wraps command execution with timeout
 func (b *BaseCommand) executeWithTimeout(
 	ctx context.Context,
@@ -161,6 +288,24 @@ func (b *BaseCommand) executeWithTimeout(
 	}
 }
 
+// executeTask runs a command that waits for API tasks. Unlike
+// executeWithTimeout it does not walk away from fn: pollTask stops once
+// ctx ends or the timeout expires, and fn returns the error.
+func (b *BaseCommand) executeTask(
+	ctx context.Context,
+	fn func() (string, error),
+) (string, error) {
+	if b.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, b.config.Timeout)
+		defer cancel()
+	}
+
+	b.ctx = ctx
+	defer func() { b.ctx = nil }()
+	return fn()
+}
+
 // executeWithRetry wraps command execution with retry logic
 func (b *BaseCommand) executeWithRetry(
 	ctx context.Context,
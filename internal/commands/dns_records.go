@@ -0,0 +1,508 @@
+// internal/commands/dns_records.go
+package commands
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"ovh-terminal/internal/api"
+	"ovh-terminal/internal/dnszone"
+	"ovh-terminal/internal/logger"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// dnsRecordTypes are the record types accepted by the zone API
+var dnsRecordTypes = []string{"A", "AAAA", "CAA", "CNAME", "DKIM", "DMARC", "DNAME", "LOC", "MX",
+	"NAPTR", "NS", "PTR", "SPF", "SRV", "SSHFP", "TLSA", "TXT"}
+
+// DNSRecordSpec describes a record in a records file. An empty or "@"
+// subdomain is the zone apex and a zero TTL the zone default.
+type DNSRecordSpec struct {
+	SubDomain string `toml:"subdomain" yaml:"subdomain"`
+	Type      string `toml:"type" yaml:"type"`
+	TTL       int    `toml:"ttl,omitempty" yaml:"ttl,omitempty"`
+	Target    string `toml:"target" yaml:"target"`
+}
+
+// DNSZoneSpec lists the desired records of a zone
+type DNSZoneSpec struct {
+	Name    string          `toml:"name" yaml:"name"`
+	Records []DNSRecordSpec `toml:"record" yaml:"records"`
+}
+
+// DNSRecordsFile is the desired state of one or more zones. With
+// IgnoreUnmanaged, live records whose subdomain and type appear nowhere in
+// the file are left alone instead of being removed.
+type DNSRecordsFile struct {
+	IgnoreUnmanaged bool          `toml:"ignore_unmanaged" yaml:"ignore_unmanaged"`
+	Zones           []DNSZoneSpec `toml:"zone" yaml:"zones"`
+}
+
+// LoadDNSRecords reads and validates a records file, in YAML when its
+// extension says so and in TOML otherwise
+func LoadDNSRecords(path string) (*DNSRecordsFile, error) {
+	data, err := os.ReadFile(expandPath(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read records file: %w", err)
+	}
+
+	var file DNSRecordsFile
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&file); err != nil {
+			return nil, fmt.Errorf("failed to parse records file: %w", err)
+		}
+	default:
+		meta, err := toml.Decode(string(data), &file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse records file: %w", err)
+		}
+		if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+			return nil, fmt.Errorf("failed to parse records file: unknown key %s", undecoded[0])
+		}
+	}
+
+	seen := make(map[string]bool)
+	for i := range file.Zones {
+		zone := &file.Zones[i]
+		if err := zone.normalize(); err != nil {
+			return nil, err
+		}
+		if seen[zone.Name] {
+			return nil, fmt.Errorf("zone %s: listed twice", zone.Name)
+		}
+		seen[zone.Name] = true
+	}
+	sort.Slice(file.Zones, func(i, j int) bool {
+		return file.Zones[i].Name < file.Zones[j].Name
+	})
+
+	return &file, nil
+}
+
+// normalize validates a zone and puts its records in canonical form
+func (z *DNSZoneSpec) normalize() error {
+	z.Name = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(z.Name), "."))
+	if !hostnamePattern.MatchString(z.Name) {
+		return fmt.Errorf("zone %q: invalid name", z.Name)
+	}
+
+	seen := make(map[string]bool)
+	for i := range z.Records {
+		record := &z.Records[i]
+		if err := record.normalize(); err != nil {
+			return fmt.Errorf("zone %s, record %d: %w", z.Name, i+1, err)
+		}
+		key := record.SubDomain + " " + record.Type + " " + dnszone.CanonicalTarget(z.Name, record.Type, record.Target)
+		if seen[key] {
+			return fmt.Errorf("zone %s, record %d: duplicate of %s", z.Name, i+1, record)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// normalize validates a record and puts its fields in canonical form
+func (r *DNSRecordSpec) normalize() error {
+	r.SubDomain = strings.ToLower(strings.TrimSpace(r.SubDomain))
+	if r.SubDomain == "@" {
+		r.SubDomain = ""
+	}
+	if r.SubDomain != "" && !subDomainPattern.MatchString(r.SubDomain) {
+		return fmt.Errorf("invalid subdomain %q", r.SubDomain)
+	}
+
+	r.Type = strings.ToUpper(strings.TrimSpace(r.Type))
+	if !containsString(dnsRecordTypes, r.Type) {
+		return fmt.Errorf("unsupported type %q", r.Type)
+	}
+	if r.TTL < 0 {
+		return fmt.Errorf("invalid TTL %d", r.TTL)
+	}
+
+	r.Target = strings.TrimSpace(r.Target)
+	if r.Target == "" {
+		return fmt.Errorf("target is required")
+	}
+	switch r.Type {
+	case "A":
+		if ip := net.ParseIP(r.Target); ip == nil || ip.To4() == nil {
+			return fmt.Errorf("%q is not an IPv4 address", r.Target)
+		}
+	case "AAAA":
+		if ip := net.ParseIP(r.Target); ip == nil || ip.To4() != nil {
+			return fmt.Errorf("%q is not an IPv6 address", r.Target)
+		}
+	}
+	return nil
+}
+
+// String formats the record as a zone file line
+func (r DNSRecordSpec) String() string {
+	return r.record().String()
+}
+
+// record converts the spec for comparison with live records
+func (r DNSRecordSpec) record() dnszone.Record {
+	return dnszone.Record{SubDomain: r.SubDomain, Type: r.Type, TTL: r.TTL, Target: r.Target}
+}
+
+// DNSZonePlan holds the planned changes of a zone
+type DNSZonePlan struct {
+	Zone string
+	Diff dnszone.Diff
+}
+
+// DNSPlan holds the planned changes of all zones of a records file
+type DNSPlan struct {
+	Zones []DNSZonePlan
+}
+
+// PlanDNSZone compares the live records of a zone with the records file.
+// With ignoreUnmanaged, only the subdomains and types of the file are
+// considered.
+func PlanDNSZone(spec DNSZoneSpec, live []dnszone.Record, ignoreUnmanaged bool) DNSZonePlan {
+	desired := make([]dnszone.Record, len(spec.Records))
+	managed := make(map[string]bool)
+	for i, record := range spec.Records {
+		desired[i] = record.record()
+		managed[record.SubDomain+" "+record.Type] = true
+	}
+
+	current := live
+	if ignoreUnmanaged {
+		current = nil
+		for _, record := range live {
+			if managed[record.SubDomain+" "+record.Type] {
+				current = append(current, record)
+			}
+		}
+	}
+
+	return DNSZonePlan{Zone: spec.Name, Diff: dnszone.Compare(spec.Name, current, desired)}
+}
+
+// Empty checks if the plan has nothing to do
+func (p *DNSPlan) Empty() bool {
+	for _, zone := range p.Zones {
+		if !zone.Diff.Empty() {
+			return false
+		}
+	}
+	return true
+}
+
+// Counts returns the number of additions, changes and removals
+func (p *DNSPlan) Counts() (add, change, remove int) {
+	for _, zone := range p.Zones {
+		add += len(zone.Diff.Add)
+		change += len(zone.Diff.Change)
+		remove += len(zone.Diff.Delete)
+	}
+	return add, change, remove
+}
+
+// String renders the plan with one line per record change
+func (p *DNSPlan) String() string {
+	var b strings.Builder
+	for _, zone := range p.Zones {
+		if zone.Diff.Empty() {
+			fmt.Fprintf(&b, "Zone %s matches the records file, no changes.\n\n", zone.Zone)
+			continue
+		}
+		fmt.Fprintf(&b, "Zone plan for %s\n\n", zone.Zone)
+		for _, line := range zone.Diff.Lines() {
+			fmt.Fprintf(&b, "  %s\n", line)
+		}
+		b.WriteString("\n")
+	}
+
+	add, change, remove := p.Counts()
+	fmt.Fprintf(&b, "%d to add, %d to change, %d to remove.", add, change, remove)
+	return b.String()
+}
+
+// dnsRecordsBase holds what the records file commands share
+type dnsRecordsBase struct {
+	BaseCommand
+	client          *api.Client
+	log             *logger.Logger
+	ignoreUnmanaged bool
+}
+
+// newDNSRecordsBase creates the shared state of a records file command
+func newDNSRecordsBase(
+	client *api.Client,
+	name string,
+	ignoreUnmanaged bool,
+	cmdType CommandType,
+	opts ...CommandOption,
+) dnsRecordsBase {
+	return dnsRecordsBase{
+		BaseCommand:     NewBaseCommand(cmdType, opts...),
+		client:          client,
+		log:             logger.Log.With(map[string]interface{}{"command": name}),
+		ignoreUnmanaged: ignoreUnmanaged,
+	}
+}
+
+// SetInput implements the InteractiveCommand interface
+func (c *dnsRecordsBase) SetInput(key, value string) error {
+	if key == "file" {
+		if _, err := LoadDNSRecords(value); err != nil {
+			return err
+		}
+		value = expandPath(value)
+	}
+	return c.BaseCommand.SetInput(key, value)
+}
+
+// filePrompt asks for the "file" input, the path of a records file
+func (c *dnsRecordsBase) filePrompt() *Prompt {
+	if _, ok := c.input("file"); ok {
+		return nil
+	}
+	return &Prompt{Key: "file", Label: "Records file", Kind: PromptText, Default: "dns.toml"}
+}
+
+// plan compares the live zones with the records file
+func (c *dnsRecordsBase) plan() (*DNSPlan, error) {
+	path, ok := c.input("file")
+	if !ok {
+		return nil, fmt.Errorf("records file is required")
+	}
+	file, err := LoadDNSRecords(path)
+	if err != nil {
+		return nil, err
+	}
+	ignoreUnmanaged := c.ignoreUnmanaged || file.IgnoreUnmanaged
+
+	plan := &DNSPlan{}
+	for i, zone := range file.Zones {
+		c.reportProgress(i, len(file.Zones), fmt.Sprintf("Reading records of %s", zone.Name))
+		live, err := LoadZoneRecords(c.client, zone.Name)
+		if err != nil {
+			return nil, err
+		}
+		plan.Zones = append(plan.Zones, PlanDNSZone(zone, live, ignoreUnmanaged))
+	}
+	return plan, nil
+}
+
+// PlanDNSCommand shows how a records file differs from the live zones
+type PlanDNSCommand struct {
+	dnsRecordsBase
+}
+
+// NewPlanDNSCommand creates a new DNS plan command instance
+func NewPlanDNSCommand(client *api.Client, ignoreUnmanaged bool) *PlanDNSCommand {
+	return &PlanDNSCommand{
+		dnsRecordsBase: newDNSRecordsBase(client, "plan_dns", ignoreUnmanaged, TypeInfo),
+	}
+}
+
+// Execute implements the Command interface
+func (c *PlanDNSCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *PlanDNSCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *PlanDNSCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// NextPrompt implements the InteractiveCommand interface
+func (c *PlanDNSCommand) NextPrompt() (*Prompt, error) {
+	return c.filePrompt(), nil
+}
+
+// executeCommand handles the actual command execution
+func (c *PlanDNSCommand) executeCommand() (string, error) {
+	plan, err := c.plan()
+	if err != nil {
+		return "", err
+	}
+	return plan.String(), nil
+}
+
+// ApplyDNSCommand brings the live zones in line with a records file. The
+// plan is computed once, so what was confirmed is what gets applied.
+type ApplyDNSCommand struct {
+	dnsRecordsBase
+	current *DNSPlan
+}
+
+// NewApplyDNSCommand creates a new DNS apply command instance
+func NewApplyDNSCommand(client *api.Client, ignoreUnmanaged bool) *ApplyDNSCommand {
+	return &ApplyDNSCommand{
+		dnsRecordsBase: newDNSRecordsBase(client, "apply_dns", ignoreUnmanaged, TypeAction,
+			WithTimeout(taskTimeout)),
+	}
+}
+
+// Execute implements the Command interface
+func (c *ApplyDNSCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *ApplyDNSCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeTask(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *ApplyDNSCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(func() (string, error) {
+		return c.executeTask(ctx, c.executeCommand)
+	})
+}
+
+// NextPrompt implements the InteractiveCommand interface
+func (c *ApplyDNSCommand) NextPrompt() (*Prompt, error) {
+	if prompt := c.filePrompt(); prompt != nil {
+		return prompt, nil
+	}
+
+	plan, err := c.Plan()
+	if err != nil {
+		return nil, err
+	}
+	if plan.Empty() {
+		return nil, nil
+	}
+	add, change, remove := plan.Counts()
+	return c.confirmPrompt(fmt.Sprintf("Apply to %d zone(s): %d to add, %d to change, %d to remove?",
+		len(plan.Zones), add, change, remove)), nil
+}
+
+// SetInput implements the InteractiveCommand interface
+func (c *ApplyDNSCommand) SetInput(key, value string) error {
+	if key == "file" {
+		c.current = nil
+	}
+	return c.dnsRecordsBase.SetInput(key, value)
+}
+
+// Plan returns the changes the command applies
+func (c *ApplyDNSCommand) Plan() (*DNSPlan, error) {
+	if c.current != nil {
+		return c.current, nil
+	}
+	plan, err := c.plan()
+	if err != nil {
+		return nil, err
+	}
+	c.current = plan
+	return plan, nil
+}
+
+// executeCommand handles the actual command execution
+func (c *ApplyDNSCommand) executeCommand() (string, error) {
+	plan, err := c.Plan()
+	if err != nil {
+		return "", err
+	}
+	if plan.Empty() {
+		return plan.String(), nil
+	}
+
+	var zones []string
+	for _, zone := range plan.Zones {
+		if zone.Diff.Empty() {
+			continue
+		}
+		c.log.Info("Applying DNS records", "zone", zone.Zone, "add", len(zone.Diff.Add),
+			"change", len(zone.Diff.Change), "remove", len(zone.Diff.Delete))
+		if err := c.applyZone(zone); err != nil {
+			if len(zones) > 0 {
+				return "", fmt.Errorf("%w (%s already applied)", err, strings.Join(zones, ", "))
+			}
+			return "", err
+		}
+		zones = append(zones, zone.Zone)
+	}
+
+	add, change, remove := plan.Counts()
+	return fmt.Sprintf("Applied %d addition(s), %d change(s) and %d removal(s) to %s.",
+		add, change, remove, strings.Join(zones, ", ")), nil
+}
+
+// applyZone performs the changes of a zone and refreshes it. A zone that
+// fails partway is still refreshed, so the changes made are published.
+func (c *ApplyDNSCommand) applyZone(zone DNSZonePlan) error {
+	applied, err := c.changeZone(zone)
+	if err != nil {
+		if applied == 0 {
+			return err
+		}
+		total := len(zone.Diff.Delete) + len(zone.Diff.Change) + len(zone.Diff.Add)
+		if refreshErr := c.client.RefreshDNSZone(zone.Zone); refreshErr != nil {
+			c.log.Error("Failed to refresh DNS zone", "zone", zone.Zone, "error", refreshErr)
+		}
+		return fmt.Errorf("applied %d of %d change(s) to %s: %w", applied, total, zone.Zone, err)
+	}
+
+	c.reportProgress(0, 0, fmt.Sprintf("Refreshing %s", zone.Zone))
+	return c.client.RefreshDNSZone(zone.Zone)
+}
+
+// changeZone performs the changes of a zone and returns how many went
+// through. Removals go first so that a CNAME can replace other records of
+// its subdomain.
+func (c *ApplyDNSCommand) changeZone(zone DNSZonePlan) (int, error) {
+	applied := 0
+	for _, record := range zone.Diff.Delete {
+		c.reportProgress(0, 0, fmt.Sprintf("Removing %s", record.Name(zone.Zone)))
+		if err := c.client.DeleteZoneRecord(zone.Zone, record.ID); err != nil {
+			return applied, err
+		}
+		applied++
+	}
+	for _, change := range zone.Diff.Change {
+		c.reportProgress(0, 0, fmt.Sprintf("Updating %s", change.To.Name(zone.Zone)))
+		err := c.client.UpdateZoneRecord(zone.Zone, change.From.ID, api.DNSRecord{
+			SubDomain: change.To.SubDomain,
+			Target:    change.To.Target,
+			TTL:       change.To.TTL,
+		})
+		if err != nil {
+			return applied, err
+		}
+		applied++
+	}
+	for _, record := range zone.Diff.Add {
+		c.reportProgress(0, 0, fmt.Sprintf("Adding %s", record.Name(zone.Zone)))
+		_, err := c.client.CreateZoneRecord(zone.Zone, api.DNSRecord{
+			SubDomain: record.SubDomain,
+			FieldType: record.Type,
+			Target:    record.Target,
+			TTL:       record.TTL,
+		})
+		if err != nil {
+			return applied, err
+		}
+		applied++
+	}
+	return applied, nil
+}
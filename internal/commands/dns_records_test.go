@@ -0,0 +1,101 @@
+// internal/commands/dns_records_test.go
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"ovh-terminal/internal/dnszone"
+)
+
+func TestLoadDNSRecords(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	file, err := LoadDNSRecords(write("dns.toml", `
+ignore_unmanaged = true
+
+[[zone]]
+name = "Example.org."
+
+[[zone]]
+name = "example.com"
+
+[[zone.record]]
+subdomain = "@"
+type = "a"
+target = "203.0.113.20"
+
+[[zone.record]]
+subdomain = "WWW"
+type = "CNAME"
+ttl = 300
+target = "example.com."
+`))
+	if err != nil {
+		t.Fatalf("LoadDNSRecords failed: %v", err)
+	}
+	if !file.IgnoreUnmanaged || len(file.Zones) != 2 || file.Zones[1].Name != "example.org" {
+		t.Fatalf("Expected sorted, normalized zones, got %+v", file)
+	}
+	records := file.Zones[0].Records
+	if records[0].SubDomain != "" || records[0].Type != "A" || records[1].SubDomain != "www" {
+		t.Errorf("Expected normalized records, got %+v", records)
+	}
+
+	yamlFile, err := LoadDNSRecords(write("dns.yaml", `
+zones:
+  - name: example.com
+    records:
+      - {subdomain: mail, type: AAAA, target: "2001:db8::25"}
+`))
+	if err != nil {
+		t.Fatalf("LoadDNSRecords failed on YAML: %v", err)
+	}
+	if len(yamlFile.Zones) != 1 || yamlFile.Zones[0].Records[0].Target != "2001:db8::25" {
+		t.Errorf("Unexpected YAML records %+v", yamlFile.Zones)
+	}
+
+	invalid := map[string]string{
+		"duplicate zone":   "[[zone]]\nname = \"example.com\"\n[[zone]]\nname = \"example.com\"\n",
+		"unknown key":      "[[zone]]\nname = \"example.com\"\n[[zone.records]]\ntype = \"A\"\n",
+		"unsupported type": "[[zone]]\nname = \"example.com\"\n[[zone.record]]\ntype = \"SOA\"\ntarget = \"x\"\n",
+		"bad IPv4":         "[[zone]]\nname = \"example.com\"\n[[zone.record]]\ntype = \"A\"\ntarget = \"2001:db8::1\"\n",
+		"duplicate record": "[[zone]]\nname = \"example.com\"\n[[zone.record]]\ntype = \"CNAME\"\nsubdomain = \"www\"\ntarget = \"@\"\n" +
+			"[[zone.record]]\ntype = \"CNAME\"\nsubdomain = \"www\"\ntarget = \"example.com.\"\n",
+	}
+	for name, content := range invalid {
+		if _, err := LoadDNSRecords(write("invalid.toml", content)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestPlanDNSZone(t *testing.T) {
+	live := []dnszone.Record{
+		{ID: 1, Type: "A", Target: "203.0.113.20"},
+		{ID: 2, SubDomain: "www", Type: "CNAME", Target: "example.com."},
+		{ID: 3, Type: "MX", Target: "1 mx1.mail.ovh.net."},
+	}
+	spec := DNSZoneSpec{Name: "example.com", Records: []DNSRecordSpec{
+		{Type: "A", Target: "203.0.113.21"},
+		{SubDomain: "api", Type: "A", Target: "203.0.113.30"},
+	}}
+
+	plan := PlanDNSZone(spec, live, false)
+	if len(plan.Diff.Add) != 1 || len(plan.Diff.Change) != 1 || len(plan.Diff.Delete) != 2 {
+		t.Errorf("Expected 1 addition, 1 change and 2 removals, got %+v", plan.Diff)
+	}
+
+	plan = PlanDNSZone(spec, live, true)
+	if len(plan.Diff.Add) != 1 || len(plan.Diff.Change) != 1 || len(plan.Diff.Delete) != 0 {
+		t.Errorf("Expected unmanaged records to be left alone, got %+v", plan.Diff)
+	}
+}
@@ -0,0 +1,352 @@
+// internal/commands/dns_zone.go
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"ovh-terminal/internal/api"
+	"ovh-terminal/internal/dnszone"
+	"ovh-terminal/internal/logger"
+)
+
+// LoadZoneRecords fetches the records of a hosted zone, sorted by
+// subdomain and type
+func LoadZoneRecords(client *api.Client, zone string) ([]dnszone.Record, error) {
+	ids, err := client.ListZoneRecords(zone, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]dnszone.Record, 0, len(ids))
+	for _, id := range ids {
+		record, err := client.GetZoneRecord(zone, id)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, dnszone.Record{
+			ID:        record.ID,
+			SubDomain: record.SubDomain,
+			Type:      record.FieldType,
+			TTL:       record.TTL,
+			Target:    record.Target,
+		})
+	}
+	dnszone.Sort(records)
+	return records, nil
+}
+
+// LoadZoneFile reads the records of a BIND zone file
+func LoadZoneFile(zone, path string) ([]dnszone.Record, error) {
+	data, err := os.ReadFile(expandPath(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read zone file: %w", err)
+	}
+	records, err := dnszone.Parse(zone, string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse zone file: %w", err)
+	}
+	return records, nil
+}
+
+// FormatZoneDiff renders the changes turning the live zone into the
+// content of a zone file, one line per record
+func FormatZoneDiff(zone string, diff dnszone.Diff) string {
+	if diff.Empty() {
+		return fmt.Sprintf("Zone %s matches the zone file, no changes.", zone)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Zone plan for %s\n\n", zone)
+	for _, line := range diff.Lines() {
+		fmt.Fprintf(&b, "  %s\n", line)
+	}
+	fmt.Fprintf(&b, "\n%d to add, %d to change, %d to remove.", len(diff.Add), len(diff.Change), len(diff.Delete))
+	return b.String()
+}
+
+// zoneTaskCheck follows a task of a zone
+func zoneTaskCheck(client *api.Client, zone string, task *api.DomainTask) TaskCheck {
+	return func() (string, bool, error) {
+		current, err := client.GetZoneTask(zone, task.ID)
+		if err != nil {
+			return "", false, err
+		}
+		if current.IsFailed() {
+			return current.Status, false, fmt.Errorf("task %d (%s) ended with status %s",
+				current.ID, current.Function, current.Status)
+		}
+		return current.Status, current.IsDone(), nil
+	}
+}
+
+// zoneFileBase holds what the zone file commands share
+type zoneFileBase struct {
+	BaseCommand
+	client *api.Client
+	log    *logger.Logger
+	zone   string
+}
+
+// newZoneFileBase creates the shared state of a zone file command
+func newZoneFileBase(client *api.Client, zone, name string, cmdType CommandType, opts ...CommandOption) zoneFileBase {
+	return zoneFileBase{
+		BaseCommand: NewBaseCommand(cmdType, opts...),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": name}),
+		zone:        zone,
+	}
+}
+
+// filePrompt asks for the "file" input, the path of the zone file
+func (c *zoneFileBase) filePrompt() *Prompt {
+	if _, ok := c.input("file"); ok {
+		return nil
+	}
+	return &Prompt{Key: "file", Label: "Zone file", Kind: PromptText, Default: c.defaultPath()}
+}
+
+// defaultPath names the zone file after the zone
+func (c *zoneFileBase) defaultPath() string {
+	return c.zone + ".zone"
+}
+
+// path returns the zone file path
+func (c *zoneFileBase) path() string {
+	path, ok := c.input("file")
+	if !ok || strings.TrimSpace(path) == "" {
+		path = c.defaultPath()
+	}
+	return expandPath(path)
+}
+
+// diff compares the live zone with the zone file
+func (c *zoneFileBase) diff() (dnszone.Diff, error) {
+	desired, err := LoadZoneFile(c.zone, c.path())
+	if err != nil {
+		return dnszone.Diff{}, err
+	}
+	current, err := LoadZoneRecords(c.client, c.zone)
+	if err != nil {
+		return dnszone.Diff{}, err
+	}
+	return dnszone.Compare(c.zone, current, desired), nil
+}
+
+// setZoneFile validates the "file" input of the commands reading a zone
+// file
+func (c *zoneFileBase) setZoneFile(key, value string) error {
+	if key == "file" {
+		if strings.TrimSpace(value) == "" {
+			value = c.defaultPath()
+		}
+		if _, err := LoadZoneFile(c.zone, value); err != nil {
+			return err
+		}
+		value = expandPath(value)
+	}
+	return c.BaseCommand.SetInput(key, value)
+}
+
+// ExportZoneCommand saves a hosted zone to a BIND zone file
+type ExportZoneCommand struct {
+	zoneFileBase
+}
+
+// NewExportZoneCommand creates a new zone export command instance
+func NewExportZoneCommand(client *api.Client, zone string) *ExportZoneCommand {
+	return &ExportZoneCommand{
+		zoneFileBase: newZoneFileBase(client, zone, "export_zone", TypeAction),
+	}
+}
+
+// Execute implements the Command interface
+func (c *ExportZoneCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *ExportZoneCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *ExportZoneCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// NextPrompt implements the InteractiveCommand interface
+func (c *ExportZoneCommand) NextPrompt() (*Prompt, error) {
+	if prompt := c.filePrompt(); prompt != nil {
+		return prompt, nil
+	}
+	path, _ := c.input("file")
+	if _, err := os.Stat(expandPath(path)); err == nil {
+		return c.confirmPrompt(fmt.Sprintf("Overwrite %s?", path)), nil
+	}
+	return nil, nil
+}
+
+// SetInput implements the InteractiveCommand interface
+func (c *ExportZoneCommand) SetInput(key, value string) error {
+	if key == "file" && strings.TrimSpace(value) == "" {
+		value = c.defaultPath()
+	}
+	return c.BaseCommand.SetInput(key, value)
+}
+
+// executeCommand handles the actual command execution
+func (c *ExportZoneCommand) executeCommand() (string, error) {
+	content, err := c.client.ExportDNSZone(c.zone)
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+
+	path := c.path()
+	if err := writePrivateFile(path, []byte(content)); err != nil {
+		return "", err
+	}
+
+	c.log.Info("Exported zone", "zone", c.zone, "path", path)
+	return fmt.Sprintf("Saved zone %s to %s.", c.zone, path), nil
+}
+
+// DiffZoneCommand shows how a zone file differs from the live zone
+type DiffZoneCommand struct {
+	zoneFileBase
+}
+
+// NewDiffZoneCommand creates a new zone diff command instance
+func NewDiffZoneCommand(client *api.Client, zone string) *DiffZoneCommand {
+	return &DiffZoneCommand{
+		zoneFileBase: newZoneFileBase(client, zone, "diff_zone", TypeInfo),
+	}
+}
+
+// Execute implements the Command interface
+func (c *DiffZoneCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *DiffZoneCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *DiffZoneCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// NextPrompt implements the InteractiveCommand interface
+func (c *DiffZoneCommand) NextPrompt() (*Prompt, error) {
+	return c.filePrompt(), nil
+}
+
+// SetInput implements the InteractiveCommand interface
+func (c *DiffZoneCommand) SetInput(key, value string) error {
+	return c.setZoneFile(key, value)
+}
+
+// executeCommand handles the actual command execution
+func (c *DiffZoneCommand) executeCommand() (string, error) {
+	diff, err := c.diff()
+	if err != nil {
+		return "", err
+	}
+	return FormatZoneDiff(c.zone, diff), nil
+}
+
+// ImportZoneCommand replaces the records of a hosted zone with a zone file
+type ImportZoneCommand struct {
+	zoneFileBase
+}
+
+// NewImportZoneCommand creates a new zone import command instance
+func NewImportZoneCommand(client *api.Client, zone string) *ImportZoneCommand {
+	return &ImportZoneCommand{
+		zoneFileBase: newZoneFileBase(client, zone, "import_zone", TypeAction, WithTimeout(taskTimeout)),
+	}
+}
+
+// Execute implements the Command interface
+func (c *ImportZoneCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *ImportZoneCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeTask(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *ImportZoneCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(func() (string, error) {
+		return c.executeTask(ctx, c.executeCommand)
+	})
+}
+
+// NextPrompt implements the InteractiveCommand interface
+func (c *ImportZoneCommand) NextPrompt() (*Prompt, error) {
+	if prompt := c.filePrompt(); prompt != nil {
+		return prompt, nil
+	}
+
+	diff, err := c.diff()
+	if err != nil {
+		return nil, err
+	}
+	if diff.Empty() {
+		return nil, nil
+	}
+	return c.confirmPrompt(fmt.Sprintf("Import into %s: %d to add, %d to change, %d to remove?",
+		c.zone, len(diff.Add), len(diff.Change), len(diff.Delete))), nil
+}
+
+// SetInput implements the InteractiveCommand interface
+func (c *ImportZoneCommand) SetInput(key, value string) error {
+	return c.setZoneFile(key, value)
+}
+
+// executeCommand handles the actual command execution
+func (c *ImportZoneCommand) executeCommand() (string, error) {
+	diff, err := c.diff()
+	if err != nil {
+		return "", err
+	}
+	if diff.Empty() {
+		return FormatZoneDiff(c.zone, diff), nil
+	}
+
+	path := c.path()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read zone file: %w", err)
+	}
+
+	c.log.Info("Importing zone", "zone", c.zone, "path", path, "add", len(diff.Add),
+		"change", len(diff.Change), "remove", len(diff.Delete))
+	task, err := c.client.ImportDNSZone(c.zone, string(content))
+	if err != nil {
+		return "", err
+	}
+	if err := c.pollTask(fmt.Sprintf("Importing zone %s", c.zone), zoneTaskCheck(c.client, c.zone, task)); err != nil {
+		return "", err
+	}
+
+	return FormatZoneDiff(c.zone, diff) + "\n\nImported.", nil
+}
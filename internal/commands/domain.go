@@ -0,0 +1,381 @@
+// internal/commands/domain.go
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"ovh-terminal/internal/api"
+	"ovh-terminal/internal/format"
+	"ovh-terminal/internal/logger"
+)
+
+// redirectionTypes are the labels of web redirection types
+var redirectionTypes = []struct {
+	value string
+	label string
+}{
+	{api.RedirectionVisible, "Visible, temporary (302)"},
+	{api.RedirectionVisiblePermanent, "Visible, permanent (301)"},
+	{api.RedirectionInvisible, "Invisible (framed)"},
+}
+
+// redirectionTypeLabel describes a web redirection type
+func redirectionTypeLabel(value string) string {
+	for _, kind := range redirectionTypes {
+		if kind.value == value {
+			return kind.label
+		}
+	}
+	return value
+}
+
+// nameServerTypeLabel describes where the nameservers of a domain live
+func nameServerTypeLabel(nameServerType string) string {
+	switch nameServerType {
+	case api.NameServerHosted:
+		return "OVHcloud DNS"
+	case api.NameServerExternal:
+		return "External"
+	}
+	return nameServerType
+}
+
+// DomainSummary describes a domain on one line, for menus
+func DomainSummary(domain *api.DomainInfo) string {
+	parts := []string{"expires " + domain.Expiration.Format(format.DateLayout)}
+	if domain.NameServerType != "" {
+		parts = append(parts, nameServerTypeLabel(domain.NameServerType)+" nameservers")
+	}
+	if domain.DnssecStatus == api.DNSSECEnabled {
+		parts = append(parts, "DNSSEC")
+	}
+	if !domain.IsTransferLocked() {
+		parts = append(parts, "unlocked")
+	}
+	return strings.Join(parts, " · ")
+}
+
+// WebRedirectionSummary describes a web redirection on one line
+func WebRedirectionSummary(redirection *api.WebRedirection) string {
+	return fmt.Sprintf("→ %s (%s)", redirection.Target, redirectionTypeLabel(redirection.Type))
+}
+
+// LoadDomainNameServers returns the nameservers of a domain in ID order
+func LoadDomainNameServers(client *api.Client, domain string) ([]*api.DomainNameServer, error) {
+	ids, err := client.ListDomainNameServers(domain)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	servers := make([]*api.DomainNameServer, 0, len(ids))
+	for _, id := range ids {
+		server, err := client.GetDomainNameServer(domain, id)
+		if err != nil {
+			return nil, err
+		}
+		servers = append(servers, server)
+	}
+	return servers, nil
+}
+
+// LoadWebRedirections returns the web redirections of a zone by host
+func LoadWebRedirections(client *api.Client, zone string) ([]*api.WebRedirection, error) {
+	ids, err := client.ListZoneRedirections(zone)
+	if err != nil {
+		return nil, err
+	}
+
+	redirections := make([]*api.WebRedirection, 0, len(ids))
+	for _, id := range ids {
+		redirection, err := client.GetZoneRedirection(zone, id)
+		if err != nil {
+			return nil, err
+		}
+		redirections = append(redirections, redirection)
+	}
+	sort.Slice(redirections, func(i, j int) bool {
+		return redirections[i].SubDomain < redirections[j].SubDomain
+	})
+	return redirections, nil
+}
+
+// DomainsCommand lists the domain names of the account
+type DomainsCommand struct {
+	BaseCommand
+	client *api.Client
+	log    *logger.Logger
+}
+
+// NewDomainsCommand creates a new domains command instance
+func NewDomainsCommand(client *api.Client) *DomainsCommand {
+	return &DomainsCommand{
+		BaseCommand: NewBaseCommand(TypeInfo),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "domains"}),
+	}
+}
+
+// Execute implements the Command interface
+func (c *DomainsCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *DomainsCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *DomainsCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// Domains returns the domain names sorted by name
+func (c *DomainsCommand) Domains() ([]*api.DomainInfo, error) {
+	names, err := c.client.ListDomains()
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	domains := make([]*api.DomainInfo, 0, len(names))
+	for _, name := range names {
+		domain, err := c.client.GetDomainInfo(name)
+		if err != nil {
+			return nil, err
+		}
+		domains = append(domains, domain)
+	}
+	return domains, nil
+}
+
+// executeCommand handles the actual command execution
+func (c *DomainsCommand) executeCommand() (string, error) {
+	c.log.Debug("Executing domains command")
+
+	domains, err := c.Domains()
+	if err != nil {
+		return "", err
+	}
+
+	output := format.NewOutputFormatter(
+		format.WithMaxWidth(maxWidth),
+		format.WithSeparator("\n"),
+	)
+	section := output.AddSection("Domain Names")
+	section.SetConfig(format.SectionConfig{
+		KeyValueSpacing: keyValueSpacing,
+		TitleDecorator:  "=",
+	})
+
+	if len(domains) == 0 {
+		section.AddField("Domains", "None")
+	}
+	for _, domain := range domains {
+		section.AddField(domain.Domain, DomainSummary(domain))
+	}
+
+	return output.String(), nil
+}
+
+// DomainCommand shows the settings of a domain name
+type DomainCommand struct {
+	BaseCommand
+	client *api.Client
+	log    *logger.Logger
+	domain string
+}
+
+// NewDomainCommand creates a new domain command instance
+func NewDomainCommand(client *api.Client, domain string) *DomainCommand {
+	return &DomainCommand{
+		BaseCommand: NewBaseCommand(TypeInfo),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "domain"}),
+		domain:      domain,
+	}
+}
+
+// Execute implements the Command interface
+func (c *DomainCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *DomainCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *DomainCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// executeCommand handles the actual command execution
+func (c *DomainCommand) executeCommand() (string, error) {
+	c.log.Debug("Executing domain command", "domain", c.domain)
+
+	domain, err := c.client.GetDomainInfo(c.domain)
+	if err != nil {
+		return "", err
+	}
+
+	output := format.NewOutputFormatter(
+		format.WithMaxWidth(maxWidth),
+		format.WithSeparator("\n"),
+	)
+	config := format.SectionConfig{
+		KeyValueSpacing: keyValueSpacing,
+		TitleDecorator:  "=",
+	}
+
+	section := output.AddSection("Domain")
+	section.SetConfig(config)
+	section.AddField("Domain", domain.Domain)
+	section.AddField("Expires", domain.Expiration.Format(format.DateLayout))
+	section.AddField("Owner", domain.WhoisOwner)
+	section.AddField("Transfer Lock", domain.TransferLockStatus)
+	section.AddField("DNSSEC", domain.DnssecStatus)
+
+	section = output.AddSection("Nameservers")
+	section.SetConfig(config)
+	section.AddField("Type", nameServerTypeLabel(domain.NameServerType))
+	servers, err := LoadDomainNameServers(c.client, c.domain)
+	if err != nil {
+		c.log.Error("Failed to load nameservers", "domain", c.domain, "error", err)
+		section.AddField("Servers", domain.GetFormattedNameServers())
+	}
+	for _, server := range servers {
+		value := server.Host
+		if server.IP != "" {
+			value += " (" + server.IP + ")"
+		}
+		if server.ToDelete {
+			value += " · being removed"
+		}
+		section.AddField(fmt.Sprintf("Server %d", server.ID), value)
+	}
+
+	c.addDSRecords(output, config)
+
+	if domain.NameServerType != api.NameServerExternal {
+		section = output.AddSection("Web Redirections")
+		section.SetConfig(config)
+		redirections, err := LoadWebRedirections(c.client, c.domain)
+		switch {
+		case err != nil:
+			c.log.Error("Failed to load web redirections", "domain", c.domain, "error", err)
+			section.AddField("Redirections", "(unavailable)")
+		case len(redirections) == 0:
+			section.AddField("Redirections", "None")
+		}
+		for _, redirection := range redirections {
+			section.AddField(redirection.Host(), WebRedirectionSummary(redirection))
+		}
+	}
+
+	return output.String(), nil
+}
+
+// addDSRecords adds the DNSSEC keys published at the registry, if any
+func (c *DomainCommand) addDSRecords(output *format.OutputFormatter, config format.SectionConfig) {
+	ids, err := c.client.ListDomainDSRecords(c.domain)
+	if err != nil {
+		c.log.Error("Failed to list DS records", "domain", c.domain, "error", err)
+		return
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	section := output.AddSection("DNSSEC Keys")
+	section.SetConfig(config)
+	for _, id := range ids {
+		record, err := c.client.GetDomainDSRecord(c.domain, id)
+		if err != nil {
+			c.log.Error("Failed to get DS record", "domain", c.domain, "id", id, "error", err)
+			continue
+		}
+		section.AddField("Key "+strconv.Itoa(record.Tag), fmt.Sprintf("algorithm %d, flags %d (%s)",
+			record.Algorithm, record.Flags, record.Status))
+	}
+}
+
+// WebRedirectionCommand shows a web redirection of a zone
+type WebRedirectionCommand struct {
+	BaseCommand
+	client *api.Client
+	log    *logger.Logger
+	zone   string
+	id     int64
+}
+
+// NewWebRedirectionCommand creates a new web redirection command instance
+func NewWebRedirectionCommand(client *api.Client, zone string, id int64) *WebRedirectionCommand {
+	return &WebRedirectionCommand{
+		BaseCommand: NewBaseCommand(TypeInfo),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "web_redirection"}),
+		zone:        zone,
+		id:          id,
+	}
+}
+
+// Execute implements the Command interface
+func (c *WebRedirectionCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *WebRedirectionCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *WebRedirectionCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// executeCommand handles the actual command execution
+func (c *WebRedirectionCommand) executeCommand() (string, error) {
+	c.log.Debug("Executing web redirection command", "zone", c.zone, "id", c.id)
+
+	redirection, err := c.client.GetZoneRedirection(c.zone, c.id)
+	if err != nil {
+		return "", err
+	}
+
+	output := format.NewOutputFormatter(
+		format.WithMaxWidth(maxWidth),
+		format.WithSeparator("\n"),
+	)
+	section := output.AddSection("Web Redirection")
+	section.SetConfig(format.SectionConfig{
+		KeyValueSpacing: keyValueSpacing,
+		TitleDecorator:  "=",
+	})
+	section.AddField("Host", redirection.Host())
+	section.AddField("Target", redirection.Target)
+	section.AddField("Type", redirectionTypeLabel(redirection.Type))
+	if redirection.Type == api.RedirectionInvisible {
+		section.AddField("Title", redirection.Title)
+		section.AddField("Description", redirection.Description)
+		section.AddField("Keywords", redirection.Keywords)
+	}
+
+	return output.String(), nil
+}
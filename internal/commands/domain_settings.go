@@ -0,0 +1,642 @@
+// internal/commands/domain_settings.go
+package commands
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"ovh-terminal/internal/api"
+	"ovh-terminal/internal/logger"
+)
+
+// Nameserver choices of NameServersCommand
+const (
+	nameServersHosted   = "OVHcloud DNS"
+	nameServersExternal = "External nameservers"
+)
+
+// hostnamePattern matches a fully qualified host name
+var hostnamePattern = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z]{2,63}$`)
+
+// subDomainPattern matches the label part of a redirected host
+var subDomainPattern = regexp.MustCompile(`^(\*|[a-z0-9_]([a-z0-9_-]{0,61}[a-z0-9])?)(\.[a-z0-9_]([a-z0-9_-]{0,61}[a-z0-9])?)*$`)
+
+// domainTaskCheck follows a task of a domain
+func domainTaskCheck(client *api.Client, domain string, task *api.DomainTask) TaskCheck {
+	return func() (string, bool, error) {
+		current, err := client.GetDomainTask(domain, task.ID)
+		if err != nil {
+			return "", false, err
+		}
+		if current.IsFailed() {
+			return current.Status, false, fmt.Errorf("task %d (%s) ended with status %s",
+				current.ID, current.Function, current.Status)
+		}
+		return current.Status, current.IsDone(), nil
+	}
+}
+
+// parseNameServers reads a list of host names separated by spaces or
+// commas. Registries need at least two nameservers.
+func parseNameServers(value string) ([]string, error) {
+	fields := strings.FieldsFunc(strings.ToLower(value), func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+
+	hosts := make([]string, 0, len(fields))
+	for _, field := range fields {
+		host := strings.TrimSuffix(field, ".")
+		if !hostnamePattern.MatchString(host) {
+			return nil, fmt.Errorf("%q is not a host name", field)
+		}
+		if containsString(hosts, host) {
+			return nil, fmt.Errorf("%s is listed twice", host)
+		}
+		hosts = append(hosts, host)
+	}
+	if len(hosts) < 2 {
+		return nil, fmt.Errorf("at least two nameservers are required")
+	}
+	return hosts, nil
+}
+
+// parseDSKeys reads DNSSEC keys, one per line as "tag algorithm flags
+// public-key". Blank lines and lines starting with # are skipped.
+func parseDSKeys(value string) ([]api.DSKey, error) {
+	var keys []api.DSKey
+	for n, line := range strings.Split(value, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			return nil, fmt.Errorf("line %d: expected tag, algorithm, flags and public key", n+1)
+		}
+		var numbers [3]int
+		for i, field := range fields[:3] {
+			number, err := strconv.Atoi(field)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %q is not a number", n+1, field)
+			}
+			numbers[i] = number
+		}
+		keys = append(keys, api.DSKey{
+			Tag:       numbers[0],
+			Algorithm: numbers[1],
+			Flags:     numbers[2],
+			PublicKey: strings.Join(fields[3:], ""),
+		})
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("at least one key is required")
+	}
+	return keys, nil
+}
+
+// NameServersCommand switches a domain between OVHcloud DNS and external
+// nameservers
+type NameServersCommand struct {
+	BaseCommand
+	client *api.Client
+	log    *logger.Logger
+	domain string
+}
+
+// NewNameServersCommand creates a new nameservers command instance
+func NewNameServersCommand(client *api.Client, domain string) *NameServersCommand {
+	return &NameServersCommand{
+		BaseCommand: NewBaseCommand(TypeAction, WithTimeout(taskTimeout)),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "name_servers"}),
+		domain:      domain,
+	}
+}
+
+// Execute implements the Command interface
+func (c *NameServersCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *NameServersCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeTask(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *NameServersCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(func() (string, error) {
+		return c.executeTask(ctx, c.executeCommand)
+	})
+}
+
+// NextPrompt implements the InteractiveCommand interface
+func (c *NameServersCommand) NextPrompt() (*Prompt, error) {
+	kind, ok := c.input("type")
+	if !ok {
+		return &Prompt{Key: "type", Label: "Nameservers of " + c.domain, Kind: PromptChoice,
+			Choices: []string{nameServersHosted, nameServersExternal}}, nil
+	}
+	if kind == nameServersHosted {
+		return c.confirmPrompt(fmt.Sprintf("Serve %s from its OVHcloud DNS zone?", c.domain)), nil
+	}
+
+	servers, ok := c.input("servers")
+	if !ok {
+		return &Prompt{Key: "servers", Label: "Nameservers (separated by spaces)", Kind: PromptText}, nil
+	}
+	return c.confirmPrompt(fmt.Sprintf("Delegate %s to %s? Resolution fails until they serve the zone.",
+		c.domain, strings.Join(strings.Fields(servers), ", "))), nil
+}
+
+// SetInput implements the InteractiveCommand interface
+func (c *NameServersCommand) SetInput(key, value string) error {
+	switch key {
+	case "type":
+		if value != nameServersHosted && value != nameServersExternal {
+			return fmt.Errorf("unknown nameserver choice %q", value)
+		}
+	case "servers":
+		hosts, err := parseNameServers(value)
+		if err != nil {
+			return err
+		}
+		value = strings.Join(hosts, " ")
+	}
+	return c.BaseCommand.SetInput(key, value)
+}
+
+// executeCommand handles the actual command execution
+func (c *NameServersCommand) executeCommand() (string, error) {
+	kind, ok := c.input("type")
+	if !ok {
+		return "", fmt.Errorf("nameserver choice is required")
+	}
+
+	nameServerType := api.NameServerHosted
+	var hosts []string
+	if kind == nameServersExternal {
+		servers, _ := c.input("servers")
+		parsed, err := parseNameServers(servers)
+		if err != nil {
+			return "", err
+		}
+		nameServerType, hosts = api.NameServerExternal, parsed
+	} else {
+		zone, err := c.client.GetDNSZone(c.domain)
+		if err != nil {
+			return "", fmt.Errorf("%s has no OVHcloud DNS zone: %w", c.domain, err)
+		}
+		hosts = zone.NameServers
+	}
+
+	servers := make([]api.NameServerInput, len(hosts))
+	for i, host := range hosts {
+		servers[i] = api.NameServerInput{Host: host}
+	}
+
+	c.log.Info("Changing nameservers", "domain", c.domain, "type", nameServerType, "servers", hosts)
+	if err := c.client.SetDomainNameServerType(c.domain, nameServerType); err != nil {
+		return "", err
+	}
+	task, err := c.client.UpdateDomainNameServers(c.domain, servers)
+	if err != nil {
+		return "", err
+	}
+	err = c.pollTask(fmt.Sprintf("Updating nameservers of %s", c.domain), domainTaskCheck(c.client, c.domain, task))
+	if err != nil {
+		return "", err
+	}
+
+	if nameServerType == api.NameServerExternal {
+		return fmt.Sprintf("%s is now delegated to %s.", c.domain, strings.Join(hosts, ", ")), nil
+	}
+	return fmt.Sprintf("%s is now served by OVHcloud DNS (%s).", c.domain, strings.Join(hosts, ", ")), nil
+}
+
+// DNSSECCommand enables or disables DNSSEC on a domain. Zones served by
+// OVHcloud DNS are signed by OVHcloud; with external nameservers the keys
+// of the zone are published at the registry.
+type DNSSECCommand struct {
+	BaseCommand
+	client *api.Client
+	log    *logger.Logger
+	domain string
+	info   *api.DomainInfo
+}
+
+// NewDNSSECCommand creates a new DNSSEC toggle command instance
+func NewDNSSECCommand(client *api.Client, domain string) *DNSSECCommand {
+	return &DNSSECCommand{
+		BaseCommand: NewBaseCommand(TypeAction, WithTimeout(taskTimeout)),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "dnssec"}),
+		domain:      domain,
+	}
+}
+
+// Execute implements the Command interface
+func (c *DNSSECCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *DNSSECCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeTask(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *DNSSECCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(func() (string, error) {
+		return c.executeTask(ctx, c.executeCommand)
+	})
+}
+
+// NextPrompt implements the InteractiveCommand interface
+func (c *DNSSECCommand) NextPrompt() (*Prompt, error) {
+	info, err := c.loadInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.enabling() {
+		return c.confirmPrompt(fmt.Sprintf("Disable DNSSEC on %s?", c.domain)), nil
+	}
+	if info.NameServerType == api.NameServerExternal {
+		if _, ok := c.input("keys"); !ok {
+			return &Prompt{Key: "keys", Label: "DNSSEC keys (tag algorithm flags public-key, one per line)",
+				Kind: PromptEditor}, nil
+		}
+	}
+	return c.confirmPrompt(fmt.Sprintf("Enable DNSSEC on %s?", c.domain)), nil
+}
+
+// SetInput implements the InteractiveCommand interface
+func (c *DNSSECCommand) SetInput(key, value string) error {
+	if key == "keys" {
+		if _, err := parseDSKeys(value); err != nil {
+			return err
+		}
+	}
+	return c.BaseCommand.SetInput(key, value)
+}
+
+// loadInfo fetches the domain once and checks DNSSEC is available
+func (c *DNSSECCommand) loadInfo() (*api.DomainInfo, error) {
+	if c.info != nil {
+		return c.info, nil
+	}
+	info, err := c.client.GetDomainInfo(c.domain)
+	if err != nil {
+		return nil, err
+	}
+	if !info.DnssecSupported {
+		return nil, fmt.Errorf("the registry of %s does not support DNSSEC", c.domain)
+	}
+	c.info = info
+	return info, nil
+}
+
+// enabling reports whether the command turns DNSSEC on
+func (c *DNSSECCommand) enabling() bool {
+	return c.info.DnssecStatus != api.DNSSECEnabled
+}
+
+// executeCommand handles the actual command execution
+func (c *DNSSECCommand) executeCommand() (string, error) {
+	info, err := c.loadInfo()
+	if err != nil {
+		return "", err
+	}
+	enable := c.enabling()
+
+	c.log.Info("Changing DNSSEC", "domain", c.domain, "enable", enable, "nameservers", info.NameServerType)
+	if info.NameServerType != api.NameServerExternal {
+		if enable {
+			err = c.client.EnableZoneDNSSEC(c.domain)
+		} else {
+			err = c.client.DisableZoneDNSSEC(c.domain)
+		}
+		if err != nil {
+			return "", err
+		}
+		if enable {
+			return fmt.Sprintf("Enabling DNSSEC on %s, keys are published within a day.", c.domain), nil
+		}
+		return fmt.Sprintf("Disabling DNSSEC on %s.", c.domain), nil
+	}
+
+	var keys []api.DSKey
+	if enable {
+		value, _ := c.input("keys")
+		if keys, err = parseDSKeys(value); err != nil {
+			return "", err
+		}
+	}
+	task, err := c.client.UpdateDomainDSRecords(c.domain, keys)
+	if err != nil {
+		return "", err
+	}
+	err = c.pollTask(fmt.Sprintf("Updating DNSSEC keys of %s", c.domain), domainTaskCheck(c.client, c.domain, task))
+	if err != nil {
+		return "", err
+	}
+
+	if enable {
+		return fmt.Sprintf("Published %d DNSSEC key(s) for %s.", len(keys), c.domain), nil
+	}
+	return fmt.Sprintf("Removed the DNSSEC keys of %s.", c.domain), nil
+}
+
+// TransferLockCommand locks or unlocks the transfer of a domain
+type TransferLockCommand struct {
+	BaseCommand
+	client *api.Client
+	log    *logger.Logger
+	domain string
+	info   *api.DomainInfo
+}
+
+// NewTransferLockCommand creates a new transfer lock toggle command
+// instance
+func NewTransferLockCommand(client *api.Client, domain string) *TransferLockCommand {
+	return &TransferLockCommand{
+		BaseCommand: NewBaseCommand(TypeAction),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "transfer_lock"}),
+		domain:      domain,
+	}
+}
+
+// Execute implements the Command interface
+func (c *TransferLockCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *TransferLockCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *TransferLockCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// NextPrompt implements the InteractiveCommand interface
+func (c *TransferLockCommand) NextPrompt() (*Prompt, error) {
+	info, err := c.loadInfo()
+	if err != nil {
+		return nil, err
+	}
+	if info.IsTransferLocked() {
+		return c.confirmPrompt(fmt.Sprintf("Unlock %s? It can then be transferred to another registrar.",
+			c.domain)), nil
+	}
+	return c.confirmPrompt(fmt.Sprintf("Lock %s against transfers?", c.domain)), nil
+}
+
+// loadInfo fetches the domain once and checks no change is in progress
+func (c *TransferLockCommand) loadInfo() (*api.DomainInfo, error) {
+	if c.info != nil {
+		return c.info, nil
+	}
+	info, err := c.client.GetDomainInfo(c.domain)
+	if err != nil {
+		return nil, err
+	}
+	switch info.TransferLockStatus {
+	case api.TransferLocking, api.TransferUnlocking:
+		return nil, fmt.Errorf("%s is already %s", c.domain, info.TransferLockStatus)
+	case api.TransferLocked, api.TransferUnlocked:
+	default:
+		return nil, fmt.Errorf("the transfer lock of %s cannot be changed", c.domain)
+	}
+	c.info = info
+	return info, nil
+}
+
+// executeCommand handles the actual command execution
+func (c *TransferLockCommand) executeCommand() (string, error) {
+	info, err := c.loadInfo()
+	if err != nil {
+		return "", err
+	}
+	lock := !info.IsTransferLocked()
+
+	c.log.Info("Changing transfer lock", "domain", c.domain, "locked", lock)
+	if err := c.client.SetDomainTransferLock(c.domain, lock); err != nil {
+		return "", err
+	}
+	if lock {
+		return fmt.Sprintf("Locking %s against transfers.", c.domain), nil
+	}
+	return fmt.Sprintf("Unlocking %s, ask for the transfer code at the new registrar.", c.domain), nil
+}
+
+// AddWebRedirectionCommand redirects a host of a zone to a URL
+type AddWebRedirectionCommand struct {
+	BaseCommand
+	client *api.Client
+	log    *logger.Logger
+	zone   string
+}
+
+// NewAddWebRedirectionCommand creates a new web redirection command
+// instance
+func NewAddWebRedirectionCommand(client *api.Client, zone string) *AddWebRedirectionCommand {
+	return &AddWebRedirectionCommand{
+		BaseCommand: NewBaseCommand(TypeAction),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "add_web_redirection"}),
+		zone:        zone,
+	}
+}
+
+// Execute implements the Command interface
+func (c *AddWebRedirectionCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *AddWebRedirectionCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *AddWebRedirectionCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// NextPrompt implements the InteractiveCommand interface
+func (c *AddWebRedirectionCommand) NextPrompt() (*Prompt, error) {
+	if _, ok := c.input("subdomain"); !ok {
+		return &Prompt{Key: "subdomain", Label: "Subdomain (empty for " + c.zone + ")", Kind: PromptText}, nil
+	}
+	target, ok := c.input("target")
+	if !ok {
+		return &Prompt{Key: "target", Label: "Redirect to URL", Kind: PromptText}, nil
+	}
+	kind, ok := c.input("type")
+	if !ok {
+		labels := make([]string, len(redirectionTypes))
+		for i, kind := range redirectionTypes {
+			labels[i] = kind.label
+		}
+		return &Prompt{Key: "type", Label: "Redirection type", Kind: PromptChoice, Choices: labels}, nil
+	}
+	if kind == api.RedirectionInvisible {
+		if _, ok := c.input("title"); !ok {
+			return &Prompt{Key: "title", Label: "Page title", Kind: PromptText}, nil
+		}
+	}
+	redirection := c.redirection()
+	return c.confirmPrompt(fmt.Sprintf("Redirect %s to %s?", redirection.Host(), target)), nil
+}
+
+// SetInput implements the InteractiveCommand interface
+func (c *AddWebRedirectionCommand) SetInput(key, value string) error {
+	value = strings.TrimSpace(value)
+	switch key {
+	case "subdomain":
+		value = strings.ToLower(strings.TrimSuffix(strings.TrimSuffix(value, "."+c.zone), "."))
+		if value == "@" || value == c.zone {
+			value = ""
+		}
+		if value != "" && !subDomainPattern.MatchString(value) {
+			return fmt.Errorf("%q is not a valid subdomain", value)
+		}
+	case "target":
+		target, err := url.Parse(value)
+		if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+			return fmt.Errorf("the target must be an http:// or https:// URL")
+		}
+	case "type":
+		found := false
+		for _, kind := range redirectionTypes {
+			if kind.label == value || kind.value == value {
+				value, found = kind.value, true
+			}
+		}
+		if !found {
+			return fmt.Errorf("unknown redirection type %q", value)
+		}
+	}
+	return c.BaseCommand.SetInput(key, value)
+}
+
+// redirection builds the redirection from the inputs
+func (c *AddWebRedirectionCommand) redirection() api.WebRedirection {
+	subDomain, _ := c.input("subdomain")
+	target, _ := c.input("target")
+	kind, ok := c.input("type")
+	if !ok {
+		kind = api.RedirectionVisible
+	}
+	title, _ := c.input("title")
+	return api.WebRedirection{
+		Zone:      c.zone,
+		SubDomain: subDomain,
+		Target:    target,
+		Type:      kind,
+		Title:     title,
+	}
+}
+
+// executeCommand handles the actual command execution
+func (c *AddWebRedirectionCommand) executeCommand() (string, error) {
+	redirection := c.redirection()
+	if redirection.Target == "" {
+		return "", fmt.Errorf("target URL is required")
+	}
+	host := redirection.Host()
+	redirection.Zone = ""
+
+	c.log.Info("Adding web redirection", "zone", c.zone, "host", host, "target", redirection.Target,
+		"type", redirection.Type)
+	if _, err := c.client.CreateZoneRedirection(c.zone, redirection); err != nil {
+		return "", err
+	}
+	if err := c.client.RefreshDNSZone(c.zone); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Redirecting %s to %s.", host, redirection.Target), nil
+}
+
+// DeleteWebRedirectionCommand removes a web redirection of a zone
+type DeleteWebRedirectionCommand struct {
+	BaseCommand
+	client *api.Client
+	log    *logger.Logger
+	zone   string
+	id     int64
+}
+
+// NewDeleteWebRedirectionCommand creates a new web redirection deletion
+// command instance
+func NewDeleteWebRedirectionCommand(client *api.Client, zone string, id int64) *DeleteWebRedirectionCommand {
+	return &DeleteWebRedirectionCommand{
+		BaseCommand: NewBaseCommand(TypeAction),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "delete_web_redirection"}),
+		zone:        zone,
+		id:          id,
+	}
+}
+
+// Execute implements the Command interface
+func (c *DeleteWebRedirectionCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *DeleteWebRedirectionCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *DeleteWebRedirectionCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// NextPrompt implements the InteractiveCommand interface
+func (c *DeleteWebRedirectionCommand) NextPrompt() (*Prompt, error) {
+	redirection, err := c.client.GetZoneRedirection(c.zone, c.id)
+	if err != nil {
+		return nil, err
+	}
+	return c.confirmPrompt(fmt.Sprintf("Stop redirecting %s to %s?",
+		redirection.Host(), redirection.Target)), nil
+}
+
+// executeCommand handles the actual command execution
+func (c *DeleteWebRedirectionCommand) executeCommand() (string, error) {
+	c.log.Info("Deleting web redirection", "zone", c.zone, "id", c.id)
+	if err := c.client.DeleteZoneRedirection(c.zone, c.id); err != nil {
+		return "", err
+	}
+	if err := c.client.RefreshDNSZone(c.zone); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Deleted web redirection %d of %s.", c.id, c.zone), nil
+}
@@ -0,0 +1,800 @@
+// internal/commands/email.go
+package commands
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"ovh-terminal/internal/api"
+	"ovh-terminal/internal/format"
+	"ovh-terminal/internal/logger"
+)
+
+// Email resource kinds shown by EmailResourceCommand
+const (
+	EmailResourceAccount     = "account"
+	EmailResourceRedirection = "redirection"
+	EmailResourceMailingList = "mailinglist"
+	EmailResourceResponder   = "responder"
+)
+
+// Mailbox password length limits of MX Plan
+const (
+	minMailboxPassword = 9
+	maxMailboxPassword = 30
+)
+
+// mailboxNamePattern matches the local part accepted for new mailboxes
+var mailboxNamePattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9._-]{0,30}[a-z0-9])?$`)
+
+// validateMailboxPassword checks a password against the MX Plan rules. The
+// password itself never appears in the error.
+func validateMailboxPassword(account, password string) error {
+	if n := len(password); n < minMailboxPassword || n > maxMailboxPassword {
+		return fmt.Errorf("password must be %d to %d characters long", minMailboxPassword, maxMailboxPassword)
+	}
+	if strings.ContainsAny(password, " \t") {
+		return fmt.Errorf("password must not contain spaces")
+	}
+	if account != "" && strings.Contains(strings.ToLower(password), account) {
+		return fmt.Errorf("password must not contain the account name")
+	}
+	return nil
+}
+
+// validateAddress checks that value looks like an email address
+func validateAddress(value string) error {
+	local, domain, ok := strings.Cut(value, "@")
+	if !ok || local == "" || !strings.Contains(domain, ".") || strings.ContainsAny(value, " \t") {
+		return fmt.Errorf("%q is not an email address", value)
+	}
+	return nil
+}
+
+// EmailAccountSummary describes a mailbox on one line, for menus
+func EmailAccountSummary(account *api.EmailAccount, usage *api.EmailUsage) string {
+	parts := []string{account.State}
+	if usage != nil {
+		parts = append([]string{fmt.Sprintf("%s / %s",
+			format.Bytes(usage.Quota), format.Bytes(account.Size))}, parts...)
+	}
+	if account.IsBlocked {
+		parts = append(parts, "blocked")
+	}
+	return strings.Join(parts, " · ")
+}
+
+// EmailMailingListSummary describes a mailing list on one line
+func EmailMailingListSummary(list *api.EmailMailingList) string {
+	return fmt.Sprintf("%d subscribers · %s", list.NbSubscribers, list.OwnerEmail)
+}
+
+// EmailResponderSummary describes an automatic reply on one line
+func EmailResponderSummary(responder *api.EmailResponder) string {
+	if responder.From == nil && responder.To == nil {
+		return "always on"
+	}
+	return fmt.Sprintf("%s to %s", format.Date(responder.From), format.Date(responder.To))
+}
+
+// EmailDomainsCommand lists the email domains of the account
+type EmailDomainsCommand struct {
+	BaseCommand
+	client *api.Client
+	log    *logger.Logger
+}
+
+// NewEmailDomainsCommand creates a new email domains command instance
+func NewEmailDomainsCommand(client *api.Client) *EmailDomainsCommand {
+	return &EmailDomainsCommand{
+		BaseCommand: NewBaseCommand(TypeInfo),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "email_domains"}),
+	}
+}
+
+// Execute implements the Command interface
+func (c *EmailDomainsCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *EmailDomainsCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *EmailDomainsCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// Domains returns the email domains sorted by name
+func (c *EmailDomainsCommand) Domains() ([]*api.EmailDomain, error) {
+	names, err := c.client.ListEmailDomains()
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	domains := make([]*api.EmailDomain, 0, len(names))
+	for _, name := range names {
+		domain, err := c.client.GetEmailDomain(name)
+		if err != nil {
+			return nil, err
+		}
+		domains = append(domains, domain)
+	}
+	return domains, nil
+}
+
+// executeCommand handles the actual command execution
+func (c *EmailDomainsCommand) executeCommand() (string, error) {
+	c.log.Debug("Executing email domains command")
+
+	domains, err := c.Domains()
+	if err != nil {
+		return "", err
+	}
+
+	output := format.NewOutputFormatter(
+		format.WithMaxWidth(maxWidth),
+		format.WithSeparator("\n"),
+	)
+	section := output.AddSection("Email Domains")
+	section.SetConfig(format.SectionConfig{
+		KeyValueSpacing: keyValueSpacing,
+		TitleDecorator:  "=",
+	})
+
+	if len(domains) == 0 {
+		section.AddField("Domains", "None")
+	}
+	for _, domain := range domains {
+		section.AddField(domain.Domain, fmt.Sprintf("%s (%s)", domain.Offer, domain.Status))
+	}
+
+	return output.String(), nil
+}
+
+// EmailDomainCommand shows an email domain and its resource counts
+type EmailDomainCommand struct {
+	BaseCommand
+	client *api.Client
+	log    *logger.Logger
+	domain string
+}
+
+// NewEmailDomainCommand creates a new email domain command instance
+func NewEmailDomainCommand(client *api.Client, domain string) *EmailDomainCommand {
+	return &EmailDomainCommand{
+		BaseCommand: NewBaseCommand(TypeInfo),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "email_domain"}),
+		domain:      domain,
+	}
+}
+
+// Execute implements the Command interface
+func (c *EmailDomainCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *EmailDomainCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *EmailDomainCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// executeCommand handles the actual command execution
+func (c *EmailDomainCommand) executeCommand() (string, error) {
+	c.log.Debug("Executing email domain command", "domain", c.domain)
+
+	domain, err := c.client.GetEmailDomain(c.domain)
+	if err != nil {
+		return "", err
+	}
+
+	output := format.NewOutputFormatter(
+		format.WithMaxWidth(maxWidth),
+		format.WithSeparator("\n"),
+	)
+	config := format.SectionConfig{
+		KeyValueSpacing: keyValueSpacing,
+		TitleDecorator:  "=",
+	}
+
+	section := output.AddSection("Email Domain")
+	section.SetConfig(config)
+	section.AddField("Domain", domain.Domain)
+	section.AddField("Offer", domain.Offer)
+	section.AddField("Status", domain.Status)
+	section.AddField("Created", format.Date(domain.CreationDate))
+	sizes := make([]string, len(domain.AllowedAccountSize))
+	for i, size := range domain.AllowedAccountSize {
+		sizes[i] = format.Bytes(size)
+	}
+	section.AddField("Mailbox Sizes", strings.Join(sizes, ", "))
+
+	section = output.AddSection("Contents")
+	section.SetConfig(config)
+	count := func(title string, list func() (int, error)) {
+		n, err := list()
+		if err != nil {
+			c.log.Error("Failed to count email resources", "resource", title, "error", err)
+			section.AddField(title, "(unavailable)")
+			return
+		}
+		section.AddField(title, fmt.Sprint(n))
+	}
+	count("Mailboxes", func() (int, error) {
+		items, err := c.client.ListEmailAccounts(c.domain)
+		return len(items), err
+	})
+	count("Redirections", func() (int, error) {
+		items, err := c.client.ListEmailRedirections(c.domain)
+		return len(items), err
+	})
+	count("Mailing lists", func() (int, error) {
+		items, err := c.client.ListEmailMailingLists(c.domain)
+		return len(items), err
+	})
+	count("Responders", func() (int, error) {
+		items, err := c.client.ListEmailResponders(c.domain)
+		return len(items), err
+	})
+
+	return output.String(), nil
+}
+
+// EmailResourceCommand shows the details of a resource of an email domain
+type EmailResourceCommand struct {
+	BaseCommand
+	client *api.Client
+	log    *logger.Logger
+	kind   string
+	domain string
+	id     string
+}
+
+// NewEmailResourceCommand creates a detail command for a resource of kind
+func NewEmailResourceCommand(client *api.Client, kind, domain, id string) *EmailResourceCommand {
+	return &EmailResourceCommand{
+		BaseCommand: NewBaseCommand(TypeInfo),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "email_" + kind}),
+		kind:        kind,
+		domain:      domain,
+		id:          id,
+	}
+}
+
+// Execute implements the Command interface
+func (c *EmailResourceCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *EmailResourceCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *EmailResourceCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// executeCommand handles the actual command execution
+func (c *EmailResourceCommand) executeCommand() (string, error) {
+	c.log.Debug("Executing email resource command", "domain", c.domain, "id", c.id)
+
+	output := format.NewOutputFormatter(
+		format.WithMaxWidth(maxWidth),
+		format.WithSeparator("\n"),
+	)
+	config := format.SectionConfig{
+		KeyValueSpacing: keyValueSpacing,
+		TitleDecorator:  "=",
+	}
+
+	var err error
+	switch c.kind {
+	case EmailResourceAccount:
+		err = c.renderAccount(output, config)
+	case EmailResourceRedirection:
+		err = c.renderRedirection(output, config)
+	case EmailResourceMailingList:
+		err = c.renderMailingList(output, config)
+	case EmailResourceResponder:
+		err = c.renderResponder(output, config)
+	default:
+		err = fmt.Errorf("unknown email resource kind %q", c.kind)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return output.String(), nil
+}
+
+// renderAccount adds the sections of a mailbox
+func (c *EmailResourceCommand) renderAccount(
+	output *format.OutputFormatter,
+	config format.SectionConfig,
+) error {
+	account, err := c.client.GetEmailAccount(c.domain, c.id)
+	if err != nil {
+		return err
+	}
+
+	section := output.AddSection("Mailbox")
+	section.SetConfig(config)
+	section.AddField("Address", account.Email)
+	section.AddField("Description", account.Description)
+	section.AddField("State", account.State)
+	section.AddField("Blocked", formatYesNo(account.IsBlocked))
+
+	usage, err := c.client.GetEmailAccountUsage(c.domain, c.id)
+	if err != nil {
+		c.log.Error("Failed to get mailbox usage", "account", c.id, "error", err)
+		section.AddField("Quota", "(unavailable)")
+		return nil
+	}
+	used, size := float64(usage.Quota), float64(account.Size)
+	percent := 0.0
+	if size > 0 {
+		percent = used * 100 / size
+	}
+	section.AddField("Quota", fmt.Sprintf("%s %s / %s (%.0f%%)", format.Bar(used, size, quotaBarWidth),
+		format.Bytes(usage.Quota), format.Bytes(account.Size), percent))
+	section.AddField("Messages", fmt.Sprint(usage.EmailCount))
+	section.AddField("Measured", format.DateTime(usage.Date))
+	return nil
+}
+
+// renderRedirection adds the sections of a redirection
+func (c *EmailResourceCommand) renderRedirection(
+	output *format.OutputFormatter,
+	config format.SectionConfig,
+) error {
+	redirection, err := c.client.GetEmailRedirection(c.domain, c.id)
+	if err != nil {
+		return err
+	}
+
+	section := output.AddSection("Redirection")
+	section.SetConfig(config)
+	section.AddField("ID", redirection.ID)
+	section.AddField("From", redirection.From)
+	section.AddField("To", redirection.To)
+	return nil
+}
+
+// renderMailingList adds the sections of a mailing list
+func (c *EmailResourceCommand) renderMailingList(
+	output *format.OutputFormatter,
+	config format.SectionConfig,
+) error {
+	list, err := c.client.GetEmailMailingList(c.domain, c.id)
+	if err != nil {
+		return err
+	}
+
+	section := output.AddSection("Mailing List")
+	section.SetConfig(config)
+	section.AddField("Address", list.Name+"@"+c.domain)
+	section.AddField("Owner", list.OwnerEmail)
+	section.AddField("Reply To", list.ReplyTo)
+	section.AddField("Language", list.Language)
+	section.AddField("Subscribers", fmt.Sprint(list.NbSubscribers))
+	return nil
+}
+
+// renderResponder adds the sections of an automatic reply
+func (c *EmailResourceCommand) renderResponder(
+	output *format.OutputFormatter,
+	config format.SectionConfig,
+) error {
+	responder, err := c.client.GetEmailResponder(c.domain, c.id)
+	if err != nil {
+		return err
+	}
+
+	section := output.AddSection("Responder")
+	section.SetConfig(config)
+	section.AddField("Account", responder.Account+"@"+c.domain)
+	section.AddField("Active", EmailResponderSummary(responder))
+	if responder.Copy {
+		section.AddField("Copy To", responder.CopyTo)
+	} else {
+		section.AddField("Copy To", "No copy")
+	}
+	section.AddLines("Message", wrapParagraphs(responder.Content, maxWidth-20))
+	return nil
+}
+
+// CreateMailboxCommand creates a mailbox on an email domain
+type CreateMailboxCommand struct {
+	BaseCommand
+	client *api.Client
+	log    *logger.Logger
+	domain string
+	info   *api.EmailDomain
+}
+
+// NewCreateMailboxCommand creates a new mailbox creation command instance
+func NewCreateMailboxCommand(client *api.Client, domain string) *CreateMailboxCommand {
+	return &CreateMailboxCommand{
+		BaseCommand: NewBaseCommand(TypeAction),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "create_mailbox"}),
+		domain:      domain,
+	}
+}
+
+// Execute implements the Command interface
+func (c *CreateMailboxCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *CreateMailboxCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *CreateMailboxCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// NextPrompt implements the InteractiveCommand interface
+func (c *CreateMailboxCommand) NextPrompt() (*Prompt, error) {
+	if err := c.loadDomain(); err != nil {
+		return nil, err
+	}
+
+	account, ok := c.input("account")
+	if !ok {
+		return &Prompt{Key: "account", Label: "Mailbox name (before @" + c.domain + ")", Kind: PromptText}, nil
+	}
+	if _, ok := c.input("password"); !ok {
+		return &Prompt{Key: "password", Label: "Password", Kind: PromptSecret}, nil
+	}
+	size, ok := c.input("size")
+	if !ok {
+		return &Prompt{Key: "size", Label: "Mailbox size", Kind: PromptChoice, Choices: c.sizeLabels()}, nil
+	}
+	return c.confirmPrompt(fmt.Sprintf("Create mailbox %s@%s of %s?", account, c.domain, size)), nil
+}
+
+// SetInput implements the InteractiveCommand interface
+func (c *CreateMailboxCommand) SetInput(key, value string) error {
+	switch key {
+	case "account":
+		value = strings.ToLower(strings.TrimSpace(strings.TrimSuffix(value, "@"+c.domain)))
+		if !mailboxNamePattern.MatchString(value) {
+			return fmt.Errorf("mailbox names use lowercase letters, digits, dots, dashes and underscores")
+		}
+	case "password":
+		account, _ := c.input("account")
+		if err := validateMailboxPassword(account, value); err != nil {
+			return err
+		}
+	case "size":
+		if err := c.loadDomain(); err != nil {
+			return err
+		}
+		if !containsString(c.sizeLabels(), value) {
+			return fmt.Errorf("%s is not an available mailbox size", value)
+		}
+	}
+	return c.BaseCommand.SetInput(key, value)
+}
+
+// loadDomain fetches the domain and its mailbox sizes once
+func (c *CreateMailboxCommand) loadDomain() error {
+	if c.info != nil {
+		return nil
+	}
+	info, err := c.client.GetEmailDomain(c.domain)
+	if err != nil {
+		return err
+	}
+	if len(info.AllowedAccountSize) == 0 {
+		return fmt.Errorf("%s does not allow new mailboxes", c.domain)
+	}
+	c.info = info
+	return nil
+}
+
+// sizeLabels returns the mailbox sizes offered by the domain
+func (c *CreateMailboxCommand) sizeLabels() []string {
+	labels := make([]string, len(c.info.AllowedAccountSize))
+	for i, size := range c.info.AllowedAccountSize {
+		labels[i] = format.Bytes(size)
+	}
+	return labels
+}
+
+// executeCommand handles the actual command execution
+func (c *CreateMailboxCommand) executeCommand() (string, error) {
+	account, ok := c.input("account")
+	if !ok {
+		return "", fmt.Errorf("mailbox name is required")
+	}
+	password, ok := c.input("password")
+	if !ok {
+		return "", fmt.Errorf("password is required")
+	}
+	if err := c.loadDomain(); err != nil {
+		return "", err
+	}
+
+	// Default to the smallest size, labels follow the allowed sizes
+	size := c.info.AllowedAccountSize[0]
+	if label, ok := c.input("size"); ok {
+		for i, candidate := range c.sizeLabels() {
+			if candidate == label {
+				size = c.info.AllowedAccountSize[i]
+			}
+		}
+	}
+
+	c.log.Info("Creating mailbox", "domain", c.domain, "account", account, "size", size)
+	if _, err := c.client.CreateEmailAccount(c.domain, account, password, size); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Creating mailbox %s@%s (%s), it will be ready in a few minutes.",
+		account, c.domain, format.Bytes(size)), nil
+}
+
+// DeleteMailboxCommand deletes a mailbox and its messages
+type DeleteMailboxCommand struct {
+	BaseCommand
+	client  *api.Client
+	log     *logger.Logger
+	domain  string
+	account string
+}
+
+// NewDeleteMailboxCommand creates a new mailbox deletion command instance
+func NewDeleteMailboxCommand(client *api.Client, domain, account string) *DeleteMailboxCommand {
+	return &DeleteMailboxCommand{
+		BaseCommand: NewBaseCommand(TypeAction),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "delete_mailbox"}),
+		domain:      domain,
+		account:     account,
+	}
+}
+
+// Execute implements the Command interface
+func (c *DeleteMailboxCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *DeleteMailboxCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *DeleteMailboxCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// NextPrompt implements the InteractiveCommand interface
+func (c *DeleteMailboxCommand) NextPrompt() (*Prompt, error) {
+	return c.confirmPrompt(fmt.Sprintf("Delete mailbox %s@%s and all its messages?",
+		c.account, c.domain)), nil
+}
+
+// executeCommand handles the actual command execution
+func (c *DeleteMailboxCommand) executeCommand() (string, error) {
+	c.log.Info("Deleting mailbox", "domain", c.domain, "account", c.account)
+	if _, err := c.client.DeleteEmailAccount(c.domain, c.account); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Deleting mailbox %s@%s.", c.account, c.domain), nil
+}
+
+// MailboxPasswordCommand changes the password of a mailbox. The password
+// is asked twice and never logged.
+type MailboxPasswordCommand struct {
+	BaseCommand
+	client  *api.Client
+	log     *logger.Logger
+	domain  string
+	account string
+}
+
+// NewMailboxPasswordCommand creates a new mailbox password command instance
+func NewMailboxPasswordCommand(client *api.Client, domain, account string) *MailboxPasswordCommand {
+	return &MailboxPasswordCommand{
+		BaseCommand: NewBaseCommand(TypeAction),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "mailbox_password"}),
+		domain:      domain,
+		account:     account,
+	}
+}
+
+// Execute implements the Command interface
+func (c *MailboxPasswordCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *MailboxPasswordCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *MailboxPasswordCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// NextPrompt implements the InteractiveCommand interface
+func (c *MailboxPasswordCommand) NextPrompt() (*Prompt, error) {
+	if _, ok := c.input("password"); !ok {
+		return &Prompt{Key: "password", Label: "New password for " + c.account + "@" + c.domain,
+			Kind: PromptSecret}, nil
+	}
+	if _, ok := c.input("repeat"); !ok {
+		return &Prompt{Key: "repeat", Label: "Repeat password", Kind: PromptSecret}, nil
+	}
+	return nil, nil
+}
+
+// SetInput implements the InteractiveCommand interface
+func (c *MailboxPasswordCommand) SetInput(key, value string) error {
+	switch key {
+	case "password":
+		if err := validateMailboxPassword(c.account, value); err != nil {
+			return err
+		}
+	case "repeat":
+		if password, _ := c.input("password"); value != password {
+			return fmt.Errorf("passwords do not match")
+		}
+	}
+	return c.BaseCommand.SetInput(key, value)
+}
+
+// executeCommand handles the actual command execution
+func (c *MailboxPasswordCommand) executeCommand() (string, error) {
+	password, ok := c.input("password")
+	if !ok {
+		return "", fmt.Errorf("password is required")
+	}
+
+	c.log.Info("Changing mailbox password", "domain", c.domain, "account", c.account)
+	if _, err := c.client.ChangeEmailPassword(c.domain, c.account, password); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Changing the password of %s@%s.", c.account, c.domain), nil
+}
+
+// AddRedirectionCommand forwards an address of an email domain
+type AddRedirectionCommand struct {
+	BaseCommand
+	client *api.Client
+	log    *logger.Logger
+	domain string
+}
+
+// NewAddRedirectionCommand creates a new redirection command instance
+func NewAddRedirectionCommand(client *api.Client, domain string) *AddRedirectionCommand {
+	return &AddRedirectionCommand{
+		BaseCommand: NewBaseCommand(TypeAction),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "add_redirection"}),
+		domain:      domain,
+	}
+}
+
+// Execute implements the Command interface
+func (c *AddRedirectionCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *AddRedirectionCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *AddRedirectionCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// NextPrompt implements the InteractiveCommand interface
+func (c *AddRedirectionCommand) NextPrompt() (*Prompt, error) {
+	from, ok := c.input("from")
+	if !ok {
+		return &Prompt{Key: "from", Label: "Redirect address (on " + c.domain + ")", Kind: PromptText}, nil
+	}
+	to, ok := c.input("to")
+	if !ok {
+		return &Prompt{Key: "to", Label: "Forward to", Kind: PromptText}, nil
+	}
+	if _, ok := c.input("copy"); !ok {
+		return &Prompt{Key: "copy", Label: "Keep a copy in " + from, Kind: PromptChoice,
+			Choices: []string{"No", "Yes"}}, nil
+	}
+	return c.confirmPrompt(fmt.Sprintf("Forward %s to %s?", from, to)), nil
+}
+
+// SetInput implements the InteractiveCommand interface
+func (c *AddRedirectionCommand) SetInput(key, value string) error {
+	value = strings.TrimSpace(value)
+	switch key {
+	case "from":
+		// A bare local part is an address of the domain
+		if !strings.Contains(value, "@") {
+			value += "@" + c.domain
+		}
+		value = strings.ToLower(value)
+		if err := validateAddress(value); err != nil {
+			return err
+		}
+		if !strings.HasSuffix(value, "@"+c.domain) {
+			return fmt.Errorf("only addresses of %s can be redirected", c.domain)
+		}
+	case "to":
+		if err := validateAddress(value); err != nil {
+			return err
+		}
+		if from, _ := c.input("from"); strings.EqualFold(value, from) {
+			return fmt.Errorf("an address cannot be redirected to itself")
+		}
+	}
+	return c.BaseCommand.SetInput(key, value)
+}
+
+// executeCommand handles the actual command execution
+func (c *AddRedirectionCommand) executeCommand() (string, error) {
+	from, ok := c.input("from")
+	if !ok {
+		return "", fmt.Errorf("address to redirect is required")
+	}
+	to, ok := c.input("to")
+	if !ok {
+		return "", fmt.Errorf("destination address is required")
+	}
+	copy, _ := c.input("copy")
+	localCopy := copy == "Yes"
+
+	c.log.Info("Adding redirection", "domain", c.domain, "from", from, "to", to, "copy", localCopy)
+	if _, err := c.client.CreateEmailRedirection(c.domain, from, to, localCopy); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Forwarding %s to %s.", from, to), nil
+}
@@ -0,0 +1,884 @@
+// internal/commands/firewall.go
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"ovh-terminal/internal/api"
+	"ovh-terminal/internal/format"
+	"ovh-terminal/internal/logger"
+)
+
+// firewallBase holds what all edge firewall commands of a block share
+type firewallBase struct {
+	BaseCommand
+	client *api.Client
+	log    *logger.Logger
+	block  string
+}
+
+// newFirewallBase creates the shared state of a firewall command
+func newFirewallBase(
+	client *api.Client,
+	block, name string,
+	cmdType CommandType,
+	opts ...CommandOption,
+) firewallBase {
+	return firewallBase{
+		BaseCommand: NewBaseCommand(cmdType, opts...),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": name}),
+		block:       block,
+	}
+}
+
+// SetInput implements the InteractiveCommand interface
+func (c *firewallBase) SetInput(key, value string) error {
+	switch key {
+	case "ip":
+		ip, err := addressInBlock(c.block, value)
+		if err != nil {
+			return err
+		}
+		value = ip
+	case "file":
+		if _, err := LoadFirewallRules(value); err != nil {
+			return err
+		}
+		value = expandPath(value)
+	}
+	return c.BaseCommand.SetInput(key, value)
+}
+
+// address returns the collected address, single address blocks need none
+func (c *firewallBase) address() (string, error) {
+	c.addressPrompt(c.block)
+	ip, ok := c.input("ip")
+	if !ok {
+		return "", fmt.Errorf("address is required")
+	}
+	return ip, nil
+}
+
+// firewall returns the firewall of an address, nil if it has none
+func (c *firewallBase) firewall(ip string) (*api.FirewallIP, error) {
+	firewall, err := c.client.GetFirewall(c.block, ip)
+	if api.IsNotFound(err) {
+		return nil, nil
+	}
+	return firewall, err
+}
+
+// rules returns the live rules of an address sorted by sequence
+func (c *firewallBase) rules(ip string) ([]*api.FirewallRule, error) {
+	sequences, err := c.client.ListFirewallRules(c.block, ip)
+	if err != nil {
+		return nil, err
+	}
+	sort.Ints(sequences)
+
+	rules := make([]*api.FirewallRule, 0, len(sequences))
+	for _, sequence := range sequences {
+		rule, err := c.client.GetFirewallRule(c.block, ip, sequence)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// ensureFirewall creates the firewall of an address if it has none and
+// waits until it is ready
+func (c *firewallBase) ensureFirewall(ip string) (*api.FirewallIP, error) {
+	firewall, err := c.firewall(ip)
+	if err != nil || firewall != nil {
+		return firewall, err
+	}
+
+	c.log.Info("Creating firewall", "block", c.block, "ip", ip)
+	if _, err := c.client.AddFirewallIP(c.block, ip); err != nil {
+		return nil, err
+	}
+	return c.waitFirewall(ip)
+}
+
+// waitFirewall polls the firewall of an address until no change is pending
+func (c *firewallBase) waitFirewall(ip string) (*api.FirewallIP, error) {
+	var firewall *api.FirewallIP
+	err := c.pollTask(fmt.Sprintf("Firewall of %s", ip), func() (string, bool, error) {
+		current, err := c.client.GetFirewall(c.block, ip)
+		if err != nil {
+			return "", false, err
+		}
+		firewall = current
+		return current.State, current.State == api.FirewallRuleOK, nil
+	})
+	return firewall, err
+}
+
+// waitRules polls until created rules are active and removed ones are gone
+func (c *firewallBase) waitRules(ip string, created, removed []int) error {
+	if len(created) == 0 && len(removed) == 0 {
+		return nil
+	}
+
+	return c.pollTask(fmt.Sprintf("Firewall rules of %s", ip), func() (string, bool, error) {
+		sequences, err := c.client.ListFirewallRules(c.block, ip)
+		if err != nil {
+			return "", false, err
+		}
+		present := make(map[int]bool, len(sequences))
+		for _, sequence := range sequences {
+			present[sequence] = true
+		}
+
+		pending := 0
+		for _, sequence := range removed {
+			if present[sequence] {
+				pending++
+			}
+		}
+		for _, sequence := range created {
+			rule, err := c.client.GetFirewallRule(c.block, ip, sequence)
+			if err != nil {
+				return "", false, err
+			}
+			if rule.State != api.FirewallRuleOK {
+				pending++
+			}
+		}
+
+		if pending == 0 {
+			return "done", true, nil
+		}
+		return fmt.Sprintf("%d pending", pending), false, nil
+	})
+}
+
+// FirewallCommand shows the edge firewall of an address
+type FirewallCommand struct {
+	firewallBase
+}
+
+// NewFirewallCommand creates a new firewall command instance
+func NewFirewallCommand(client *api.Client, block string) *FirewallCommand {
+	return &FirewallCommand{
+		firewallBase: newFirewallBase(client, block, "firewall", TypeInfo),
+	}
+}
+
+// Execute implements the Command interface
+func (c *FirewallCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *FirewallCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *FirewallCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// NextPrompt implements the InteractiveCommand interface
+func (c *FirewallCommand) NextPrompt() (*Prompt, error) {
+	return c.addressPrompt(c.block), nil
+}
+
+// executeCommand handles the actual command execution
+func (c *FirewallCommand) executeCommand() (string, error) {
+	ip, err := c.address()
+	if err != nil {
+		return "", err
+	}
+	c.log.Debug("Executing firewall command", "block", c.block, "ip", ip)
+
+	firewall, err := c.firewall(ip)
+	if err != nil {
+		return "", err
+	}
+
+	output := format.NewOutputFormatter(
+		format.WithMaxWidth(maxWidth),
+		format.WithSeparator("\n"),
+	)
+	config := format.SectionConfig{
+		KeyValueSpacing: keyValueSpacing,
+		TitleDecorator:  "=",
+	}
+
+	section := output.AddSection("Edge Firewall")
+	section.SetConfig(config)
+	section.AddField("Address", ip)
+	if firewall == nil {
+		section.AddField("Status", "Not configured")
+		return output.String(), nil
+	}
+	section.AddField("Status", enabledLabel(firewall.Enabled))
+	section.AddField("State", firewall.State)
+
+	rules, err := c.rules(ip)
+	if err != nil {
+		return "", err
+	}
+
+	section = output.AddSection("Rules")
+	section.SetConfig(config)
+	if len(rules) == 0 {
+		section.AddField("Rules", "No rules")
+	}
+	for _, rule := range rules {
+		line := specFromRule(rule).String()
+		if rule.State != api.FirewallRuleOK {
+			line = fmt.Sprintf("%s (%s)", line, rule.State)
+		}
+		section.AddField(fmt.Sprintf("%d", rule.Sequence), line)
+	}
+
+	return output.String(), nil
+}
+
+// SetFirewallCommand enables or disables the edge firewall of an address
+type SetFirewallCommand struct {
+	firewallBase
+	enabled bool
+}
+
+// NewSetFirewallCommand creates a new firewall toggle command instance
+func NewSetFirewallCommand(client *api.Client, block string, enabled bool) *SetFirewallCommand {
+	return &SetFirewallCommand{
+		firewallBase: newFirewallBase(client, block, "set_firewall", TypeAction,
+			WithTimeout(taskTimeout)),
+		enabled: enabled,
+	}
+}
+
+// Execute implements the Command interface
+func (c *SetFirewallCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *SetFirewallCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeTask(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *SetFirewallCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(func() (string, error) {
+		return c.executeTask(ctx, c.executeCommand)
+	})
+}
+
+// NextPrompt implements the InteractiveCommand interface
+func (c *SetFirewallCommand) NextPrompt() (*Prompt, error) {
+	if prompt := c.addressPrompt(c.block); prompt != nil {
+		return prompt, nil
+	}
+	ip, _ := c.input("ip")
+
+	verb := "Enable"
+	if !c.enabled {
+		verb = "Disable"
+	}
+	return c.confirmPrompt(fmt.Sprintf("%s the firewall of %s?", verb, ip)), nil
+}
+
+// executeCommand handles the actual command execution
+func (c *SetFirewallCommand) executeCommand() (string, error) {
+	ip, err := c.address()
+	if err != nil {
+		return "", err
+	}
+
+	var firewall *api.FirewallIP
+	if c.enabled {
+		firewall, err = c.ensureFirewall(ip)
+	} else {
+		firewall, err = c.firewall(ip)
+	}
+	if err != nil {
+		return "", err
+	}
+	if firewall == nil {
+		return fmt.Sprintf("%s has no firewall, nothing to disable.", ip), nil
+	}
+	if firewall.Enabled == c.enabled {
+		return fmt.Sprintf("Firewall of %s is already %s.", ip, enabledLabel(c.enabled)), nil
+	}
+
+	c.log.Info("Updating firewall", "block", c.block, "ip", ip, "enabled", c.enabled)
+	if err := c.client.SetFirewallEnabled(c.block, ip, c.enabled); err != nil {
+		return "", err
+	}
+	if _, err := c.waitFirewall(ip); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Firewall of %s %s.", ip, enabledLabel(c.enabled)), nil
+}
+
+// AddFirewallRuleCommand adds a rule to the edge firewall of an address
+type AddFirewallRuleCommand struct {
+	firewallBase
+	free []string
+}
+
+// NewAddFirewallRuleCommand creates a new add firewall rule command instance
+func NewAddFirewallRuleCommand(client *api.Client, block string) *AddFirewallRuleCommand {
+	return &AddFirewallRuleCommand{
+		firewallBase: newFirewallBase(client, block, "add_firewall_rule", TypeAction,
+			WithTimeout(taskTimeout)),
+	}
+}
+
+// Execute implements the Command interface
+func (c *AddFirewallRuleCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *AddFirewallRuleCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeTask(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *AddFirewallRuleCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(func() (string, error) {
+		return c.executeTask(ctx, c.executeCommand)
+	})
+}
+
+// NextPrompt implements the InteractiveCommand interface
+func (c *AddFirewallRuleCommand) NextPrompt() (*Prompt, error) {
+	if prompt := c.addressPrompt(c.block); prompt != nil {
+		return prompt, nil
+	}
+	ip, _ := c.input("ip")
+
+	if _, ok := c.input("sequence"); !ok {
+		free, err := c.freeSequences(ip)
+		if err != nil {
+			return nil, err
+		}
+		return &Prompt{Key: "sequence", Label: "Sequence", Kind: PromptChoice, Choices: free}, nil
+	}
+	if _, ok := c.input("action"); !ok {
+		return &Prompt{Key: "action", Label: "Action", Kind: PromptChoice, Choices: firewallActions}, nil
+	}
+	protocol, ok := c.input("protocol")
+	if !ok {
+		return &Prompt{
+			Key: "protocol", Label: "Protocol", Kind: PromptChoice, Choices: firewallProtocols,
+		}, nil
+	}
+	if _, ok := c.input("source"); !ok {
+		return &Prompt{Key: "source", Label: "Source IP or network (empty for any)", Kind: PromptText}, nil
+	}
+
+	if protocol == "tcp" || protocol == "udp" {
+		if _, ok := c.input("destination_port"); !ok {
+			return &Prompt{
+				Key: "destination_port", Label: "Destination port (empty for any)", Kind: PromptText,
+			}, nil
+		}
+		if _, ok := c.input("source_port"); !ok {
+			return &Prompt{Key: "source_port", Label: "Source port (empty for any)", Kind: PromptText}, nil
+		}
+	}
+	if _, ok := c.input("tcp_option"); !ok && protocol == "tcp" {
+		return &Prompt{
+			Key:     "tcp_option",
+			Label:   "TCP option",
+			Kind:    PromptChoice,
+			Choices: append([]string{"none"}, firewallTCPOptions...),
+		}, nil
+	}
+
+	spec, err := c.spec()
+	if err != nil {
+		return nil, err
+	}
+	return c.confirmPrompt(fmt.Sprintf("Add rule %d to %s: %s?", spec.Sequence, ip, spec)), nil
+}
+
+// SetInput implements the InteractiveCommand interface
+func (c *AddFirewallRuleCommand) SetInput(key, value string) error {
+	switch key {
+	case "sequence":
+		ip, _ := c.input("ip")
+		free, err := c.freeSequences(ip)
+		if err != nil {
+			return err
+		}
+		if !containsString(free, value) {
+			return fmt.Errorf("sequence %s is not available", value)
+		}
+	case "source":
+		source, err := normalizeSource(value)
+		if err != nil {
+			return err
+		}
+		value = source
+	case "destination_port", "source_port":
+		if value != "" {
+			port, err := strconv.Atoi(value)
+			if err != nil || port < 1 || port > 65535 {
+				return fmt.Errorf("invalid port %q", value)
+			}
+		}
+	case "tcp_option":
+		if value == "none" {
+			value = ""
+		}
+	}
+	return c.firewallBase.SetInput(key, value)
+}
+
+// freeSequences lists the sequences not used by a rule yet
+func (c *AddFirewallRuleCommand) freeSequences(ip string) ([]string, error) {
+	if c.free != nil {
+		return c.free, nil
+	}
+
+	used := make(map[int]bool)
+	if firewall, err := c.firewall(ip); err != nil {
+		return nil, err
+	} else if firewall != nil {
+		sequences, err := c.client.ListFirewallRules(c.block, ip)
+		if err != nil {
+			return nil, err
+		}
+		for _, sequence := range sequences {
+			used[sequence] = true
+		}
+	}
+
+	free := []string{}
+	for sequence := 0; sequence <= maxFirewallSequence; sequence++ {
+		if !used[sequence] {
+			free = append(free, strconv.Itoa(sequence))
+		}
+	}
+	if len(free) == 0 {
+		return nil, fmt.Errorf("all %d firewall rules of %s are in use", maxFirewallSequence+1, ip)
+	}
+	c.free = free
+	return free, nil
+}
+
+// spec builds the rule from the collected inputs
+func (c *AddFirewallRuleCommand) spec() (FirewallRuleSpec, error) {
+	var spec FirewallRuleSpec
+	var err error
+
+	sequence, _ := c.input("sequence")
+	if spec.Sequence, err = strconv.Atoi(sequence); err != nil {
+		return spec, fmt.Errorf("sequence is required")
+	}
+	spec.Action, _ = c.input("action")
+	spec.Protocol, _ = c.input("protocol")
+	spec.Source, _ = c.input("source")
+	spec.TCPOption, _ = c.input("tcp_option")
+	if port, ok := c.input("destination_port"); ok && port != "" {
+		spec.DestinationPort, _ = strconv.Atoi(port)
+	}
+	if port, ok := c.input("source_port"); ok && port != "" {
+		spec.SourcePort, _ = strconv.Atoi(port)
+	}
+
+	return spec, spec.normalize()
+}
+
+// executeCommand handles the actual command execution
+func (c *AddFirewallRuleCommand) executeCommand() (string, error) {
+	ip, err := c.address()
+	if err != nil {
+		return "", err
+	}
+	spec, err := c.spec()
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := c.ensureFirewall(ip); err != nil {
+		return "", err
+	}
+
+	c.log.Info("Adding firewall rule", "block", c.block, "ip", ip, "rule", spec.String())
+	if _, err := c.client.CreateFirewallRule(c.block, ip, spec.create()); err != nil {
+		return "", err
+	}
+	if err := c.waitRules(ip, []int{spec.Sequence}, nil); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Rule %d added to %s: %s.", spec.Sequence, ip, spec), nil
+}
+
+// RemoveFirewallRuleCommand removes a rule from the edge firewall of an address
+type RemoveFirewallRuleCommand struct {
+	firewallBase
+	live map[string]int
+}
+
+// NewRemoveFirewallRuleCommand creates a new remove firewall rule command instance
+func NewRemoveFirewallRuleCommand(client *api.Client, block string) *RemoveFirewallRuleCommand {
+	return &RemoveFirewallRuleCommand{
+		firewallBase: newFirewallBase(client, block, "remove_firewall_rule", TypeAction,
+			WithTimeout(taskTimeout)),
+	}
+}
+
+// Execute implements the Command interface
+func (c *RemoveFirewallRuleCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *RemoveFirewallRuleCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeTask(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *RemoveFirewallRuleCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(func() (string, error) {
+		return c.executeTask(ctx, c.executeCommand)
+	})
+}
+
+// NextPrompt implements the InteractiveCommand interface
+func (c *RemoveFirewallRuleCommand) NextPrompt() (*Prompt, error) {
+	if prompt := c.addressPrompt(c.block); prompt != nil {
+		return prompt, nil
+	}
+	ip, _ := c.input("ip")
+
+	rule, ok := c.input("rule")
+	if !ok {
+		labels, err := c.ruleLabels(ip)
+		if err != nil {
+			return nil, err
+		}
+		return &Prompt{Key: "rule", Label: "Rule", Kind: PromptChoice, Choices: labels}, nil
+	}
+
+	return c.confirmPrompt(fmt.Sprintf("Remove rule %s from %s?", rule, ip)), nil
+}
+
+// SetInput implements the InteractiveCommand interface
+func (c *RemoveFirewallRuleCommand) SetInput(key, value string) error {
+	if key == "rule" {
+		// Accept both the picker label and a bare sequence
+		for label, sequence := range c.live {
+			if value == label || value == strconv.Itoa(sequence) {
+				return c.BaseCommand.SetInput(key, strconv.Itoa(sequence))
+			}
+		}
+		return fmt.Errorf("no firewall rule %s", value)
+	}
+	return c.firewallBase.SetInput(key, value)
+}
+
+// ruleLabels lists the live rules of an address for the picker
+func (c *RemoveFirewallRuleCommand) ruleLabels(ip string) ([]string, error) {
+	firewall, err := c.firewall(ip)
+	if err != nil {
+		return nil, err
+	}
+	if firewall == nil {
+		return nil, fmt.Errorf("%s has no firewall", ip)
+	}
+
+	rules, err := c.rules(ip)
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		return nil, fmt.Errorf("the firewall of %s has no rules", ip)
+	}
+
+	c.live = make(map[string]int, len(rules))
+	labels := make([]string, len(rules))
+	for i, rule := range rules {
+		labels[i] = fmt.Sprintf("%d: %s", rule.Sequence, specFromRule(rule))
+		c.live[labels[i]] = rule.Sequence
+	}
+	return labels, nil
+}
+
+// executeCommand handles the actual command execution
+func (c *RemoveFirewallRuleCommand) executeCommand() (string, error) {
+	ip, err := c.address()
+	if err != nil {
+		return "", err
+	}
+	rule, ok := c.input("rule")
+	if !ok {
+		return "", fmt.Errorf("rule is required")
+	}
+	sequence, err := strconv.Atoi(rule)
+	if err != nil {
+		return "", fmt.Errorf("invalid rule %q", rule)
+	}
+
+	c.log.Info("Removing firewall rule", "block", c.block, "ip", ip, "sequence", sequence)
+	if err := c.client.DeleteFirewallRule(c.block, ip, sequence); err != nil {
+		return "", err
+	}
+	if err := c.waitRules(ip, nil, []int{sequence}); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Rule %d removed from %s.", sequence, ip), nil
+}
+
+// filePrompt asks for the "file" input, the path of a rules file
+func (c *firewallBase) filePrompt() *Prompt {
+	if _, ok := c.input("file"); ok {
+		return nil
+	}
+	return &Prompt{Key: "file", Label: "Rules file", Kind: PromptText, Default: "firewall.toml"}
+}
+
+// plan compares the live firewall of an address with the rules file
+func (c *firewallBase) plan(ip string) (*FirewallPlan, error) {
+	path, ok := c.input("file")
+	if !ok {
+		return nil, fmt.Errorf("rules file is required")
+	}
+	desired, err := LoadFirewallRules(path)
+	if err != nil {
+		return nil, err
+	}
+
+	firewall, err := c.firewall(ip)
+	if err != nil {
+		return nil, err
+	}
+
+	var live []FirewallRuleSpec
+	if firewall != nil {
+		rules, err := c.rules(ip)
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range rules {
+			live = append(live, specFromRule(rule))
+		}
+	}
+
+	return PlanFirewallRules(ip, firewall, live, desired), nil
+}
+
+// PlanFirewallCommand shows how a rules file differs from the live firewall
+type PlanFirewallCommand struct {
+	firewallBase
+}
+
+// NewPlanFirewallCommand creates a new firewall plan command instance
+func NewPlanFirewallCommand(client *api.Client, block string) *PlanFirewallCommand {
+	return &PlanFirewallCommand{
+		firewallBase: newFirewallBase(client, block, "plan_firewall", TypeInfo),
+	}
+}
+
+// Execute implements the Command interface
+func (c *PlanFirewallCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *PlanFirewallCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *PlanFirewallCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// NextPrompt implements the InteractiveCommand interface
+func (c *PlanFirewallCommand) NextPrompt() (*Prompt, error) {
+	if prompt := c.addressPrompt(c.block); prompt != nil {
+		return prompt, nil
+	}
+	return c.filePrompt(), nil
+}
+
+// executeCommand handles the actual command execution
+func (c *PlanFirewallCommand) executeCommand() (string, error) {
+	ip, err := c.address()
+	if err != nil {
+		return "", err
+	}
+	plan, err := c.plan(ip)
+	if err != nil {
+		return "", err
+	}
+	return plan.String(), nil
+}
+
+// ApplyFirewallCommand brings the live firewall in line with a rules file.
+// The plan is computed once, so what was confirmed is what gets applied.
+type ApplyFirewallCommand struct {
+	firewallBase
+	current *FirewallPlan
+}
+
+// NewApplyFirewallCommand creates a new firewall apply command instance
+func NewApplyFirewallCommand(client *api.Client, block string) *ApplyFirewallCommand {
+	return &ApplyFirewallCommand{
+		firewallBase: newFirewallBase(client, block, "apply_firewall", TypeAction,
+			WithTimeout(taskTimeout)),
+	}
+}
+
+// Execute implements the Command interface
+func (c *ApplyFirewallCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *ApplyFirewallCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeTask(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *ApplyFirewallCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(func() (string, error) {
+		return c.executeTask(ctx, c.executeCommand)
+	})
+}
+
+// NextPrompt implements the InteractiveCommand interface
+func (c *ApplyFirewallCommand) NextPrompt() (*Prompt, error) {
+	if prompt := c.addressPrompt(c.block); prompt != nil {
+		return prompt, nil
+	}
+	if prompt := c.filePrompt(); prompt != nil {
+		return prompt, nil
+	}
+	ip, _ := c.input("ip")
+
+	plan, err := c.Plan()
+	if err != nil {
+		return nil, err
+	}
+	if plan.Empty() {
+		return nil, nil
+	}
+	add, change, remove := plan.Counts()
+	return c.confirmPrompt(fmt.Sprintf("Apply to %s: %d to add, %d to change, %d to remove?",
+		ip, add, change, remove)), nil
+}
+
+// SetInput implements the InteractiveCommand interface
+func (c *ApplyFirewallCommand) SetInput(key, value string) error {
+	if key == "ip" || key == "file" {
+		c.current = nil
+	}
+	return c.firewallBase.SetInput(key, value)
+}
+
+// Plan returns the changes the command applies
+func (c *ApplyFirewallCommand) Plan() (*FirewallPlan, error) {
+	if c.current != nil {
+		return c.current, nil
+	}
+	ip, err := c.address()
+	if err != nil {
+		return nil, err
+	}
+	plan, err := c.plan(ip)
+	if err != nil {
+		return nil, err
+	}
+	c.current = plan
+	return plan, nil
+}
+
+// executeCommand handles the actual command execution
+func (c *ApplyFirewallCommand) executeCommand() (string, error) {
+	ip, err := c.address()
+	if err != nil {
+		return "", err
+	}
+	plan, err := c.Plan()
+	if err != nil {
+		return "", err
+	}
+	if plan.Empty() {
+		return plan.String(), nil
+	}
+
+	c.log.Info("Applying firewall rules", "block", c.block, "ip", ip, "changes", len(plan.Changes))
+	if !plan.Exists {
+		if _, err := c.ensureFirewall(ip); err != nil {
+			return "", err
+		}
+	}
+
+	// Rules are immutable, so updates are removed before being recreated
+	var removed, created []int
+	for _, change := range plan.Changes {
+		if change.Kind == ChangeRemove || change.Kind == ChangeUpdate {
+			c.reportProgress(0, 0, fmt.Sprintf("Removing rule %d", change.Sequence()))
+			if err := c.client.DeleteFirewallRule(c.block, ip, change.Sequence()); err != nil {
+				return "", err
+			}
+			removed = append(removed, change.Sequence())
+		}
+	}
+	if err := c.waitRules(ip, nil, removed); err != nil {
+		return "", err
+	}
+
+	for _, change := range plan.Changes {
+		if change.Kind == ChangeAdd || change.Kind == ChangeUpdate {
+			c.reportProgress(0, 0, fmt.Sprintf("Adding rule %d", change.Sequence()))
+			if _, err := c.client.CreateFirewallRule(c.block, ip, change.Desired.create()); err != nil {
+				return "", err
+			}
+			created = append(created, change.Sequence())
+		}
+	}
+	if err := c.waitRules(ip, created, nil); err != nil {
+		return "", err
+	}
+
+	if plan.Enabled != nil {
+		if err := c.client.SetFirewallEnabled(c.block, ip, *plan.Enabled); err != nil {
+			return "", err
+		}
+		if _, err := c.waitFirewall(ip); err != nil {
+			return "", err
+		}
+	}
+
+	return plan.String() + "\n\nApplied.", nil
+}
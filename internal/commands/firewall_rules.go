@@ -0,0 +1,375 @@
+// internal/commands/firewall_rules.go
+package commands
+
+import (
+	"fmt"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"ovh-terminal/internal/api"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Edge firewall limits and accepted values
+const maxFirewallSequence = 19
+
+var (
+	firewallActions    = []string{"permit", "deny"}
+	firewallProtocols  = []string{"tcp", "udp", "icmp", "ipv4", "gre", "esp", "ah"}
+	firewallTCPOptions = []string{"established", "syn"}
+)
+
+// FirewallRuleSpec describes a firewall rule in a rules file.
+// Empty sources and zero ports match anything.
+type FirewallRuleSpec struct {
+	Sequence        int    `toml:"sequence"`
+	Action          string `toml:"action"`
+	Protocol        string `toml:"protocol"`
+	Source          string `toml:"source,omitempty"`
+	SourcePort      int    `toml:"source_port,omitempty"`
+	DestinationPort int    `toml:"destination_port,omitempty"`
+	TCPOption       string `toml:"tcp_option,omitempty"`
+	Fragments       bool   `toml:"fragments,omitempty"`
+}
+
+// FirewallRulesFile is the desired firewall state of an address
+type FirewallRulesFile struct {
+	Enabled *bool              `toml:"enabled"`
+	Rules   []FirewallRuleSpec `toml:"rule"`
+}
+
+// ChangeKind classifies a planned change
+type ChangeKind int
+
+const (
+	ChangeAdd ChangeKind = iota
+	ChangeUpdate
+	ChangeRemove
+)
+
+// Symbol returns the plan marker of a change kind
+func (k ChangeKind) Symbol() string {
+	switch k {
+	case ChangeAdd:
+		return "+"
+	case ChangeUpdate:
+		return "~"
+	default:
+		return "-"
+	}
+}
+
+// FirewallChange is a single planned rule change
+type FirewallChange struct {
+	Kind    ChangeKind
+	Current *FirewallRuleSpec
+	Desired *FirewallRuleSpec
+}
+
+// Sequence returns the rule sequence affected by the change
+func (c FirewallChange) Sequence() int {
+	if c.Desired != nil {
+		return c.Desired.Sequence
+	}
+	return c.Current.Sequence
+}
+
+// FirewallPlan lists the changes bringing a firewall to a rules file
+type FirewallPlan struct {
+	IP             string
+	Exists         bool
+	CurrentEnabled bool
+	Enabled        *bool
+	Changes        []FirewallChange
+}
+
+// LoadFirewallRules reads and validates a rules file
+func LoadFirewallRules(path string) (*FirewallRulesFile, error) {
+	data, err := os.ReadFile(expandPath(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules file: %w", err)
+	}
+
+	var file FirewallRulesFile
+	if err := toml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse rules file: %w", err)
+	}
+
+	seen := make(map[int]bool)
+	for i := range file.Rules {
+		rule := &file.Rules[i]
+		if err := rule.normalize(); err != nil {
+			return nil, fmt.Errorf("rule %d: %w", rule.Sequence, err)
+		}
+		if seen[rule.Sequence] {
+			return nil, fmt.Errorf("rule %d: duplicate sequence", rule.Sequence)
+		}
+		seen[rule.Sequence] = true
+	}
+	sort.Slice(file.Rules, func(i, j int) bool {
+		return file.Rules[i].Sequence < file.Rules[j].Sequence
+	})
+
+	return &file, nil
+}
+
+// normalize validates a rule and puts its fields in canonical form
+func (r *FirewallRuleSpec) normalize() error {
+	if r.Sequence < 0 || r.Sequence > maxFirewallSequence {
+		return fmt.Errorf("sequence must be between 0 and %d", maxFirewallSequence)
+	}
+
+	r.Action = strings.ToLower(strings.TrimSpace(r.Action))
+	if !containsString(firewallActions, r.Action) {
+		return fmt.Errorf("action must be one of %s", strings.Join(firewallActions, ", "))
+	}
+
+	r.Protocol = strings.ToLower(strings.TrimSpace(r.Protocol))
+	if !containsString(firewallProtocols, r.Protocol) {
+		return fmt.Errorf("protocol must be one of %s", strings.Join(firewallProtocols, ", "))
+	}
+
+	source, err := normalizeSource(r.Source)
+	if err != nil {
+		return err
+	}
+	r.Source = source
+
+	hasPorts := r.Protocol == "tcp" || r.Protocol == "udp"
+	for _, port := range []int{r.SourcePort, r.DestinationPort} {
+		if port < 0 || port > 65535 {
+			return fmt.Errorf("port %d is out of range", port)
+		}
+		if port != 0 && !hasPorts {
+			return fmt.Errorf("ports require the tcp or udp protocol")
+		}
+	}
+
+	r.TCPOption = strings.ToLower(strings.TrimSpace(r.TCPOption))
+	if r.TCPOption != "" {
+		if r.Protocol != "tcp" {
+			return fmt.Errorf("tcp_option requires the tcp protocol")
+		}
+		if !containsString(firewallTCPOptions, r.TCPOption) {
+			return fmt.Errorf("tcp_option must be one of %s", strings.Join(firewallTCPOptions, ", "))
+		}
+	}
+	if r.Fragments && r.Protocol != "tcp" {
+		return fmt.Errorf("fragments require the tcp protocol")
+	}
+	return nil
+}
+
+// String renders a rule in a compact, readable form
+func (r FirewallRuleSpec) String() string {
+	parts := []string{r.Action, r.Protocol}
+
+	source := r.Source
+	if source == "" {
+		source = "any"
+	}
+	parts = append(parts, "from", source)
+	if r.SourcePort != 0 {
+		parts = append(parts, "port", strconv.Itoa(r.SourcePort))
+	}
+	if r.DestinationPort != 0 {
+		parts = append(parts, "to port", strconv.Itoa(r.DestinationPort))
+	}
+	if r.TCPOption != "" {
+		parts = append(parts, r.TCPOption)
+	}
+	if r.Fragments {
+		parts = append(parts, "fragments")
+	}
+	return strings.Join(parts, " ")
+}
+
+// create converts a rule to the API creation payload
+func (r FirewallRuleSpec) create() api.FirewallRuleCreate {
+	create := api.FirewallRuleCreate{
+		Sequence:        r.Sequence,
+		Action:          r.Action,
+		Protocol:        r.Protocol,
+		Source:          r.Source,
+		SourcePort:      r.SourcePort,
+		DestinationPort: r.DestinationPort,
+	}
+	if r.TCPOption != "" || r.Fragments {
+		create.TCPOption = &api.FirewallOptionTCP{Option: r.TCPOption, Fragments: r.Fragments}
+	}
+	return create
+}
+
+// specFromRule converts a live rule to its rules file form
+func specFromRule(rule *api.FirewallRule) FirewallRuleSpec {
+	spec := FirewallRuleSpec{
+		Sequence:        rule.Sequence,
+		Action:          strings.ToLower(rule.Action),
+		Protocol:        strings.ToLower(rule.Protocol),
+		SourcePort:      parsePortExpression(rule.SourcePort),
+		DestinationPort: parsePortExpression(rule.DestinationPort),
+		TCPOption:       strings.ToLower(rule.TCPOption),
+		Fragments:       rule.Fragments != nil && *rule.Fragments,
+	}
+	if source, err := normalizeSource(rule.Source); err == nil {
+		spec.Source = source
+	}
+	return spec
+}
+
+// parsePortExpression extracts the port of an "eq N" expression
+func parsePortExpression(expr string) int {
+	fields := strings.Fields(expr)
+	if len(fields) == 0 {
+		return 0
+	}
+	port, err := strconv.Atoi(fields[len(fields)-1])
+	if err != nil {
+		return 0
+	}
+	return port
+}
+
+// normalizeSource turns an address or network into CIDR form,
+// "any" and empty sources become empty
+func normalizeSource(source string) (string, error) {
+	source = strings.TrimSpace(source)
+	if source == "" || strings.EqualFold(source, "any") {
+		return "", nil
+	}
+	if addr, err := netip.ParseAddr(source); err == nil {
+		return netip.PrefixFrom(addr, addr.BitLen()).String(), nil
+	}
+	prefix, err := netip.ParsePrefix(source)
+	if err != nil {
+		return "", fmt.Errorf("invalid source %q", source)
+	}
+	return prefix.Masked().String(), nil
+}
+
+// PlanFirewallRules compares live rules with a rules file
+func PlanFirewallRules(
+	ip string,
+	firewall *api.FirewallIP,
+	live []FirewallRuleSpec,
+	desired *FirewallRulesFile,
+) *FirewallPlan {
+	plan := &FirewallPlan{IP: ip, Exists: firewall != nil}
+	if firewall != nil {
+		plan.CurrentEnabled = firewall.Enabled
+	}
+	if desired.Enabled != nil && *desired.Enabled != plan.CurrentEnabled {
+		plan.Enabled = desired.Enabled
+	}
+
+	current := make(map[int]FirewallRuleSpec, len(live))
+	for _, rule := range live {
+		current[rule.Sequence] = rule
+	}
+
+	for i := range desired.Rules {
+		want := desired.Rules[i]
+		have, exists := current[want.Sequence]
+		switch {
+		case !exists:
+			plan.Changes = append(plan.Changes, FirewallChange{Kind: ChangeAdd, Desired: &want})
+		case have != want:
+			plan.Changes = append(plan.Changes, FirewallChange{
+				Kind: ChangeUpdate, Current: &have, Desired: &want,
+			})
+		}
+		delete(current, want.Sequence)
+	}
+	for _, rule := range live {
+		if _, stale := current[rule.Sequence]; stale {
+			have := rule
+			plan.Changes = append(plan.Changes, FirewallChange{Kind: ChangeRemove, Current: &have})
+		}
+	}
+
+	sort.SliceStable(plan.Changes, func(i, j int) bool {
+		return plan.Changes[i].Sequence() < plan.Changes[j].Sequence()
+	})
+	return plan
+}
+
+// Empty checks if the plan has nothing to do
+func (p *FirewallPlan) Empty() bool {
+	return p.Enabled == nil && len(p.Changes) == 0
+}
+
+// Counts returns the number of additions, updates and removals
+func (p *FirewallPlan) Counts() (add, change, remove int) {
+	for _, c := range p.Changes {
+		switch c.Kind {
+		case ChangeAdd:
+			add++
+		case ChangeUpdate:
+			change++
+		case ChangeRemove:
+			remove++
+		}
+	}
+	return add, change, remove
+}
+
+// String renders the plan with one line per change
+func (p *FirewallPlan) String() string {
+	if p.Empty() {
+		return fmt.Sprintf("Firewall of %s matches the rules file, no changes.", p.IP)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Firewall plan for %s\n\n", p.IP)
+	if p.Enabled != nil {
+		fmt.Fprintf(&b, "  ~ firewall %s\n", enabledLabel(*p.Enabled))
+	}
+	for _, c := range p.Changes {
+		switch c.Kind {
+		case ChangeAdd:
+			fmt.Fprintf(&b, "  + rule %d: %s\n", c.Sequence(), c.Desired)
+		case ChangeUpdate:
+			fmt.Fprintf(&b, "  ~ rule %d: %s\n        -> %s\n", c.Sequence(), c.Current, c.Desired)
+		case ChangeRemove:
+			fmt.Fprintf(&b, "  - rule %d: %s\n", c.Sequence(), c.Current)
+		}
+	}
+
+	add, change, remove := p.Counts()
+	fmt.Fprintf(&b, "\n%d to add, %d to change, %d to remove.", add, change, remove)
+	return b.String()
+}
+
+// enabledLabel describes a firewall state
+func enabledLabel(enabled bool) string {
+	if enabled {
+		return "enabled"
+	}
+	return "disabled"
+}
+
+// containsString checks if a list holds a value
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// expandPath resolves a leading ~ to the home directory
+func expandPath(path string) string {
+	path = strings.TrimSpace(path)
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, strings.TrimPrefix(path, "~"))
+		}
+	}
+	return path
+}
@@ -0,0 +1,88 @@
+// internal/commands/firewall_rules_test.go
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"ovh-terminal/internal/api"
+)
+
+func TestLoadFirewallRules(t *testing.T) {
+	dir := t.TempDir()
+	write := func(content string) string {
+		path := filepath.Join(dir, "rules.toml")
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	file, err := LoadFirewallRules(write(`
+[[rule]]
+sequence = 5
+action = "Deny"
+protocol = "ipv4"
+
+[[rule]]
+sequence = 1
+action = "permit"
+protocol = "tcp"
+source = "198.51.100.7"
+destination_port = 22
+`))
+	if err != nil {
+		t.Fatalf("LoadFirewallRules failed: %v", err)
+	}
+	if file.Rules[0].Sequence != 1 || file.Rules[0].Source != "198.51.100.7/32" {
+		t.Errorf("Expected sorted, normalized rules, got %+v", file.Rules)
+	}
+	if file.Rules[1].Action != "deny" {
+		t.Errorf("Expected lowercase action, got %q", file.Rules[1].Action)
+	}
+
+	invalid := map[string]string{
+		"duplicate sequence": "[[rule]]\nsequence = 1\naction = \"deny\"\nprotocol = \"ipv4\"\n" +
+			"[[rule]]\nsequence = 1\naction = \"deny\"\nprotocol = \"tcp\"\n",
+		"sequence out of range": "[[rule]]\nsequence = 20\naction = \"deny\"\nprotocol = \"ipv4\"\n",
+		"port without tcp":      "[[rule]]\nsequence = 0\naction = \"deny\"\nprotocol = \"icmp\"\ndestination_port = 1\n",
+		"bad source":            "[[rule]]\nsequence = 0\naction = \"deny\"\nprotocol = \"tcp\"\nsource = \"nope\"\n",
+	}
+	for name, content := range invalid {
+		if _, err := LoadFirewallRules(write(content)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestPlanFirewallRules(t *testing.T) {
+	live := []FirewallRuleSpec{
+		specFromRule(&api.FirewallRule{
+			Sequence: 0, Action: "permit", Protocol: "tcp",
+			Source: "any", DestinationPort: "eq 22",
+		}),
+		{Sequence: 1, Action: "permit", Protocol: "tcp", DestinationPort: 80},
+		{Sequence: 19, Action: "deny", Protocol: "ipv4"},
+	}
+	desired := &FirewallRulesFile{Rules: []FirewallRuleSpec{
+		{Sequence: 0, Action: "permit", Protocol: "tcp", DestinationPort: 22},
+		{Sequence: 1, Action: "permit", Protocol: "tcp", DestinationPort: 443},
+		{Sequence: 2, Action: "permit", Protocol: "icmp"},
+	}}
+
+	plan := PlanFirewallRules("203.0.113.10", &api.FirewallIP{Enabled: true}, live, desired)
+	if add, change, remove := plan.Counts(); add != 1 || change != 1 || remove != 1 {
+		t.Errorf("Expected 1 add, 1 change, 1 remove, got %d, %d, %d", add, change, remove)
+	}
+	if plan.Enabled != nil {
+		t.Error("Expected no firewall state change without enabled in the file")
+	}
+
+	kinds := []ChangeKind{ChangeUpdate, ChangeAdd, ChangeRemove}
+	for i, change := range plan.Changes {
+		if change.Kind != kinds[i] {
+			t.Errorf("Change %d: expected %s, got %s", i, kinds[i].Symbol(), change.Kind.Symbol())
+		}
+	}
+}
@@ -0,0 +1,538 @@
+// internal/commands/hosting.go
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"ovh-terminal/internal/api"
+	"ovh-terminal/internal/format"
+	"ovh-terminal/internal/logger"
+)
+
+// Hosting resource kinds shown by HostingResourceCommand
+const (
+	HostingResourceDomain   = "domain"
+	HostingResourceUser     = "user"
+	HostingResourceDatabase = "database"
+	HostingResourceCron     = "cron"
+	HostingResourceSSL      = "ssl"
+)
+
+// HostingSummary describes a hosting plan on one line, for menus
+func HostingSummary(hosting *api.Hosting) string {
+	return strings.Join([]string{hosting.Offer, hosting.Cluster, hosting.State}, " · ")
+}
+
+// HostingDomainSummary describes an attached domain on one line
+func HostingDomainSummary(domain *api.HostingDomain) string {
+	parts := []string{domain.Path}
+	if domain.SSL {
+		parts = append(parts, "SSL")
+	}
+	if domain.CDN == "active" {
+		parts = append(parts, "CDN")
+	}
+	return strings.Join(parts, " · ")
+}
+
+// HostingUserSummary describes an FTP/SSH user on one line
+func HostingUserSummary(user *api.HostingUser) string {
+	return strings.Join([]string{user.Home, "ssh " + user.SSHState, user.State}, " · ")
+}
+
+// HostingDatabaseSummary describes a database on one line
+func HostingDatabaseSummary(database *api.HostingDatabase) string {
+	return strings.Join([]string{database.Type + " " + database.Version,
+		format.Bytes(database.QuotaUsed.Bytes()), database.State}, " · ")
+}
+
+// HostingCronSummary describes a scheduled task on one line
+func HostingCronSummary(cron *api.HostingCron) string {
+	return strings.Join([]string{cron.Frequency, cron.Command, cron.State}, " · ")
+}
+
+// HostingSSLSummary describes a certificate on one line
+func HostingSSLSummary(ssl *api.HostingSSL) string {
+	return fmt.Sprintf("%s %s (%s)", ssl.Provider, ssl.Type, ssl.Status)
+}
+
+// hostingQuotaWarning is the storage usage percentage above which hosting
+// quotas are flagged, ui.quota_warning only covers Public Cloud quotas
+const hostingQuotaWarning = DefaultQuotaWarning
+
+// formatQuota renders storage usage with a bar,
+// "██░░░░ 1.2 GiB / 100.0 GiB (1%)"
+func formatQuota(used, size *api.UnitValue) string {
+	if size == nil {
+		return format.Bytes(used.Bytes())
+	}
+	usage := float64(used.Bytes())
+	limit := float64(size.Bytes())
+	percent := 0.0
+	if limit > 0 {
+		percent = usage * 100 / limit
+	}
+	line := fmt.Sprintf("%s %s / %s (%.0f%%)", format.Bar(usage, limit, quotaBarWidth),
+		format.Bytes(used.Bytes()), format.Bytes(size.Bytes()), percent)
+	if usageAbove(usage, limit, hostingQuotaWarning) {
+		line += " ⚠"
+	}
+	return line
+}
+
+// rootConfig returns the configuration of the hosting root folder, or the
+// first one when no configuration applies to the root
+func rootConfig(configs []api.HostingConfig) *api.HostingConfig {
+	for i := range configs {
+		if configs[i].Path == "" || configs[i].Path == "/" {
+			return &configs[i]
+		}
+	}
+	if len(configs) > 0 {
+		return &configs[0]
+	}
+	return nil
+}
+
+// hostingPHPVersion describes the runtime of a hosting plan, "php 8.2"
+func hostingPHPVersion(client *api.Client, log *logger.Logger, service string) string {
+	configs, err := client.ListHostingConfigs(service)
+	if err != nil {
+		log.Error("Failed to get hosting configuration", "hosting", service, "error", err)
+		return "(unavailable)"
+	}
+	config := rootConfig(configs)
+	if config == nil {
+		return "Default"
+	}
+	return fmt.Sprintf("%s %s", config.EngineName, config.EngineVersion)
+}
+
+// HostingsCommand lists the web hosting plans of the account
+type HostingsCommand struct {
+	BaseCommand
+	client *api.Client
+	log    *logger.Logger
+}
+
+// NewHostingsCommand creates a new hosting plans command instance
+func NewHostingsCommand(client *api.Client) *HostingsCommand {
+	return &HostingsCommand{
+		BaseCommand: NewBaseCommand(TypeInfo),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "hostings"}),
+	}
+}
+
+// Execute implements the Command interface
+func (c *HostingsCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *HostingsCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *HostingsCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// Hostings returns the hosting plans sorted by service name
+func (c *HostingsCommand) Hostings() ([]*api.Hosting, error) {
+	names, err := c.client.ListHostings()
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	hostings := make([]*api.Hosting, 0, len(names))
+	for _, name := range names {
+		hosting, err := c.client.GetHosting(name)
+		if err != nil {
+			return nil, err
+		}
+		hostings = append(hostings, hosting)
+	}
+	return hostings, nil
+}
+
+// executeCommand handles the actual command execution
+func (c *HostingsCommand) executeCommand() (string, error) {
+	c.log.Debug("Executing hosting plans command")
+
+	hostings, err := c.Hostings()
+	if err != nil {
+		return "", err
+	}
+
+	output := format.NewOutputFormatter(
+		format.WithMaxWidth(maxWidth),
+		format.WithSeparator("\n"),
+	)
+	config := format.SectionConfig{
+		KeyValueSpacing: keyValueSpacing,
+		TitleDecorator:  "=",
+	}
+
+	if len(hostings) == 0 {
+		section := output.AddSection("Hosting Plans")
+		section.SetConfig(config)
+		section.AddField("Hosting plans", "None")
+		return output.String(), nil
+	}
+
+	for _, hosting := range hostings {
+		section := output.AddSection(hosting.GetDisplayName())
+		section.SetConfig(config)
+		section.AddField("Offer", hosting.Offer)
+		section.AddField("Cluster", hosting.Cluster)
+		section.AddField("Quota", formatQuota(hosting.QuotaUsed, hosting.QuotaSize))
+		section.AddField("PHP", hostingPHPVersion(c.client, c.log, hosting.ServiceName))
+		section.AddField("State", hosting.State)
+	}
+
+	return output.String(), nil
+}
+
+// HostingCommand shows a hosting plan and the resources it contains
+type HostingCommand struct {
+	BaseCommand
+	client  *api.Client
+	log     *logger.Logger
+	service string
+}
+
+// NewHostingCommand creates a new hosting plan command instance
+func NewHostingCommand(client *api.Client, service string) *HostingCommand {
+	return &HostingCommand{
+		BaseCommand: NewBaseCommand(TypeInfo),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "hosting"}),
+		service:     service,
+	}
+}
+
+// Execute implements the Command interface
+func (c *HostingCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *HostingCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *HostingCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// executeCommand handles the actual command execution
+func (c *HostingCommand) executeCommand() (string, error) {
+	c.log.Debug("Executing hosting command", "hosting", c.service)
+
+	hosting, err := c.client.GetHosting(c.service)
+	if err != nil {
+		return "", err
+	}
+
+	output := format.NewOutputFormatter(
+		format.WithMaxWidth(maxWidth),
+		format.WithSeparator("\n"),
+	)
+	config := format.SectionConfig{
+		KeyValueSpacing: keyValueSpacing,
+		TitleDecorator:  "=",
+	}
+
+	section := output.AddSection("Hosting Plan")
+	section.SetConfig(config)
+	section.AddField("Name", hosting.GetDisplayName())
+	section.AddField("Service", hosting.ServiceName)
+	section.AddField("Offer", hosting.Offer)
+	section.AddField("State", hosting.State)
+	section.AddField("Cluster", hosting.Cluster)
+	section.AddField("Cluster IP", hosting.ClusterIP)
+	section.AddField("Datacenter", hosting.Datacenter)
+	section.AddField("System", hosting.OperatingSystem)
+	section.AddField("Home", hosting.Home)
+	section.AddField("Primary Login", hosting.PrimaryLogin)
+	section.AddField("Quota", formatQuota(hosting.QuotaUsed, hosting.QuotaSize))
+	section.AddField("PHP", hostingPHPVersion(c.client, c.log, c.service))
+	section.AddField("CDN", formatYesNo(hosting.HasCDN))
+
+	section = output.AddSection("Contents")
+	section.SetConfig(config)
+	count := func(title string, list func() (int, error)) {
+		n, err := list()
+		if err != nil {
+			c.log.Error("Failed to count hosting resources", "resource", title, "error", err)
+			section.AddField(title, "(unavailable)")
+			return
+		}
+		section.AddField(title, fmt.Sprint(n))
+	}
+	count("Domains", func() (int, error) {
+		items, err := c.client.ListHostingDomains(c.service)
+		return len(items), err
+	})
+	count("FTP/SSH users", func() (int, error) {
+		items, err := c.client.ListHostingUsers(c.service)
+		return len(items), err
+	})
+	count("Databases", func() (int, error) {
+		items, err := c.client.ListHostingDatabases(c.service)
+		return len(items), err
+	})
+	count("Cron jobs", func() (int, error) {
+		items, err := c.client.ListHostingCrons(c.service)
+		return len(items), err
+	})
+
+	ssl, err := c.client.GetHostingSSL(c.service)
+	switch {
+	case err != nil:
+		c.log.Error("Failed to get SSL certificate", "hosting", c.service, "error", err)
+		section.AddField("SSL", "(unavailable)")
+	case ssl == nil:
+		section.AddField("SSL", "None")
+	default:
+		section.AddField("SSL", HostingSSLSummary(ssl))
+	}
+
+	return output.String(), nil
+}
+
+// HostingResourceCommand shows the details of a resource of a hosting plan
+type HostingResourceCommand struct {
+	BaseCommand
+	client  *api.Client
+	log     *logger.Logger
+	kind    string
+	service string
+	id      string
+}
+
+// NewHostingResourceCommand creates a detail command for a resource of kind
+func NewHostingResourceCommand(client *api.Client, kind, service, id string) *HostingResourceCommand {
+	return &HostingResourceCommand{
+		BaseCommand: NewBaseCommand(TypeInfo),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "hosting_" + kind}),
+		kind:        kind,
+		service:     service,
+		id:          id,
+	}
+}
+
+// Execute implements the Command interface
+func (c *HostingResourceCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *HostingResourceCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *HostingResourceCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// executeCommand handles the actual command execution
+func (c *HostingResourceCommand) executeCommand() (string, error) {
+	c.log.Debug("Executing hosting resource command", "hosting", c.service, "id", c.id)
+
+	output := format.NewOutputFormatter(
+		format.WithMaxWidth(maxWidth),
+		format.WithSeparator("\n"),
+	)
+	config := format.SectionConfig{
+		KeyValueSpacing: keyValueSpacing,
+		TitleDecorator:  "=",
+	}
+
+	var err error
+	switch c.kind {
+	case HostingResourceDomain:
+		err = c.renderDomain(output, config)
+	case HostingResourceUser:
+		err = c.renderUser(output, config)
+	case HostingResourceDatabase:
+		err = c.renderDatabase(output, config)
+	case HostingResourceCron:
+		err = c.renderCron(output, config)
+	case HostingResourceSSL:
+		err = c.renderSSL(output, config)
+	default:
+		err = fmt.Errorf("unknown hosting resource kind %q", c.kind)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return output.String(), nil
+}
+
+// renderDomain adds the sections of an attached domain
+func (c *HostingResourceCommand) renderDomain(
+	output *format.OutputFormatter,
+	config format.SectionConfig,
+) error {
+	domain, err := c.client.GetHostingDomain(c.service, c.id)
+	if err != nil {
+		return err
+	}
+
+	section := output.AddSection("Attached Domain")
+	section.SetConfig(config)
+	section.AddField("Domain", domain.Domain)
+	section.AddField("Hosting", c.service)
+	section.AddField("Folder", domain.Path)
+	section.AddField("SSL", formatYesNo(domain.SSL))
+	section.AddField("CDN", domain.CDN)
+	section.AddField("Firewall", domain.Firewall)
+	section.AddField("Separate Logs", domain.OwnLog)
+	section.AddField("Status", domain.Status)
+	return nil
+}
+
+// renderUser adds the sections of an FTP/SSH user
+func (c *HostingResourceCommand) renderUser(
+	output *format.OutputFormatter,
+	config format.SectionConfig,
+) error {
+	user, err := c.client.GetHostingUser(c.service, c.id)
+	if err != nil {
+		return err
+	}
+
+	section := output.AddSection("FTP/SSH User")
+	section.SetConfig(config)
+	section.AddField("Login", user.Login)
+	section.AddField("Hosting", c.service)
+	section.AddField("Home", user.Home)
+	section.AddField("SSH", user.SSHState)
+	section.AddField("State", user.State)
+	section.AddField("Primary", formatYesNo(user.PrimaryAccount))
+	section.AddField("Status", user.Status)
+	return nil
+}
+
+// renderDatabase adds the sections of a database
+func (c *HostingResourceCommand) renderDatabase(
+	output *format.OutputFormatter,
+	config format.SectionConfig,
+) error {
+	database, err := c.client.GetHostingDatabase(c.service, c.id)
+	if err != nil {
+		return err
+	}
+
+	section := output.AddSection("Database")
+	section.SetConfig(config)
+	section.AddField("Name", database.Name)
+	section.AddField("Hosting", c.service)
+	section.AddField("Type", database.Type)
+	section.AddField("Version", database.Version)
+	section.AddField("Server", fmt.Sprintf("%s:%d", database.Server, database.Port))
+	section.AddField("User", database.User)
+	section.AddField("Mode", database.Mode)
+	section.AddField("Quota", formatQuota(database.QuotaUsed, database.QuotaSize))
+	section.AddField("State", database.State)
+
+	section = output.AddSection("Dumps")
+	section.SetConfig(config)
+	dumps, err := LoadDumps(c.client, c.service, c.id)
+	if err != nil {
+		c.log.Error("Failed to list dumps", "database", c.id, "error", err)
+		section.AddField("Dumps", "(unavailable)")
+		return nil
+	}
+	if len(dumps) == 0 {
+		section.AddField("Dumps", "None")
+	}
+	for _, dump := range dumps {
+		section.AddField(fmt.Sprintf("#%d", dump.ID),
+			fmt.Sprintf("%s · %s · %s", format.DateTime(dump.CreationDate), dump.Type, dump.Status))
+	}
+	return nil
+}
+
+// renderCron adds the sections of a scheduled task
+func (c *HostingResourceCommand) renderCron(
+	output *format.OutputFormatter,
+	config format.SectionConfig,
+) error {
+	id, err := strconv.ParseInt(c.id, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid cron job ID %q", c.id)
+	}
+	cron, err := c.client.GetHostingCron(c.service, id)
+	if err != nil {
+		return err
+	}
+
+	section := output.AddSection("Cron Job")
+	section.SetConfig(config)
+	section.AddField("ID", fmt.Sprint(cron.ID))
+	section.AddField("Hosting", c.service)
+	section.AddField("Description", cron.Description)
+	section.AddField("Command", cron.Command)
+	section.AddField("Language", cron.Language)
+	section.AddField("Frequency", cron.Frequency)
+	section.AddField("Email", cron.Email)
+	section.AddField("State", cron.State)
+	section.AddField("Status", cron.Status)
+	return nil
+}
+
+// renderSSL adds the sections of the hosting certificate
+func (c *HostingResourceCommand) renderSSL(
+	output *format.OutputFormatter,
+	config format.SectionConfig,
+) error {
+	ssl, err := c.client.GetHostingSSL(c.service)
+	if err != nil {
+		return err
+	}
+
+	section := output.AddSection("SSL Certificate")
+	section.SetConfig(config)
+	section.AddField("Hosting", c.service)
+	if ssl == nil {
+		section.AddField("Certificate", "None")
+		return nil
+	}
+	section.AddField("Provider", ssl.Provider)
+	section.AddField("Type", ssl.Type)
+	section.AddField("Status", ssl.Status)
+	section.AddField("Regenerable", formatYesNo(ssl.Regenerable))
+
+	domains, err := c.client.ListHostingSSLDomains(c.service)
+	if err != nil {
+		c.log.Error("Failed to list SSL domains", "hosting", c.service, "error", err)
+		domains = []string{"(unavailable)"}
+	}
+	if len(domains) == 0 {
+		domains = []string{"None"}
+	}
+	section.AddLines("Domains", domains)
+	return nil
+}
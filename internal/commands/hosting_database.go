@@ -0,0 +1,550 @@
+// internal/commands/hosting_database.go
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"ovh-terminal/internal/api"
+	"ovh-terminal/internal/format"
+	"ovh-terminal/internal/logger"
+)
+
+// dumpDates are the sources offered when creating a dump
+var dumpDates = []struct {
+	label string
+	date  string
+}{
+	{"Current content", api.DumpNow},
+	{"Yesterday's backup", api.DumpYesterday},
+	{"Last week's backup", api.DumpLastWeek},
+}
+
+// downloadStep is the progress interval of dump downloads of unknown size
+const downloadStep = 8 << 20
+
+// DumpLabel describes a dump on one line, "#12 · 2026-10-17 03:00 · daily.1"
+func DumpLabel(dump *api.HostingDump) string {
+	return fmt.Sprintf("#%d · %s · %s", dump.ID, format.DateTime(dump.CreationDate), dump.Type)
+}
+
+// LoadDumps returns the dumps of a database, newest first
+func LoadDumps(client *api.Client, service, database string) ([]*api.HostingDump, error) {
+	ids, err := client.ListHostingDatabaseDumps(service, database)
+	if err != nil {
+		return nil, err
+	}
+
+	dumps := make([]*api.HostingDump, 0, len(ids))
+	for _, id := range ids {
+		dump, err := client.GetHostingDatabaseDump(service, database, id)
+		if err != nil {
+			return nil, err
+		}
+		dumps = append(dumps, dump)
+	}
+	sort.Slice(dumps, func(i, j int) bool { return dumps[i].ID > dumps[j].ID })
+	return dumps, nil
+}
+
+// hostingTaskCheck follows a task of a hosting plan
+func hostingTaskCheck(client *api.Client, service string, task *api.HostingTask) TaskCheck {
+	return func() (string, bool, error) {
+		current, err := client.GetHostingTask(service, task.ID)
+		if err != nil {
+			return "", false, err
+		}
+		if current.IsFailed() {
+			return current.Status, false, fmt.Errorf("task %d (%s) ended with status %s",
+				current.ID, current.Function, current.Status)
+		}
+		return current.Status, current.IsDone(), nil
+	}
+}
+
+// dumpPicker lets interactive commands choose a dump of a database
+type dumpPicker struct {
+	client   *api.Client
+	service  string
+	database string
+	dumps    []*api.HostingDump
+}
+
+// load fetches the dumps once, failing when there is none to choose
+func (p *dumpPicker) load() error {
+	if p.dumps != nil {
+		return nil
+	}
+	dumps, err := LoadDumps(p.client, p.service, p.database)
+	if err != nil {
+		return err
+	}
+	if len(dumps) == 0 {
+		return fmt.Errorf("%s has no dumps, create one first", p.database)
+	}
+	p.dumps = dumps
+	return nil
+}
+
+// prompt asks for a dump, newest first
+func (p *dumpPicker) prompt() *Prompt {
+	labels := make([]string, len(p.dumps))
+	for i, dump := range p.dumps {
+		labels[i] = DumpLabel(dump)
+	}
+	return &Prompt{Key: "dump", Label: "Dump", Kind: PromptChoice, Choices: labels}
+}
+
+// resolve maps a picker label or a bare ID to the dump ID
+func (p *dumpPicker) resolve(value string) (string, error) {
+	if err := p.load(); err != nil {
+		return "", err
+	}
+	for _, dump := range p.dumps {
+		id := strconv.FormatInt(dump.ID, 10)
+		if value == DumpLabel(dump) || value == id {
+			return id, nil
+		}
+	}
+	return "", fmt.Errorf("%s is not a dump of %s", value, p.database)
+}
+
+// dump returns the dump with an ID set by resolve
+func (p *dumpPicker) dump(id string) *api.HostingDump {
+	for _, dump := range p.dumps {
+		if strconv.FormatInt(dump.ID, 10) == id {
+			return dump
+		}
+	}
+	return nil
+}
+
+// HostingDatabasesCommand lists the databases of a hosting plan
+type HostingDatabasesCommand struct {
+	BaseCommand
+	client  *api.Client
+	log     *logger.Logger
+	service string
+}
+
+// NewHostingDatabasesCommand creates a new hosting databases command instance
+func NewHostingDatabasesCommand(client *api.Client, service string) *HostingDatabasesCommand {
+	return &HostingDatabasesCommand{
+		BaseCommand: NewBaseCommand(TypeInfo),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "hosting_databases"}),
+		service:     service,
+	}
+}
+
+// Execute implements the Command interface
+func (c *HostingDatabasesCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *HostingDatabasesCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *HostingDatabasesCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// executeCommand handles the actual command execution
+func (c *HostingDatabasesCommand) executeCommand() (string, error) {
+	c.log.Debug("Executing hosting databases command", "hosting", c.service)
+
+	names, err := c.client.ListHostingDatabases(c.service)
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(names)
+
+	output := format.NewOutputFormatter(
+		format.WithMaxWidth(maxWidth),
+		format.WithSeparator("\n"),
+	)
+	section := output.AddSection("Databases of " + c.service)
+	section.SetConfig(format.SectionConfig{
+		KeyValueSpacing: keyValueSpacing,
+		TitleDecorator:  "=",
+	})
+
+	if len(names) == 0 {
+		section.AddField("Databases", "None")
+		return output.String(), nil
+	}
+	for _, name := range names {
+		database, err := c.client.GetHostingDatabase(c.service, name)
+		if err != nil {
+			return "", err
+		}
+		section.AddLines(name, []string{
+			fmt.Sprintf("%s %s on %s", database.Type, database.Version, database.Server),
+			formatQuota(database.QuotaUsed, database.QuotaSize),
+		})
+	}
+
+	return output.String(), nil
+}
+
+// CreateDumpCommand exports a hosting database and waits for the dump
+type CreateDumpCommand struct {
+	BaseCommand
+	client   *api.Client
+	log      *logger.Logger
+	service  string
+	database string
+}
+
+// NewCreateDumpCommand creates a new database dump command instance
+func NewCreateDumpCommand(client *api.Client, service, database string) *CreateDumpCommand {
+	return &CreateDumpCommand{
+		BaseCommand: NewBaseCommand(TypeAction, WithTimeout(taskTimeout)),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "create_dump"}),
+		service:     service,
+		database:    database,
+	}
+}
+
+// Execute implements the Command interface
+func (c *CreateDumpCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *CreateDumpCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeTask(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *CreateDumpCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(func() (string, error) {
+		return c.executeTask(ctx, c.executeCommand)
+	})
+}
+
+// NextPrompt implements the InteractiveCommand interface
+func (c *CreateDumpCommand) NextPrompt() (*Prompt, error) {
+	date, ok := c.input("date")
+	if !ok {
+		labels := make([]string, len(dumpDates))
+		for i, source := range dumpDates {
+			labels[i] = source.label
+		}
+		return &Prompt{Key: "date", Label: "Dump from", Kind: PromptChoice, Choices: labels}, nil
+	}
+	source := strings.ToLower(dumpDateLabel(date))
+	return c.confirmPrompt(fmt.Sprintf("Dump %s from %s?", c.database, source)), nil
+}
+
+// SetInput implements the InteractiveCommand interface
+func (c *CreateDumpCommand) SetInput(key, value string) error {
+	if key == "date" {
+		// Accept both the picker label and a bare API date
+		for _, source := range dumpDates {
+			if value == source.label || value == source.date {
+				return c.BaseCommand.SetInput(key, source.date)
+			}
+		}
+		return fmt.Errorf("unknown dump source %q", value)
+	}
+	return c.BaseCommand.SetInput(key, value)
+}
+
+// dumpDateLabel returns the picker label of an API date
+func dumpDateLabel(date string) string {
+	for _, source := range dumpDates {
+		if source.date == date {
+			return source.label
+		}
+	}
+	return date
+}
+
+// executeCommand handles the actual command execution
+func (c *CreateDumpCommand) executeCommand() (string, error) {
+	date, ok := c.input("date")
+	if !ok {
+		date = api.DumpNow
+	}
+
+	c.log.Info("Dumping database", "hosting", c.service, "database", c.database, "date", date)
+	task, err := c.client.CreateHostingDatabaseDump(c.service, c.database, date)
+	if err != nil {
+		return "", err
+	}
+	err = c.pollTask(fmt.Sprintf("Dumping %s", c.database), hostingTaskCheck(c.client, c.service, task))
+	if err != nil {
+		return "", err
+	}
+
+	dumps, err := LoadDumps(c.client, c.service, c.database)
+	if err != nil || len(dumps) == 0 {
+		return fmt.Sprintf("Dumped %s.", c.database), nil
+	}
+	return fmt.Sprintf("Dumped %s: %s.", c.database, DumpLabel(dumps[0])), nil
+}
+
+// DownloadDumpCommand saves a dump of a hosting database to disk
+type DownloadDumpCommand struct {
+	BaseCommand
+	client   *api.Client
+	log      *logger.Logger
+	service  string
+	database string
+	dumps    dumpPicker
+}
+
+// NewDownloadDumpCommand creates a new dump download command instance
+func NewDownloadDumpCommand(client *api.Client, service, database string) *DownloadDumpCommand {
+	return &DownloadDumpCommand{
+		BaseCommand: NewBaseCommand(TypeAction, WithTimeout(taskTimeout)),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "download_dump"}),
+		service:     service,
+		database:    database,
+		dumps:       dumpPicker{client: client, service: service, database: database},
+	}
+}
+
+// Execute implements the Command interface
+func (c *DownloadDumpCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *DownloadDumpCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *DownloadDumpCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(func() (string, error) {
+		return c.download(ctx)
+	})
+}
+
+// NextPrompt implements the InteractiveCommand interface
+func (c *DownloadDumpCommand) NextPrompt() (*Prompt, error) {
+	if err := c.dumps.load(); err != nil {
+		return nil, err
+	}
+
+	id, ok := c.input("dump")
+	if !ok {
+		return c.dumps.prompt(), nil
+	}
+
+	path, ok := c.input("file")
+	if !ok {
+		return &Prompt{Key: "file", Label: "Dump file", Kind: PromptText, Default: c.defaultPath(id)}, nil
+	}
+	if _, err := os.Stat(expandPath(path)); err == nil {
+		return c.confirmPrompt(fmt.Sprintf("Overwrite %s?", path)), nil
+	}
+	return nil, nil
+}
+
+// SetInput implements the InteractiveCommand interface
+func (c *DownloadDumpCommand) SetInput(key, value string) error {
+	switch key {
+	case "dump":
+		id, err := c.dumps.resolve(value)
+		if err != nil {
+			return err
+		}
+		value = id
+	case "file":
+		if strings.TrimSpace(value) == "" {
+			id, _ := c.input("dump")
+			value = c.defaultPath(id)
+		}
+	}
+	return c.BaseCommand.SetInput(key, value)
+}
+
+// defaultPath names the file after the database and the dump date
+func (c *DownloadDumpCommand) defaultPath(id string) string {
+	dump := c.dumps.dump(id)
+	if dump == nil || dump.CreationDate == nil {
+		return fmt.Sprintf("%s.sql.gz", c.database)
+	}
+	return fmt.Sprintf("%s-%s.sql.gz", c.database, dump.CreationDate.Format("20060102-1504"))
+}
+
+// executeCommand handles the actual command execution
+func (c *DownloadDumpCommand) executeCommand() (string, error) {
+	return c.download(context.Background())
+}
+
+// download streams the dump to disk. Dumps can weigh gigabytes, so the
+// download is bounded by the task timeout instead of the API one.
+func (c *DownloadDumpCommand) download(ctx context.Context) (string, error) {
+	value, ok := c.input("dump")
+	if !ok {
+		return "", fmt.Errorf("dump is required")
+	}
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid dump ID %q", value)
+	}
+
+	dump, err := c.client.GetHostingDatabaseDump(c.service, c.database, id)
+	if err != nil {
+		return "", err
+	}
+	if dump.URL == "" {
+		return "", fmt.Errorf("dump #%d of %s is not ready for download (%s)", id, c.database, dump.Status)
+	}
+
+	path, ok := c.input("file")
+	if !ok {
+		path = c.defaultPath(value)
+	}
+	path = expandPath(path)
+
+	ctx, cancel := context.WithTimeout(ctx, taskTimeout)
+	defer cancel()
+
+	var size int64
+	err = streamPrivateFile(path, func(w io.Writer) error {
+		size, err = c.client.DownloadTo(ctx, dump.URL, w, c.downloadProgress(id))
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	c.log.Info("Downloaded dump", "hosting", c.service, "database", c.database, "dump", id, "path", path)
+	return fmt.Sprintf("Saved dump #%d of %s to %s (%s).", id, c.database, path, format.Bytes(size)), nil
+}
+
+// downloadProgress reports every percent of a download, or every
+// downloadStep bytes when its size is unknown
+func (c *DownloadDumpCommand) downloadProgress(id int64) func(written, total int64) {
+	last := int64(-1)
+	return func(written, total int64) {
+		if total <= 0 {
+			if step := written / downloadStep; step != last {
+				last = step
+				c.reportProgress(0, 0, fmt.Sprintf("Downloading dump #%d: %s", id, format.Bytes(written)))
+			}
+			return
+		}
+		if percent := written * 100 / total; percent != last {
+			last = percent
+			c.reportProgress(int(percent), 100, fmt.Sprintf("Downloading dump #%d: %s of %s",
+				id, format.Bytes(written), format.Bytes(total)))
+		}
+	}
+}
+
+// RestoreDumpCommand replaces the content of a hosting database with a dump
+type RestoreDumpCommand struct {
+	BaseCommand
+	client   *api.Client
+	log      *logger.Logger
+	service  string
+	database string
+	dumps    dumpPicker
+}
+
+// NewRestoreDumpCommand creates a new dump restore command instance
+func NewRestoreDumpCommand(client *api.Client, service, database string) *RestoreDumpCommand {
+	return &RestoreDumpCommand{
+		BaseCommand: NewBaseCommand(TypeAction, WithTimeout(taskTimeout)),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "restore_dump"}),
+		service:     service,
+		database:    database,
+		dumps:       dumpPicker{client: client, service: service, database: database},
+	}
+}
+
+// Execute implements the Command interface
+func (c *RestoreDumpCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *RestoreDumpCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeTask(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *RestoreDumpCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(func() (string, error) {
+		return c.executeTask(ctx, c.executeCommand)
+	})
+}
+
+// NextPrompt implements the InteractiveCommand interface
+func (c *RestoreDumpCommand) NextPrompt() (*Prompt, error) {
+	if err := c.dumps.load(); err != nil {
+		return nil, err
+	}
+
+	id, ok := c.input("dump")
+	if !ok {
+		return c.dumps.prompt(), nil
+	}
+	return c.confirmPrompt(fmt.Sprintf("Replace the content of %s with dump %s?",
+		c.database, DumpLabel(c.dumps.dump(id)))), nil
+}
+
+// SetInput implements the InteractiveCommand interface
+func (c *RestoreDumpCommand) SetInput(key, value string) error {
+	if key == "dump" {
+		id, err := c.dumps.resolve(value)
+		if err != nil {
+			return err
+		}
+		value = id
+	}
+	return c.BaseCommand.SetInput(key, value)
+}
+
+// executeCommand handles the actual command execution
+func (c *RestoreDumpCommand) executeCommand() (string, error) {
+	value, ok := c.input("dump")
+	if !ok {
+		return "", fmt.Errorf("dump is required")
+	}
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid dump ID %q", value)
+	}
+
+	c.log.Info("Restoring database", "hosting", c.service, "database", c.database, "dump", id)
+	task, err := c.client.RestoreHostingDatabaseDump(c.service, c.database, id)
+	if err != nil {
+		return "", err
+	}
+	err = c.pollTask(fmt.Sprintf("Restoring %s", c.database), hostingTaskCheck(c.client, c.service, task))
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Restored %s from dump #%d.", c.database, id), nil
+}
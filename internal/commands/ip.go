@@ -0,0 +1,593 @@
+// internal/commands/ip.go
+package commands
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"sort"
+	"strings"
+	"time"
+
+	"ovh-terminal/internal/api"
+	"ovh-terminal/internal/format"
+	"ovh-terminal/internal/logger"
+)
+
+// maxListedAddresses caps the addresses offered as choices for a block
+const maxListedAddresses = 256
+
+// forwardLookupTimeout bounds the forward record check of a reverse
+const forwardLookupTimeout = 5 * time.Second
+
+// ipTypeOrder defines the display order of IP groups
+var ipTypeOrder = []api.IPType{
+	api.IPTypeDedicated,
+	api.IPTypeFailover,
+	api.IPTypeCloud,
+	api.IPTypeVPS,
+}
+
+// ipGroupTitles maps IP types to their group titles
+var ipGroupTitles = map[api.IPType]string{
+	api.IPTypeDedicated: "Dedicated IPs",
+	api.IPTypeFailover:  "Failover IPs",
+	api.IPTypeCloud:     "Cloud IPs",
+	api.IPTypeVPS:       "VPS IPs",
+}
+
+// lookupHost resolves forward records, replaced in tests
+var lookupHost = net.DefaultResolver.LookupHost
+
+// IPGroup holds the blocks of one IP type
+type IPGroup struct {
+	Type   api.IPType
+	Title  string
+	Blocks []*api.IPInfo
+}
+
+// IPOverviewCommand lists IP blocks grouped by type and routed service
+type IPOverviewCommand struct {
+	BaseCommand
+	client *api.Client
+	log    *logger.Logger
+}
+
+// NewIPOverviewCommand creates a new IP overview command instance
+func NewIPOverviewCommand(client *api.Client) *IPOverviewCommand {
+	return &IPOverviewCommand{
+		BaseCommand: NewBaseCommand(TypeInfo),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "ip_overview"}),
+	}
+}
+
+// Execute implements the Command interface
+func (c *IPOverviewCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *IPOverviewCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *IPOverviewCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// Groups returns all IP blocks grouped by type, each group sorted by
+// routed service and block
+func (c *IPOverviewCommand) Groups() ([]IPGroup, error) {
+	c.log.Debug("Fetching IP blocks")
+
+	blocks, err := c.client.ListIPs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list IP blocks: %w", err)
+	}
+
+	byType := make(map[api.IPType][]*api.IPInfo)
+	for _, block := range blocks {
+		info, err := c.client.GetIPInfo(block)
+		if err != nil {
+			c.log.Error("Failed to get IP info", "block", block, "error", err)
+			continue
+		}
+		byType[info.Type] = append(byType[info.Type], info)
+	}
+
+	// Known types first, then anything else alphabetically
+	types := append([]api.IPType(nil), ipTypeOrder...)
+	var others []api.IPType
+	for ipType := range byType {
+		if _, known := ipGroupTitles[ipType]; !known {
+			others = append(others, ipType)
+		}
+	}
+	sort.Slice(others, func(i, j int) bool { return others[i] < others[j] })
+	types = append(types, others...)
+
+	var groups []IPGroup
+	for _, ipType := range types {
+		infos := byType[ipType]
+		if len(infos) == 0 {
+			continue
+		}
+
+		sort.Slice(infos, func(i, j int) bool {
+			if infos[i].GetRoutedService() != infos[j].GetRoutedService() {
+				return infos[i].GetRoutedService() < infos[j].GetRoutedService()
+			}
+			return infos[i].IP < infos[j].IP
+		})
+
+		title, ok := ipGroupTitles[ipType]
+		if !ok {
+			title = fmt.Sprintf("%s IPs", ipType)
+		}
+		groups = append(groups, IPGroup{Type: ipType, Title: title, Blocks: infos})
+	}
+
+	return groups, nil
+}
+
+// executeCommand handles the actual command execution
+func (c *IPOverviewCommand) executeCommand() (string, error) {
+	c.log.Debug("Executing ip overview command")
+
+	groups, err := c.Groups()
+	if err != nil {
+		return "", err
+	}
+	if len(groups) == 0 {
+		return "No IP blocks found.", nil
+	}
+
+	output := format.NewOutputFormatter(
+		format.WithMaxWidth(maxWidth),
+		format.WithSeparator("\n"),
+	)
+
+	for _, group := range groups {
+		section := output.AddSection(group.Title)
+		section.SetConfig(format.SectionConfig{
+			KeyValueSpacing: keyValueSpacing,
+			TitleDecorator:  "=",
+		})
+
+		// Blocks are sorted by service, collect consecutive runs
+		var service string
+		var lines []string
+		for i, block := range group.Blocks {
+			if i > 0 && block.GetRoutedService() != service {
+				section.AddLines(routedServiceLabel(service), lines)
+				lines = nil
+			}
+			service = block.GetRoutedService()
+			lines = append(lines, formatBlockLine(block))
+		}
+		section.AddLines(routedServiceLabel(service), lines)
+	}
+
+	return output.String(), nil
+}
+
+// IPCommand shows a single IP block and the reverse of its addresses
+type IPCommand struct {
+	BaseCommand
+	client *api.Client
+	log    *logger.Logger
+	block  string
+}
+
+// NewIPCommand creates a new IP block command instance
+func NewIPCommand(client *api.Client, block string) *IPCommand {
+	return &IPCommand{
+		BaseCommand: NewBaseCommand(TypeInfo),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "ip"}),
+		block:       block,
+	}
+}
+
+// Execute implements the Command interface
+func (c *IPCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *IPCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *IPCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// executeCommand handles the actual command execution
+func (c *IPCommand) executeCommand() (string, error) {
+	c.log.Debug("Executing ip command", "block", c.block)
+
+	info, err := c.client.GetIPInfo(c.block)
+	if err != nil {
+		return "", fmt.Errorf("failed to get IP block: %w", err)
+	}
+
+	reverses, err := c.client.ListIPReverses(c.block)
+	if err != nil {
+		return "", fmt.Errorf("failed to list reverse DNS: %w", err)
+	}
+	sortAddresses(reverses)
+
+	output := format.NewOutputFormatter(
+		format.WithMaxWidth(maxWidth),
+		format.WithSeparator("\n"),
+	)
+	config := format.SectionConfig{
+		KeyValueSpacing: keyValueSpacing,
+		TitleDecorator:  "=",
+	}
+
+	section := output.AddSection("IP Block")
+	section.SetConfig(config)
+	section.AddField("Block", info.IP)
+	section.AddField("Type", info.GetFormattedType())
+	section.AddField("Routed To", routedServiceLabel(info.GetRoutedService()))
+	section.AddField("Description", info.GetFormattedDescription())
+	section.AddField("Country", strings.ToUpper(info.Country))
+
+	section = output.AddSection("Reverse DNS")
+	section.SetConfig(config)
+	if len(reverses) == 0 {
+		section.AddField("Reverse", "No reverse DNS entries")
+	}
+	for _, ip := range reverses {
+		reverse, err := c.client.GetIPReverse(c.block, ip)
+		if err != nil {
+			c.log.Error("Failed to get reverse", "ip", ip, "error", err)
+			section.AddField(ip, "(unavailable)")
+			continue
+		}
+		section.AddField(ip, reverse.Reverse)
+	}
+
+	return output.String(), nil
+}
+
+// SetReverseCommand sets the reverse DNS of an address
+type SetReverseCommand struct {
+	BaseCommand
+	client *api.Client
+	log    *logger.Logger
+	block  string
+}
+
+// NewSetReverseCommand creates a new set reverse command instance
+func NewSetReverseCommand(client *api.Client, block string) *SetReverseCommand {
+	return &SetReverseCommand{
+		BaseCommand: NewBaseCommand(TypeAction),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "set_reverse"}),
+		block:       block,
+	}
+}
+
+// Execute implements the Command interface
+func (c *SetReverseCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *SetReverseCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *SetReverseCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// NextPrompt implements the InteractiveCommand interface
+func (c *SetReverseCommand) NextPrompt() (*Prompt, error) {
+	if prompt := c.addressPrompt(c.block); prompt != nil {
+		return prompt, nil
+	}
+	ip, _ := c.input("ip")
+
+	reverse, ok := c.input("reverse")
+	if !ok {
+		prompt := &Prompt{Key: "reverse", Label: "Reverse hostname", Kind: PromptText}
+		if current, err := c.client.GetIPReverse(c.block, ip); err == nil {
+			prompt.Default = current.Reverse
+		}
+		return prompt, nil
+	}
+
+	return c.confirmPrompt(fmt.Sprintf("Set reverse of %s to %s?", ip, reverse)), nil
+}
+
+// SetInput implements the InteractiveCommand interface
+func (c *SetReverseCommand) SetInput(key, value string) error {
+	switch key {
+	case "ip":
+		ip, err := addressInBlock(c.block, value)
+		if err != nil {
+			return err
+		}
+		value = ip
+	case "reverse":
+		ip, _ := c.input("ip")
+		reverse, err := validateReverse(ip, value)
+		if err != nil {
+			return err
+		}
+		value = reverse
+	}
+	return c.BaseCommand.SetInput(key, value)
+}
+
+// executeCommand handles the actual command execution
+func (c *SetReverseCommand) executeCommand() (string, error) {
+	ip, hasIP := c.input("ip")
+	reverse, hasReverse := c.input("reverse")
+	if !hasIP || !hasReverse {
+		return "", fmt.Errorf("address and reverse hostname are required")
+	}
+
+	c.log.Info("Setting reverse DNS", "block", c.block, "ip", ip, "reverse", reverse)
+	result, err := c.client.SetIPReverse(c.block, ip, reverse)
+	if err != nil {
+		return "", fmt.Errorf("failed to set reverse DNS: %w", err)
+	}
+
+	return fmt.Sprintf("Reverse DNS of %s set to %s.", result.IPReverse, result.Reverse), nil
+}
+
+// DeleteReverseCommand removes the reverse DNS of an address
+type DeleteReverseCommand struct {
+	BaseCommand
+	client *api.Client
+	log    *logger.Logger
+	block  string
+}
+
+// NewDeleteReverseCommand creates a new delete reverse command instance
+func NewDeleteReverseCommand(client *api.Client, block string) *DeleteReverseCommand {
+	return &DeleteReverseCommand{
+		BaseCommand: NewBaseCommand(TypeAction),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "delete_reverse"}),
+		block:       block,
+	}
+}
+
+// Execute implements the Command interface
+func (c *DeleteReverseCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *DeleteReverseCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *DeleteReverseCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// NextPrompt implements the InteractiveCommand interface
+func (c *DeleteReverseCommand) NextPrompt() (*Prompt, error) {
+	ip, ok := c.input("ip")
+	if !ok {
+		reverses, err := c.client.ListIPReverses(c.block)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list reverse DNS: %w", err)
+		}
+		if len(reverses) == 0 {
+			return nil, fmt.Errorf("no reverse DNS entries in %s", c.block)
+		}
+		sortAddresses(reverses)
+		return &Prompt{Key: "ip", Label: "Address", Kind: PromptChoice, Choices: reverses}, nil
+	}
+
+	return c.confirmPrompt(fmt.Sprintf("Delete reverse DNS of %s?", ip)), nil
+}
+
+// executeCommand handles the actual command execution
+func (c *DeleteReverseCommand) executeCommand() (string, error) {
+	ip, ok := c.input("ip")
+	if !ok {
+		return "", fmt.Errorf("address is required")
+	}
+
+	c.log.Info("Deleting reverse DNS", "block", c.block, "ip", ip)
+	if err := c.client.DeleteIPReverse(c.block, ip); err != nil {
+		return "", fmt.Errorf("failed to delete reverse DNS: %w", err)
+	}
+
+	return fmt.Sprintf("Reverse DNS of %s deleted.", ip), nil
+}
+
+// addressPrompt asks for the "ip" input, an address of block. Single
+// address blocks are filled in without asking.
+func (b *BaseCommand) addressPrompt(block string) *Prompt {
+	if _, ok := b.inputs["ip"]; ok {
+		return nil
+	}
+
+	addresses, listed := blockAddresses(block)
+	switch {
+	case len(addresses) == 1:
+		b.inputs["ip"] = addresses[0]
+		return nil
+	case listed:
+		return &Prompt{Key: "ip", Label: "Address", Kind: PromptChoice, Choices: addresses}
+	default:
+		return &Prompt{Key: "ip", Label: fmt.Sprintf("Address in %s", block), Kind: PromptText}
+	}
+}
+
+// routedServiceLabel returns the service name or a placeholder
+func routedServiceLabel(service string) string {
+	if service == "" {
+		return "Not routed"
+	}
+	return service
+}
+
+// formatBlockLine formats a block with its optional description
+func formatBlockLine(info *api.IPInfo) string {
+	if info.Description != "" {
+		return fmt.Sprintf("%s (%s)", info.IP, info.Description)
+	}
+	return info.IP
+}
+
+// blockAddresses lists the addresses of a block. It reports false when
+// the block is too large to list.
+func blockAddresses(block string) ([]string, bool) {
+	prefix, err := parseBlock(block)
+	if err != nil {
+		return nil, false
+	}
+
+	hostBits := prefix.Addr().BitLen() - prefix.Bits()
+	if hostBits >= 31 || 1<<hostBits > maxListedAddresses {
+		return nil, false
+	}
+
+	var addresses []string
+	for addr := prefix.Addr(); prefix.Contains(addr); addr = addr.Next() {
+		addresses = append(addresses, addr.String())
+	}
+	return addresses, true
+}
+
+// addressInBlock parses ip and checks that it belongs to block
+func addressInBlock(block, ip string) (string, error) {
+	prefix, err := parseBlock(block)
+	if err != nil {
+		return "", err
+	}
+	addr, err := netip.ParseAddr(strings.TrimSpace(ip))
+	if err != nil {
+		return "", fmt.Errorf("invalid IP address %q", ip)
+	}
+	if !prefix.Contains(addr) {
+		return "", fmt.Errorf("%s is not part of %s", addr, block)
+	}
+	return addr.String(), nil
+}
+
+// parseBlock parses a block, a bare address is a single address block
+func parseBlock(block string) (netip.Prefix, error) {
+	if !strings.Contains(block, "/") {
+		addr, err := netip.ParseAddr(block)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("invalid IP block %q", block)
+		}
+		return netip.PrefixFrom(addr, addr.BitLen()), nil
+	}
+
+	prefix, err := netip.ParsePrefix(block)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid IP block %q", block)
+	}
+	return prefix.Masked(), nil
+}
+
+// sortAddresses orders addresses numerically
+func sortAddresses(addresses []string) {
+	sort.Slice(addresses, func(i, j int) bool {
+		a, errA := netip.ParseAddr(addresses[i])
+		b, errB := netip.ParseAddr(addresses[j])
+		if errA != nil || errB != nil {
+			return addresses[i] < addresses[j]
+		}
+		return a.Less(b)
+	})
+}
+
+// validateReverse normalizes a reverse hostname and checks that its
+// forward record points back to ip, which OVH requires
+func validateReverse(ip, hostname string) (string, error) {
+	hostname = strings.ToLower(strings.TrimSpace(hostname))
+	if err := validateHostname(strings.TrimSuffix(hostname, ".")); err != nil {
+		return "", err
+	}
+	if err := checkForwardRecord(ip, hostname); err != nil {
+		return "", err
+	}
+	if !strings.HasSuffix(hostname, ".") {
+		hostname += "."
+	}
+	return hostname, nil
+}
+
+// validateHostname checks the syntax of a fully qualified hostname
+func validateHostname(hostname string) error {
+	if hostname == "" {
+		return fmt.Errorf("hostname is required")
+	}
+	if len(hostname) > 253 {
+		return fmt.Errorf("hostname is too long")
+	}
+
+	labels := strings.Split(hostname, ".")
+	if len(labels) < 2 {
+		return fmt.Errorf("%q is not a fully qualified hostname", hostname)
+	}
+	for _, label := range labels {
+		if label == "" || len(label) > 63 {
+			return fmt.Errorf("invalid label %q in hostname", label)
+		}
+		if strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return fmt.Errorf("label %q cannot start or end with a hyphen", label)
+		}
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-') {
+				return fmt.Errorf("invalid character %q in hostname", r)
+			}
+		}
+	}
+	return nil
+}
+
+// checkForwardRecord verifies that hostname resolves to ip
+func checkForwardRecord(ip, hostname string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), forwardLookupTimeout)
+	defer cancel()
+
+	host := strings.TrimSuffix(hostname, ".")
+	addrs, err := lookupHost(ctx, host)
+	if err != nil {
+		return fmt.Errorf("%s does not resolve, create its A/AAAA record first: %w", host, err)
+	}
+
+	want, err := netip.ParseAddr(ip)
+	if err != nil {
+		return fmt.Errorf("invalid IP address %q", ip)
+	}
+	for _, addr := range addrs {
+		if parsed, err := netip.ParseAddr(addr); err == nil && parsed.Unmap() == want.Unmap() {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s resolves to %s, not %s", host, strings.Join(addrs, ", "), ip)
+}
@@ -0,0 +1,196 @@
+// internal/commands/ip_move.go
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"ovh-terminal/internal/api"
+	"ovh-terminal/internal/logger"
+)
+
+// moveTarget is an eligible destination offered by MoveIPCommand
+type moveTarget struct {
+	label       string
+	destination api.IPDestination
+}
+
+// MoveIPCommand routes a failover IP block to another service
+type MoveIPCommand struct {
+	BaseCommand
+	client  *api.Client
+	log     *logger.Logger
+	block   string
+	info    *api.IPInfo
+	targets []moveTarget
+}
+
+// NewMoveIPCommand creates a new failover IP move command instance
+func NewMoveIPCommand(client *api.Client, block string) *MoveIPCommand {
+	return &MoveIPCommand{
+		BaseCommand: NewBaseCommand(TypeAction, WithTimeout(taskTimeout)),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "move_ip"}),
+		block:       block,
+	}
+}
+
+// Execute implements the Command interface
+func (c *MoveIPCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *MoveIPCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeTask(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *MoveIPCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(func() (string, error) {
+		return c.executeTask(ctx, c.executeCommand)
+	})
+}
+
+// NextPrompt implements the InteractiveCommand interface
+func (c *MoveIPCommand) NextPrompt() (*Prompt, error) {
+	if err := c.loadTargets(); err != nil {
+		return nil, err
+	}
+
+	to, ok := c.input("to")
+	if !ok {
+		labels := make([]string, len(c.targets))
+		for i, target := range c.targets {
+			labels[i] = target.label
+		}
+		return &Prompt{Key: "to", Label: "Destination", Kind: PromptChoice, Choices: labels}, nil
+	}
+
+	target := c.target(to)
+	if _, ok := c.input("nexthop"); !ok && len(target.destination.Nexthop) > 1 {
+		return &Prompt{
+			Key:     "nexthop",
+			Label:   "Next hop",
+			Kind:    PromptChoice,
+			Choices: target.destination.Nexthop,
+		}, nil
+	}
+
+	return c.confirmPrompt(fmt.Sprintf("Move %s from %s to %s?",
+		c.block, routedServiceLabel(c.info.GetRoutedService()), to)), nil
+}
+
+// SetInput implements the InteractiveCommand interface
+func (c *MoveIPCommand) SetInput(key, value string) error {
+	if key == "to" {
+		if err := c.loadTargets(); err != nil {
+			return err
+		}
+
+		// Accept both the picker label and a bare service name
+		for _, target := range c.targets {
+			if value == target.label || value == target.destination.Service {
+				return c.BaseCommand.SetInput(key, target.destination.Service)
+			}
+		}
+		return fmt.Errorf("%s is not an eligible destination for %s", value, c.block)
+	}
+	return c.BaseCommand.SetInput(key, value)
+}
+
+// loadTargets fetches the block and its eligible destinations once
+func (c *MoveIPCommand) loadTargets() error {
+	if c.info != nil {
+		return nil
+	}
+
+	info, err := c.client.GetIPInfo(c.block)
+	if err != nil {
+		return fmt.Errorf("failed to get IP block: %w", err)
+	}
+	if !info.IsFailover() {
+		return fmt.Errorf("only failover IPs can be moved, %s is a %s", c.block, info.GetFormattedType())
+	}
+
+	destinations, err := c.client.GetIPMoveDestinations(c.block)
+	if err != nil {
+		return err
+	}
+
+	var targets []moveTarget
+	add := func(kind string, list []api.IPDestination) {
+		for _, destination := range list {
+			targets = append(targets, moveTarget{
+				label:       fmt.Sprintf("%s (%s)", destination.Service, kind),
+				destination: destination,
+			})
+		}
+	}
+	add("dedicated server", destinations.DedicatedServer)
+	add("VPS", destinations.VPS)
+	add("cloud project", destinations.CloudProject)
+	add("dedicated cloud", destinations.DedicatedCloud)
+	add("load balancer", destinations.IPLoadbalancing)
+
+	if len(targets) == 0 {
+		return fmt.Errorf("no eligible destinations for %s", c.block)
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i].label < targets[j].label })
+
+	c.info = info
+	c.targets = targets
+	return nil
+}
+
+// target returns the destination of a service
+func (c *MoveIPCommand) target(service string) moveTarget {
+	for _, target := range c.targets {
+		if target.destination.Service == service {
+			return target
+		}
+	}
+	return moveTarget{label: service, destination: api.IPDestination{Service: service}}
+}
+
+// executeCommand handles the actual command execution
+func (c *MoveIPCommand) executeCommand() (string, error) {
+	to, ok := c.input("to")
+	if !ok {
+		return "", fmt.Errorf("destination is required")
+	}
+
+	nexthop, _ := c.input("nexthop")
+	if nexthop == "" {
+		if hops := c.target(to).destination.Nexthop; len(hops) == 1 {
+			nexthop = hops[0]
+		}
+	}
+
+	c.log.Info("Moving IP block", "block", c.block, "to", to, "nexthop", nexthop)
+	task, err := c.client.MoveIP(c.block, to, nexthop)
+	if err != nil {
+		return "", err
+	}
+
+	err = c.pollTask(fmt.Sprintf("Moving %s", c.block), func() (string, bool, error) {
+		current, err := c.client.GetIPTask(c.block, task.TaskID)
+		if err != nil {
+			return "", false, err
+		}
+		if current.IsFailed() {
+			return current.Status, false, fmt.Errorf("move task %d ended with status %s: %s",
+				current.TaskID, current.Status, current.Comment)
+		}
+		return current.Status, current.IsDone(), nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s is now routed to %s.", c.block, to), nil
+}
@@ -0,0 +1,69 @@
+// internal/commands/ip_test.go
+package commands
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestBlockAddresses(t *testing.T) {
+	addresses, listed := blockAddresses("198.51.100.8/29")
+	if !listed || len(addresses) != 8 || addresses[0] != "198.51.100.8" {
+		t.Errorf("Expected 8 addresses from 198.51.100.8, got %v", addresses)
+	}
+
+	if addresses, _ := blockAddresses("203.0.113.10/32"); len(addresses) != 1 {
+		t.Errorf("Expected a single address, got %v", addresses)
+	}
+
+	if _, listed := blockAddresses("2001:db8::/64"); listed {
+		t.Error("Expected IPv6 /64 not to be listed")
+	}
+
+	if _, err := addressInBlock("198.51.100.8/29", "198.51.100.20"); err == nil {
+		t.Error("Expected address outside the block to be rejected")
+	}
+}
+
+func TestValidateReverse(t *testing.T) {
+	previous := lookupHost
+	defer func() { lookupHost = previous }()
+
+	records := map[string][]string{
+		"web1.example.com": {"203.0.113.10"},
+		"old.example.com":  {"192.0.2.1"},
+	}
+	lookupHost = func(ctx context.Context, host string) ([]string, error) {
+		if addrs, ok := records[host]; ok {
+			return addrs, nil
+		}
+		return nil, fmt.Errorf("no such host")
+	}
+
+	tests := []struct {
+		name     string
+		hostname string
+		want     string
+		wantErr  bool
+	}{
+		{"matching forward record", "Web1.Example.com", "web1.example.com.", false},
+		{"trailing dot kept", "web1.example.com.", "web1.example.com.", false},
+		{"forward record mismatch", "old.example.com", "", true},
+		{"unresolvable", "missing.example.com", "", true},
+		{"invalid hostname", "web_1.example.com", "", true},
+		{"not qualified", "localhost", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validateReverse("203.0.113.10", tt.hostname)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateReverse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("validateReverse() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
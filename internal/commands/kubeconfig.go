@@ -0,0 +1,139 @@
+// internal/commands/kubeconfig.go
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// kubeconfigFile is the part of a kubeconfig needed to merge files.
+// Fields not listed are kept through the inline maps.
+type kubeconfigFile struct {
+	APIVersion     string                 `yaml:"apiVersion"`
+	Kind           string                 `yaml:"kind"`
+	Clusters       []kubeconfigEntry      `yaml:"clusters"`
+	Contexts       []kubeconfigEntry      `yaml:"contexts"`
+	Users          []kubeconfigEntry      `yaml:"users"`
+	CurrentContext string                 `yaml:"current-context"`
+	Extra          map[string]interface{} `yaml:",inline"`
+}
+
+// kubeconfigEntry is a named cluster, context or user
+type kubeconfigEntry struct {
+	Name  string                 `yaml:"name"`
+	Extra map[string]interface{} `yaml:",inline"`
+}
+
+// MergeKubeconfig adds the clusters, contexts and users of incoming to
+// existing, replacing entries with the same name. The current context is
+// only set when existing has none. It returns the merged file and the
+// names of the added contexts.
+func MergeKubeconfig(existing, incoming []byte) ([]byte, []string, error) {
+	var add kubeconfigFile
+	if err := yaml.Unmarshal(incoming, &add); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+	contexts := make([]string, len(add.Contexts))
+	for i, entry := range add.Contexts {
+		contexts[i] = entry.Name
+	}
+
+	var base kubeconfigFile
+	if err := yaml.Unmarshal(existing, &base); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse existing kubeconfig: %w", err)
+	}
+	if base.APIVersion == "" {
+		base.APIVersion = "v1"
+	}
+	if base.Kind == "" {
+		base.Kind = "Config"
+	}
+
+	base.Clusters = mergeKubeconfigEntries(base.Clusters, add.Clusters)
+	base.Contexts = mergeKubeconfigEntries(base.Contexts, add.Contexts)
+	base.Users = mergeKubeconfigEntries(base.Users, add.Users)
+	if base.CurrentContext == "" {
+		base.CurrentContext = add.CurrentContext
+	}
+
+	data, err := yaml.Marshal(&base)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode kubeconfig: %w", err)
+	}
+	return data, contexts, nil
+}
+
+// mergeKubeconfigEntries replaces entries by name and appends new ones
+func mergeKubeconfigEntries(base, add []kubeconfigEntry) []kubeconfigEntry {
+	for _, entry := range add {
+		replaced := false
+		for i := range base {
+			if base[i].Name == entry.Name {
+				base[i] = entry
+				replaced = true
+			}
+		}
+		if !replaced {
+			base = append(base, entry)
+		}
+	}
+	return base
+}
+
+// writePrivateFile writes data readable by the owner only, through a
+// temporary file so that an existing file is never left half written
+func writePrivateFile(path string, data []byte) error {
+	return streamPrivateFile(path, func(w io.Writer) error {
+		if _, err := w.Write(data); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		return nil
+	})
+}
+
+// streamPrivateFile is writePrivateFile for content written by fn, such
+// as a download too large to hold in memory. Errors of fn are returned as
+// is and leave any existing file untouched.
+func streamPrivateFile(path string, fn func(w io.Writer) error) error {
+	return streamFile(path, 0o600, fn)
+}
+
+// streamFile writes the content of fn through a temporary file renamed
+// over path, and creates missing directories. Files readable by the owner
+// only get private directories too.
+func streamFile(path string, perm os.FileMode, fn func(w io.Writer) error) error {
+	dir := filepath.Dir(path)
+	dirPerm := os.FileMode(0o755)
+	if perm&0o077 == 0 {
+		dirPerm = 0o700
+	}
+	if err := os.MkdirAll(dir, dirPerm); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to set permissions: %w", err)
+	}
+	if err := fn(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
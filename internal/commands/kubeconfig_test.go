@@ -0,0 +1,90 @@
+// internal/commands/kubeconfig_test.go
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestMergeKubeconfig(t *testing.T) {
+	existing := `apiVersion: v1
+kind: Config
+current-context: minikube
+preferences:
+  colors: true
+clusters:
+- name: minikube
+  cluster:
+    server: https://192.168.49.2:8443
+- name: prod
+  cluster:
+    server: https://old.example.net
+contexts:
+- name: minikube
+  context:
+    cluster: minikube
+    user: minikube
+users:
+- name: minikube
+  user:
+    token: secret
+`
+	incoming := `apiVersion: v1
+kind: Config
+current-context: admin@prod
+clusters:
+- name: prod
+  cluster:
+    server: https://new.example.net
+contexts:
+- name: admin@prod
+  context:
+    cluster: prod
+    user: admin-prod
+users:
+- name: admin-prod
+  user:
+    client-key-data: a2V5
+`
+
+	merged, contexts, err := MergeKubeconfig([]byte(existing), []byte(incoming))
+	if err != nil {
+		t.Fatalf("MergeKubeconfig failed: %v", err)
+	}
+	if strings.Join(contexts, ",") != "admin@prod" {
+		t.Errorf("Expected added context admin@prod, got %v", contexts)
+	}
+
+	var result kubeconfigFile
+	if err := yaml.Unmarshal(merged, &result); err != nil {
+		t.Fatalf("Merged kubeconfig is invalid: %v", err)
+	}
+	if result.CurrentContext != "minikube" {
+		t.Errorf("Expected current context to be kept, got %q", result.CurrentContext)
+	}
+	if len(result.Clusters) != 2 || len(result.Contexts) != 2 || len(result.Users) != 2 {
+		t.Errorf("Expected 2 clusters, contexts and users, got %d, %d, %d",
+			len(result.Clusters), len(result.Contexts), len(result.Users))
+	}
+	if !strings.Contains(string(merged), "https://new.example.net") ||
+		strings.Contains(string(merged), "https://old.example.net") {
+		t.Errorf("Expected cluster prod to be replaced, got:\n%s", merged)
+	}
+	if !strings.Contains(string(merged), "colors: true") {
+		t.Errorf("Expected preferences to be kept, got:\n%s", merged)
+	}
+
+	merged, _, err = MergeKubeconfig(nil, []byte(incoming))
+	if err != nil {
+		t.Fatalf("MergeKubeconfig into an empty file failed: %v", err)
+	}
+	if err := yaml.Unmarshal(merged, &result); err != nil || result.CurrentContext != "admin@prod" {
+		t.Errorf("Expected the new context to become current, got %q (err %v)", result.CurrentContext, err)
+	}
+
+	if _, _, err := MergeKubeconfig(nil, []byte("clusters: {")); err == nil {
+		t.Error("Expected invalid kubeconfig to be rejected")
+	}
+}
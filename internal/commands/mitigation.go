@@ -0,0 +1,437 @@
+// internal/commands/mitigation.go
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"ovh-terminal/internal/api"
+	"ovh-terminal/internal/format"
+	"ovh-terminal/internal/logger"
+)
+
+// Kinds of address blocks applied by OVH
+const (
+	blockKindAntihack = "anti-hack"
+	blockKindSpam     = "spam"
+)
+
+// blockedAddress is an address blocked for hacking or spam
+type blockedAddress struct {
+	ip    string
+	kind  string
+	state string
+	// wait is how long until an unblock can be requested
+	wait time.Duration
+	date *time.Time
+	logs string
+}
+
+// blocked reports if the address is still blocked
+func (b blockedAddress) blocked() bool {
+	return b.state == api.IPBlockedStateBlocked || b.state == api.IPSpamStateBlocked
+}
+
+// unblockable reports if an unblock can be requested now
+func (b blockedAddress) unblockable() bool {
+	return b.blocked() && b.wait <= 0
+}
+
+// label identifies the address in pickers
+func (b blockedAddress) label() string {
+	return fmt.Sprintf("%s (%s)", b.ip, b.kind)
+}
+
+// status describes the block and what can be done about it
+func (b blockedAddress) status() string {
+	switch {
+	case b.unblockable():
+		return fmt.Sprintf("%s, unblock available", b.state)
+	case b.blocked():
+		return fmt.Sprintf("%s, unblock possible in %s", b.state, format.Duration(b.wait))
+	default:
+		return b.state
+	}
+}
+
+// loadBlockedAddresses fetches the anti-hack and spam blocks of a block
+func loadBlockedAddresses(client *api.Client, block string) ([]blockedAddress, error) {
+	var blocked []blockedAddress
+
+	ips, err := client.ListAntihack(block, "")
+	if err != nil {
+		return nil, err
+	}
+	sortAddresses(ips)
+	for _, ip := range ips {
+		entry, err := client.GetAntihack(block, ip)
+		if err != nil {
+			return nil, err
+		}
+		blocked = append(blocked, blockedAddress{
+			ip:    entry.IPBlocked,
+			kind:  blockKindAntihack,
+			state: entry.State,
+			wait:  time.Duration(entry.Time) * time.Second,
+			logs:  entry.Logs,
+		})
+	}
+
+	ips, err = client.ListSpam(block, "")
+	if err != nil {
+		return nil, err
+	}
+	sortAddresses(ips)
+	for _, ip := range ips {
+		entry, err := client.GetSpam(block, ip)
+		if err != nil {
+			return nil, err
+		}
+		blocked = append(blocked, blockedAddress{
+			ip:    entry.IPSpamming,
+			kind:  blockKindSpam,
+			state: entry.State,
+			wait:  time.Duration(entry.Time) * time.Second,
+			date:  entry.Date,
+		})
+	}
+
+	return blocked, nil
+}
+
+// MitigationCommand shows the anti-DDoS mitigation of an IP block
+type MitigationCommand struct {
+	BaseCommand
+	client *api.Client
+	log    *logger.Logger
+	block  string
+}
+
+// NewMitigationCommand creates a new mitigation command instance
+func NewMitigationCommand(client *api.Client, block string) *MitigationCommand {
+	return &MitigationCommand{
+		BaseCommand: NewBaseCommand(TypeInfo),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "mitigation"}),
+		block:       block,
+	}
+}
+
+// Execute implements the Command interface
+func (c *MitigationCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *MitigationCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *MitigationCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// executeCommand handles the actual command execution
+func (c *MitigationCommand) executeCommand() (string, error) {
+	c.log.Debug("Executing mitigation command", "block", c.block)
+
+	mitigations, err := c.client.ListIPMitigations(c.block)
+	if err != nil {
+		return "", err
+	}
+	sortAddresses(mitigations)
+
+	profiles, err := c.client.ListMitigationProfiles(c.block)
+	if err != nil {
+		return "", err
+	}
+	sortAddresses(profiles)
+
+	output := format.NewOutputFormatter(
+		format.WithMaxWidth(maxWidth),
+		format.WithSeparator("\n"),
+	)
+	config := format.SectionConfig{
+		KeyValueSpacing: keyValueSpacing,
+		TitleDecorator:  "=",
+	}
+
+	section := output.AddSection("DDoS Mitigation")
+	section.SetConfig(config)
+	section.AddField("Block", c.block)
+	if len(mitigations) == 0 {
+		section.AddField("Status", "Automatic, no mitigation in progress")
+	}
+	for _, ip := range mitigations {
+		mitigation, err := c.client.GetIPMitigation(c.block, ip)
+		if err != nil {
+			c.log.Error("Failed to get mitigation", "ip", ip, "error", err)
+			section.AddField(ip, "(unavailable)")
+			continue
+		}
+		section.AddField(ip, formatMitigation(mitigation))
+	}
+
+	section = output.AddSection("Mitigation Profiles")
+	section.SetConfig(config)
+	if len(profiles) == 0 {
+		section.AddField("Profiles", "Default profile")
+	}
+	for _, ip := range profiles {
+		profile, err := c.client.GetMitigationProfile(c.block, ip)
+		if err != nil {
+			c.log.Error("Failed to get mitigation profile", "ip", ip, "error", err)
+			section.AddField(ip, "(unavailable)")
+			continue
+		}
+		section.AddField(ip, formatMitigationProfile(profile))
+	}
+
+	return output.String(), nil
+}
+
+// formatMitigation describes whether mitigation is forced or automatic
+func formatMitigation(m *api.IPMitigation) string {
+	var mode string
+	switch {
+	case m.Permanent:
+		mode = "Permanent (forced)"
+	case m.Auto:
+		mode = "Automatic (attack detected)"
+	default:
+		mode = "Inactive"
+	}
+	if m.State != "" && m.State != api.IPMitigationStateOK {
+		mode = fmt.Sprintf("%s, %s", mode, m.State)
+	}
+	return mode
+}
+
+// formatMitigationProfile describes how long auto mitigation lasts
+func formatMitigationProfile(p *api.IPMitigationProfile) string {
+	value := "Auto mitigation ends as soon as the attack stops"
+	if p.AutoMitigationTimeOut > 0 {
+		value = fmt.Sprintf("Auto mitigation lasts %s after an attack",
+			format.Duration(time.Duration(p.AutoMitigationTimeOut)*time.Minute))
+	}
+	if p.State != "" && p.State != api.IPMitigationStateOK {
+		value = fmt.Sprintf("%s (%s)", value, p.State)
+	}
+	return value
+}
+
+// AttackHistoryCommand shows addresses of a block blocked for hacking or spam
+type AttackHistoryCommand struct {
+	BaseCommand
+	client *api.Client
+	log    *logger.Logger
+	block  string
+}
+
+// NewAttackHistoryCommand creates a new attack history command instance
+func NewAttackHistoryCommand(client *api.Client, block string) *AttackHistoryCommand {
+	return &AttackHistoryCommand{
+		BaseCommand: NewBaseCommand(TypeInfo),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "attack_history"}),
+		block:       block,
+	}
+}
+
+// Execute implements the Command interface
+func (c *AttackHistoryCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *AttackHistoryCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *AttackHistoryCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// executeCommand handles the actual command execution
+func (c *AttackHistoryCommand) executeCommand() (string, error) {
+	c.log.Debug("Executing attack history command", "block", c.block)
+
+	blocked, err := loadBlockedAddresses(c.client, c.block)
+	if err != nil {
+		return "", err
+	}
+
+	output := format.NewOutputFormatter(
+		format.WithMaxWidth(maxWidth),
+		format.WithSeparator("\n"),
+	)
+	config := format.SectionConfig{
+		KeyValueSpacing: keyValueSpacing,
+		TitleDecorator:  "=",
+	}
+
+	for _, kind := range []string{blockKindAntihack, blockKindSpam} {
+		title := "Anti-hack"
+		if kind == blockKindSpam {
+			title = "Spam"
+		}
+		section := output.AddSection(title)
+		section.SetConfig(config)
+
+		count := 0
+		for _, entry := range blocked {
+			if entry.kind != kind {
+				continue
+			}
+			count++
+			lines := []string{entry.status()}
+			if entry.date != nil {
+				lines = append(lines, "Since "+format.DateTime(entry.date))
+			}
+			if logs := strings.TrimSpace(entry.logs); logs != "" {
+				lines = append(lines, strings.Split(logs, "\n")...)
+			}
+			section.AddLines(entry.ip, lines)
+		}
+		if count == 0 {
+			section.AddField("Blocked", "No blocked addresses")
+		}
+	}
+
+	return output.String(), nil
+}
+
+// UnblockIPCommand requests an anti-hack or spam block to be lifted
+type UnblockIPCommand struct {
+	BaseCommand
+	client  *api.Client
+	log     *logger.Logger
+	block   string
+	blocked []blockedAddress
+}
+
+// NewUnblockIPCommand creates a new unblock command instance
+func NewUnblockIPCommand(client *api.Client, block string) *UnblockIPCommand {
+	return &UnblockIPCommand{
+		BaseCommand: NewBaseCommand(TypeAction),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "unblock_ip"}),
+		block:       block,
+	}
+}
+
+// Execute implements the Command interface
+func (c *UnblockIPCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *UnblockIPCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *UnblockIPCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// NextPrompt implements the InteractiveCommand interface
+func (c *UnblockIPCommand) NextPrompt() (*Prompt, error) {
+	address, ok := c.input("address")
+	if !ok {
+		if err := c.loadBlocked(); err != nil {
+			return nil, err
+		}
+		var labels []string
+		for _, entry := range c.blocked {
+			if entry.unblockable() {
+				labels = append(labels, entry.label())
+			}
+		}
+		if len(labels) == 0 {
+			return nil, fmt.Errorf("no address of %s can be unblocked right now", c.block)
+		}
+		return &Prompt{Key: "address", Label: "Address", Kind: PromptChoice, Choices: labels}, nil
+	}
+
+	return c.confirmPrompt(fmt.Sprintf("Request unblocking of %s?", address)), nil
+}
+
+// SetInput implements the InteractiveCommand interface
+func (c *UnblockIPCommand) SetInput(key, value string) error {
+	if key == "address" {
+		if err := c.loadBlocked(); err != nil {
+			return err
+		}
+		entry, ok := c.find(value)
+		if !ok {
+			return fmt.Errorf("%s is not blocked", value)
+		}
+		if !entry.unblockable() {
+			return fmt.Errorf("%s cannot be unblocked: %s", value, entry.status())
+		}
+		value = entry.label()
+	}
+	return c.BaseCommand.SetInput(key, value)
+}
+
+// loadBlocked fetches the blocked addresses once
+func (c *UnblockIPCommand) loadBlocked() error {
+	if c.blocked != nil {
+		return nil
+	}
+	blocked, err := loadBlockedAddresses(c.client, c.block)
+	if err != nil {
+		return err
+	}
+	c.blocked = blocked
+	return nil
+}
+
+// find looks up a blocked address by label or bare address
+func (c *UnblockIPCommand) find(value string) (blockedAddress, bool) {
+	for _, entry := range c.blocked {
+		if value == entry.label() || value == entry.ip {
+			return entry, true
+		}
+	}
+	return blockedAddress{}, false
+}
+
+// executeCommand handles the actual command execution
+func (c *UnblockIPCommand) executeCommand() (string, error) {
+	address, ok := c.input("address")
+	if !ok {
+		return "", fmt.Errorf("address is required")
+	}
+	entry, ok := c.find(address)
+	if !ok {
+		return "", fmt.Errorf("%s is not blocked", address)
+	}
+
+	c.log.Info("Unblocking address", "block", c.block, "ip", entry.ip, "kind", entry.kind)
+	var err error
+	if entry.kind == blockKindSpam {
+		_, err = c.client.UnblockSpam(c.block, entry.ip)
+	} else {
+		err = c.client.UnblockAntihack(c.block, entry.ip)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Unblocking of %s requested.", entry.label()), nil
+}
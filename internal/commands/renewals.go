@@ -0,0 +1,479 @@
+// internal/commands/renewals.go
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"ovh-terminal/internal/api"
+	"ovh-terminal/internal/format"
+	"ovh-terminal/internal/logger"
+)
+
+// Renewal dashboard orders
+const (
+	RenewalsByDate = "date"
+	RenewalsByType = "type"
+)
+
+// renewalWarningDays is how close an expiration is flagged
+const renewalWarningDays = 30
+
+// renewalKind is a type of service with a subscription
+type renewalKind struct {
+	key      string
+	title    string
+	resource api.ResourceType
+	list     func(*api.Client) ([]string, error)
+}
+
+// renewalKinds are the services shown on the renewal dashboard, in
+// display order
+var renewalKinds = []renewalKind{
+	{key: "server", title: "Dedicated server", resource: api.ResourceServer, list: (*api.Client).ListDedicatedServers},
+	{key: "vps", title: "VPS", resource: api.ResourceVPS, list: (*api.Client).ListVPS},
+	{key: "domain", title: "Domain", resource: api.ResourceDomain, list: (*api.Client).ListDomains},
+	{key: "hosting", title: "Hosting plan", resource: api.ResourceHosting, list: (*api.Client).ListHostings},
+}
+
+// findRenewalKind returns the service type with key
+func findRenewalKind(key string) (renewalKind, error) {
+	for _, kind := range renewalKinds {
+		if kind.key == key {
+			return kind, nil
+		}
+	}
+	return renewalKind{}, fmt.Errorf("unknown service type %q", key)
+}
+
+// RenewalID identifies a service on the renewal dashboard
+func RenewalID(kind, name string) string {
+	return kind + "/" + name
+}
+
+// Renewal is the subscription of a service
+type Renewal struct {
+	Kind  string
+	Type  string
+	Name  string
+	Infos *api.ServiceInfos
+}
+
+// ID identifies the service, see RenewalID
+func (r Renewal) ID() string {
+	return RenewalID(r.Kind, r.Name)
+}
+
+// Mode describes how the service renews
+func (r Renewal) Mode() string {
+	renew := r.Infos.Renew
+	switch {
+	case renew == nil:
+		return "manual"
+	case renew.DeleteAtExpiration:
+		return "delete at expiration"
+	case renew.Automatic && renew.Period == 1:
+		return "automatic, every month"
+	case renew.Automatic && renew.Period > 0:
+		return fmt.Sprintf("automatic, every %d months", renew.Period)
+	case renew.Automatic:
+		return "automatic"
+	}
+	return "manual"
+}
+
+// Summary describes the renewal on one line, for menus
+func (r Renewal) Summary(now time.Time) string {
+	return strings.Join([]string{r.Type, r.Expires(now), r.Mode()}, " · ")
+}
+
+// Expires describes the expiration date relative to now
+func (r Renewal) Expires(now time.Time) string {
+	date, ok := r.Infos.ExpirationDate()
+	if !ok {
+		return "no expiration"
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	days := int(date.Sub(today).Hours() / 24)
+	var relative string
+	switch {
+	case days < 0:
+		relative = fmt.Sprintf("expired %d days ago", -days)
+	case days == 0:
+		relative = "today"
+	case days == 1:
+		relative = "tomorrow"
+	default:
+		relative = fmt.Sprintf("in %d days", days)
+	}
+	if days >= 0 && days <= renewalWarningDays && !r.autoRenews() {
+		relative = "⚠ " + relative
+	}
+	return fmt.Sprintf("%s (%s)", date.Format(format.DateLayout), relative)
+}
+
+// autoRenews reports whether the service renews without action
+func (r Renewal) autoRenews() bool {
+	renew := r.Infos.Renew
+	return renew != nil && renew.Automatic && !renew.DeleteAtExpiration
+}
+
+// LoadRenewals fetches the subscriptions of all services. Service types
+// that cannot be listed are returned as unavailable instead of failing.
+func LoadRenewals(client *api.Client, log *logger.Logger) ([]Renewal, []string) {
+	var renewals []Renewal
+	var unavailable []string
+	for _, kind := range renewalKinds {
+		names, err := kind.list(client)
+		if err != nil {
+			log.Error("Failed to list services", "type", kind.key, "error", err)
+			unavailable = append(unavailable, kind.title)
+			continue
+		}
+		for _, name := range names {
+			infos, err := client.GetServiceInfos(kind.resource, name)
+			if err != nil {
+				log.Error("Failed to get service infos", "type", kind.key, "service", name, "error", err)
+				unavailable = append(unavailable, fmt.Sprintf("%s %s", kind.title, name))
+				continue
+			}
+			renewals = append(renewals, Renewal{Kind: kind.key, Type: kind.title, Name: name, Infos: infos})
+		}
+	}
+	return renewals, unavailable
+}
+
+// SortRenewals orders renewals by expiration date, soonest first, or by
+// service type then date. Services without expiration come last.
+func SortRenewals(renewals []Renewal, order string) {
+	typeRank := make(map[string]int, len(renewalKinds))
+	for i, kind := range renewalKinds {
+		typeRank[kind.key] = i
+	}
+
+	sort.SliceStable(renewals, func(i, j int) bool {
+		a, b := renewals[i], renewals[j]
+		if order == RenewalsByType && a.Kind != b.Kind {
+			return typeRank[a.Kind] < typeRank[b.Kind]
+		}
+		da, okA := a.Infos.ExpirationDate()
+		db, okB := b.Infos.ExpirationDate()
+		if okA != okB {
+			return okA
+		}
+		if !da.Equal(db) {
+			return da.Before(db)
+		}
+		return a.Name < b.Name
+	})
+}
+
+// RenewalsCommand shows the expiration and renewal mode of every service
+type RenewalsCommand struct {
+	BaseCommand
+	client *api.Client
+	log    *logger.Logger
+	order  string
+}
+
+// NewRenewalsCommand creates a new renewal dashboard command instance
+func NewRenewalsCommand(client *api.Client, order string) *RenewalsCommand {
+	return &RenewalsCommand{
+		BaseCommand: NewBaseCommand(TypeInfo),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "renewals"}),
+		order:       order,
+	}
+}
+
+// Execute implements the Command interface
+func (c *RenewalsCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *RenewalsCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *RenewalsCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// Renewals returns the subscriptions of all services in the command order
+func (c *RenewalsCommand) Renewals() ([]Renewal, []string) {
+	renewals, unavailable := LoadRenewals(c.client, c.log)
+	SortRenewals(renewals, c.order)
+	return renewals, unavailable
+}
+
+// executeCommand handles the actual command execution
+func (c *RenewalsCommand) executeCommand() (string, error) {
+	c.log.Debug("Executing renewals command", "order", c.order)
+
+	renewals, unavailable := c.Renewals()
+	now := time.Now()
+
+	output := format.NewOutputFormatter(
+		format.WithMaxWidth(maxWidth),
+		format.WithSeparator("\n"),
+	)
+	config := format.SectionConfig{
+		KeyValueSpacing: keyValueSpacing,
+		TitleDecorator:  "=",
+	}
+
+	soon, manual := 0, 0
+	for _, renewal := range renewals {
+		if !renewal.autoRenews() {
+			manual++
+			if date, ok := renewal.Infos.ExpirationDate(); ok &&
+				date.Before(now.AddDate(0, 0, renewalWarningDays+1)) {
+				soon++
+			}
+		}
+	}
+
+	section := output.AddSection("Service Renewals")
+	section.SetConfig(config)
+	section.AddField("Services", fmt.Sprintf("%d", len(renewals)))
+	section.AddField("Not renewed", fmt.Sprintf("%d, %d expiring within %d days",
+		manual, soon, renewalWarningDays))
+	if len(unavailable) > 0 {
+		section.AddLines("Unavailable", unavailable)
+	}
+
+	var list *format.Section
+	group := ""
+	for _, renewal := range renewals {
+		heading, mode := "By Expiration Date", renewal.Type+", "+renewal.Mode()
+		if c.order == RenewalsByType {
+			heading, mode = renewal.Type, renewal.Mode()
+		}
+		if list == nil || heading != group {
+			group = heading
+			list = output.AddSection(heading)
+			list.SetConfig(config)
+		}
+
+		lines := []string{renewal.Expires(now), mode}
+		if engaged, ok := renewal.Infos.EngagementDate(); ok {
+			lines = append(lines, "engaged until "+engaged.Format(format.DateLayout))
+		}
+		list.AddLines(renewal.Name, lines)
+	}
+
+	return output.String(), nil
+}
+
+// ServiceRenewalCommand shows the subscription of a single service
+type ServiceRenewalCommand struct {
+	BaseCommand
+	client *api.Client
+	log    *logger.Logger
+	kind   string
+	name   string
+}
+
+// NewServiceRenewalCommand creates a new service renewal command instance
+// for a service of kind, one of the dashboard service types
+func NewServiceRenewalCommand(client *api.Client, kind, name string) *ServiceRenewalCommand {
+	return &ServiceRenewalCommand{
+		BaseCommand: NewBaseCommand(TypeInfo),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "service_renewal"}),
+		kind:        kind,
+		name:        name,
+	}
+}
+
+// Execute implements the Command interface
+func (c *ServiceRenewalCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *ServiceRenewalCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *ServiceRenewalCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// executeCommand handles the actual command execution
+func (c *ServiceRenewalCommand) executeCommand() (string, error) {
+	c.log.Debug("Executing service renewal command", "type", c.kind, "service", c.name)
+
+	kind, err := findRenewalKind(c.kind)
+	if err != nil {
+		return "", err
+	}
+	infos, err := c.client.GetServiceInfos(kind.resource, c.name)
+	if err != nil {
+		return "", err
+	}
+	renewal := Renewal{Kind: kind.key, Type: kind.title, Name: c.name, Infos: infos}
+
+	output := format.NewOutputFormatter(
+		format.WithMaxWidth(maxWidth),
+		format.WithSeparator("\n"),
+	)
+	section := output.AddSection("Service Renewal")
+	section.SetConfig(format.SectionConfig{
+		KeyValueSpacing: keyValueSpacing,
+		TitleDecorator:  "=",
+	})
+	section.AddField("Service", c.name)
+	section.AddField("Type", kind.title)
+	section.AddField("Status", infos.Status)
+	section.AddField("Created", infos.Creation)
+	section.AddField("Expires", renewal.Expires(time.Now()))
+	section.AddField("Renewal", renewal.Mode())
+	if infos.Renew != nil && infos.Renew.Forced {
+		section.AddField("Forced", "automatic renewal cannot be disabled")
+	}
+	if engaged, ok := infos.EngagementDate(); ok {
+		section.AddField("Engaged until", engaged.Format(format.DateLayout))
+	}
+	if len(infos.PossibleRenewPeriod) > 0 {
+		periods := make([]string, len(infos.PossibleRenewPeriod))
+		for i, period := range infos.PossibleRenewPeriod {
+			periods[i] = fmt.Sprintf("%d", period)
+		}
+		section.AddField("Periods", strings.Join(periods, ", ")+" months")
+	}
+
+	return output.String(), nil
+}
+
+// AutoRenewCommand switches a service between automatic and manual renewal
+type AutoRenewCommand struct {
+	BaseCommand
+	client *api.Client
+	log    *logger.Logger
+	kind   string
+	name   string
+	infos  *api.ServiceInfos
+}
+
+// NewAutoRenewCommand creates a new automatic renewal toggle command
+// instance for a service of kind, one of the dashboard service types
+func NewAutoRenewCommand(client *api.Client, kind, name string) *AutoRenewCommand {
+	return &AutoRenewCommand{
+		BaseCommand: NewBaseCommand(TypeAction),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "auto_renew"}),
+		kind:        kind,
+		name:        name,
+	}
+}
+
+// Execute implements the Command interface
+func (c *AutoRenewCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *AutoRenewCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *AutoRenewCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// NextPrompt implements the InteractiveCommand interface
+func (c *AutoRenewCommand) NextPrompt() (*Prompt, error) {
+	infos, err := c.loadInfos()
+	if err != nil {
+		return nil, err
+	}
+
+	expires := infos.Expiration
+	if date, ok := infos.ExpirationDate(); ok {
+		expires = date.Format(format.DateLayout)
+	}
+	if c.enabling() {
+		return c.confirmPrompt(fmt.Sprintf("Enable automatic renewal of %s (expires %s)?",
+			c.name, expires)), nil
+	}
+	return c.confirmPrompt(fmt.Sprintf("Disable automatic renewal of %s? It expires on %s unless renewed manually.",
+		c.name, expires)), nil
+}
+
+// loadInfos fetches the subscription once and checks it can be changed
+func (c *AutoRenewCommand) loadInfos() (*api.ServiceInfos, error) {
+	if c.infos != nil {
+		return c.infos, nil
+	}
+
+	kind, err := findRenewalKind(c.kind)
+	if err != nil {
+		return nil, err
+	}
+	infos, err := c.client.GetServiceInfos(kind.resource, c.name)
+	if err != nil {
+		return nil, err
+	}
+	if infos.Renew != nil && infos.Renew.Forced {
+		return nil, fmt.Errorf("%s always renews automatically", c.name)
+	}
+
+	c.infos = infos
+	return infos, nil
+}
+
+// enabling reports whether the command turns automatic renewal on
+func (c *AutoRenewCommand) enabling() bool {
+	renew := c.infos.Renew
+	return renew == nil || !renew.Automatic || renew.DeleteAtExpiration
+}
+
+// executeCommand handles the actual command execution
+func (c *AutoRenewCommand) executeCommand() (string, error) {
+	infos, err := c.loadInfos()
+	if err != nil {
+		return "", err
+	}
+	kind, err := findRenewalKind(c.kind)
+	if err != nil {
+		return "", err
+	}
+
+	renew := api.ServiceRenew{}
+	if infos.Renew != nil {
+		renew = *infos.Renew
+	}
+	enable := c.enabling()
+	renew.Automatic = enable
+	if enable {
+		renew.DeleteAtExpiration = false
+	}
+
+	c.log.Info("Changing automatic renewal", "type", c.kind, "service", c.name, "automatic", enable)
+	if err := c.client.UpdateServiceRenew(kind.resource, c.name, renew); err != nil {
+		return "", err
+	}
+
+	if enable {
+		return fmt.Sprintf("%s now renews automatically.", c.name), nil
+	}
+	return fmt.Sprintf("%s now needs a manual renewal.", c.name), nil
+}
@@ -0,0 +1,415 @@
+// internal/commands/support.go
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"ovh-terminal/internal/api"
+	"ovh-terminal/internal/format"
+	"ovh-terminal/internal/logger"
+)
+
+// Ticket actions run by SupportTicketActionCommand
+const (
+	TicketReply  = "reply"
+	TicketClose  = "close"
+	TicketReopen = "reopen"
+)
+
+// threadIndent is the indentation of message bodies in a ticket thread
+const threadIndent = "  "
+
+// TicketTitle names a ticket by its number and subject
+func TicketTitle(ticket *api.SupportTicket) string {
+	return fmt.Sprintf("#%d %s", ticket.TicketNumber, ticket.Subject)
+}
+
+// TicketSummary describes a ticket on one line, for menus
+func TicketSummary(ticket *api.SupportTicket) string {
+	parts := []string{ticket.State}
+	if ticket.Product != "" {
+		parts = append(parts, ticket.Product)
+	}
+	parts = append(parts, "updated "+format.Date(ticket.UpdateDate))
+	return strings.Join(parts, " · ")
+}
+
+// messageAuthor names the author of a support message
+func messageAuthor(from string) string {
+	switch from {
+	case api.MessageFromCustomer:
+		return "You"
+	case api.MessageFromSupport:
+		return "OVHcloud support"
+	}
+	return from
+}
+
+// SupportTicketsCommand lists the support tickets of the account
+type SupportTicketsCommand struct {
+	BaseCommand
+	client *api.Client
+	log    *logger.Logger
+}
+
+// NewSupportTicketsCommand creates a new support tickets command instance
+func NewSupportTicketsCommand(client *api.Client) *SupportTicketsCommand {
+	return &SupportTicketsCommand{
+		BaseCommand: NewBaseCommand(TypeInfo),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "support_tickets"}),
+	}
+}
+
+// Execute implements the Command interface
+func (c *SupportTicketsCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *SupportTicketsCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *SupportTicketsCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// Tickets returns the tickets of the account, open tickets first, then
+// by last update, most recent first
+func (c *SupportTicketsCommand) Tickets() ([]*api.SupportTicket, error) {
+	ids, err := c.client.ListSupportTickets()
+	if err != nil {
+		return nil, err
+	}
+
+	tickets := make([]*api.SupportTicket, 0, len(ids))
+	for _, id := range ids {
+		ticket, err := c.client.GetSupportTicket(id)
+		if err != nil {
+			return nil, err
+		}
+		tickets = append(tickets, ticket)
+	}
+
+	sort.SliceStable(tickets, func(i, j int) bool {
+		a, b := tickets[i], tickets[j]
+		if (a.State == api.TicketOpen) != (b.State == api.TicketOpen) {
+			return a.State == api.TicketOpen
+		}
+		if a.UpdateDate == nil || b.UpdateDate == nil {
+			return b.UpdateDate == nil && a.UpdateDate != nil
+		}
+		return a.UpdateDate.After(*b.UpdateDate)
+	})
+	return tickets, nil
+}
+
+// executeCommand handles the actual command execution
+func (c *SupportTicketsCommand) executeCommand() (string, error) {
+	c.log.Debug("Executing support tickets command")
+
+	tickets, err := c.Tickets()
+	if err != nil {
+		return "", err
+	}
+
+	output := format.NewOutputFormatter(
+		format.WithMaxWidth(maxWidth),
+		format.WithSeparator("\n"),
+	)
+	section := output.AddSection("Support Tickets")
+	section.SetConfig(format.SectionConfig{
+		KeyValueSpacing: keyValueSpacing,
+		TitleDecorator:  "=",
+	})
+	if len(tickets) == 0 {
+		section.AddField("Tickets", "No support tickets")
+	}
+	for _, ticket := range tickets {
+		section.AddLines(fmt.Sprintf("#%d", ticket.TicketNumber), []string{
+			ticket.Subject,
+			TicketSummary(ticket),
+		})
+	}
+
+	return output.String(), nil
+}
+
+// SupportTicketCommand shows a ticket and its messages as a thread
+type SupportTicketCommand struct {
+	BaseCommand
+	client   *api.Client
+	log      *logger.Logger
+	ticketID int64
+}
+
+// NewSupportTicketCommand creates a new support ticket command instance
+func NewSupportTicketCommand(client *api.Client, ticketID int64) *SupportTicketCommand {
+	return &SupportTicketCommand{
+		BaseCommand: NewBaseCommand(TypeInfo),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "support_ticket"}),
+		ticketID:    ticketID,
+	}
+}
+
+// Execute implements the Command interface
+func (c *SupportTicketCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *SupportTicketCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *SupportTicketCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// executeCommand handles the actual command execution
+func (c *SupportTicketCommand) executeCommand() (string, error) {
+	c.log.Debug("Executing support ticket command", "ticket", c.ticketID)
+
+	ticket, err := c.client.GetSupportTicket(c.ticketID)
+	if err != nil {
+		return "", err
+	}
+	messages, err := c.client.ListSupportMessages(c.ticketID)
+	if err != nil {
+		return "", err
+	}
+
+	output := format.NewOutputFormatter(
+		format.WithMaxWidth(maxWidth),
+		format.WithSeparator("\n"),
+	)
+	section := output.AddSection(TicketTitle(ticket))
+	section.SetConfig(format.SectionConfig{
+		KeyValueSpacing: keyValueSpacing,
+		TitleDecorator:  "=",
+	})
+	section.AddField("State", ticket.State)
+	section.AddField("Product", ticket.Product)
+	section.AddField("Service", ticket.ServiceName)
+	section.AddField("Category", ticket.Category)
+	section.AddField("Opened", format.DateTime(ticket.CreationDate))
+	section.AddField("Updated", format.DateTime(ticket.UpdateDate))
+	section.AddField("Messages", strconv.Itoa(len(messages)))
+
+	return output.String() + "\n" + renderThread(messages, maxWidth), nil
+}
+
+// renderThread lays out messages oldest first, each under a header
+// naming its author, with the body indented and wrapped to width
+func renderThread(messages []api.SupportMessage, width int) string {
+	sorted := append([]api.SupportMessage(nil), messages...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i].CreationDate, sorted[j].CreationDate
+		if a == nil || b == nil {
+			return a == nil && b != nil
+		}
+		return a.Before(*b)
+	})
+
+	var thread strings.Builder
+	for i, message := range sorted {
+		if i > 0 {
+			thread.WriteString("\n")
+		}
+		header := fmt.Sprintf("── %s · %s ", messageAuthor(message.From), format.DateTime(message.CreationDate))
+		if pad := width - len([]rune(header)); pad > 0 {
+			header += strings.Repeat("─", pad)
+		}
+		thread.WriteString(header + "\n")
+		for _, line := range wrapParagraphs(message.Body, width-len(threadIndent)) {
+			thread.WriteString(strings.TrimRight(threadIndent+line, " ") + "\n")
+		}
+	}
+	return thread.String()
+}
+
+// wrapParagraphs wraps each line of text at word boundaries, keeping
+// blank lines and words longer than width
+func wrapParagraphs(text string, width int) []string {
+	text = strings.ReplaceAll(strings.TrimSpace(text), "\r\n", "\n")
+
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			switch {
+			case line == "":
+				line = word
+			case len([]rune(line))+1+len([]rune(word)) > width:
+				lines = append(lines, line)
+				line = word
+			default:
+				line += " " + word
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// SupportTicketActionCommand replies to, closes or reopens a ticket.
+// Replies and reopenings ask for a message, composed in $EDITOR when long.
+type SupportTicketActionCommand struct {
+	BaseCommand
+	client   *api.Client
+	log      *logger.Logger
+	ticketID int64
+	kind     string
+	ticket   *api.SupportTicket
+}
+
+// NewSupportTicketActionCommand creates a new ticket action command instance
+func NewSupportTicketActionCommand(client *api.Client, ticketID int64, kind string) *SupportTicketActionCommand {
+	return &SupportTicketActionCommand{
+		BaseCommand: NewBaseCommand(TypeAction),
+		client:      client,
+		log:         logger.Log.With(map[string]interface{}{"command": "support_ticket_" + kind}),
+		ticketID:    ticketID,
+		kind:        kind,
+	}
+}
+
+// Execute implements the Command interface
+func (c *SupportTicketActionCommand) Execute() (string, error) {
+	return c.ExecuteWithOptions()
+}
+
+// ExecuteWithOptions implements the Command interface
+func (c *SupportTicketActionCommand) ExecuteWithOptions(opts ...CommandOption) (string, error) {
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c.executeWithTimeout(context.Background(), c.executeCommand)
+}
+
+// ExecuteAsync implements the Command interface
+func (c *SupportTicketActionCommand) ExecuteAsync(ctx context.Context) (<-chan CommandResult, error) {
+	return c.executeAsync(c.executeCommand)
+}
+
+// NextPrompt implements the InteractiveCommand interface
+func (c *SupportTicketActionCommand) NextPrompt() (*Prompt, error) {
+	ticket, err := c.loadTicket()
+	if err != nil {
+		return nil, err
+	}
+
+	if c.kind == TicketClose {
+		return c.confirmPrompt(fmt.Sprintf("Close ticket %s?", TicketTitle(ticket))), nil
+	}
+
+	message, ok := c.input("message")
+	if !ok {
+		label := "Reply"
+		if c.kind == TicketReopen {
+			label = "Reason for reopening"
+		}
+		return &Prompt{Key: "message", Label: label, Kind: PromptEditor}, nil
+	}
+
+	verb := "Send reply to"
+	if c.kind == TicketReopen {
+		verb = "Reopen"
+	}
+	lines := len(strings.Split(message, "\n"))
+	return c.confirmPrompt(fmt.Sprintf("%s ticket #%d (%d lines, %d characters)?",
+		verb, ticket.TicketNumber, lines, len([]rune(message)))), nil
+}
+
+// SetInput implements the InteractiveCommand interface
+func (c *SupportTicketActionCommand) SetInput(key, value string) error {
+	if key == "message" {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return fmt.Errorf("the message is empty")
+		}
+	}
+	return c.BaseCommand.SetInput(key, value)
+}
+
+// loadTicket fetches the ticket once and checks the action applies to
+// its current state
+func (c *SupportTicketActionCommand) loadTicket() (*api.SupportTicket, error) {
+	if c.ticket != nil {
+		return c.ticket, nil
+	}
+
+	ticket, err := c.client.GetSupportTicket(c.ticketID)
+	if err != nil {
+		return nil, err
+	}
+	switch c.kind {
+	case TicketReply:
+		if ticket.State != api.TicketOpen {
+			return nil, fmt.Errorf("ticket #%d is %s, reopen it to reply", ticket.TicketNumber, ticket.State)
+		}
+	case TicketClose:
+		if ticket.State != api.TicketOpen {
+			return nil, fmt.Errorf("ticket #%d is already %s", ticket.TicketNumber, ticket.State)
+		}
+		if !ticket.CanBeClosed {
+			return nil, fmt.Errorf("ticket #%d cannot be closed yet", ticket.TicketNumber)
+		}
+	case TicketReopen:
+		if ticket.State != api.TicketClosed {
+			return nil, fmt.Errorf("ticket #%d is %s", ticket.TicketNumber, ticket.State)
+		}
+	default:
+		return nil, fmt.Errorf("unknown ticket action %q", c.kind)
+	}
+
+	c.ticket = ticket
+	return ticket, nil
+}
+
+// executeCommand handles the actual command execution
+func (c *SupportTicketActionCommand) executeCommand() (string, error) {
+	ticket, err := c.loadTicket()
+	if err != nil {
+		return "", err
+	}
+
+	message, _ := c.input("message")
+	if c.kind != TicketClose && message == "" {
+		return "", fmt.Errorf("a message is required to %s ticket #%d", c.kind, ticket.TicketNumber)
+	}
+
+	c.log.Info("Running ticket action", "ticket", c.ticketID, "action", c.kind)
+
+	switch c.kind {
+	case TicketReply:
+		if err := c.client.ReplySupportTicket(c.ticketID, message); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Replied to ticket %s.", TicketTitle(ticket)), nil
+	case TicketClose:
+		if err := c.client.CloseSupportTicket(c.ticketID); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Closed ticket %s.", TicketTitle(ticket)), nil
+	default:
+		if err := c.client.ReopenSupportTicket(c.ticketID, message); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Reopened ticket %s.", TicketTitle(ticket)), nil
+	}
+}
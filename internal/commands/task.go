@@ -0,0 +1,56 @@
+// internal/commands/task.go
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// TaskCheck fetches the status of an API task. It reports done once the
+// task completed and returns an error if the task failed.
+type TaskCheck func() (status string, done bool, err error)
+
+// pollTask waits for an API task to complete, reporting each status
+// change as progress. It stops early when the context of executeTask ends.
+func (b *BaseCommand) pollTask(name string, check TaskCheck) error {
+	ctx := b.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	var deadline time.Time
+	if b.config.Timeout > 0 {
+		deadline = time.Now().Add(b.config.Timeout)
+	}
+
+	step := 0
+	lastStatus := ""
+	for {
+		status, done, err := check()
+		if err != nil {
+			return err
+		}
+
+		if status != lastStatus {
+			step++
+			lastStatus = status
+			b.reportProgress(step, 0, fmt.Sprintf("%s: %s", name, status))
+		}
+		if done {
+			return nil
+		}
+
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for %s (last status: %s)", name, status)
+		}
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("timed out waiting for %s (last status: %s)", name, status)
+			}
+			return fmt.Errorf("stopped waiting for %s (last status: %s): %w", name, status, ctx.Err())
+		case <-time.After(b.config.PollInterval):
+		}
+	}
+}
@@ -0,0 +1,35 @@
+// internal/commands/task_test.go
+package commands
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExecuteTaskStopsPolling(t *testing.T) {
+	pending := func() (string, bool, error) { return "todo", false, nil }
+
+	t.Run("timeout", func(t *testing.T) {
+		base := NewBaseCommand(TypeAction, WithTimeout(20*time.Millisecond), WithPollInterval(time.Hour))
+		_, err := base.executeTask(context.Background(), func() (string, error) {
+			return "", base.pollTask("Moving", pending)
+		})
+		if err == nil || !strings.Contains(err.Error(), "timed out waiting for Moving (last status: todo)") {
+			t.Errorf("Expected a timeout from the poll, got %v", err)
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		base := NewBaseCommand(TypeAction, WithTimeout(time.Hour), WithPollInterval(time.Hour))
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := base.executeTask(ctx, func() (string, error) {
+			return "", base.pollTask("Moving", pending)
+		})
+		if err == nil || !strings.Contains(err.Error(), "stopped waiting for Moving") {
+			t.Errorf("Expected the poll to stop on cancel, got %v", err)
+		}
+	})
+}
@@ -2,7 +2,9 @@
 package config
 
 import (
+	"crypto/x509"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -28,7 +30,8 @@ var ValidLogLevels = map[string]bool{
 	"error": true,
 }
 
-// ValidEndpoints defines allowed OVH API endpoints
+// ValidEndpoints defines allowed named OVH API endpoints. Any https:// URL
+// is accepted as well.
 var ValidEndpoints = map[string]bool{
 	"ovh-eu":     true,
 	"ovh-us":     true,
@@ -99,6 +102,10 @@ func validateConfig(cfg *Config) error {
 		return err
 	}
 
+	if err := validateDynDNS(&cfg.DynDNS); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -140,6 +147,13 @@ func validateUI(ui *UIConfig) error {
 		}
 	}
 
+	if ui.QuotaWarning < 0 || ui.QuotaWarning > 100 {
+		return &ValidationError{
+			Field:   "ui.quota_warning",
+			Message: "quota warning must be a percentage between 0 and 100",
+		}
+	}
+
 	return nil
 }
 
@@ -179,13 +193,60 @@ func validateAccount(name string, acc *AccountConfig) error {
 			Message: "missing endpoint",
 		}
 	}
-	if !ValidEndpoints[acc.Endpoint] {
+	if acc.IsCustomEndpoint() {
+		if err := validateEndpointURL(acc.Endpoint); err != nil {
+			return &ValidationError{
+				Field:   fmt.Sprintf("accounts.%s.endpoint", name),
+				Message: err.Error(),
+			}
+		}
+	} else if !ValidEndpoints[acc.Endpoint] {
 		return &ValidationError{
 			Field:   fmt.Sprintf("accounts.%s.endpoint", name),
 			Message: fmt.Sprintf("invalid endpoint: %s", acc.Endpoint),
 		}
 	}
 
+	if acc.CAFile != "" {
+		if err := validateCAFile(acc.CAFile); err != nil {
+			return &ValidationError{
+				Field:   fmt.Sprintf("accounts.%s.ca_file", name),
+				Message: err.Error(),
+			}
+		}
+	}
+
+	if acc.Proxy != "" {
+		if err := validateProxyURL(acc.Proxy); err != nil {
+			return &ValidationError{
+				Field:   fmt.Sprintf("accounts.%s.http_proxy", name),
+				Message: err.Error(),
+			}
+		}
+	}
+
+	switch acc.AuthMode() {
+	case AuthConsumerKey:
+		if err := validateConsumerKeyAuth(name, acc); err != nil {
+			return err
+		}
+	case AuthOAuth2:
+		if err := validateOAuth2Auth(name, acc); err != nil {
+			return err
+		}
+	default:
+		return &ValidationError{
+			Field: fmt.Sprintf("accounts.%s.auth", name),
+			Message: fmt.Sprintf("invalid auth mode %q, use %q or %q",
+				acc.Auth, AuthConsumerKey, AuthOAuth2),
+		}
+	}
+
+	return nil
+}
+
+// validateConsumerKeyAuth validates application and consumer key credentials
+func validateConsumerKeyAuth(name string, acc *AccountConfig) error {
 	if acc.AppKey == "" {
 		return &ValidationError{
 			Field:   fmt.Sprintf("accounts.%s.app_key", name),
@@ -207,6 +268,160 @@ func validateAccount(name string, acc *AccountConfig) error {
 		}
 	}
 
+	if acc.ClientID != "" || acc.ClientSecret != "" {
+		return &ValidationError{
+			Field: fmt.Sprintf("accounts.%s.client_id", name),
+			Message: fmt.Sprintf("client_id/client_secret require auth = %q",
+				AuthOAuth2),
+		}
+	}
+
+	return nil
+}
+
+// validateOAuth2Auth validates service account client credentials
+func validateOAuth2Auth(name string, acc *AccountConfig) error {
+	if acc.ClientID == "" {
+		return &ValidationError{
+			Field:   fmt.Sprintf("accounts.%s.client_id", name),
+			Message: "missing client_id",
+		}
+	}
+
+	if acc.ClientSecret == "" {
+		return &ValidationError{
+			Field:   fmt.Sprintf("accounts.%s.client_secret", name),
+			Message: "missing client_secret",
+		}
+	}
+
+	if acc.AppKey != "" || acc.AppSecret != "" || acc.ConsumerKey != "" {
+		return &ValidationError{
+			Field: fmt.Sprintf("accounts.%s.auth", name),
+			Message: "app_key, app_secret and consumer_key cannot be combined " +
+				"with oauth2 authentication",
+		}
+	}
+
+	if acc.TokenURL != "" {
+		if err := validateEndpointURL(acc.TokenURL); err != nil {
+			return &ValidationError{
+				Field:   fmt.Sprintf("accounts.%s.token_url", name),
+				Message: err.Error(),
+			}
+		}
+	} else if acc.OAuth2TokenURL() == "" {
+		return &ValidationError{
+			Field: fmt.Sprintf("accounts.%s.token_url", name),
+			Message: fmt.Sprintf("endpoint %s has no known OAuth2 token URL, set token_url",
+				acc.Endpoint),
+		}
+	}
+
+	return nil
+}
+
+// validateEndpointURL checks a custom API endpoint or token URL
+func validateEndpointURL(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %v", err)
+	}
+	if u.Scheme != "https" {
+		return fmt.Errorf("URL must use https: %s", endpoint)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("URL has no host: %s", endpoint)
+	}
+	if strings.HasSuffix(endpoint, "/") {
+		return fmt.Errorf("URL cannot have a trailing slash: %s", endpoint)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("URL cannot have a query or fragment: %s", endpoint)
+	}
+	return nil
+}
+
+// validateCAFile checks that a CA bundle exists and contains certificates
+func validateCAFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("cannot read CA bundle: %v", err)
+	}
+	if !x509.NewCertPool().AppendCertsFromPEM(data) {
+		return fmt.Errorf("no PEM certificates found in %s", path)
+	}
+	return nil
+}
+
+// validateProxyURL checks an HTTP proxy URL
+func validateProxyURL(proxy string) error {
+	u, err := url.Parse(proxy)
+	if err != nil {
+		return fmt.Errorf("invalid proxy URL: %v", err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return fmt.Errorf("unsupported proxy scheme %q, use http, https or socks5", u.Scheme)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("proxy URL has no host: %s", proxy)
+	}
+	return nil
+}
+
+// validateDynDNS validates the dynamic DNS updater settings. The section is
+// optional, an empty one is valid.
+func validateDynDNS(dyn *DynDNSConfig) error {
+	if dyn.Interval < 0 || (dyn.Interval > 0 && dyn.Interval < 30) {
+		return &ValidationError{
+			Field:   "dyndns.interval",
+			Message: "interval must be at least 30 seconds",
+		}
+	}
+
+	if dyn.IPCommand != "" && dyn.Interface != "" {
+		return &ValidationError{
+			Field:   "dyndns.ip_command",
+			Message: "ip_command and interface cannot be combined",
+		}
+	}
+
+	method := dyn.UpdateMethod()
+	if method != DynDNSZone && method != DynDNSDynHost {
+		return &ValidationError{
+			Field: "dyndns.method",
+			Message: fmt.Sprintf("invalid method %q, use %q or %q",
+				dyn.Method, DynDNSZone, DynDNSDynHost),
+		}
+	}
+
+	for i, record := range dyn.Records {
+		field := fmt.Sprintf("dyndns.record[%d]", i)
+		if record.Zone == "" {
+			return &ValidationError{Field: field + ".zone", Message: "missing zone"}
+		}
+		switch record.Type {
+		case "A":
+		case "AAAA":
+			if method == DynDNSDynHost {
+				return &ValidationError{
+					Field:   field + ".type",
+					Message: "DynHost records only hold IPv4 addresses, use method = \"zone\" for AAAA",
+				}
+			}
+		default:
+			return &ValidationError{
+				Field:   field + ".type",
+				Message: fmt.Sprintf("invalid type %q, use A or AAAA", record.Type),
+			}
+		}
+		if record.TTL < 0 {
+			return &ValidationError{Field: field + ".ttl", Message: "TTL cannot be negative"}
+		}
+	}
+
 	return nil
 }
 
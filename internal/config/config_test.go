@@ -0,0 +1,175 @@
+// internal/config/config_test.go
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateAccountEndpoint(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		valid    bool
+	}{
+		{"ovh-eu", true},
+		{"ovh-moon", false},
+		{"https://api.example.net/1.0", true},
+		{"https://10.0.0.5:8443/1.0", true},
+		{"http://api.example.net/1.0", false},
+		{"https:///1.0", false},
+		{"https://api.example.net/1.0/", false},
+		{"https://api.example.net/1.0?debug=1", false},
+	}
+
+	for _, tt := range tests {
+		acc := AccountConfig{
+			Endpoint:    tt.endpoint,
+			AppKey:      "key",
+			AppSecret:   "secret",
+			ConsumerKey: "consumer",
+		}
+		err := validateAccount("test", &acc)
+		if tt.valid && err != nil {
+			t.Errorf("Expected %s to be valid, got %v", tt.endpoint, err)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("Expected %s to be rejected", tt.endpoint)
+		}
+	}
+}
+
+func TestValidateAccountConnection(t *testing.T) {
+	dir := t.TempDir()
+	notPEM := filepath.Join(dir, "not-a-cert.pem")
+	if err := os.WriteFile(notPEM, []byte("hello"), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		caFile string
+		proxy  string
+		valid  bool
+	}{
+		{"no settings", "", "", true},
+		{"http proxy", "", "http://proxy.example.net:3128", true},
+		{"socks proxy", "", "socks5://127.0.0.1:1080", true},
+		{"ftp proxy", "", "ftp://proxy.example.net", false},
+		{"proxy without host", "", "http://", false},
+		{"missing CA file", filepath.Join(dir, "missing.pem"), "", false},
+		{"CA file without certificates", notPEM, "", false},
+	}
+
+	for _, tt := range tests {
+		acc := AccountConfig{
+			Endpoint:    "https://api.example.net/1.0",
+			AppKey:      "key",
+			AppSecret:   "secret",
+			ConsumerKey: "consumer",
+			CAFile:      tt.caFile,
+			Proxy:       tt.proxy,
+		}
+		err := validateAccount("test", &acc)
+		if tt.valid && err != nil {
+			t.Errorf("%s: expected valid, got %v", tt.name, err)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
+
+func TestValidateAccountAuthModes(t *testing.T) {
+	tests := []struct {
+		name  string
+		acc   AccountConfig
+		valid bool
+	}{
+		{"consumer key", AccountConfig{
+			Endpoint: "ovh-eu", AppKey: "k", AppSecret: "s", ConsumerKey: "c",
+		}, true},
+		{"consumer key missing secret", AccountConfig{
+			Endpoint: "ovh-eu", AppKey: "k", ConsumerKey: "c",
+		}, false},
+		{"oauth2", AccountConfig{
+			Endpoint: "ovh-eu", Auth: AuthOAuth2, ClientID: "id", ClientSecret: "secret",
+		}, true},
+		{"oauth2 missing secret", AccountConfig{
+			Endpoint: "ovh-eu", Auth: AuthOAuth2, ClientID: "id",
+		}, false},
+		{"oauth2 mixed with consumer key", AccountConfig{
+			Endpoint: "ovh-eu", Auth: AuthOAuth2, ClientID: "id", ClientSecret: "secret",
+			ConsumerKey: "c",
+		}, false},
+		{"oauth2 without token endpoint", AccountConfig{
+			Endpoint: "kimsufi-eu", Auth: AuthOAuth2, ClientID: "id", ClientSecret: "secret",
+		}, false},
+		{"oauth2 custom endpoint with token URL", AccountConfig{
+			Endpoint: "https://api.example.net/1.0", Auth: AuthOAuth2,
+			ClientID: "id", ClientSecret: "secret",
+			TokenURL: "https://auth.example.net/oauth2/token",
+		}, true},
+		{"unknown mode", AccountConfig{
+			Endpoint: "ovh-eu", Auth: "password",
+		}, false},
+	}
+
+	for _, tt := range tests {
+		err := validateAccount("test", &tt.acc)
+		if tt.valid && err != nil {
+			t.Errorf("%s: expected valid, got %v", tt.name, err)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
+
+func TestValidateUIQuotaWarning(t *testing.T) {
+	for _, percent := range []int{0, 80, 100} {
+		if err := validateUI(&UIConfig{QuotaWarning: percent}); err != nil {
+			t.Errorf("Expected quota warning %d to be valid, got %v", percent, err)
+		}
+	}
+	for _, percent := range []int{-1, 101} {
+		if err := validateUI(&UIConfig{QuotaWarning: percent}); err == nil {
+			t.Errorf("Expected quota warning %d to be rejected", percent)
+		}
+	}
+}
+
+func TestValidateDynDNS(t *testing.T) {
+	if err := validateDynDNS(&DynDNSConfig{}); err != nil {
+		t.Errorf("Expected an empty dyndns section to be valid, got %v", err)
+	}
+
+	valid := DynDNSConfig{
+		Interval:  60,
+		IPCommand: "curl -s https://ifconfig.me",
+		Records: []DynDNSRecord{
+			{Zone: "example.com", SubDomain: "office", Type: "A"},
+			{Zone: "example.com", SubDomain: "office", Type: "AAAA", TTL: 60},
+		},
+	}
+	if err := validateDynDNS(&valid); err != nil {
+		t.Errorf("Expected valid dyndns settings, got %v", err)
+	}
+
+	invalid := map[string]DynDNSConfig{
+		"short interval": {Interval: 5},
+		"two sources":    {IPCommand: "true", Interface: "eth0"},
+		"bad method":     {Method: "http"},
+		"missing zone":   {Records: []DynDNSRecord{{Type: "A"}}},
+		"bad type":       {Records: []DynDNSRecord{{Zone: "example.com", Type: "CNAME"}}},
+		"dynhost AAAA": {
+			Method:  DynDNSDynHost,
+			Records: []DynDNSRecord{{Zone: "example.com", Type: "AAAA"}},
+		},
+	}
+	for name, dyn := range invalid {
+		if err := validateDynDNS(&dyn); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -1,12 +1,18 @@
 // internal/config/types.go
 package config
 
+import (
+	"strings"
+	"time"
+)
+
 // Config represents the root configuration structure
 type Config struct {
 	General  GeneralConfig            `toml:"general"`
 	UI       UIConfig                 `toml:"ui"`
 	Accounts map[string]AccountConfig `toml:"accounts"`
 	KeyBinds KeyBindConfig            `toml:"keybindings"`
+	DynDNS   DynDNSConfig             `toml:"dyndns"`
 }
 
 // GeneralConfig holds general application settings
@@ -23,15 +29,67 @@ type UIConfig struct {
 	CompactView     bool   `toml:"compact_view"`
 	StatusBar       bool   `toml:"status_bar"`
 	RefreshInterval int    `toml:"refresh_interval"`
+	QuotaWarning    int    `toml:"quota_warning"`
+}
+
+// Authentication modes supported by an account
+const (
+	// AuthConsumerKey signs requests with an application and consumer key
+	AuthConsumerKey = "consumer_key"
+
+	// AuthOAuth2 uses OAuth2 client credentials of an IAM service account
+	AuthOAuth2 = "oauth2"
+)
+
+// OAuth2TokenURLs maps named endpoints to their OAuth2 token endpoint
+var OAuth2TokenURLs = map[string]string{
+	"ovh-eu": "https://www.ovh.com/auth/oauth2/token",
+	"ovh-ca": "https://ca.ovh.com/auth/oauth2/token",
+	"ovh-us": "https://us.ovhcloud.com/auth/oauth2/token",
 }
 
 // AccountConfig holds OVH API credentials
 type AccountConfig struct {
-	Name        string `toml:"name"`
-	Endpoint    string `toml:"endpoint"`
+	Name     string `toml:"name"`
+	Endpoint string `toml:"endpoint"`
+	Auth     string `toml:"auth"`
+
+	// Consumer key authentication
 	AppKey      string `toml:"app_key"`
 	AppSecret   string `toml:"app_secret"`
 	ConsumerKey string `toml:"consumer_key"`
+
+	// OAuth2 service account authentication
+	ClientID     string `toml:"client_id"`
+	ClientSecret string `toml:"client_secret"`
+	TokenURL     string `toml:"token_url"`
+
+	// Optional connection settings, mostly useful with custom endpoints
+	CAFile string `toml:"ca_file"`
+	Proxy  string `toml:"http_proxy"`
+}
+
+// AuthMode returns the configured authentication mode, defaulting to
+// consumer keys
+func (a *AccountConfig) AuthMode() string {
+	if a.Auth == "" {
+		return AuthConsumerKey
+	}
+	return a.Auth
+}
+
+// OAuth2TokenURL returns the token endpoint for OAuth2 authentication
+func (a *AccountConfig) OAuth2TokenURL() string {
+	if a.TokenURL != "" {
+		return a.TokenURL
+	}
+	return OAuth2TokenURLs[a.Endpoint]
+}
+
+// IsCustomEndpoint reports whether the endpoint is a URL rather than a
+// named OVH endpoint
+func (a *AccountConfig) IsCustomEndpoint() bool {
+	return strings.Contains(a.Endpoint, "://")
 }
 
 // KeyBindConfig holds keyboard shortcuts configuration
@@ -42,3 +100,50 @@ type KeyBindConfig struct {
 	SwitchAccount []string `toml:"switch_account"`
 	ToggleView    []string `toml:"toggle_view"`
 }
+
+// DynDNS update methods
+const (
+	// DynDNSZone updates A and AAAA records through the zone API
+	DynDNSZone = "zone"
+
+	// DynDNSDynHost updates DynHost records, IPv4 only
+	DynDNSDynHost = "dynhost"
+)
+
+// DefaultDynDNSInterval is the time between two address checks
+const DefaultDynDNSInterval = 300
+
+// DynDNSConfig holds the settings of the dynamic DNS updater. The public
+// address comes from the output of IPCommand or from Interface.
+type DynDNSConfig struct {
+	Interval  int            `toml:"interval"`
+	IPCommand string         `toml:"ip_command"`
+	Interface string         `toml:"interface"`
+	Method    string         `toml:"method"`
+	Records   []DynDNSRecord `toml:"record"`
+}
+
+// DynDNSRecord is a record kept pointing to the public address
+type DynDNSRecord struct {
+	Zone      string `toml:"zone"`
+	SubDomain string `toml:"subdomain"`
+	Type      string `toml:"type"`
+	TTL       int    `toml:"ttl"`
+}
+
+// CheckInterval returns the time between two address checks
+func (d *DynDNSConfig) CheckInterval() time.Duration {
+	if d.Interval == 0 {
+		return DefaultDynDNSInterval * time.Second
+	}
+	return time.Duration(d.Interval) * time.Second
+}
+
+// UpdateMethod returns the configured update method, defaulting to zone
+// records
+func (d *DynDNSConfig) UpdateMethod() string {
+	if d.Method == "" {
+		return DynDNSZone
+	}
+	return d.Method
+}
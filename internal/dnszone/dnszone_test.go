@@ -0,0 +1,86 @@
+// internal/dnszone/dnszone_test.go
+package dnszone
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	records, err := Parse("example.com", `
+$TTL 3600
+@	IN SOA dns10.ovh.net. tech.ovh.net. (2024010101 86400 3600 3600000 300)
+@	IN NS	dns10.ovh.net.
+	IN NS	ns10.ovh.net. ; same owner
+@	300 IN A 203.0.113.20
+www	IN 1h CNAME @
+$ORIGIN mail.example.com.
+@	IN MX 10 mx1
+spf.example.com. IN TXT ( "v=spf1 include:mx.ovh.com"
+	" ~all" )
+`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	want := []string{
+		" NS dns10.ovh.net. 0",
+		" NS ns10.ovh.net. 0",
+		" A 203.0.113.20 300",
+		"www CNAME @ 3600",
+		"mail MX 10 mx1 0",
+		"spf TXT \"v=spf1 include:mx.ovh.com\" \" ~all\" 0",
+	}
+	if len(records) != len(want) {
+		t.Fatalf("Expected %d records, got %+v", len(want), records)
+	}
+	for i, record := range records {
+		got := fmt.Sprintf("%s %s %s %d", record.SubDomain, record.Type, record.Target, record.TTL)
+		if got != want[i] {
+			t.Errorf("Record %d: expected %q, got %q", i, want[i], got)
+		}
+	}
+
+	invalid := map[string]string{
+		"outside of zone": "www.example.net. IN A 192.0.2.1\n",
+		"missing value":   "www IN A\n",
+		"include":         "$INCLUDE other.zone\n",
+		"unclosed":        "txt IN TXT ( \"a\"\n",
+		"bad ttl":         "www 1x IN A 192.0.2.1\n",
+	}
+	for name, text := range invalid {
+		if _, err := Parse("example.com", text); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	current := []Record{
+		{ID: 1, Type: "A", Target: "203.0.113.20"},
+		{ID: 2, SubDomain: "www", Type: "CNAME", Target: "example.com."},
+		{ID: 3, Type: "TXT", TTL: 60, Target: `"v=spf1 -all"`},
+		{ID: 4, SubDomain: "old", Type: "A", Target: "192.0.2.1"},
+	}
+	desired := []Record{
+		{Type: "A", Target: "203.0.113.21"},
+		{SubDomain: "www", Type: "CNAME", Target: "@"},
+		{Type: "TXT", Target: "v=spf1 -all"},
+		{SubDomain: "new", Type: "AAAA", Target: "2001:db8::1"},
+	}
+
+	diff := Compare("example.com", current, desired)
+	if len(diff.Add) != 1 || diff.Add[0].SubDomain != "new" {
+		t.Errorf("Expected new to be added, got %+v", diff.Add)
+	}
+	if len(diff.Delete) != 1 || diff.Delete[0].ID != 4 {
+		t.Errorf("Expected old to be deleted, got %+v", diff.Delete)
+	}
+	if len(diff.Change) != 2 || diff.Change[0].To.ID != 1 || diff.Change[1].To.ID != 3 {
+		t.Errorf("Expected the A value and TXT TTL to change, got %+v", diff.Change)
+	}
+
+	if !Compare("example.com", current, current).Empty() {
+		t.Error("Expected no differences between identical sets")
+	}
+}
@@ -0,0 +1,262 @@
+// internal/dnszone/parse.go
+package dnszone
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// classes are the record classes accepted before or after the TTL
+var classes = map[string]bool{"IN": true, "CH": true, "HS": true}
+
+// ttlUnits are the BIND TTL suffixes, in seconds
+var ttlUnits = map[rune]int{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+
+// Parse reads the records of a BIND zone file for zone. SOA records are
+// skipped, the API manages them. Records without a TTL get 0, the zone
+// default, whatever $TTL says, so exports of the API read back unchanged.
+func Parse(zone, text string) ([]Record, error) {
+	zone = strings.ToLower(strings.TrimSuffix(zone, "."))
+	origin := zone + "."
+	owner := ""
+
+	var records []Record
+	lines, err := logicalLines(text)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range lines {
+		fields := tokenize(line.text)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch strings.ToUpper(fields[0]) {
+		case "$ORIGIN":
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("line %d: $ORIGIN takes one name", line.number)
+			}
+			origin = absolute(origin, fields[1])
+			continue
+		case "$TTL":
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("line %d: $TTL takes one value", line.number)
+			}
+			if _, err := parseTTL(fields[1]); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line.number, err)
+			}
+			continue
+		case "$INCLUDE", "$GENERATE":
+			return nil, fmt.Errorf("line %d: %s is not supported", line.number, fields[0])
+		}
+
+		if !line.continued {
+			owner, fields = absolute(origin, fields[0]), fields[1:]
+		} else if owner == "" {
+			return nil, fmt.Errorf("line %d: record without owner name", line.number)
+		}
+
+		record, err := parseRecord(fields)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line.number, err)
+		}
+		if record.Type == "SOA" {
+			continue
+		}
+
+		subDomain, ok := relative(zone, owner)
+		if !ok {
+			return nil, fmt.Errorf("line %d: %s is outside of %s", line.number, owner, zone)
+		}
+		record.SubDomain = subDomain
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// parseRecord reads the optional TTL and class, the type and the value
+func parseRecord(fields []string) (Record, error) {
+	var record Record
+	for len(fields) > 0 {
+		field := strings.ToUpper(fields[0])
+		if classes[field] {
+			fields = fields[1:]
+			continue
+		}
+		if field[0] >= '0' && field[0] <= '9' {
+			ttl, err := parseTTL(fields[0])
+			if err != nil {
+				return record, err
+			}
+			record.TTL, fields = ttl, fields[1:]
+			continue
+		}
+		break
+	}
+	if len(fields) < 2 {
+		return record, fmt.Errorf("expected a record type and value")
+	}
+
+	record.Type = strings.ToUpper(fields[0])
+	record.Target = strings.Join(fields[1:], " ")
+	return record, nil
+}
+
+// parseTTL reads a TTL in seconds or with BIND units, such as 1h30m
+func parseTTL(value string) (int, error) {
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return seconds, nil
+	}
+
+	total, number := 0, -1
+	for _, r := range strings.ToLower(value) {
+		switch {
+		case unicode.IsDigit(r):
+			if number < 0 {
+				number = 0
+			}
+			number = number*10 + int(r-'0')
+		case ttlUnits[r] > 0 && number >= 0:
+			total += number * ttlUnits[r]
+			number = -1
+		default:
+			return 0, fmt.Errorf("invalid TTL %q", value)
+		}
+	}
+	if number >= 0 {
+		return 0, fmt.Errorf("invalid TTL %q", value)
+	}
+	return total, nil
+}
+
+// absolute makes an owner name absolute, relative names being inside
+// origin
+func absolute(origin, name string) string {
+	name = strings.ToLower(name)
+	switch {
+	case name == "@":
+		return origin
+	case strings.HasSuffix(name, "."):
+		return name
+	}
+	return name + "." + origin
+}
+
+// relative returns the subdomain of an absolute name inside zone
+func relative(zone, name string) (string, bool) {
+	name = strings.TrimSuffix(name, ".")
+	if name == zone {
+		return "", true
+	}
+	subDomain, ok := strings.CutSuffix(name, "."+zone)
+	return subDomain, ok
+}
+
+// logicalLine is a record spread over one or more lines
+type logicalLine struct {
+	number    int
+	text      string
+	continued bool
+}
+
+// logicalLines strips comments and joins lines inside parentheses. Lines
+// starting with a blank continue the previous owner.
+func logicalLines(text string) ([]logicalLine, error) {
+	var lines []logicalLine
+	var current *logicalLine
+	depth := 0
+
+	for n, raw := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		content, opened, err := stripComment(raw)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n+1, err)
+		}
+
+		if depth == 0 {
+			if strings.TrimSpace(content) == "" {
+				continue
+			}
+			lines = append(lines, logicalLine{
+				number:    n + 1,
+				continued: content[0] == ' ' || content[0] == '\t',
+			})
+			current = &lines[len(lines)-1]
+		}
+		current.text += " " + content
+		depth += opened
+		if depth < 0 {
+			return nil, fmt.Errorf("line %d: unbalanced parenthesis", n+1)
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("line %d: unclosed parenthesis", current.number)
+	}
+
+	for i := range lines {
+		lines[i].text = strings.NewReplacer("(", " ", ")", " ").Replace(lines[i].text)
+	}
+	return lines, nil
+}
+
+// stripComment removes a trailing comment and counts the parentheses
+// opened on the line, ignoring both inside quotes
+func stripComment(line string) (string, int, error) {
+	quoted, escaped := false, false
+	opened := 0
+	for i, r := range line {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\' && quoted:
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+		case quoted:
+		case r == ';':
+			return line[:i], opened, nil
+		case r == '(':
+			opened++
+		case r == ')':
+			opened--
+		}
+	}
+	if quoted {
+		return "", 0, fmt.Errorf("unterminated quoted string")
+	}
+	return line, opened, nil
+}
+
+// tokenize splits a line on blanks, keeping quoted strings whole
+func tokenize(line string) []string {
+	var fields []string
+	var field strings.Builder
+	quoted, escaped := false, false
+
+	flush := func() {
+		if field.Len() > 0 {
+			fields = append(fields, field.String())
+			field.Reset()
+		}
+	}
+	for _, r := range line {
+		switch {
+		case escaped:
+			field.WriteRune(r)
+			escaped = false
+		case r == '\\' && quoted:
+			field.WriteRune(r)
+			escaped = true
+		case r == '"':
+			field.WriteRune(r)
+			quoted = !quoted
+		case !quoted && (r == ' ' || r == '\t'):
+			flush()
+		default:
+			field.WriteRune(r)
+		}
+	}
+	flush()
+	return fields
+}
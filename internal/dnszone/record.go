@@ -0,0 +1,230 @@
+// internal/dnszone/record.go
+
+// Package dnszone reads BIND zone files and compares sets of DNS records
+package dnszone
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+)
+
+// Record is a DNS record relative to its zone. SubDomain is empty for the
+// zone apex and a TTL of 0 stands for the zone default.
+type Record struct {
+	ID        int64
+	SubDomain string
+	Type      string
+	TTL       int
+	Target    string
+}
+
+// Name returns the fully qualified owner name of the record
+func (r Record) Name(zone string) string {
+	if r.SubDomain == "" {
+		return zone + "."
+	}
+	return r.SubDomain + "." + zone + "."
+}
+
+// String formats the record as a zone file line
+func (r Record) String() string {
+	owner := r.SubDomain
+	if owner == "" {
+		owner = "@"
+	}
+	ttl := ""
+	if r.TTL > 0 {
+		ttl = fmt.Sprint(r.TTL)
+	}
+	return strings.TrimRight(fmt.Sprintf("%-24s %-6s IN %-5s %s", owner, ttl, r.Type, r.Target), " ")
+}
+
+// key identifies a record by owner, type and value, ignoring its TTL
+func (r Record) key(zone string) string {
+	return r.SubDomain + " " + r.Type + " " + CanonicalTarget(zone, r.Type, r.Target)
+}
+
+// group identifies the records sharing an owner and type
+func (r Record) group() string {
+	return r.SubDomain + " " + r.Type
+}
+
+// CanonicalTarget normalizes a record value so that equivalent spellings
+// compare equal: host names are made absolute and lowercase, IPv6
+// addresses compressed and TXT strings joined.
+func CanonicalTarget(zone, recordType, target string) string {
+	fields := strings.Fields(target)
+	switch recordType {
+	case "A", "AAAA":
+		if ip := net.ParseIP(target); ip != nil {
+			return ip.String()
+		}
+	case "CNAME", "NS", "PTR", "DNAME":
+		if len(fields) == 1 {
+			return qualify(zone, fields[0])
+		}
+	case "MX":
+		if len(fields) == 2 {
+			return fields[0] + " " + qualify(zone, fields[1])
+		}
+	case "SRV":
+		if len(fields) == 4 {
+			return strings.Join(fields[:3], " ") + " " + qualify(zone, fields[3])
+		}
+	case "TXT", "SPF", "DKIM", "DMARC":
+		return unquote(target)
+	}
+	return strings.Join(fields, " ")
+}
+
+// qualify makes a host name absolute, relative names being inside zone
+func qualify(zone, name string) string {
+	name = strings.ToLower(name)
+	switch {
+	case name == "@":
+		return strings.ToLower(zone) + "."
+	case strings.HasSuffix(name, "."):
+		return name
+	}
+	return name + "." + strings.ToLower(zone) + "."
+}
+
+// unquote joins the character strings of a TXT value. Unquoted values
+// are kept as they are.
+func unquote(value string) string {
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, `"`) {
+		return value
+	}
+
+	var joined strings.Builder
+	quoted, escaped := false, false
+	for _, r := range value {
+		switch {
+		case escaped:
+			joined.WriteRune(r)
+			escaped = false
+		case r == '\\' && quoted:
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+		case quoted:
+			joined.WriteRune(r)
+		}
+	}
+	return joined.String()
+}
+
+// Change is a record whose value or TTL changes
+type Change struct {
+	From Record
+	To   Record
+}
+
+// Diff lists what turns one set of records into another
+type Diff struct {
+	Add    []Record
+	Change []Change
+	Delete []Record
+}
+
+// Empty reports whether both sets hold the same records
+func (d Diff) Empty() bool {
+	return len(d.Add) == 0 && len(d.Change) == 0 && len(d.Delete) == 0
+}
+
+// Lines formats the diff, one "+", "~" or "-" line per record
+func (d Diff) Lines() []string {
+	lines := make([]string, 0, len(d.Add)+len(d.Change)+len(d.Delete))
+	for _, record := range d.Add {
+		lines = append(lines, "+ "+record.String())
+	}
+	for _, change := range d.Change {
+		lines = append(lines, "~ "+change.From.String()+" -> "+change.To.String())
+	}
+	for _, record := range d.Delete {
+		lines = append(lines, "- "+record.String())
+	}
+	return lines
+}
+
+// Compare returns the changes turning current into desired. Records with
+// the same owner, type and value only change when their TTL differs; the
+// remaining records of an owner and type are paired as value changes, in
+// order, and the rest are added or deleted.
+func Compare(zone string, current, desired []Record) Diff {
+	remaining := make(map[string][]Record)
+	for _, record := range current {
+		key := record.key(zone)
+		remaining[key] = append(remaining[key], record)
+	}
+
+	var diff Diff
+	var added []Record
+	for _, record := range desired {
+		key := record.key(zone)
+		matches := remaining[key]
+		if len(matches) == 0 {
+			added = append(added, record)
+			continue
+		}
+		match := matches[0]
+		remaining[key] = matches[1:]
+		if match.TTL != record.TTL {
+			record.ID = match.ID
+			diff.Change = append(diff.Change, Change{From: match, To: record})
+		}
+	}
+
+	removed := make(map[string][]Record)
+	for _, record := range current {
+		key := record.key(zone)
+		if len(remaining[key]) > 0 && remaining[key][0] == record {
+			remaining[key] = remaining[key][1:]
+			removed[record.group()] = append(removed[record.group()], record)
+		}
+	}
+
+	for _, record := range added {
+		group := removed[record.group()]
+		if len(group) == 0 {
+			diff.Add = append(diff.Add, record)
+			continue
+		}
+		record.ID = group[0].ID
+		diff.Change = append(diff.Change, Change{From: group[0], To: record})
+		removed[record.group()] = group[1:]
+	}
+	for _, record := range current {
+		group := removed[record.group()]
+		if len(group) > 0 && group[0] == record {
+			diff.Delete = append(diff.Delete, record)
+			removed[record.group()] = group[1:]
+		}
+	}
+
+	Sort(diff.Add)
+	Sort(diff.Delete)
+	sort.SliceStable(diff.Change, func(i, j int) bool {
+		return less(diff.Change[i].To, diff.Change[j].To)
+	})
+	return diff
+}
+
+// Sort orders records by owner, type and value
+func Sort(records []Record) {
+	sort.SliceStable(records, func(i, j int) bool { return less(records[i], records[j]) })
+}
+
+// less orders the apex first, then owners, types and values
+func less(a, b Record) bool {
+	if a.SubDomain != b.SubDomain {
+		return a.SubDomain < b.SubDomain
+	}
+	if a.Type != b.Type {
+		return a.Type < b.Type
+	}
+	return a.Target < b.Target
+}
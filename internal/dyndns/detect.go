@@ -0,0 +1,105 @@
+// internal/dyndns/detect.go
+
+// Package dyndns keeps DNS records pointing to the public addresses of the
+// host it runs on
+package dyndns
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os/exec"
+	"strings"
+	"time"
+
+	"ovh-terminal/internal/config"
+)
+
+// commandTimeout bounds the run of an address command
+const commandTimeout = 30 * time.Second
+
+// Detector returns the current public addresses of the host
+type Detector func(ctx context.Context) ([]net.IP, error)
+
+// NewDetector returns the detector configured by ip_command or interface
+func NewDetector(cfg config.DynDNSConfig) (Detector, error) {
+	switch {
+	case cfg.IPCommand != "":
+		return CommandDetector(cfg.IPCommand), nil
+	case cfg.Interface != "":
+		return InterfaceDetector(cfg.Interface), nil
+	}
+	return nil, fmt.Errorf("set dyndns.ip_command or dyndns.interface to detect the public address")
+}
+
+// CommandDetector runs a shell command and reads the addresses it prints,
+// such as `curl -s https://ifconfig.me`. Words that are not addresses are
+// skipped.
+func CommandDetector(command string) Detector {
+	return func(ctx context.Context) ([]net.IP, error) {
+		ctx, cancel := context.WithTimeout(ctx, commandTimeout)
+		defer cancel()
+
+		output, err := exec.CommandContext(ctx, "sh", "-c", command).Output()
+		if err != nil {
+			return nil, fmt.Errorf("address command failed: %w", err)
+		}
+
+		var addresses []net.IP
+		for _, field := range strings.Fields(string(output)) {
+			if ip := net.ParseIP(field); ip != nil {
+				addresses = append(addresses, ip)
+			}
+		}
+		if len(addresses) == 0 {
+			return nil, fmt.Errorf("address command printed no address: %q", strings.TrimSpace(string(output)))
+		}
+		return addresses, nil
+	}
+}
+
+// InterfaceDetector reads the public addresses of a network interface.
+// Private, loopback and link-local addresses are skipped.
+func InterfaceDetector(name string) Detector {
+	return func(ctx context.Context) ([]net.IP, error) {
+		iface, err := net.InterfaceByName(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find interface %s: %w", name, err)
+		}
+		addrs, err := iface.Addrs()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read addresses of %s: %w", name, err)
+		}
+
+		addresses := publicAddresses(addrs)
+		if len(addresses) == 0 {
+			return nil, fmt.Errorf("interface %s has no public address", name)
+		}
+		return addresses, nil
+	}
+}
+
+// publicAddresses keeps the global unicast, non-private addresses of an
+// interface
+func publicAddresses(addrs []net.Addr) []net.IP {
+	var addresses []net.IP
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok || !ipNet.IP.IsGlobalUnicast() || ipNet.IP.IsPrivate() {
+			continue
+		}
+		addresses = append(addresses, ipNet.IP)
+	}
+	return addresses
+}
+
+// addressFor returns the first address fitting a record type, nil when
+// there is none
+func addressFor(addresses []net.IP, recordType string) net.IP {
+	for _, ip := range addresses {
+		if (ip.To4() != nil) == (recordType == "A") {
+			return ip
+		}
+	}
+	return nil
+}
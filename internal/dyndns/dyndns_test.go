@@ -0,0 +1,240 @@
+// internal/dyndns/dyndns_test.go
+package dyndns
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"ovh-terminal/internal/api"
+	"ovh-terminal/internal/config"
+	"ovh-terminal/internal/logger"
+	"ovh-terminal/internal/ovhfake"
+)
+
+func TestAddressFor(t *testing.T) {
+	v4, v6 := net.ParseIP("203.0.113.20"), net.ParseIP("2001:db8::20")
+	tests := []struct {
+		name       string
+		addresses  []net.IP
+		recordType string
+		want       net.IP
+	}{
+		{"A picks IPv4", []net.IP{v6, v4}, "A", v4},
+		{"AAAA picks IPv6", []net.IP{v4, v6}, "AAAA", v6},
+		{"A without IPv4", []net.IP{v6}, "A", nil},
+		{"AAAA without IPv6", []net.IP{v4}, "AAAA", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := addressFor(tt.addresses, tt.recordType); !got.Equal(tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPublicAddresses(t *testing.T) {
+	tests := []struct {
+		addr   string
+		public bool
+	}{
+		{"203.0.113.5/24", true},
+		{"2001:db8::5/64", true},
+		{"127.0.0.1/8", false},
+		{"::1/128", false},
+		{"10.0.0.2/8", false},
+		{"192.168.1.2/24", false},
+		{"fd00::2/8", false},
+		{"fe80::2/64", false},
+		{"169.254.0.2/16", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			ip, ipNet, err := net.ParseCIDR(tt.addr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ipNet.IP = ip
+			got := publicAddresses([]net.Addr{ipNet})
+			if (len(got) == 1) != tt.public {
+				t.Errorf("Expected public=%v, got %v", tt.public, got)
+			}
+		})
+	}
+
+	if got := publicAddresses([]net.Addr{&net.IPAddr{IP: net.ParseIP("203.0.113.5")}}); len(got) != 0 {
+		t.Errorf("Expected addresses without a network to be skipped, got %v", got)
+	}
+}
+
+func TestCommandDetector(t *testing.T) {
+	tests := []struct {
+		command string
+		want    []string
+		err     string
+	}{
+		{"echo 203.0.113.7", []string{"203.0.113.7"}, ""},
+		{"echo 'v4: 203.0.113.7 v6: 2001:db8::7'", []string{"203.0.113.7", "2001:db8::7"}, ""},
+		{"echo unknown", nil, "printed no address"},
+		{"exit 3", nil, "address command failed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			got, err := CommandDetector(tt.command)(context.Background())
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("Expected error containing %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Detection failed: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected %v, got %v", tt.want, got)
+			}
+			for i, ip := range got {
+				if ip.String() != tt.want[i] {
+					t.Errorf("Expected %v, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+// fakeUpdater creates an updater against a fake server with a fixed set
+// of detected addresses
+func fakeUpdater(t *testing.T, srv *ovhfake.Server, cfg config.DynDNSConfig, addresses ...string) *Updater {
+	t.Helper()
+	client, err := api.NewClient(srv.AccountConfig(), logger.NewLogger())
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	var ips []net.IP
+	for _, address := range addresses {
+		ips = append(ips, net.ParseIP(address))
+	}
+	detect := func(ctx context.Context) ([]net.IP, error) { return ips, nil }
+
+	updater, err := NewUpdater(client, cfg, logger.NewLogger(), WithDetector(detect))
+	if err != nil {
+		t.Fatalf("NewUpdater failed: %v", err)
+	}
+	return updater
+}
+
+// writes lists the requests of the fake server that change something
+func writes(srv *ovhfake.Server) []string {
+	var writes []string
+	for _, req := range srv.Requests() {
+		if !strings.HasPrefix(req, "GET ") {
+			writes = append(writes, req)
+		}
+	}
+	return writes
+}
+
+func TestUpdaterCheck(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       config.DynDNSConfig
+		addresses []string
+		// seed runs before the first check
+		seed func(t *testing.T, client *api.Client)
+		// verify checks the fake server after two checks
+		verify func(t *testing.T, srv *ovhfake.Server)
+	}{
+		{
+			name: "updates only on change",
+			cfg: config.DynDNSConfig{Records: []config.DynDNSRecord{
+				{Zone: "example.com", SubDomain: "office", Type: "A"},
+			}},
+			addresses: []string{"198.51.100.9"},
+			verify: func(t *testing.T, srv *ovhfake.Server) {
+				// One create and one refresh, the second check changes nothing
+				if got := writes(srv); len(got) != 2 {
+					t.Errorf("Expected a single update, got %v", got)
+				}
+			},
+		},
+		{
+			name: "apex leaves subdomains alone",
+			cfg: config.DynDNSConfig{Records: []config.DynDNSRecord{
+				{Zone: "example.com", Type: "A"},
+			}},
+			addresses: []string{"198.51.100.9"},
+			seed: func(t *testing.T, client *api.Client) {
+				_, err := client.CreateZoneRecord("example.com", api.DNSRecord{
+					SubDomain: "office", FieldType: "A", Target: "192.0.2.1",
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+			},
+			verify: func(t *testing.T, srv *ovhfake.Server) {
+				for _, record := range srv.Fixtures().Zones["example.com"].Records {
+					if record.FieldType != "A" {
+						continue
+					}
+					want := "198.51.100.9"
+					if record.SubDomain == "office" {
+						want = "192.0.2.1"
+					}
+					if record.Target != want {
+						t.Errorf("Expected %q to point to %s, got %+v", record.SubDomain, want, record)
+					}
+				}
+			},
+		},
+		{
+			name: "dynhost uses the IPv4 address",
+			cfg: config.DynDNSConfig{
+				Method:  config.DynDNSDynHost,
+				Records: []config.DynDNSRecord{{Zone: "example.org", SubDomain: "home", Type: "A"}},
+			},
+			addresses: []string{"2001:db8::44", "192.0.2.44"},
+			verify: func(t *testing.T, srv *ovhfake.Server) {
+				hosts := srv.Fixtures().Zones["example.org"].DynHosts
+				if len(hosts) != 1 || hosts["1"].IP != "192.0.2.44" {
+					t.Errorf("Expected one DynHost on the IPv4 address, got %+v", hosts)
+				}
+			},
+		},
+		{
+			name: "dynhost without IPv4 skips",
+			cfg: config.DynDNSConfig{
+				Method:  config.DynDNSDynHost,
+				Records: []config.DynDNSRecord{{Zone: "example.org", SubDomain: "home", Type: "A"}},
+			},
+			addresses: []string{"2001:db8::44"},
+			verify: func(t *testing.T, srv *ovhfake.Server) {
+				if got := writes(srv); len(got) != 0 {
+					t.Errorf("Expected no update without an IPv4 address, got %v", got)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := ovhfake.NewServer()
+			defer srv.Close()
+
+			updater := fakeUpdater(t, srv, tt.cfg, tt.addresses...)
+			if tt.seed != nil {
+				tt.seed(t, updater.client)
+			}
+			srv.Reset()
+
+			for i := 0; i < 2; i++ {
+				if err := updater.Check(context.Background()); err != nil {
+					t.Fatalf("Check %d failed: %v", i+1, err)
+				}
+			}
+			tt.verify(t, srv)
+		})
+	}
+}
@@ -0,0 +1,244 @@
+// internal/dyndns/updater.go
+package dyndns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"time"
+
+	"ovh-terminal/internal/api"
+	"ovh-terminal/internal/config"
+	"ovh-terminal/internal/logger"
+)
+
+// Updater keeps the configured records pointing to the detected addresses.
+// It remembers the address of each record and only calls the API when it
+// changes.
+type Updater struct {
+	client   *api.Client
+	log      *logger.Logger
+	detect   Detector
+	method   string
+	interval time.Duration
+	records  []config.DynDNSRecord
+	current  map[string]string
+}
+
+// UpdaterOption configures an Updater
+type UpdaterOption func(*Updater)
+
+// WithDetector replaces the configured address detection
+func WithDetector(detect Detector) UpdaterOption {
+	return func(u *Updater) {
+		u.detect = detect
+	}
+}
+
+// NewUpdater creates an updater for the records of the dyndns settings
+func NewUpdater(client *api.Client, cfg config.DynDNSConfig, log *logger.Logger, opts ...UpdaterOption) (*Updater, error) {
+	u := &Updater{
+		client:   client,
+		log:      log,
+		method:   cfg.UpdateMethod(),
+		interval: cfg.CheckInterval(),
+		records:  cfg.Records,
+		current:  make(map[string]string),
+	}
+	for _, opt := range opts {
+		opt(u)
+	}
+
+	if len(u.records) == 0 {
+		return nil, fmt.Errorf("no dyndns records configured")
+	}
+	if u.detect == nil {
+		detect, err := NewDetector(cfg)
+		if err != nil {
+			return nil, err
+		}
+		u.detect = detect
+	}
+	return u, nil
+}
+
+// Run checks the addresses at every interval until the context ends.
+// Failed updates are logged and retried at the next check.
+func (u *Updater) Run(ctx context.Context) error {
+	u.log.Info("Starting dynamic DNS updater", "records", len(u.records), "method", u.method,
+		"interval", u.interval)
+
+	ticker := time.NewTicker(u.interval)
+	defer ticker.Stop()
+	for {
+		if err := u.Check(ctx); err != nil {
+			u.log.Error("Address check failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			u.log.Info("Stopping dynamic DNS updater")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check detects the addresses once and updates the records that changed,
+// refreshing their zones
+func (u *Updater) Check(ctx context.Context) error {
+	addresses, err := u.detect(ctx)
+	if err != nil {
+		return err
+	}
+	u.log.Debug("Detected addresses", "addresses", addresses)
+
+	var errs []error
+	refresh := make(map[string]bool)
+	for _, record := range u.records {
+		host := hostName(record)
+		ip := addressFor(addresses, record.Type)
+		if ip == nil {
+			u.log.Warn("No address for record", "record", host, "type", record.Type)
+			continue
+		}
+
+		key := record.Zone + " " + record.SubDomain + " " + record.Type
+		if u.current[key] == ip.String() {
+			u.log.Debug("Address unchanged", "record", host, "type", record.Type, "address", ip)
+			continue
+		}
+
+		changed, err := u.update(record, ip)
+		if err != nil {
+			u.log.Error("Failed to update record", "record", host, "type", record.Type, "error", err)
+			errs = append(errs, err)
+			continue
+		}
+		u.current[key] = ip.String()
+		if changed {
+			u.log.Info("Updated record", "record", host, "type", record.Type, "address", ip)
+			refresh[record.Zone] = true
+		} else {
+			u.log.Info("Record already up to date", "record", host, "type", record.Type, "address", ip)
+		}
+	}
+
+	zones := make([]string, 0, len(refresh))
+	for zone := range refresh {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+	for _, zone := range zones {
+		if err := u.client.RefreshDNSZone(zone); err != nil {
+			u.log.Error("Failed to refresh zone", "zone", zone, "error", err)
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// update points a record to an address, reporting whether it changed
+func (u *Updater) update(record config.DynDNSRecord, ip net.IP) (bool, error) {
+	if u.method == config.DynDNSDynHost {
+		return u.updateDynHost(record, ip)
+	}
+	return u.updateZoneRecord(record, ip)
+}
+
+// updateZoneRecord creates or updates the A or AAAA record of a
+// subdomain. The TTL of an existing record is kept unless one is set.
+func (u *Updater) updateZoneRecord(record config.DynDNSRecord, ip net.IP) (bool, error) {
+	ids, err := u.client.ListZoneRecords(record.Zone, record.Type, record.SubDomain)
+	if err != nil {
+		return false, err
+	}
+
+	// An empty subdomain filter lists the whole zone, keep the apex only
+	var existing []*api.DNSRecord
+	for _, id := range ids {
+		current, err := u.client.GetZoneRecord(record.Zone, id)
+		if err != nil {
+			return false, err
+		}
+		if current.SubDomain == record.SubDomain {
+			existing = append(existing, current)
+		}
+	}
+
+	switch len(existing) {
+	case 0:
+		_, err := u.client.CreateZoneRecord(record.Zone, api.DNSRecord{
+			SubDomain: record.SubDomain,
+			FieldType: record.Type,
+			Target:    ip.String(),
+			TTL:       record.TTL,
+		})
+		return err == nil, err
+	case 1:
+	default:
+		return false, fmt.Errorf("%s has %d %s records, expected one", hostName(record), len(existing), record.Type)
+	}
+
+	current := existing[0]
+	if target := net.ParseIP(current.Target); target != nil && target.Equal(ip) &&
+		(record.TTL == 0 || record.TTL == current.TTL) {
+		return false, nil
+	}
+	ttl := record.TTL
+	if ttl == 0 {
+		ttl = current.TTL
+	}
+	err = u.client.UpdateZoneRecord(record.Zone, current.ID, api.DNSRecord{
+		SubDomain: record.SubDomain,
+		Target:    ip.String(),
+		TTL:       ttl,
+	})
+	return err == nil, err
+}
+
+// updateDynHost creates or updates the DynHost record of a subdomain
+func (u *Updater) updateDynHost(record config.DynDNSRecord, ip net.IP) (bool, error) {
+	ids, err := u.client.ListDynHostRecords(record.Zone, record.SubDomain)
+	if err != nil {
+		return false, err
+	}
+
+	var existing []*api.DynHostRecord
+	for _, id := range ids {
+		current, err := u.client.GetDynHostRecord(record.Zone, id)
+		if err != nil {
+			return false, err
+		}
+		if current.SubDomain == record.SubDomain {
+			existing = append(existing, current)
+		}
+	}
+
+	switch len(existing) {
+	case 0:
+		_, err := u.client.CreateDynHostRecord(record.Zone, record.SubDomain, ip.String())
+		return err == nil, err
+	case 1:
+	default:
+		return false, fmt.Errorf("%s has %d DynHost records, expected one", hostName(record), len(existing))
+	}
+
+	current := existing[0]
+	if target := net.ParseIP(current.IP); target != nil && target.Equal(ip) {
+		return false, nil
+	}
+	err = u.client.UpdateDynHostRecord(record.Zone, current.ID, ip.String())
+	return err == nil, err
+}
+
+// hostName returns the fully qualified name of a record
+func hostName(record config.DynDNSRecord) string {
+	if record.SubDomain == "" {
+		return record.Zone
+	}
+	return record.SubDomain + "." + record.Zone
+}
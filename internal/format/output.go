@@ -127,6 +127,18 @@ func (s *Section) AddField(key, value string) *Section {
 	return s
 }
 
+// AddLines adds a field whose value spans several lines
+func (s *Section) AddLines(key string, lines []string) *Section {
+	if len(lines) > 0 {
+		s.Content = append(s.Content, Field{
+			Key:         key,
+			ValueLines:  lines,
+			SkipIfEmpty: true,
+		})
+	}
+	return s
+}
+
 // AddFields adds multiple fields at once
 func (s *Section) AddFields(fields map[string]string) *Section {
 	for key, value := range fields {
@@ -0,0 +1,83 @@
+// internal/format/values.go
+package format
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Layouts used when displaying API timestamps
+const (
+	DateLayout     = "2006-01-02"
+	DateTimeLayout = "2006-01-02 15:04"
+)
+
+// Date formats an optional timestamp as a day, "-" when unset
+func Date(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format(DateLayout)
+}
+
+// DateTime formats an optional timestamp to the minute, "-" when unset
+func DateTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format(DateTimeLayout)
+}
+
+// Duration formats a duration in its two largest units, like "2d 4h"
+func Duration(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	minutes := int(d.Minutes()) % 60
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
+
+// Amount formats a price with two decimals followed by a currency symbol
+// or code
+func Amount(value float64, currency string) string {
+	if currency == "" {
+		return fmt.Sprintf("%.2f", value)
+	}
+	return fmt.Sprintf("%.2f %s", value, currency)
+}
+
+// Bytes formats a size with binary units, "1.5 GiB"
+func Bytes(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+// Bar renders used out of limit as a bar of width cells, "█████░░░░░".
+// A zero limit renders an empty bar.
+func Bar(used, limit float64, width int) string {
+	filled := 0
+	if limit > 0 {
+		filled = int(used/limit*float64(width) + 0.5)
+	}
+	filled = min(max(filled, 0), width)
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
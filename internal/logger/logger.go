@@ -107,6 +107,12 @@ func (l *Logger) Configure(level string, logFile string, useConsole bool) error
 	return nil
 }
 
+// SetConsole enables or disables console logging, for modes running
+// without the UI
+func (l *Logger) SetConsole(enabled bool) {
+	l.console = enabled
+}
+
 // With creates a new logger with additional fields
 func (l *Logger) With(fields map[string]interface{}) *Logger {
 	newLogger := &Logger{
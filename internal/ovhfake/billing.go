@@ -0,0 +1,233 @@
+// internal/ovhfake/billing.go
+package ovhfake
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"ovh-terminal/internal/api"
+)
+
+// DownloadPath serves invoice documents. Like the real links, they are
+// authorized by the bill password in the query, not by a signature.
+const DownloadPath = "/cgi-bin/order/"
+
+// billTaxRate is the VAT applied to fake bills
+const billTaxRate = 0.2
+
+// Bill is a fake invoice with its lines and payment, nil when unpaid
+type Bill struct {
+	api.Bill
+	Details map[string]api.BillDetail `json:"-"`
+	Payment *api.BillPayment          `json:"-"`
+}
+
+// euros returns a price in euros
+func euros(value float64) api.Price {
+	return api.Price{CurrencyCode: "EUR", Text: fmt.Sprintf("%.2f €", value), Value: value}
+}
+
+// newBill builds a bill issued on date from its lines. Lines are keyed by
+// their position, prices are computed from them.
+func newBill(id string, date time.Time, paid bool, details ...api.BillDetail) *Bill {
+	bill := &Bill{
+		Bill: api.Bill{
+			BillID:   id,
+			Date:     timePtr(date),
+			Category: "autorenew",
+			Password: "pw" + strings.ToLower(id),
+		},
+		Details: make(map[string]api.BillDetail),
+	}
+
+	total := 0.0
+	for i, detail := range details {
+		detail.BillDetailID = fmt.Sprintf("%s%02d", id, i+1)
+		detail.UnitPrice = euros(detail.TotalPrice.Value)
+		detail.Quantity = "1"
+		detail.PeriodStart = date.Format("2006-01-02")
+		detail.PeriodEnd = date.AddDate(0, 1, -1).Format("2006-01-02")
+		bill.Details[detail.BillDetailID] = detail
+		total += detail.TotalPrice.Value
+	}
+
+	tax := math.Round(total*billTaxRate*100) / 100
+	bill.PriceWithoutTax = euros(total)
+	bill.Tax = euros(tax)
+	bill.PriceWithTax = euros(math.Round((total+tax)*100) / 100)
+	if paid {
+		bill.Payment = &api.BillPayment{
+			PaymentDate:       timePtr(date.AddDate(0, 0, 2)),
+			PaymentIdentifier: "**** 4242",
+			PaymentType:       "creditCard",
+		}
+	}
+	return bill
+}
+
+// billLine is a fake bill line for a service
+func billLine(domain, description string, price float64) api.BillDetail {
+	return api.BillDetail{Domain: domain, Description: description, TotalPrice: euros(price)}
+}
+
+// defaultBills returns invoices issued at the start of the current and
+// two previous months, and one from last year, so that they stay inside
+// the listed range whatever the date
+func defaultBills(now time.Time) map[string]*Bill {
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	server := billLine("ns1001.ip-203-0-113.eu", "Advance-1 server rental", 89.99)
+
+	bills := []*Bill{
+		newBill("FR54012345", month, false,
+			server,
+			billLine("example.com", "Domain name renewal", 9.99)),
+		newBill("FR53998765", month.AddDate(0, -1, 0), true,
+			server,
+			billLine("vps-0a1b2c3d.vps.ovh.net", "VPS Value 1-4-80", 6.49)),
+		newBill("FR53870021", month.AddDate(0, -2, 0), true, server),
+		newBill("FR52011234", month.AddDate(-1, -2, 0), true,
+			billLine("example.org", "Domain name renewal", 9.99)),
+	}
+
+	result := make(map[string]*Bill, len(bills))
+	for _, bill := range bills {
+		result[bill.BillID] = bill
+	}
+	return result
+}
+
+// serverURL returns the scheme and host the request was sent to, for
+// links back to the fake server
+func serverURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
+
+// billURL returns the link to a document of a bill, on the fake server
+func billURL(r *http.Request, bill *Bill, document string) string {
+	query := url.Values{"reference": {bill.BillID}, "passwd": {bill.Password}}
+	return fmt.Sprintf("%s%sfacture.%s?%s", serverURL(r), DownloadPath, document, query.Encode())
+}
+
+// bill returns the bill of the request, writing a 404 when it is unknown.
+// Callers must hold s.mu.
+func (s *Server) bill(w http.ResponseWriter, r *http.Request) (*Bill, bool) {
+	id := r.PathValue("bill")
+	bill, ok := s.fixtures.Bills[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+id+") does not exist")
+		return nil, false
+	}
+	return bill, true
+}
+
+// listBills filters bills on the date.from and date.to parameters
+func (s *Server) listBills(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var from, to time.Time
+	for name, bound := range map[string]*time.Time{"date.from": &from, "date.to": &to} {
+		value := r.URL.Query().Get(name)
+		if value == "" {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid "+name+": "+value)
+			return
+		}
+		*bound = date
+	}
+
+	ids := make([]string, 0, len(s.fixtures.Bills))
+	for id, bill := range s.fixtures.Bills {
+		if !from.IsZero() && bill.Date.Before(from) {
+			continue
+		}
+		if !to.IsZero() && bill.Date.After(to) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	writeJSON(w, http.StatusOK, ids)
+}
+
+// getBill fills in the document links of a bill
+func (s *Server) getBill(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	bill, ok := s.bill(w, r)
+	if !ok {
+		return
+	}
+	result := bill.Bill
+	result.PdfURL = billURL(r, bill, "pdf")
+	result.URL = billURL(r, bill, "html")
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) listBillDetails(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if bill, ok := s.bill(w, r); ok {
+		writeJSON(w, http.StatusOK, sortedKeys(bill.Details))
+	}
+}
+
+func (s *Server) getBillDetail(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if bill, ok := s.bill(w, r); ok {
+		writeFixture(w, bill.Details, r.PathValue("detail"))
+	}
+}
+
+// getBillPayment answers 404 for unpaid bills, like the real API
+func (s *Server) getBillPayment(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	bill, ok := s.bill(w, r)
+	if !ok {
+		return
+	}
+	if bill.Payment == nil {
+		writeError(w, http.StatusNotFound, "This bill has not been paid")
+		return
+	}
+	writeJSON(w, http.StatusOK, bill.Payment)
+}
+
+// downloadBill serves a placeholder PDF or HTML invoice
+func (s *Server) downloadBill(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query := r.URL.Query()
+	bill, ok := s.fixtures.Bills[query.Get("reference")]
+	if !ok || query.Get("passwd") != bill.Password {
+		http.Error(w, "Invoice not found", http.StatusNotFound)
+		return
+	}
+
+	switch strings.TrimPrefix(r.URL.Path, DownloadPath) {
+	case "facture.pdf":
+		w.Header().Set("Content-Type", "application/pdf")
+		fmt.Fprintf(w, "%%PDF-1.4\n%% Invoice %s, %s\n%%%%EOF\n", bill.BillID, bill.PriceWithTax.Text)
+	case "facture.html":
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprintf(w, "<html><body><h1>Invoice %s</h1><p>Total: %s</p></body></html>\n",
+			bill.BillID, bill.PriceWithTax.Text)
+	default:
+		http.NotFound(w, r)
+	}
+}
@@ -0,0 +1,610 @@
+// internal/ovhfake/cloud.go
+package ovhfake
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"ovh-terminal/internal/api"
+)
+
+// instanceTransitions maps the transitional statuses set by instance
+// actions to the status they settle in
+var instanceTransitions = map[string]string{
+	"POWERING_ON":  api.CloudInstanceActive,
+	"POWERING_OFF": api.CloudInstanceShutoff,
+	"REBOOT":       api.CloudInstanceActive,
+	"HARD_REBOOT":  api.CloudInstanceActive,
+	"SHELVING":     api.CloudInstanceShelvedOffloaded,
+	"UNSHELVING":   api.CloudInstanceActive,
+	"RESCUING":     api.CloudInstanceRescue,
+	"UNRESCUING":   api.CloudInstanceActive,
+}
+
+// cloudProject returns the project of the request, writing a 404 when it
+// is unknown. Callers must hold s.mu.
+func (s *Server) cloudProject(w http.ResponseWriter, r *http.Request) (*CloudProject, bool) {
+	id := r.PathValue("id")
+	project, ok := s.fixtures.CloudProjects[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+id+") does not exist")
+		return nil, false
+	}
+	return project, true
+}
+
+// sortedValues returns the values of a fixture map ordered by key
+func sortedValues[V any](m map[string]V) []V {
+	values := make([]V, 0, len(m))
+	for _, key := range sortedKeys(m) {
+		values = append(values, m[key])
+	}
+	return values
+}
+
+// settleInstances moves transitional instances to their final status, so
+// that each transition is seen by exactly one read, and starts pending
+// transitions
+func settleInstances(project *CloudProject) {
+	for id, instance := range project.Instances {
+		if final, ok := instanceTransitions[instance.Status]; ok {
+			instance.Status = final
+			project.Instances[id] = instance
+		}
+	}
+	for id, status := range project.pending {
+		instance := project.Instances[id]
+		instance.Status = status
+		project.Instances[id] = instance
+	}
+	project.pending = nil
+}
+
+func (s *Server) listCloudInstances(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if project, ok := s.cloudProject(w, r); ok {
+		writeJSON(w, http.StatusOK, sortedValues(project.Instances))
+		settleInstances(project)
+	}
+}
+
+func (s *Server) getCloudInstance(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	project, ok := s.cloudProject(w, r)
+	if !ok {
+		return
+	}
+
+	instance, ok := project.Instances[r.PathValue("instance")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+r.PathValue("instance")+") does not exist")
+		return
+	}
+	for _, flavor := range project.Flavors {
+		if flavor.ID == instance.FlavorID {
+			instance.Flavor = &flavor
+		}
+	}
+	writeJSON(w, http.StatusOK, instance)
+	settleInstances(project)
+}
+
+// cloudInstanceAction applies a lifecycle action to an instance. The
+// instance enters the transitional status, from one of the listed
+// statuses only.
+func (s *Server) cloudInstanceAction(transition string, from ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		project, ok := s.cloudProject(w, r)
+		if !ok {
+			return
+		}
+		id := r.PathValue("instance")
+		instance, ok := project.Instances[id]
+		if !ok {
+			writeError(w, http.StatusNotFound, "The requested object ("+id+") does not exist")
+			return
+		}
+
+		status, allowedFrom := transition, from
+		if transition == "REBOOT" {
+			var payload struct {
+				Type string `json:"type"`
+			}
+			if !readJSON(w, r, &payload) {
+				return
+			}
+			// Only a hard reboot recovers an instance in error
+			if payload.Type == api.CloudRebootHard {
+				status = "HARD_REBOOT"
+				allowedFrom = append([]string{api.CloudInstanceError}, from...)
+			}
+		}
+
+		allowed := false
+		for _, status := range allowedFrom {
+			allowed = allowed || instance.Status == status
+		}
+		if !allowed {
+			writeError(w, http.StatusBadRequest, "Instance is "+instance.Status)
+			return
+		}
+
+		// An instance in error keeps reporting it until the action starts
+		if instance.Status == api.CloudInstanceError {
+			if project.pending == nil {
+				project.pending = make(map[string]string)
+			}
+			project.pending[id] = status
+			writeJSON(w, http.StatusOK, nil)
+			return
+		}
+
+		instance.Status = status
+		project.Instances[id] = instance
+		writeJSON(w, http.StatusOK, nil)
+	}
+}
+
+func (s *Server) setCloudInstanceRescue(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	project, ok := s.cloudProject(w, r)
+	if !ok {
+		return
+	}
+	id := r.PathValue("instance")
+	instance, ok := project.Instances[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+id+") does not exist")
+		return
+	}
+
+	var payload struct {
+		Rescue bool `json:"rescue"`
+	}
+	if !readJSON(w, r, &payload) {
+		return
+	}
+
+	result := api.CloudRescue{}
+	switch {
+	case payload.Rescue && instance.Status != api.CloudInstanceRescue:
+		instance.Status = "RESCUING"
+		result.AdminPassword = "rescue-Xk2p9QwL"
+	case !payload.Rescue && instance.Status == api.CloudInstanceRescue:
+		instance.Status = "UNRESCUING"
+	default:
+		writeError(w, http.StatusBadRequest, "Instance is "+instance.Status)
+		return
+	}
+	project.Instances[id] = instance
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) createCloudInstanceSnapshot(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	project, ok := s.cloudProject(w, r)
+	if !ok {
+		return
+	}
+	id := r.PathValue("instance")
+	instance, ok := project.Instances[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+id+") does not exist")
+		return
+	}
+
+	var payload struct {
+		SnapshotName string `json:"snapshotName"`
+	}
+	if !readJSON(w, r, &payload) {
+		return
+	}
+	if strings.TrimSpace(payload.SnapshotName) == "" {
+		writeError(w, http.StatusBadRequest, "Missing parameter snapshotName")
+		return
+	}
+
+	// Like image IDs, snapshot IDs stay unique when a name is reused
+	base := "s-" + strings.ReplaceAll(payload.SnapshotName, " ", "-")
+	snapshotID := base
+	for n := 2; ; n++ {
+		if _, exists := project.Snapshots[snapshotID]; !exists {
+			break
+		}
+		snapshotID = fmt.Sprintf("%s-%d", base, n)
+	}
+	project.Snapshots[snapshotID] = api.CloudSnapshot{
+		ID:           snapshotID,
+		Name:         payload.SnapshotName,
+		Region:       instance.Region,
+		Status:       "queued",
+		MinDisk:      50,
+		Type:         "linux",
+		Visibility:   "private",
+		CreationDate: timePtr(time.Now().UTC()),
+	}
+	writeJSON(w, http.StatusOK, nil)
+}
+
+func (s *Server) listCloudFlavors(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if project, ok := s.cloudProject(w, r); ok {
+		writeJSON(w, http.StatusOK, project.Flavors)
+	}
+}
+
+func (s *Server) listCloudVolumes(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if project, ok := s.cloudProject(w, r); ok {
+		writeJSON(w, http.StatusOK, sortedValues(project.Volumes))
+	}
+}
+
+func (s *Server) getCloudVolume(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if project, ok := s.cloudProject(w, r); ok {
+		writeFixture(w, project.Volumes, r.PathValue("volume"))
+	}
+}
+
+// snapshotTransitions maps the statuses of new snapshots to the next one
+var snapshotTransitions = map[string]string{
+	"queued": "saving",
+	"saving": "active",
+}
+
+func (s *Server) listCloudSnapshots(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	project, ok := s.cloudProject(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, sortedValues(project.Snapshots))
+	for id, snapshot := range project.Snapshots {
+		if next, ok := snapshotTransitions[snapshot.Status]; ok {
+			snapshot.Status = next
+			project.Snapshots[id] = snapshot
+		}
+	}
+}
+
+func (s *Server) getCloudSnapshot(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if project, ok := s.cloudProject(w, r); ok {
+		writeFixture(w, project.Snapshots, r.PathValue("snapshot"))
+	}
+}
+
+func (s *Server) listCloudNetworks(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if project, ok := s.cloudProject(w, r); ok {
+		writeJSON(w, http.StatusOK, sortedValues(project.Networks))
+	}
+}
+
+func (s *Server) getCloudNetwork(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if project, ok := s.cloudProject(w, r); ok {
+		writeFixture(w, project.Networks, r.PathValue("network"))
+	}
+}
+
+func (s *Server) listCloudSSHKeys(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if project, ok := s.cloudProject(w, r); ok {
+		writeJSON(w, http.StatusOK, sortedValues(project.SSHKeys))
+	}
+}
+
+func (s *Server) getCloudSSHKey(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if project, ok := s.cloudProject(w, r); ok {
+		writeFixture(w, project.SSHKeys, r.PathValue("key"))
+	}
+}
+
+// cloudUsage serves a usage report, empty when the project has none
+func (s *Server) cloudUsage(report func(*CloudProject) *api.CloudUsage) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		project, ok := s.cloudProject(w, r)
+		if !ok {
+			return
+		}
+		usage := report(project)
+		if usage == nil {
+			usage = &api.CloudUsage{}
+		}
+		writeJSON(w, http.StatusOK, usage)
+	}
+}
+
+func (s *Server) listKubeClusters(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if project, ok := s.cloudProject(w, r); ok {
+		writeJSON(w, http.StatusOK, sortedKeys(project.Kube))
+	}
+}
+
+func (s *Server) getKubeCluster(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if project, ok := s.cloudProject(w, r); ok {
+		writeFixture(w, project.Kube, r.PathValue("kube"))
+	}
+}
+
+func (s *Server) listKubeNodePools(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	project, ok := s.cloudProject(w, r)
+	if !ok {
+		return
+	}
+	id := r.PathValue("kube")
+	if _, ok := project.Kube[id]; !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+id+") does not exist")
+		return
+	}
+	pools := project.NodePools[id]
+	if pools == nil {
+		pools = []api.KubeNodePool{}
+	}
+	writeJSON(w, http.StatusOK, pools)
+}
+
+func (s *Server) getKubeconfig(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	project, ok := s.cloudProject(w, r)
+	if !ok {
+		return
+	}
+	id := r.PathValue("kube")
+	cluster, ok := project.Kube[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+id+") does not exist")
+		return
+	}
+
+	content := fmt.Sprintf(`apiVersion: v1
+clusters:
+- cluster:
+    certificate-authority-data: ZmFrZS1jYQ==
+    server: %[2]s
+  name: %[1]s
+contexts:
+- context:
+    cluster: %[1]s
+    user: kubernetes-admin-%[1]s
+  name: kubernetes-admin@%[1]s
+current-context: kubernetes-admin@%[1]s
+kind: Config
+preferences: {}
+users:
+- name: kubernetes-admin-%[1]s
+  user:
+    client-certificate-data: ZmFrZS1jZXJ0
+    client-key-data: ZmFrZS1rZXk=
+`, cluster.Name, cluster.URL)
+	writeJSON(w, http.StatusOK, api.Kubeconfig{Content: content})
+}
+
+func (s *Server) listCloudRegions(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if project, ok := s.cloudProject(w, r); ok {
+		regions := project.Regions
+		if regions == nil {
+			regions = []string{}
+		}
+		writeJSON(w, http.StatusOK, regions)
+	}
+}
+
+func (s *Server) listStorageContainers(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	project, ok := s.cloudProject(w, r)
+	if !ok {
+		return
+	}
+
+	region := r.PathValue("region")
+	containers := []api.StorageContainer{}
+	for _, container := range sortedValues(project.Containers) {
+		if container.Region == region {
+			container.Objects = nil
+			containers = append(containers, container)
+		}
+	}
+	writeJSON(w, http.StatusOK, containers)
+}
+
+func (s *Server) getStorageContainer(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if project, ok := s.cloudProject(w, r); ok {
+		writeFixture(w, project.Containers, r.PathValue("region")+"/"+r.PathValue("name"))
+	}
+}
+
+func (s *Server) listCloudUsers(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if project, ok := s.cloudProject(w, r); ok {
+		writeJSON(w, http.StatusOK, sortedValues(project.Users))
+	}
+}
+
+func (s *Server) getCloudUser(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if project, ok := s.cloudProject(w, r); ok {
+		writeFixture(w, project.Users, r.PathValue("user"))
+	}
+}
+
+// cloudUser returns the project and user ID of the request, writing a 404
+// when either is unknown. Callers must hold s.mu.
+func (s *Server) cloudUser(w http.ResponseWriter, r *http.Request) (*CloudProject, string, bool) {
+	project, ok := s.cloudProject(w, r)
+	if !ok {
+		return nil, "", false
+	}
+	id := r.PathValue("user")
+	if _, ok := project.Users[id]; !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+id+") does not exist")
+		return nil, "", false
+	}
+	return project, id, true
+}
+
+func (s *Server) listS3Credentials(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	project, id, ok := s.cloudUser(w, r)
+	if !ok {
+		return
+	}
+
+	credentials := []api.S3Credentials{}
+	for _, credential := range project.S3Keys[id] {
+		credential.Secret = ""
+		credentials = append(credentials, credential)
+	}
+	writeJSON(w, http.StatusOK, credentials)
+}
+
+func (s *Server) createS3Credentials(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	project, id, ok := s.cloudUser(w, r)
+	if !ok {
+		return
+	}
+
+	n := len(project.S3Keys[id]) + 1
+	credential := api.S3Credentials{
+		Access:   fmt.Sprintf("AKIA0NEW%07d", n),
+		Secret:   fmt.Sprintf("s3cr3t-new-%04d", n),
+		TenantID: project.ProjectID,
+		UserID:   id,
+	}
+	if project.S3Keys == nil {
+		project.S3Keys = make(map[string][]api.S3Credentials)
+	}
+	project.S3Keys[id] = append(project.S3Keys[id], credential)
+	writeJSON(w, http.StatusOK, credential)
+}
+
+func (s *Server) getS3Secret(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	project, id, ok := s.cloudUser(w, r)
+	if !ok {
+		return
+	}
+
+	access := r.PathValue("access")
+	for _, credential := range project.S3Keys[id] {
+		if credential.Access == access {
+			writeJSON(w, http.StatusOK, map[string]string{"secret": credential.Secret})
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "The requested object ("+access+") does not exist")
+}
+
+func (s *Server) deleteS3Credentials(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	project, id, ok := s.cloudUser(w, r)
+	if !ok {
+		return
+	}
+
+	access := r.PathValue("access")
+	keys := project.S3Keys[id]
+	for i, credential := range keys {
+		if credential.Access == access {
+			project.S3Keys[id] = append(keys[:i], keys[i+1:]...)
+			writeJSON(w, http.StatusOK, nil)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "The requested object ("+access+") does not exist")
+}
+
+// regionLocations maps region names to their datacenter location
+var regionLocations = map[string]string{
+	"GRA": "Gravelines",
+	"SBG": "Strasbourg",
+	"BHS": "Beauharnois",
+	"WAW": "Warsaw",
+}
+
+func (s *Server) getCloudRegion(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	project, ok := s.cloudProject(w, r)
+	if !ok {
+		return
+	}
+	name := r.PathValue("region")
+	for _, region := range project.Regions {
+		if region == name {
+			writeJSON(w, http.StatusOK, api.CloudRegion{
+				Name:               name,
+				Status:             "UP",
+				ContinentCode:      "EU",
+				DatacenterLocation: regionLocations[strings.TrimRight(name, "0123456789")],
+			})
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "The requested object ("+name+") does not exist")
+}
+
+func (s *Server) listAvailableCloudRegions(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if project, ok := s.cloudProject(w, r); ok {
+		regions := project.Available
+		if regions == nil {
+			regions = []api.CloudRegion{}
+		}
+		writeJSON(w, http.StatusOK, regions)
+	}
+}
+
+func (s *Server) listCloudQuotas(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if project, ok := s.cloudProject(w, r); ok {
+		quotas := project.Quotas
+		if quotas == nil {
+			quotas = []api.CloudQuota{}
+		}
+		writeJSON(w, http.StatusOK, quotas)
+	}
+}
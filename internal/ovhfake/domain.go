@@ -0,0 +1,768 @@
+// internal/ovhfake/domain.go
+package ovhfake
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"ovh-terminal/internal/api"
+	"ovh-terminal/internal/dnszone"
+)
+
+// Domain is a fake domain name. Nameservers come from DomainInfo, with
+// IDs following their order; DNSSEC keys are only set with external
+// nameservers.
+type Domain struct {
+	api.DomainInfo
+	DSKeys []api.DSKey `json:"-"`
+}
+
+// Zone is a fake DNS zone. Refreshes counts the refresh calls, the point
+// where changes reach the nameservers.
+type Zone struct {
+	api.DNSZone
+	DNSSEC       string                        `json:"-"`
+	Records      map[string]api.DNSRecord      `json:"-"`
+	DynHosts     map[string]api.DynHostRecord  `json:"-"`
+	Redirections map[string]api.WebRedirection `json:"-"`
+	Refreshes    int                           `json:"-"`
+}
+
+// defaultDomains returns example.com, locked and signed, and example.org
+// without DNSSEC nor transfer lock. Both are served by OVHcloud DNS.
+func defaultDomains() map[string]*Domain {
+	return map[string]*Domain{
+		"example.com": {
+			DomainInfo: api.DomainInfo{
+				Domain:             "example.com",
+				NameServers:        []string{"dns10.ovh.net", "ns10.ovh.net"},
+				NameServerType:     api.NameServerHosted,
+				DnssecStatus:       api.DNSSECEnabled,
+				DnssecSupported:    true,
+				TransferLockStatus: api.TransferLocked,
+				WhoisOwner:         "1234567",
+				Expiration:         time.Date(2027, 3, 14, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		"example.org": {
+			DomainInfo: api.DomainInfo{
+				Domain:             "example.org",
+				NameServers:        []string{"dns11.ovh.net", "ns11.ovh.net"},
+				NameServerType:     api.NameServerHosted,
+				DnssecStatus:       api.DNSSECDisabled,
+				DnssecSupported:    true,
+				TransferLockStatus: api.TransferUnlocked,
+				WhoisOwner:         "1234567",
+				Expiration:         time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+}
+
+// defaultZones returns the zones of the default domains. example.com
+// serves a website and mail, and redirects its shop to an external store.
+func defaultZones() map[string]*Zone {
+	updated := time.Date(2026, 9, 1, 8, 30, 0, 0, time.UTC)
+	return map[string]*Zone{
+		"example.com": {
+			DNSZone: api.DNSZone{
+				Name:            "example.com",
+				NameServers:     []string{"dns10.ovh.net", "ns10.ovh.net"},
+				DnssecSupported: true,
+				LastUpdate:      &updated,
+			},
+			DNSSEC: api.DNSSECEnabled,
+			Records: zoneRecords("example.com", []api.DNSRecord{
+				{ID: 5001, FieldType: "NS", Target: "dns10.ovh.net."},
+				{ID: 5002, FieldType: "NS", Target: "ns10.ovh.net."},
+				{ID: 5003, FieldType: "A", Target: "203.0.113.20"},
+				{ID: 5004, FieldType: "MX", Target: "1 mx1.mail.ovh.net."},
+				{ID: 5005, FieldType: "TXT", TTL: 600, Target: `"v=spf1 include:mx.ovh.com ~all"`},
+				{ID: 5006, SubDomain: "www", FieldType: "CNAME", Target: "example.com."},
+			}),
+			Redirections: map[string]api.WebRedirection{
+				"1": {
+					ID:        1,
+					Zone:      "example.com",
+					SubDomain: "shop",
+					Target:    "https://store.example.net/example",
+					Type:      api.RedirectionVisiblePermanent,
+				},
+			},
+		},
+		"example.org": {
+			DNSZone: api.DNSZone{
+				Name:            "example.org",
+				NameServers:     []string{"dns11.ovh.net", "ns11.ovh.net"},
+				DnssecSupported: true,
+				LastUpdate:      &updated,
+			},
+			DNSSEC: api.DNSSECDisabled,
+		},
+	}
+}
+
+// zoneRecords indexes records by ID, setting their zone
+func zoneRecords(zone string, records []api.DNSRecord) map[string]api.DNSRecord {
+	indexed := make(map[string]api.DNSRecord, len(records))
+	for _, record := range records {
+		record.Zone = zone
+		indexed[strconv.FormatInt(record.ID, 10)] = record
+	}
+	return indexed
+}
+
+// domainTask is the view of a task returned by the domain API
+type domainTask struct {
+	ID       int        `json:"id"`
+	Function string     `json:"function"`
+	Status   string     `json:"status"`
+	TodoDate time.Time  `json:"todoDate"`
+	DoneDate *time.Time `json:"doneDate"`
+}
+
+// newDomainTask registers a task and returns its domain view. Callers
+// must hold s.mu.
+func (s *Server) newDomainTask(function string, onDone func()) domainTask {
+	t := s.newTask(function, onDone)
+	return domainTask{ID: t.ID, Function: t.Function, Status: t.Status, TodoDate: t.StartDate}
+}
+
+// domain returns the domain of the request, writing a 404 when it is
+// unknown. Callers must hold s.mu.
+func (s *Server) domain(w http.ResponseWriter, r *http.Request) (*Domain, bool) {
+	name := r.PathValue("domain")
+	domain, ok := s.fixtures.Domains[name]
+	if !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+name+") does not exist")
+		return nil, false
+	}
+	return domain, true
+}
+
+// zone returns the DNS zone of the request, writing a 404 when it is
+// unknown. Callers must hold s.mu.
+func (s *Server) zone(w http.ResponseWriter, r *http.Request) (*Zone, bool) {
+	name := r.PathValue("zone")
+	zone, ok := s.fixtures.Zones[name]
+	if !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+name+") does not exist")
+		return nil, false
+	}
+	return zone, true
+}
+
+func (s *Server) listDomains(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, sortedKeys(s.fixtures.Domains))
+}
+
+func (s *Server) getDomain(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if domain, ok := s.domain(w, r); ok {
+		writeJSON(w, http.StatusOK, domain.DomainInfo)
+	}
+}
+
+func (s *Server) updateDomain(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	domain, ok := s.domain(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		NameServerType     *string `json:"nameServerType"`
+		TransferLockStatus *string `json:"transferLockStatus"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.NameServerType != nil {
+		switch *req.NameServerType {
+		case api.NameServerHosted, api.NameServerExternal:
+			domain.NameServerType = *req.NameServerType
+		default:
+			writeError(w, http.StatusBadRequest, "Invalid nameServerType: "+*req.NameServerType)
+			return
+		}
+	}
+	if req.TransferLockStatus != nil {
+		switch *req.TransferLockStatus {
+		case api.TransferLocked, api.TransferUnlocked:
+			domain.TransferLockStatus = *req.TransferLockStatus
+		default:
+			writeError(w, http.StatusBadRequest, "Invalid transferLockStatus: "+*req.TransferLockStatus)
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, nil)
+}
+
+func (s *Server) listDomainNameServers(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if domain, ok := s.domain(w, r); ok {
+		ids := make([]int64, len(domain.NameServers))
+		for i := range domain.NameServers {
+			ids[i] = int64(i + 1)
+		}
+		writeJSON(w, http.StatusOK, ids)
+	}
+}
+
+func (s *Server) getDomainNameServer(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	domain, ok := s.domain(w, r)
+	if !ok {
+		return
+	}
+	id, _ := strconv.Atoi(r.PathValue("id"))
+	if id < 1 || id > len(domain.NameServers) {
+		writeError(w, http.StatusNotFound, "The requested object ("+r.PathValue("id")+") does not exist")
+		return
+	}
+	writeJSON(w, http.StatusOK, api.DomainNameServer{
+		ID:     int64(id),
+		Host:   domain.NameServers[id-1],
+		IsUsed: true,
+	})
+}
+
+func (s *Server) updateDomainNameServers(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	domain, ok := s.domain(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		NameServers []api.NameServerInput `json:"nameServers"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if len(req.NameServers) < 2 {
+		writeError(w, http.StatusBadRequest, "At least two nameservers are required")
+		return
+	}
+
+	hosts := make([]string, len(req.NameServers))
+	for i, server := range req.NameServers {
+		hosts[i] = server.Host
+	}
+	writeJSON(w, http.StatusOK, s.newDomainTask("DomainDnsUpdate", func() {
+		domain.NameServers = hosts
+	}))
+}
+
+func (s *Server) listDomainDSRecords(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if domain, ok := s.domain(w, r); ok {
+		ids := make([]int64, len(domain.DSKeys))
+		for i := range domain.DSKeys {
+			ids[i] = int64(i + 1)
+		}
+		writeJSON(w, http.StatusOK, ids)
+	}
+}
+
+func (s *Server) getDomainDSRecord(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	domain, ok := s.domain(w, r)
+	if !ok {
+		return
+	}
+	id, _ := strconv.Atoi(r.PathValue("id"))
+	if id < 1 || id > len(domain.DSKeys) {
+		writeError(w, http.StatusNotFound, "The requested object ("+r.PathValue("id")+") does not exist")
+		return
+	}
+	writeJSON(w, http.StatusOK, api.DomainDSRecord{
+		DSKey:  domain.DSKeys[id-1],
+		ID:     int64(id),
+		Status: "created",
+	})
+}
+
+func (s *Server) updateDomainDSRecords(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	domain, ok := s.domain(w, r)
+	if !ok {
+		return
+	}
+	if domain.NameServerType != api.NameServerExternal {
+		writeError(w, http.StatusBadRequest, "DS records are managed by the hosted zone")
+		return
+	}
+
+	var req struct {
+		Keys []api.DSKey `json:"keys"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	writeJSON(w, http.StatusOK, s.newDomainTask("DomainDsUpdate", func() {
+		domain.DSKeys = req.Keys
+		domain.DnssecStatus = api.DNSSECDisabled
+		if len(req.Keys) > 0 {
+			domain.DnssecStatus = api.DNSSECEnabled
+		}
+	}))
+}
+
+func (s *Server) getDomainTask(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.domain(w, r); !ok {
+		return
+	}
+	id, _ := strconv.Atoi(r.PathValue("id"))
+	t, ok := s.tasks[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+r.PathValue("id")+") does not exist")
+		return
+	}
+	t.advance()
+	writeJSON(w, http.StatusOK, domainTask{
+		ID: t.ID, Function: t.Function, Status: t.Status, TodoDate: t.StartDate, DoneDate: t.DoneDate,
+	})
+}
+
+func (s *Server) getZone(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if zone, ok := s.zone(w, r); ok {
+		writeJSON(w, http.StatusOK, zone.DNSZone)
+	}
+}
+
+func (s *Server) refreshZone(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if zone, ok := s.zone(w, r); ok {
+		zone.Refreshes++
+		zone.LastUpdate = timePtr(time.Now().UTC().Truncate(time.Second))
+		writeJSON(w, http.StatusOK, nil)
+	}
+}
+
+func (s *Server) getZoneDNSSEC(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if zone, ok := s.zone(w, r); ok {
+		writeJSON(w, http.StatusOK, api.ZoneDNSSEC{Status: zone.DNSSEC})
+	}
+}
+
+// setZoneDNSSEC returns a handler that signs or stops signing a zone. The
+// change is immediate, along with the DNSSEC status of the domain.
+func (s *Server) setZoneDNSSEC(status string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		zone, ok := s.zone(w, r)
+		if !ok {
+			return
+		}
+		if zone.DNSSEC == status {
+			writeError(w, http.StatusConflict, "DNSSEC is already "+status+" on "+zone.Name)
+			return
+		}
+		zone.DNSSEC = status
+		if domain, ok := s.fixtures.Domains[zone.Name]; ok {
+			domain.DnssecStatus = status
+		}
+		writeJSON(w, http.StatusOK, nil)
+	}
+}
+
+func (s *Server) listZoneRedirections(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if zone, ok := s.zone(w, r); ok {
+		writeJSON(w, http.StatusOK, sortedIDs(zone.Redirections))
+	}
+}
+
+func (s *Server) getZoneRedirection(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if zone, ok := s.zone(w, r); ok {
+		writeFixture(w, zone.Redirections, r.PathValue("id"))
+	}
+}
+
+func (s *Server) createZoneRedirection(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	zone, ok := s.zone(w, r)
+	if !ok {
+		return
+	}
+
+	var req api.WebRedirection
+	if !readJSON(w, r, &req) {
+		return
+	}
+	switch req.Type {
+	case api.RedirectionVisible, api.RedirectionVisiblePermanent, api.RedirectionInvisible:
+	default:
+		writeError(w, http.StatusBadRequest, "Invalid type: "+req.Type)
+		return
+	}
+	if req.Target == "" {
+		writeError(w, http.StatusBadRequest, "target is required")
+		return
+	}
+	for _, existing := range zone.Redirections {
+		if existing.SubDomain == req.SubDomain {
+			writeError(w, http.StatusConflict, "A redirection already exists for "+existing.Host())
+			return
+		}
+	}
+
+	if zone.Redirections == nil {
+		zone.Redirections = make(map[string]api.WebRedirection)
+	}
+	id := int64(1)
+	for _, existing := range sortedIDs(zone.Redirections) {
+		if existing >= id {
+			id = existing + 1
+		}
+	}
+	req.ID, req.Zone = id, zone.Name
+	zone.Redirections[strconv.FormatInt(id, 10)] = req
+	writeJSON(w, http.StatusOK, req)
+}
+
+func (s *Server) deleteZoneRedirection(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	zone, ok := s.zone(w, r)
+	if !ok {
+		return
+	}
+	id := r.PathValue("id")
+	if _, ok := zone.Redirections[id]; !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+id+") does not exist")
+		return
+	}
+	delete(zone.Redirections, id)
+	writeJSON(w, http.StatusOK, nil)
+}
+
+func (s *Server) listZoneRecords(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	zone, ok := s.zone(w, r)
+	if !ok {
+		return
+	}
+
+	fieldType, subDomain := r.URL.Query().Get("fieldType"), r.URL.Query().Get("subDomain")
+	matching := make(map[string]api.DNSRecord)
+	for id, record := range zone.Records {
+		if (fieldType == "" || record.FieldType == fieldType) && (subDomain == "" || record.SubDomain == subDomain) {
+			matching[id] = record
+		}
+	}
+	writeJSON(w, http.StatusOK, sortedIDs(matching))
+}
+
+func (s *Server) getZoneRecord(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if zone, ok := s.zone(w, r); ok {
+		writeFixture(w, zone.Records, r.PathValue("id"))
+	}
+}
+
+// zoneRecordTypes are the record types the fake zone API accepts
+var zoneRecordTypes = map[string]bool{
+	"A": true, "AAAA": true, "CAA": true, "CNAME": true, "DKIM": true, "DMARC": true, "DNAME": true,
+	"LOC": true, "MX": true, "NAPTR": true, "NS": true, "PTR": true, "SPF": true, "SRV": true,
+	"SSHFP": true, "TLSA": true, "TXT": true,
+}
+
+// nextRecordID returns the ID following the records of a zone
+func nextRecordID(zone *Zone) int64 {
+	id := int64(1)
+	for _, existing := range sortedIDs(zone.Records) {
+		if existing >= id {
+			id = existing + 1
+		}
+	}
+	return id
+}
+
+func (s *Server) createZoneRecord(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	zone, ok := s.zone(w, r)
+	if !ok {
+		return
+	}
+
+	var req api.DNSRecord
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if !zoneRecordTypes[req.FieldType] {
+		writeError(w, http.StatusBadRequest, "Invalid fieldType: "+req.FieldType)
+		return
+	}
+	if req.Target == "" {
+		writeError(w, http.StatusBadRequest, "target is required")
+		return
+	}
+
+	if zone.Records == nil {
+		zone.Records = make(map[string]api.DNSRecord)
+	}
+	req.ID, req.Zone = nextRecordID(zone), zone.Name
+	zone.Records[strconv.FormatInt(req.ID, 10)] = req
+	writeJSON(w, http.StatusOK, req)
+}
+
+func (s *Server) updateZoneRecord(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	zone, ok := s.zone(w, r)
+	if !ok {
+		return
+	}
+	id := r.PathValue("id")
+	record, ok := zone.Records[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+id+") does not exist")
+		return
+	}
+
+	var req struct {
+		SubDomain *string `json:"subDomain"`
+		Target    *string `json:"target"`
+		TTL       *int    `json:"ttl"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.SubDomain != nil {
+		record.SubDomain = *req.SubDomain
+	}
+	if req.Target != nil {
+		record.Target = *req.Target
+	}
+	if req.TTL != nil {
+		record.TTL = *req.TTL
+	}
+	zone.Records[id] = record
+	writeJSON(w, http.StatusOK, nil)
+}
+
+func (s *Server) deleteZoneRecord(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	zone, ok := s.zone(w, r)
+	if !ok {
+		return
+	}
+	id := r.PathValue("id")
+	if _, ok := zone.Records[id]; !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+id+") does not exist")
+		return
+	}
+	delete(zone.Records, id)
+	writeJSON(w, http.StatusOK, nil)
+}
+
+func (s *Server) listDynHostRecords(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	zone, ok := s.zone(w, r)
+	if !ok {
+		return
+	}
+
+	subDomain := r.URL.Query().Get("subDomain")
+	matching := make(map[string]api.DynHostRecord)
+	for id, record := range zone.DynHosts {
+		if subDomain == "" || record.SubDomain == subDomain {
+			matching[id] = record
+		}
+	}
+	writeJSON(w, http.StatusOK, sortedIDs(matching))
+}
+
+func (s *Server) getDynHostRecord(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if zone, ok := s.zone(w, r); ok {
+		writeFixture(w, zone.DynHosts, r.PathValue("id"))
+	}
+}
+
+// createDynHostRecord adds a DynHost record, which only takes IPv4
+// addresses
+func (s *Server) createDynHostRecord(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	zone, ok := s.zone(w, r)
+	if !ok {
+		return
+	}
+
+	var req api.DynHostRecord
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if ip := net.ParseIP(req.IP); ip == nil || ip.To4() == nil {
+		writeError(w, http.StatusBadRequest, "Invalid IPv4 address: "+req.IP)
+		return
+	}
+
+	if zone.DynHosts == nil {
+		zone.DynHosts = make(map[string]api.DynHostRecord)
+	}
+	id := int64(1)
+	for _, existing := range sortedIDs(zone.DynHosts) {
+		if existing >= id {
+			id = existing + 1
+		}
+	}
+	req.ID, req.Zone = id, zone.Name
+	zone.DynHosts[strconv.FormatInt(id, 10)] = req
+	writeJSON(w, http.StatusOK, req)
+}
+
+func (s *Server) updateDynHostRecord(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	zone, ok := s.zone(w, r)
+	if !ok {
+		return
+	}
+	id := r.PathValue("id")
+	record, ok := zone.DynHosts[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+id+") does not exist")
+		return
+	}
+
+	var req api.DynHostRecord
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if ip := net.ParseIP(req.IP); ip == nil || ip.To4() == nil {
+		writeError(w, http.StatusBadRequest, "Invalid IPv4 address: "+req.IP)
+		return
+	}
+	record.IP = req.IP
+	zone.DynHosts[id] = record
+	writeJSON(w, http.StatusOK, nil)
+}
+
+// exportZone writes the zone in BIND format, records without a TTL
+// taking the zone default
+func (s *Server) exportZone(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	zone, ok := s.zone(w, r)
+	if !ok {
+		return
+	}
+
+	var b strings.Builder
+	b.WriteString("$TTL 3600\n")
+	fmt.Fprintf(&b, "@\tIN SOA %s. tech.ovh.net. (%s 86400 3600 3600000 60)\n",
+		zone.NameServers[0], zone.LastUpdate.Format("2006010215"))
+	for _, record := range dnsRecords(zone.Records) {
+		b.WriteString(record.String() + "\n")
+	}
+	writeJSON(w, http.StatusOK, b.String())
+}
+
+// importZone replaces the records of a zone with a zone file once the
+// task completes
+func (s *Server) importZone(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	zone, ok := s.zone(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		ZoneFile string `json:"zoneFile"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	records, err := dnszone.Parse(zone.Name, req.ZoneFile)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid zone file: "+err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.newDomainTask("DnsZoneImport", func() {
+		id := nextRecordID(zone)
+		imported := make([]api.DNSRecord, len(records))
+		for i, record := range records {
+			imported[i] = api.DNSRecord{
+				ID:        id + int64(i),
+				SubDomain: record.SubDomain,
+				FieldType: record.Type,
+				Target:    record.Target,
+				TTL:       record.TTL,
+			}
+		}
+		zone.Records = zoneRecords(zone.Name, imported)
+		zone.LastUpdate = timePtr(time.Now().UTC().Truncate(time.Second))
+	}))
+}
+
+func (s *Server) getZoneTask(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.zone(w, r); !ok {
+		return
+	}
+	id, _ := strconv.Atoi(r.PathValue("id"))
+	t, ok := s.tasks[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+r.PathValue("id")+") does not exist")
+		return
+	}
+	t.advance()
+	writeJSON(w, http.StatusOK, domainTask{
+		ID: t.ID, Function: t.Function, Status: t.Status, TodoDate: t.StartDate, DoneDate: t.DoneDate,
+	})
+}
+
+// dnsRecords converts the records of a zone, sorted as in a zone file
+func dnsRecords(records map[string]api.DNSRecord) []dnszone.Record {
+	converted := make([]dnszone.Record, 0, len(records))
+	for _, record := range records {
+		converted = append(converted, dnszone.Record{
+			ID:        record.ID,
+			SubDomain: record.SubDomain,
+			Type:      record.FieldType,
+			TTL:       record.TTL,
+			Target:    record.Target,
+		})
+	}
+	dnszone.Sort(converted)
+	return converted
+}
@@ -0,0 +1,2124 @@
+// internal/ovhfake/e2e_test.go
+package ovhfake_test
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"ovh-terminal/internal/api"
+	"ovh-terminal/internal/commands"
+	"ovh-terminal/internal/config"
+	"ovh-terminal/internal/dyndns"
+	"ovh-terminal/internal/logger"
+	"ovh-terminal/internal/ovhfake"
+	"ovh-terminal/internal/ui/types"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// fastRetry keeps retry tests quick
+var fastRetry = api.RetryConfig{
+	MaxRetries:  3,
+	BaseDelay:   time.Millisecond,
+	MaxDelay:    10 * time.Millisecond,
+	RetryOnCode: []int{429, 503},
+}
+
+func newClient(t *testing.T, srv *ovhfake.Server) *api.Client {
+	t.Helper()
+	client, err := api.NewClient(srv.AccountConfig(), logger.NewLogger(), api.WithRetry(fastRetry))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	return client
+}
+
+// progressRecorder collects the progress messages of a command
+type progressRecorder struct {
+	messages []string
+}
+
+func (r *progressRecorder) ReportProgress(progress commands.CommandProgress) {
+	r.messages = append(r.messages, progress.Message)
+}
+
+func TestMeCommand(t *testing.T) {
+	srv := ovhfake.NewServer()
+	defer srv.Close()
+
+	output, err := commands.NewMeCommand(newClient(t, srv)).Execute()
+	if err != nil {
+		t.Fatalf("MeCommand failed: %v", err)
+	}
+
+	for _, want := range []string{"dj12345-ovh", "Jane Doe", "EUR"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestServerCommand(t *testing.T) {
+	srv := ovhfake.NewServer()
+	defer srv.Close()
+
+	servers, err := commands.NewServerCommand(newClient(t, srv)).ListServers()
+	if err != nil {
+		t.Fatalf("ListServers failed: %v", err)
+	}
+
+	expected := map[string]string{
+		"ns1001.ip-203-0-113.eu": "web1",
+		"ns1002.ip-203-0-113.eu": "ns1002.ip-203-0-113.eu",
+	}
+	for id, name := range expected {
+		if servers[id] != name {
+			t.Errorf("Expected display name %q for %s, got %q", name, id, servers[id])
+		}
+	}
+}
+
+func TestInventory(t *testing.T) {
+	srv := ovhfake.NewServer()
+	defer srv.Close()
+	client := newClient(t, srv)
+
+	domains, err := client.ListDomains()
+	if err != nil || len(domains) != 2 {
+		t.Fatalf("Expected 2 domains, got %v (err %v)", domains, err)
+	}
+
+	ips, err := client.ListIPs()
+	if err != nil || len(ips) != 3 {
+		t.Fatalf("Expected 3 IPs, got %v (err %v)", ips, err)
+	}
+
+	info, err := client.GetIPInfo("198.51.100.8/29")
+	if err != nil {
+		t.Fatalf("GetIPInfo failed: %v", err)
+	}
+	if !info.IsFailover() {
+		t.Errorf("Expected failover IP, got type %s", info.Type)
+	}
+
+	projects, err := client.ListCloudProjects()
+	if err != nil || len(projects) != 2 {
+		t.Fatalf("Expected 2 cloud projects, got %v (err %v)", projects, err)
+	}
+
+	vps, err := client.GetVPSInfo("vps-0a1b2c3d.vps.ovh.net")
+	if err != nil {
+		t.Fatalf("GetVPSInfo failed: %v", err)
+	}
+	if vps.GetDisplayTitle() != "staging" {
+		t.Errorf("Expected VPS display title staging, got %s", vps.GetDisplayTitle())
+	}
+}
+
+func TestMenuLoadsServers(t *testing.T) {
+	srv := ovhfake.NewServer()
+	defer srv.Close()
+
+	model := types.NewModel()
+	model.SetAPIClient(newClient(t, srv))
+	model.List = list.New(types.CreateBaseMenuItems(), types.NewDefaultDelegate(), 0, 0)
+
+	expand := func(title string) {
+		t.Helper()
+		for i, item := range model.List.Items() {
+			if item.(*types.ListItem).Title() == title {
+				model.ToggleItemExpanded(i)
+				model.UpdateMenuItems()
+				return
+			}
+		}
+		t.Fatalf("Menu item %q not found", title)
+	}
+
+	hasItem := func(title string) bool {
+		for _, item := range model.List.Items() {
+			if item.(*types.ListItem).Title() == title {
+				return true
+			}
+		}
+		return false
+	}
+
+	expand("Bare Metal Cloud")
+	expand("Dedicated Servers")
+	if !hasItem("web1") {
+		t.Error("Expected server web1 in the menu")
+	}
+
+	expand("IP addresses")
+	expand("Failover IPs")
+	if !hasItem("198.51.100.8/29") {
+		t.Error("Expected failover block in the menu")
+	}
+}
+
+func TestPublicCloud(t *testing.T) {
+	srv := ovhfake.NewServer()
+	defer srv.Close()
+	client := newClient(t, srv)
+
+	model := types.NewModel()
+	model.SetAPIClient(client)
+	model.List = list.New(types.CreateBaseMenuItems(), types.NewDefaultDelegate(), 0, 0)
+
+	find := func(title string) (int, *types.ListItem) {
+		for i, item := range model.List.Items() {
+			if listItem := item.(*types.ListItem); listItem.Title() == title {
+				return i, listItem
+			}
+		}
+		return -1, nil
+	}
+	expand := func(title string) {
+		t.Helper()
+		i, item := find(title)
+		if item == nil {
+			t.Fatalf("Menu item %q not found", title)
+		}
+		model.ToggleItemExpanded(i)
+		model.UpdateMenuItems()
+	}
+
+	expand("Public Cloud")
+	analytics, _ := find("analytics")
+	production, _ := find("production")
+	if analytics < 0 || production < analytics {
+		t.Errorf("Expected projects sorted by description, got analytics at %d, production at %d",
+			analytics, production)
+	}
+
+	expand("production")
+	expand("Instances")
+	_, web := find("web-1")
+	if web == nil {
+		t.Fatal("Expected instance web-1 in the menu")
+	}
+	if want := "b2-7 · GRA11 · ACTIVE · 51.68.10.20"; web.Description() != want {
+		t.Errorf("Expected instance summary %q, got %q", want, web.Description())
+	}
+	if kind, id := web.GetResource(); kind != "cloud-instance" || !strings.HasSuffix(id, "/i-web-1") {
+		t.Errorf("Expected instance resource, got %s %s", kind, id)
+	}
+
+	const projectID = "5c0e1f2a3b4c4d5e8f9a0b1c2d3e4f5a"
+	output, err := commands.NewCloudResourceCommand(client, commands.CloudResourceInstance,
+		projectID, "i-web-1").Execute()
+	if err != nil || !strings.Contains(output, "7000 MB") || !strings.Contains(output, "10.0.0.12") {
+		t.Errorf("Expected instance flavor and addresses, got %q (err %v)", output, err)
+	}
+
+	output, err = commands.NewCloudResourceCommand(client, commands.CloudResourceVolume,
+		projectID, "v-data").Execute()
+	if err != nil || !strings.Contains(output, "web-1 (i-web-1)") {
+		t.Errorf("Expected volume attached to web-1, got %q (err %v)", output, err)
+	}
+
+	output, err = commands.NewCloudProjectCommand(client, projectID).Execute()
+	if err != nil || !strings.Contains(output, "Instances") {
+		t.Errorf("Expected project overview, got %q (err %v)", output, err)
+	}
+}
+
+func TestCloudInstanceLifecycle(t *testing.T) {
+	srv := ovhfake.NewServer()
+	defer srv.Close()
+	client := newClient(t, srv)
+	fast := commands.WithPollInterval(time.Millisecond)
+
+	const projectID = "5c0e1f2a3b4c4d5e8f9a0b1c2d3e4f5a"
+	status := func(id string) string {
+		t.Helper()
+		instance, err := client.GetCloudInstance(projectID, id)
+		if err != nil {
+			t.Fatalf("GetCloudInstance failed: %v", err)
+		}
+		return instance.Status
+	}
+
+	t.Run("confirmation", func(t *testing.T) {
+		cmd := commands.NewCloudInstanceActionCommand(client, projectID, "i-web-1", commands.InstanceStop)
+		prompt, err := cmd.NextPrompt()
+		if err != nil || prompt == nil || prompt.Kind != commands.PromptConfirm {
+			t.Fatalf("Expected confirmation prompt, got %+v (err %v)", prompt, err)
+		}
+		if !strings.Contains(prompt.Label, "web-1 (ACTIVE)") {
+			t.Errorf("Expected instance and status in the prompt, got %q", prompt.Label)
+		}
+
+		_, err = commands.NewCloudInstanceActionCommand(client, projectID, "i-worker-1",
+			commands.InstanceStop).NextPrompt()
+		if err == nil {
+			t.Error("Expected stop of a stopped instance to be refused")
+		}
+	})
+
+	t.Run("status transitions", func(t *testing.T) {
+		steps := []struct {
+			kind string
+			want string
+		}{
+			{commands.InstanceSoftReboot, "ACTIVE"},
+			{commands.InstanceStop, "SHUTOFF"},
+			{commands.InstanceShelve, "SHELVED_OFFLOADED"},
+			{commands.InstanceUnshelve, "ACTIVE"},
+			{commands.InstanceRescue, "RESCUE"},
+			{commands.InstanceUnrescue, "ACTIVE"},
+		}
+		for _, step := range steps {
+			cmd := commands.NewCloudInstanceActionCommand(client, projectID, "i-web-1", step.kind)
+			reporter := &progressRecorder{}
+			cmd.SetProgressReporter(reporter)
+			output, err := cmd.ExecuteWithOptions(fast)
+			if err != nil {
+				t.Fatalf("%s failed: %v", step.kind, err)
+			}
+			if got := status("i-web-1"); got != step.want {
+				t.Errorf("Expected %s after %s, got %s", step.want, step.kind, got)
+			}
+			if len(reporter.messages) < 2 {
+				t.Errorf("Expected %s to report a transition, got %v", step.kind, reporter.messages)
+			}
+			if step.kind == commands.InstanceRescue && !strings.Contains(output, "rescue-Xk2p9QwL") {
+				t.Errorf("Expected rescue password in the output, got %q", output)
+			}
+		}
+	})
+
+	t.Run("hard reboot from error", func(t *testing.T) {
+		instance := srv.Fixtures().CloudProjects[projectID].Instances["i-worker-1"]
+		instance.Status = api.CloudInstanceError
+		srv.Fixtures().CloudProjects[projectID].Instances["i-worker-1"] = instance
+
+		cmd := commands.NewCloudInstanceActionCommand(client, projectID, "i-worker-1", commands.InstanceHardReboot)
+		if _, err := cmd.NextPrompt(); err != nil {
+			t.Fatalf("Expected hard reboot of an instance in error to be allowed, got %v", err)
+		}
+		if _, err := cmd.ExecuteWithOptions(fast); err != nil {
+			t.Fatalf("Hard reboot failed: %v", err)
+		}
+		if got := status("i-worker-1"); got != api.CloudInstanceActive {
+			t.Errorf("Expected ACTIVE after hard reboot, got %s", got)
+		}
+
+		stop := commands.NewCloudInstanceActionCommand(client, projectID, "i-worker-1", commands.InstanceStop)
+		if _, err := stop.ExecuteWithOptions(fast); err != nil {
+			t.Fatalf("Stop failed: %v", err)
+		}
+	})
+
+	t.Run("snapshot", func(t *testing.T) {
+		cmd := commands.NewCloudInstanceActionCommand(client, projectID, "i-worker-1", commands.InstanceSnapshot)
+		prompt, err := cmd.NextPrompt()
+		if err != nil || prompt.Key != "name" || !strings.HasPrefix(prompt.Default, "worker-1-") {
+			t.Fatalf("Expected snapshot name prompt, got %+v (err %v)", prompt, err)
+		}
+		if err := cmd.SetInput("name", "worker-1 before upgrade"); err != nil {
+			t.Fatalf("SetInput failed: %v", err)
+		}
+		if _, err := cmd.ExecuteWithOptions(fast); err != nil {
+			t.Fatalf("Snapshot failed: %v", err)
+		}
+
+		snapshots, err := client.ListCloudSnapshots(projectID)
+		if err != nil {
+			t.Fatalf("ListCloudSnapshots failed: %v", err)
+		}
+		found := false
+		for _, snapshot := range snapshots {
+			found = found || (snapshot.Name == "worker-1 before upgrade" && snapshot.Status == "active")
+		}
+		if !found {
+			t.Errorf("Expected active snapshot, got %+v", snapshots)
+		}
+	})
+
+	t.Run("snapshot with a reused name", func(t *testing.T) {
+		cmd := commands.NewCloudInstanceActionCommand(client, projectID, "i-worker-1", commands.InstanceSnapshot)
+		if err := cmd.SetInput("name", "web-1 weekly"); err != nil {
+			t.Fatalf("SetInput failed: %v", err)
+		}
+		if _, err := cmd.ExecuteWithOptions(fast); err != nil {
+			t.Fatalf("Snapshot failed: %v", err)
+		}
+
+		snapshots, err := client.ListCloudSnapshots(projectID)
+		if err != nil {
+			t.Fatalf("ListCloudSnapshots failed: %v", err)
+		}
+		active := 0
+		for _, snapshot := range snapshots {
+			if snapshot.Name == "web-1 weekly" && snapshot.Status == "active" {
+				active++
+			}
+		}
+		if active != 2 {
+			t.Errorf("Expected the new snapshot to be active on return, got %+v", snapshots)
+		}
+	})
+}
+
+func TestCloudUsage(t *testing.T) {
+	srv := ovhfake.NewServer()
+	defer srv.Close()
+	client := newClient(t, srv)
+
+	const projectID = "5c0e1f2a3b4c4d5e8f9a0b1c2d3e4f5a"
+	output, err := commands.NewCloudUsageCommand(client, projectID).Execute()
+	if err != nil {
+		t.Fatalf("CloudUsageCommand failed: %v", err)
+	}
+	for _, want := range []string{"27.84 €", "31.06 €", "34.72 €", "2026-10-01 to 2026-10-31"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in usage output, got %q", want, output)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "usage.csv")
+	cmd := commands.NewExportCloudUsageCommand(client, projectID)
+	if err := cmd.SetInput("file", path); err != nil {
+		t.Fatalf("SetInput failed: %v", err)
+	}
+	if prompt, err := cmd.NextPrompt(); err != nil || prompt != nil {
+		t.Fatalf("Expected no more prompts for a new file, got %+v (err %v)", prompt, err)
+	}
+	if _, err := cmd.Execute(); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Export file missing: %v", err)
+	}
+	defer file.Close()
+	rows, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("Invalid CSV: %v", err)
+	}
+	if len(rows) != 13 {
+		t.Fatalf("Expected header and 12 usage lines, got %d rows", len(rows))
+	}
+	if want := []string{"current", "hourly", "Instances", "instance", "GRA11", "d2-2",
+		"184", "Hour", "1.84", "EUR"}; strings.Join(rows[1], ",") != strings.Join(want, ",") {
+		t.Errorf("Expected first line %v, got %v", want, rows[1])
+	}
+
+	if prompt, _ := cmd.NextPrompt(); prompt == nil || prompt.Kind != commands.PromptConfirm {
+		t.Errorf("Expected overwrite confirmation for an existing file, got %+v", prompt)
+	}
+}
+
+func TestKubernetes(t *testing.T) {
+	srv := ovhfake.NewServer()
+	defer srv.Close()
+	client := newClient(t, srv)
+
+	const projectID = "5c0e1f2a3b4c4d5e8f9a0b1c2d3e4f5a"
+	output, err := commands.NewCloudResourceCommand(client, commands.CloudResourceKube,
+		projectID, "kube-prod").Execute()
+	if err != nil {
+		t.Fatalf("Cluster details failed: %v", err)
+	}
+	for _, want := range []string{"1.30", "READY", "5 of 5", "autoscaling 3 to 6 nodes", "fixed at 2 nodes"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in cluster details, got %q", want, output)
+		}
+	}
+
+	t.Run("write to file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "kube", "prod.yml")
+		cmd := commands.NewKubeconfigCommand(client, projectID, "kube-prod")
+		if err := cmd.SetInput("target", "file"); err != nil {
+			t.Fatalf("SetInput failed: %v", err)
+		}
+		if err := cmd.SetInput("file", path); err != nil {
+			t.Fatalf("SetInput failed: %v", err)
+		}
+		if _, err := cmd.Execute(); err != nil {
+			t.Fatalf("Kubeconfig download failed: %v", err)
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("Kubeconfig not written: %v", err)
+		}
+		if info.Mode().Perm() != 0o600 {
+			t.Errorf("Expected mode 0600, got %o", info.Mode().Perm())
+		}
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		home := t.TempDir()
+		t.Setenv("HOME", home)
+		existing := "apiVersion: v1\nkind: Config\ncurrent-context: dev\n" +
+			"contexts:\n- name: dev\n  context:\n    cluster: dev\n"
+		if err := os.MkdirAll(filepath.Join(home, ".kube"), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		path := filepath.Join(home, ".kube", "config")
+		if err := os.WriteFile(path, []byte(existing), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		cmd := commands.NewKubeconfigCommand(client, projectID, "kube-prod")
+		if err := cmd.SetInput("target", commands.KubeconfigMerge); err != nil {
+			t.Fatalf("SetInput failed: %v", err)
+		}
+		if prompt, err := cmd.NextPrompt(); err != nil || prompt == nil || prompt.Kind != commands.PromptConfirm {
+			t.Fatalf("Expected merge confirmation, got %+v (err %v)", prompt, err)
+		}
+		output, err := cmd.Execute()
+		if err != nil || !strings.Contains(output, "kubernetes-admin@prod-cluster") {
+			t.Fatalf("Expected merged context in output, got %q (err %v)", output, err)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(data), "name: dev") ||
+			!strings.Contains(string(data), "kubernetes-admin@prod-cluster") ||
+			!strings.Contains(string(data), "current-context: dev") {
+			t.Errorf("Expected both contexts with dev current, got:\n%s", data)
+		}
+		if info, _ := os.Stat(path); info.Mode().Perm() != 0o600 {
+			t.Errorf("Expected merged config mode 0600, got %o", info.Mode().Perm())
+		}
+	})
+}
+
+func TestObjectStorage(t *testing.T) {
+	srv := ovhfake.NewServer()
+	defer srv.Close()
+	client := newClient(t, srv)
+
+	const projectID = "5c0e1f2a3b4c4d5e8f9a0b1c2d3e4f5a"
+	output, err := commands.NewCloudResourceCommand(client, commands.CloudResourceContainer,
+		projectID, commands.ContainerID("GRA", "backups")).Execute()
+	if err != nil {
+		t.Fatalf("Container details failed: %v", err)
+	}
+	for _, want := range []string{"3.0 GiB", "db/2026-10-18.sql.gz", "https://s3.gra.io.cloud.ovh.net"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in container details, got %q", want, output)
+		}
+	}
+
+	output, err = commands.NewCloudResourceCommand(client, commands.CloudResourceUser,
+		projectID, "4242").Execute()
+	if err != nil || !strings.Contains(output, "AKIA0BACKUP0001") || strings.Contains(output, "s3cr3t") {
+		t.Errorf("Expected access key without secret, got %q (err %v)", output, err)
+	}
+
+	t.Run("generate credentials", func(t *testing.T) {
+		cmd := commands.NewCreateS3CredentialsCommand(client, projectID, "4242")
+		prompt, err := cmd.NextPrompt()
+		if err != nil || prompt.Key != "region" || strings.Join(prompt.Choices, ",") != "gra,sbg" {
+			t.Fatalf("Expected region choice gra, sbg, got %+v (err %v)", prompt, err)
+		}
+		if err := cmd.SetInput("region", "SBG"); err != nil {
+			t.Fatalf("SetInput failed: %v", err)
+		}
+		if prompt, _ := cmd.NextPrompt(); prompt == nil || prompt.Kind != commands.PromptConfirm {
+			t.Fatalf("Expected confirmation, got %+v", prompt)
+		}
+
+		output, err := cmd.Execute()
+		if err != nil {
+			t.Fatalf("Generate credentials failed: %v", err)
+		}
+		for _, want := range []string{
+			"aws_secret_access_key = s3cr3t-new-0002",
+			"endpoint_url = https://s3.sbg.io.cloud.ovh.net",
+			"type = s3",
+			"secret_access_key = s3cr3t-new-0002",
+		} {
+			if !strings.Contains(output, want) {
+				t.Errorf("Expected %q in snippets, got %q", want, output)
+			}
+		}
+	})
+
+	t.Run("show and revoke", func(t *testing.T) {
+		cmd := commands.NewS3ConfigCommand(client, projectID, "4242")
+		if err := cmd.SetInput("access", "AKIA0BACKUP0001"); err != nil {
+			t.Fatalf("SetInput failed: %v", err)
+		}
+		if err := cmd.SetInput("region", "gra"); err != nil {
+			t.Fatalf("SetInput failed: %v", err)
+		}
+		output, err := cmd.Execute()
+		if err != nil || !strings.Contains(output, "s3cr3t-backup-0001") {
+			t.Errorf("Expected secret in snippets, got %q (err %v)", output, err)
+		}
+
+		revoke := commands.NewDeleteS3CredentialsCommand(client, projectID, "4242")
+		if err := revoke.SetInput("access", "AKIA0UNKNOWN"); err == nil {
+			t.Error("Expected unknown access key to be rejected")
+		}
+		if err := revoke.SetInput("access", "AKIA0BACKUP0001"); err != nil {
+			t.Fatalf("SetInput failed: %v", err)
+		}
+		if _, err := revoke.Execute(); err != nil {
+			t.Fatalf("Revoke failed: %v", err)
+		}
+		keys, err := client.ListS3Credentials(projectID, "4242")
+		if err != nil || len(keys) != 1 || keys[0].Access == "AKIA0BACKUP0001" {
+			t.Errorf("Expected only the generated key to remain, got %+v (err %v)", keys, err)
+		}
+	})
+}
+
+func TestCloudQuota(t *testing.T) {
+	srv := ovhfake.NewServer()
+	defer srv.Close()
+	client := newClient(t, srv)
+	defer commands.SetQuotaWarning(0)
+
+	const projectID = "5c0e1f2a3b4c4d5e8f9a0b1c2d3e4f5a"
+	output, err := commands.NewCloudQuotaCommand(client, projectID).Execute()
+	if err != nil {
+		t.Fatalf("CloudQuotaCommand failed: %v", err)
+	}
+	for _, want := range []string{
+		"GRA11 cores at 90% (18/20)",
+		"██████████████████░░ 18/20 (90%) ⚠",
+		"Gravelines, UP",
+		"BHS5, WAW1",
+		"b2-7, d2-2",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in quota output, got %q", want, output)
+		}
+	}
+
+	commands.SetQuotaWarning(95)
+	output, err = commands.NewCloudQuotaCommand(client, projectID).Execute()
+	if err != nil || strings.Contains(output, "Quota Warnings") || strings.Contains(output, "⚠") {
+		t.Errorf("Expected no warnings above 95%%, got %q (err %v)", output, err)
+	}
+}
+
+func TestBilling(t *testing.T) {
+	srv := ovhfake.NewServer()
+	defer srv.Close()
+	client := newClient(t, srv)
+
+	invoices, err := commands.NewInvoicesCommand(client).Invoices()
+	if err != nil {
+		t.Fatalf("Invoices failed: %v", err)
+	}
+	if len(invoices) != 3 {
+		t.Fatalf("Expected the 3 invoices of the last twelve months, got %d", len(invoices))
+	}
+	if invoices[0].Bill.BillID != "FR54012345" || invoices[0].Status() != "unpaid" {
+		t.Errorf("Expected the unpaid invoice first, got %s (%s)",
+			invoices[0].Bill.BillID, invoices[0].Status())
+	}
+	if !strings.HasPrefix(invoices[1].Status(), "paid ") {
+		t.Errorf("Expected the previous invoice to be paid, got %q", invoices[1].Status())
+	}
+
+	output, err := commands.NewBillCommand(client, "FR54012345").Execute()
+	if err != nil {
+		t.Fatalf("Bill details failed: %v", err)
+	}
+	for _, want := range []string{"119.98 €", "20.00 €", "Domain name renewal", "example.com"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in bill output, got %q", want, output)
+		}
+	}
+
+	output, err = commands.NewSpendingCommand(client, commands.SpendingMonthly).Execute()
+	if err != nil {
+		t.Fatalf("Monthly spending failed: %v", err)
+	}
+	for _, want := range []string{"343.75 €", "89.99 €  ns1001.ip-203-0-113.eu", "6.49 €  vps-0a1b2c3d.vps.ovh.net"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in spending output, got %q", want, output)
+		}
+	}
+	output, err = commands.NewSpendingCommand(client, commands.SpendingYearly).Execute()
+	if err != nil {
+		t.Fatalf("Yearly spending failed: %v", err)
+	}
+	if !strings.Contains(output, "11.99 €") || !strings.Contains(output, "example.org") {
+		t.Errorf("Expected the invoice of last year in yearly spending, got %q", output)
+	}
+
+	dir := t.TempDir()
+	for _, kind := range []string{commands.BillPDF, commands.BillHTML} {
+		path := filepath.Join(dir, "FR53998765."+kind)
+		cmd := commands.NewDownloadBillCommand(client, "FR53998765", kind)
+		if err := cmd.SetInput("file", path); err != nil {
+			t.Fatalf("SetInput failed: %v", err)
+		}
+		if prompt, err := cmd.NextPrompt(); err != nil || prompt != nil {
+			t.Fatalf("Expected no more prompts for a new file, got %+v (err %v)", prompt, err)
+		}
+		if _, err := cmd.Execute(); err != nil {
+			t.Fatalf("Download failed: %v", err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Invoice file missing: %v", err)
+		}
+		if !strings.Contains(string(data), "FR53998765") {
+			t.Errorf("Expected the %s invoice document, got %q", kind, data)
+		}
+		if prompt, _ := cmd.NextPrompt(); prompt == nil || prompt.Kind != commands.PromptConfirm {
+			t.Errorf("Expected overwrite confirmation for an existing file, got %+v", prompt)
+		}
+	}
+}
+
+func TestSupportTickets(t *testing.T) {
+	srv := ovhfake.NewServer()
+	defer srv.Close()
+	client := newClient(t, srv)
+
+	tickets, err := commands.NewSupportTicketsCommand(client).Tickets()
+	if err != nil {
+		t.Fatalf("Tickets failed: %v", err)
+	}
+	if len(tickets) != 2 || tickets[0].State != api.TicketOpen {
+		t.Fatalf("Expected the open ticket first out of 2, got %+v", tickets)
+	}
+	const ticketID = 9120001
+
+	output, err := commands.NewSupportTicketCommand(client, ticketID).Execute()
+	if err != nil {
+		t.Fatalf("Ticket details failed: %v", err)
+	}
+	for _, want := range []string{"#4821357 Server unreachable after reboot", "── You · ",
+		"── OVHcloud support · ", "\n  A technician replaced"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in ticket output, got %q", want, output)
+		}
+	}
+	if first, last := strings.Index(output, "── You"), strings.Index(output, "── OVHcloud"); first > last {
+		t.Errorf("Expected messages oldest first, got %q", output)
+	}
+
+	run := func(kind string, inputs map[string]string) error {
+		t.Helper()
+		cmd := commands.NewSupportTicketActionCommand(client, ticketID, kind)
+		for {
+			prompt, err := cmd.NextPrompt()
+			if err != nil {
+				return err
+			}
+			if prompt == nil {
+				break
+			}
+			value, ok := inputs[prompt.Key]
+			if !ok {
+				t.Fatalf("Unexpected prompt %q", prompt.Key)
+			}
+			if err := cmd.SetInput(prompt.Key, value); err != nil {
+				return err
+			}
+			delete(inputs, prompt.Key)
+		}
+		_, err := cmd.Execute()
+		return err
+	}
+
+	cmd := commands.NewSupportTicketActionCommand(client, ticketID, commands.TicketReply)
+	if prompt, err := cmd.NextPrompt(); err != nil || prompt.Kind != commands.PromptEditor {
+		t.Fatalf("Expected an editor prompt for the reply, got %+v (err %v)", prompt, err)
+	}
+	if err := cmd.SetInput("message", "  \n "); err == nil {
+		t.Error("Expected an empty reply to be rejected")
+	}
+
+	reply := "It works again, thanks.\n\nJane"
+	if err := run(commands.TicketReply, map[string]string{"message": reply, "confirm": "yes"}); err != nil {
+		t.Fatalf("Reply failed: %v", err)
+	}
+	messages, err := client.ListSupportMessages(ticketID)
+	if err != nil || len(messages) != 4 || messages[3].Body != reply {
+		t.Fatalf("Expected the reply as fourth message, got %+v (err %v)", messages, err)
+	}
+
+	if err := run(commands.TicketClose, map[string]string{"confirm": "yes"}); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if err := run(commands.TicketReply, nil); err == nil || !strings.Contains(err.Error(), "reopen") {
+		t.Errorf("Expected replies to a closed ticket to be refused, got %v", err)
+	}
+	if err := run(commands.TicketReopen, map[string]string{"message": "It failed again.", "confirm": "yes"}); err != nil {
+		t.Fatalf("Reopen failed: %v", err)
+	}
+	ticket, err := client.GetSupportTicket(ticketID)
+	if err != nil || ticket.State != api.TicketOpen {
+		t.Errorf("Expected the ticket to be open again, got %+v (err %v)", ticket, err)
+	}
+}
+
+func TestRenewals(t *testing.T) {
+	srv := ovhfake.NewServer()
+	defer srv.Close()
+	client := newClient(t, srv)
+
+	renewals, unavailable := commands.NewRenewalsCommand(client, commands.RenewalsByDate).Renewals()
+	if len(unavailable) != 0 {
+		t.Errorf("Expected every service to load, got %v unavailable", unavailable)
+	}
+	var order []string
+	for _, renewal := range renewals {
+		order = append(order, renewal.ID())
+	}
+	want := []string{
+		"vps/vps-0a1b2c3d.vps.ovh.net", "domain/example.org", "server/ns1002.ip-203-0-113.eu",
+		"server/ns1001.ip-203-0-113.eu", "hosting/example.com", "domain/example.com",
+	}
+	if strings.Join(order, " ") != strings.Join(want, " ") {
+		t.Errorf("Expected renewals by date %v, got %v", want, order)
+	}
+
+	output, err := commands.NewRenewalsCommand(client, commands.RenewalsByType).Execute()
+	if err != nil {
+		t.Fatalf("Renewal dashboard failed: %v", err)
+	}
+	for _, want := range []string{"Dedicated server", "Hosting plan", "delete at expiration",
+		"engaged until 2027-01-31", "automatic, every month"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in dashboard, got %q", want, output)
+		}
+	}
+	if strings.Index(output, "Dedicated server") > strings.Index(output, "\nDomain") {
+		t.Errorf("Expected servers before domains, got %q", output)
+	}
+
+	cmd := commands.NewAutoRenewCommand(client, "domain", "example.org")
+	prompt, err := cmd.NextPrompt()
+	if err != nil || !strings.HasPrefix(prompt.Label, "Enable automatic renewal") {
+		t.Fatalf("Expected a confirmation to enable renewal, got %+v (err %v)", prompt, err)
+	}
+	if _, err := cmd.Execute(); err != nil {
+		t.Fatalf("Enabling renewal failed: %v", err)
+	}
+	infos, err := client.GetServiceInfos(api.ResourceDomain, "example.org")
+	if err != nil || !infos.Renew.Automatic || infos.Renew.DeleteAtExpiration {
+		t.Errorf("Expected example.org to renew automatically, got %+v (err %v)", infos.Renew, err)
+	}
+
+	cmd = commands.NewAutoRenewCommand(client, "domain", "example.org")
+	if prompt, err := cmd.NextPrompt(); err != nil || !strings.HasPrefix(prompt.Label, "Disable") {
+		t.Fatalf("Expected a confirmation to disable renewal, got %+v (err %v)", prompt, err)
+	}
+	if _, err := cmd.Execute(); err != nil {
+		t.Fatalf("Disabling renewal failed: %v", err)
+	}
+	if infos, _ := client.GetServiceInfos(api.ResourceDomain, "example.org"); infos.Renew.Automatic {
+		t.Error("Expected example.org to need a manual renewal")
+	}
+
+	if _, err := commands.NewAutoRenewCommand(client, "hosting", "example.com").NextPrompt(); err == nil {
+		t.Error("Expected forced automatic renewal to be refused")
+	}
+}
+
+func TestHosting(t *testing.T) {
+	srv := ovhfake.NewServer()
+	defer srv.Close()
+	client := newClient(t, srv)
+
+	hostings, err := commands.NewHostingsCommand(client).Hostings()
+	if err != nil || len(hostings) != 1 {
+		t.Fatalf("Expected one hosting plan, got %d (err %v)", len(hostings), err)
+	}
+	if summary := commands.HostingSummary(hostings[0]); summary != "perso2014 · cluster021 · active" {
+		t.Errorf("Unexpected hosting summary %q", summary)
+	}
+
+	output, err := commands.NewHostingsCommand(client).Execute()
+	if err != nil {
+		t.Fatalf("Hosting plans failed: %v", err)
+	}
+	for _, want := range []string{"perso2014", "php 8.2", "2.4 GiB / 100.0 GiB (2%)"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in hosting plans, got %q", want, output)
+		}
+	}
+
+	output, err = commands.NewHostingCommand(client, "example.com").Execute()
+	if err != nil {
+		t.Fatalf("Hosting details failed: %v", err)
+	}
+	fields := strings.Join(strings.Fields(output), " ")
+	for _, want := range []string{"Domains 3", "FTP/SSH users 2", "Databases 1", "Cron jobs 2",
+		"SSL LETSENCRYPT DV (created)"} {
+		if !strings.Contains(fields, want) {
+			t.Errorf("Expected %q in hosting details, got %q", want, output)
+		}
+	}
+
+	details := map[string]string{
+		commands.HostingResourceDomain:   "blog.example.com",
+		commands.HostingResourceUser:     "examplec-deploy",
+		commands.HostingResourceDatabase: "examplecdb",
+		commands.HostingResourceCron:     "1",
+		commands.HostingResourceSSL:      "",
+	}
+	wants := map[string]string{
+		commands.HostingResourceDomain:   "./blog",
+		commands.HostingResourceUser:     "sftponly",
+		commands.HostingResourceDatabase: "examplecdb.mysql.db:3306",
+		commands.HostingResourceCron:     "0 3 * * *",
+		commands.HostingResourceSSL:      "www.example.com",
+	}
+	for kind, id := range details {
+		output, err := commands.NewHostingResourceCommand(client, kind, "example.com", id).Execute()
+		if err != nil {
+			t.Errorf("Hosting %s details failed: %v", kind, err)
+			continue
+		}
+		if !strings.Contains(output, wants[kind]) {
+			t.Errorf("Expected %q in %s details, got %q", wants[kind], kind, output)
+		}
+	}
+
+	if _, err := commands.NewHostingResourceCommand(client, commands.HostingResourceCron, "example.com", "9").Execute(); err == nil {
+		t.Error("Expected an unknown cron job to fail")
+	}
+}
+
+func TestHostingDatabaseDumps(t *testing.T) {
+	srv := ovhfake.NewServer()
+	defer srv.Close()
+	client := newClient(t, srv)
+	fast := commands.WithPollInterval(time.Millisecond)
+
+	output, err := commands.NewHostingDatabasesCommand(client, "example.com").Execute()
+	if err != nil {
+		t.Fatalf("Hosting databases failed: %v", err)
+	}
+	for _, want := range []string{"examplecdb", "mysql 8.0 on examplecdb.mysql.db", "37.8 MiB / 400.0 MiB (9%)"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in databases, got %q", want, output)
+		}
+	}
+
+	create := commands.NewCreateDumpCommand(client, "example.com", "examplecdb")
+	if prompt, err := create.NextPrompt(); err != nil || prompt.Kind != commands.PromptChoice {
+		t.Fatalf("Expected a dump source choice, got %+v (err %v)", prompt, err)
+	}
+	if err := create.SetInput("date", "Current content"); err != nil {
+		t.Fatalf("SetInput failed: %v", err)
+	}
+	if prompt, _ := create.NextPrompt(); prompt == nil || prompt.Label != "Dump examplecdb from current content?" {
+		t.Errorf("Unexpected confirmation %+v", prompt)
+	}
+	if output, err := create.ExecuteWithOptions(fast); err != nil || !strings.HasPrefix(output, "Dumped examplecdb: #2") {
+		t.Fatalf("Expected dump #2 to be created, got %q (err %v)", output, err)
+	}
+
+	dumps, err := commands.LoadDumps(client, "example.com", "examplecdb")
+	if err != nil || len(dumps) != 2 || dumps[0].Type != api.DumpNow {
+		t.Fatalf("Expected the new dump first, got %+v (err %v)", dumps, err)
+	}
+
+	download := commands.NewDownloadDumpCommand(client, "example.com", "examplecdb")
+	prompt, err := download.NextPrompt()
+	if err != nil || len(prompt.Choices) != 2 {
+		t.Fatalf("Expected a choice of two dumps, got %+v (err %v)", prompt, err)
+	}
+	if err := download.SetInput("dump", prompt.Choices[1]); err != nil {
+		t.Fatalf("SetInput failed: %v", err)
+	}
+	prompt, _ = download.NextPrompt()
+	if prompt == nil || !strings.HasPrefix(prompt.Default, "examplecdb-") || !strings.HasSuffix(prompt.Default, ".sql.gz") {
+		t.Fatalf("Expected a default dump file name, got %+v", prompt)
+	}
+	path := filepath.Join(t.TempDir(), "backup.sql.gz")
+	if err := download.SetInput("file", path); err != nil {
+		t.Fatalf("SetInput failed: %v", err)
+	}
+	reporter := &progressRecorder{}
+	download.SetProgressReporter(reporter)
+	if _, err := download.Execute(); err != nil {
+		t.Fatalf("Dump download failed: %v", err)
+	}
+	if len(reporter.messages) == 0 || !strings.Contains(reporter.messages[len(reporter.messages)-1], "Downloading dump #1") {
+		t.Errorf("Expected download progress, got %v", reporter.messages)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Dump not saved: %v", err)
+	}
+	defer file.Close()
+	zr, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatalf("Dump is not gzipped: %v", err)
+	}
+	data, _ := io.ReadAll(zr)
+	if !strings.Contains(string(data), "MySQL dump of examplecdb (#1, daily.1)") {
+		t.Errorf("Unexpected dump content %q", data)
+	}
+
+	restore := commands.NewRestoreDumpCommand(client, "example.com", "examplecdb")
+	if err := restore.SetInput("dump", "7"); err == nil {
+		t.Error("Expected an unknown dump to be refused")
+	}
+	if err := restore.SetInput("dump", "1"); err != nil {
+		t.Fatalf("SetInput failed: %v", err)
+	}
+	if prompt, _ := restore.NextPrompt(); prompt == nil || prompt.Kind != commands.PromptConfirm {
+		t.Fatalf("Expected a restore confirmation, got %+v", prompt)
+	}
+	if output, err := restore.ExecuteWithOptions(fast); err != nil || output != "Restored examplecdb from dump #1." {
+		t.Errorf("Unexpected restore result %q (err %v)", output, err)
+	}
+}
+
+func TestEmails(t *testing.T) {
+	srv := ovhfake.NewServer()
+	defer srv.Close()
+	client := newClient(t, srv)
+
+	output, err := commands.NewEmailDomainCommand(client, "example.com").Execute()
+	if err != nil {
+		t.Fatalf("Email domain failed: %v", err)
+	}
+	normalized := strings.Join(strings.Fields(output), " ")
+	for _, want := range []string{"Offer MXPLAN", "Mailbox Sizes 1.0 GiB, 5.0 GiB",
+		"Mailboxes 2", "Redirections 2", "Mailing lists 1", "Responders 1"} {
+		if !strings.Contains(normalized, want) {
+			t.Errorf("Expected %q in email domain, got %q", want, output)
+		}
+	}
+
+	output, err = commands.NewEmailResourceCommand(client, commands.EmailResourceAccount,
+		"example.com", "contact").Execute()
+	if err != nil {
+		t.Fatalf("Mailbox details failed: %v", err)
+	}
+	for _, want := range []string{"contact@example.com", "1.2 GiB / 5.0 GiB (24%)", "4213"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in mailbox, got %q", want, output)
+		}
+	}
+
+	create := commands.NewCreateMailboxCommand(client, "example.com")
+	if prompt, err := create.NextPrompt(); err != nil || prompt.Key != "account" {
+		t.Fatalf("Expected a mailbox name prompt, got %+v (err %v)", prompt, err)
+	}
+	if err := create.SetInput("account", "Not Valid"); err == nil {
+		t.Error("Expected an invalid mailbox name to be refused")
+	}
+	if err := create.SetInput("account", "alice"); err != nil {
+		t.Fatalf("SetInput failed: %v", err)
+	}
+	if prompt, _ := create.NextPrompt(); prompt == nil || prompt.Kind != commands.PromptSecret {
+		t.Fatalf("Expected a secret password prompt, got %+v", prompt)
+	}
+	for _, weak := range []string{"short", "alice-2026-pw", "has spaces in it"} {
+		if err := create.SetInput("password", weak); err == nil || strings.Contains(err.Error(), weak) {
+			t.Errorf("Expected %q to be refused without echoing it, got %v", weak, err)
+		}
+	}
+	if err := create.SetInput("password", "Corr3ct-Horse"); err != nil {
+		t.Fatalf("SetInput failed: %v", err)
+	}
+	prompt, _ := create.NextPrompt()
+	if prompt == nil || len(prompt.Choices) != 2 || prompt.Choices[1] != "5.0 GiB" {
+		t.Fatalf("Expected the allowed sizes, got %+v", prompt)
+	}
+	if err := create.SetInput("size", "5.0 GiB"); err != nil {
+		t.Fatalf("SetInput failed: %v", err)
+	}
+	if prompt, _ := create.NextPrompt(); prompt == nil || prompt.Label != "Create mailbox alice@example.com of 5.0 GiB?" {
+		t.Errorf("Unexpected confirmation %+v", prompt)
+	}
+	if _, err := create.Execute(); err != nil {
+		t.Fatalf("Mailbox creation failed: %v", err)
+	}
+
+	password := commands.NewMailboxPasswordCommand(client, "example.com", "alice")
+	if err := password.SetInput("password", "N3w-Passphrase"); err != nil {
+		t.Fatalf("SetInput failed: %v", err)
+	}
+	if err := password.SetInput("repeat", "N3w-Passphrase!"); err == nil {
+		t.Error("Expected mismatched passwords to be refused")
+	}
+	if err := password.SetInput("repeat", "N3w-Passphrase"); err != nil {
+		t.Fatalf("SetInput failed: %v", err)
+	}
+	if prompt, _ := password.NextPrompt(); prompt != nil {
+		t.Errorf("Expected no more prompts, got %+v", prompt)
+	}
+	if _, err := password.Execute(); err != nil {
+		t.Fatalf("Password change failed: %v", err)
+	}
+	account := srv.Fixtures().EmailDomains["example.com"].Accounts["alice"]
+	if account == nil || account.Size != 5<<30 || account.Password != "N3w-Passphrase" {
+		t.Fatalf("Expected alice with the new password, got %+v", account)
+	}
+
+	redirect := commands.NewAddRedirectionCommand(client, "example.com")
+	if err := redirect.SetInput("from", "jobs@example.org"); err == nil {
+		t.Error("Expected an address of another domain to be refused")
+	}
+	if err := redirect.SetInput("from", "jobs"); err != nil {
+		t.Fatalf("SetInput failed: %v", err)
+	}
+	if err := redirect.SetInput("to", "not-an-address"); err == nil {
+		t.Error("Expected an invalid destination to be refused")
+	}
+	if err := redirect.SetInput("to", "alice@example.com"); err != nil {
+		t.Fatalf("SetInput failed: %v", err)
+	}
+	if err := redirect.SetInput("copy", "No"); err != nil {
+		t.Fatalf("SetInput failed: %v", err)
+	}
+	if prompt, _ := redirect.NextPrompt(); prompt == nil || prompt.Label != "Forward jobs@example.com to alice@example.com?" {
+		t.Errorf("Unexpected confirmation %+v", prompt)
+	}
+	if _, err := redirect.Execute(); err != nil {
+		t.Fatalf("Redirection failed: %v", err)
+	}
+	redirection, err := client.GetEmailRedirection("example.com", "1003")
+	if err != nil || redirection.From != "jobs@example.com" || redirection.To != "alice@example.com" {
+		t.Errorf("Expected the new redirection, got %+v (err %v)", redirection, err)
+	}
+
+	if _, err := commands.NewDeleteMailboxCommand(client, "example.com", "alice").Execute(); err != nil {
+		t.Fatalf("Mailbox deletion failed: %v", err)
+	}
+	accounts, err := client.ListEmailAccounts("example.com")
+	if err != nil || strings.Join(accounts, ",") != "billing,contact" {
+		t.Errorf("Expected alice to be deleted, got %v (err %v)", accounts, err)
+	}
+}
+
+func TestDomainSettings(t *testing.T) {
+	srv := ovhfake.NewServer()
+	defer srv.Close()
+	client := newClient(t, srv)
+	fast := commands.WithPollInterval(time.Millisecond)
+
+	output, err := commands.NewDomainCommand(client, "example.com").Execute()
+	if err != nil {
+		t.Fatalf("Domain failed: %v", err)
+	}
+	normalized := strings.Join(strings.Fields(output), " ")
+	for _, want := range []string{"Transfer Lock locked", "DNSSEC enabled", "Type OVHcloud DNS",
+		"Server 1 dns10.ovh.net", "shop.example.com → https://store.example.net/example (Visible, permanent (301))"} {
+		if !strings.Contains(normalized, want) {
+			t.Errorf("Expected %q in domain, got %q", want, output)
+		}
+	}
+
+	lock := commands.NewTransferLockCommand(client, "example.org")
+	if prompt, err := lock.NextPrompt(); err != nil || prompt.Label != "Lock example.org against transfers?" {
+		t.Fatalf("Unexpected lock confirmation %+v (err %v)", prompt, err)
+	}
+	if _, err := lock.Execute(); err != nil {
+		t.Fatalf("Transfer lock failed: %v", err)
+	}
+	if info, _ := client.GetDomainInfo("example.org"); !info.IsTransferLocked() {
+		t.Errorf("Expected example.org to be locked, got %q", info.TransferLockStatus)
+	}
+
+	nameServers := commands.NewNameServersCommand(client, "example.org")
+	if err := nameServers.SetInput("type", "External nameservers"); err != nil {
+		t.Fatalf("SetInput failed: %v", err)
+	}
+	if err := nameServers.SetInput("servers", "ns1.example.net"); err == nil {
+		t.Error("Expected a single nameserver to be refused")
+	}
+	if err := nameServers.SetInput("servers", "NS1.example.net., ns2.example.net"); err != nil {
+		t.Fatalf("SetInput failed: %v", err)
+	}
+	if prompt, _ := nameServers.NextPrompt(); prompt == nil || !strings.HasPrefix(prompt.Label,
+		"Delegate example.org to ns1.example.net, ns2.example.net?") {
+		t.Errorf("Unexpected delegation confirmation %+v", prompt)
+	}
+	if output, err := nameServers.ExecuteWithOptions(fast); err != nil ||
+		output != "example.org is now delegated to ns1.example.net, ns2.example.net." {
+		t.Fatalf("Unexpected nameserver result %q (err %v)", output, err)
+	}
+	servers, err := commands.LoadDomainNameServers(client, "example.org")
+	if err != nil || len(servers) != 2 || servers[1].Host != "ns2.example.net" {
+		t.Fatalf("Expected the external nameservers, got %+v (err %v)", servers, err)
+	}
+
+	dnssec := commands.NewDNSSECCommand(client, "example.org")
+	if prompt, err := dnssec.NextPrompt(); err != nil || prompt.Kind != commands.PromptEditor {
+		t.Fatalf("Expected DS keys to be asked for external nameservers, got %+v (err %v)", prompt, err)
+	}
+	if err := dnssec.SetInput("keys", "12345 13 257"); err == nil {
+		t.Error("Expected a key without public key to be refused")
+	}
+	if err := dnssec.SetInput("keys", "# example.org KSK\n12345 13 257 mdsswUyr3DPW132mOi8V9xESWE8jTo0d\n xxAsBbdPhHd4y3Nlp0AeTw==\n"); err == nil {
+		t.Error("Expected a wrapped key to be refused")
+	}
+	if err := dnssec.SetInput("keys", "# example.org KSK\n12345 13 257 mdsswUyr3DPW132mOi8V9xESWE8jTo0d xxAsBbdPhHd4y3Nlp0AeTw==\n"); err != nil {
+		t.Fatalf("SetInput failed: %v", err)
+	}
+	if output, err := dnssec.ExecuteWithOptions(fast); err != nil || output != "Published 1 DNSSEC key(s) for example.org." {
+		t.Fatalf("Unexpected DNSSEC result %q (err %v)", output, err)
+	}
+	record, err := client.GetDomainDSRecord("example.org", 1)
+	if err != nil || record.Tag != 12345 || record.PublicKey != "mdsswUyr3DPW132mOi8V9xESWE8jTo0dxxAsBbdPhHd4y3Nlp0AeTw==" {
+		t.Errorf("Expected the published key, got %+v (err %v)", record, err)
+	}
+
+	disable := commands.NewDNSSECCommand(client, "example.com")
+	if prompt, _ := disable.NextPrompt(); prompt == nil || prompt.Label != "Disable DNSSEC on example.com?" {
+		t.Fatalf("Unexpected DNSSEC confirmation %+v", prompt)
+	}
+	if _, err := disable.Execute(); err != nil {
+		t.Fatalf("Disabling DNSSEC failed: %v", err)
+	}
+	if state, _ := client.GetZoneDNSSEC("example.com"); state.Status != api.DNSSECDisabled {
+		t.Errorf("Expected the zone to be unsigned, got %+v", state)
+	}
+
+	redirect := commands.NewAddWebRedirectionCommand(client, "example.com")
+	for key, value := range map[string]string{
+		"subdomain": "blog.example.com",
+		"target":    "https://example.net/blog",
+		"type":      "Invisible (framed)",
+	} {
+		if err := redirect.SetInput(key, value); err != nil {
+			t.Fatalf("SetInput %s failed: %v", key, err)
+		}
+	}
+	if err := redirect.SetInput("target", "ftp://example.net"); err == nil {
+		t.Error("Expected a non-HTTP target to be refused")
+	}
+	if prompt, _ := redirect.NextPrompt(); prompt == nil || prompt.Key != "title" {
+		t.Fatalf("Expected a page title for invisible redirections, got %+v", prompt)
+	}
+	if err := redirect.SetInput("title", "Example blog"); err != nil {
+		t.Fatalf("SetInput failed: %v", err)
+	}
+	if prompt, _ := redirect.NextPrompt(); prompt == nil || prompt.Label != "Redirect blog.example.com to https://example.net/blog?" {
+		t.Errorf("Unexpected redirection confirmation %+v", prompt)
+	}
+	if _, err := redirect.Execute(); err != nil {
+		t.Fatalf("Adding the redirection failed: %v", err)
+	}
+	created, err := client.GetZoneRedirection("example.com", 2)
+	if err != nil || created.SubDomain != "blog" || created.Type != api.RedirectionInvisible || created.Title != "Example blog" {
+		t.Errorf("Expected the blog redirection, got %+v (err %v)", created, err)
+	}
+
+	if _, err := commands.NewDeleteWebRedirectionCommand(client, "example.com", 1).Execute(); err != nil {
+		t.Fatalf("Deleting the redirection failed: %v", err)
+	}
+	redirections, err := commands.LoadWebRedirections(client, "example.com")
+	if err != nil || len(redirections) != 1 || redirections[0].ID != 2 {
+		t.Errorf("Expected only the blog redirection, got %+v (err %v)", redirections, err)
+	}
+	if refreshes := srv.Fixtures().Zones["example.com"].Refreshes; refreshes != 2 {
+		t.Errorf("Expected the zone to be refreshed after each change, got %d refreshes", refreshes)
+	}
+}
+
+func TestZoneFile(t *testing.T) {
+	srv := ovhfake.NewServer()
+	defer srv.Close()
+	client := newClient(t, srv)
+	fast := commands.WithPollInterval(time.Millisecond)
+	path := filepath.Join(t.TempDir(), "example.com.zone")
+
+	export := commands.NewExportZoneCommand(client, "example.com")
+	if prompt, _ := export.NextPrompt(); prompt == nil || prompt.Default != "example.com.zone" {
+		t.Fatalf("Expected the zone file to be named after the zone, got %+v", prompt)
+	}
+	if err := export.SetInput("file", path); err != nil {
+		t.Fatalf("SetInput failed: %v", err)
+	}
+	if _, err := export.Execute(); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	diff := commands.NewDiffZoneCommand(client, "example.com")
+	if err := diff.SetInput("file", path); err != nil {
+		t.Fatalf("SetInput failed: %v", err)
+	}
+	if output, err := diff.Execute(); err != nil || output != "Zone example.com matches the zone file, no changes." {
+		t.Fatalf("Expected the export to match the zone, got %q (err %v)", output, err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edited := strings.Replace(string(content), "203.0.113.20", "203.0.113.21", 1)
+	edited = regexp.MustCompile(`(?m)^@\s+600(\s+IN\s+TXT)`).ReplaceAllString(edited, "@ 300$1")
+	edited = regexp.MustCompile(`(?m)^www .*\n`).ReplaceAllString(edited, "")
+	edited += "api\t60\tIN\tAAAA\t2001:db8::10\n"
+	if err := os.WriteFile(path, []byte(edited), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := diff.SetInput("file", path); err != nil {
+		t.Fatalf("SetInput failed: %v", err)
+	}
+	output, err := diff.Execute()
+	if err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+	normalized := strings.Join(strings.Fields(output), " ")
+	for _, want := range []string{"+ api 60 IN AAAA 2001:db8::10", "~ @ IN A 203.0.113.20 -> @ IN A 203.0.113.21",
+		"~ @ 600 IN TXT", "- www IN CNAME example.com.", "1 to add, 2 to change, 1 to remove."} {
+		if !strings.Contains(normalized, want) {
+			t.Errorf("Expected %q in diff, got %q", want, output)
+		}
+	}
+
+	broken := filepath.Join(t.TempDir(), "broken.zone")
+	if err := os.WriteFile(broken, []byte("www.example.net. IN A 192.0.2.1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	importZone := commands.NewImportZoneCommand(client, "example.com")
+	if err := importZone.SetInput("file", broken); err == nil {
+		t.Error("Expected a record outside of the zone to be refused")
+	}
+	if err := importZone.SetInput("file", path); err != nil {
+		t.Fatalf("SetInput failed: %v", err)
+	}
+	if prompt, _ := importZone.NextPrompt(); prompt == nil ||
+		prompt.Label != "Import into example.com: 1 to add, 2 to change, 1 to remove?" {
+		t.Fatalf("Unexpected import confirmation %+v", prompt)
+	}
+	if _, err := importZone.ExecuteWithOptions(fast); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	records, err := commands.LoadZoneRecords(client, "example.com")
+	if err != nil || len(records) != 6 {
+		t.Fatalf("Expected 6 records after import, got %+v (err %v)", records, err)
+	}
+	if output, err := diff.Execute(); err != nil || !strings.Contains(output, "no changes") {
+		t.Errorf("Expected the zone to match the file after import, got %q (err %v)", output, err)
+	}
+	if zone := srv.Fixtures().Zones["example.com"]; len(zone.Records) != 6 {
+		t.Errorf("Expected the fake zone to hold the imported records, got %+v", zone.Records)
+	}
+}
+
+func TestDNSRecords(t *testing.T) {
+	srv := ovhfake.NewServer()
+	defer srv.Close()
+	client := newClient(t, srv)
+	path := filepath.Join(t.TempDir(), "dns.toml")
+	err := os.WriteFile(path, []byte(`
+[[zone]]
+name = "example.com"
+
+[[zone.record]]
+type = "A"
+target = "203.0.113.21"
+
+[[zone.record]]
+type = "NS"
+target = "dns10.ovh.net."
+
+[[zone.record]]
+type = "NS"
+target = "ns10.ovh.net."
+
+[[zone.record]]
+type = "TXT"
+ttl = 600
+target = "v=spf1 include:mx.ovh.com ~all"
+
+[[zone.record]]
+subdomain = "www"
+type = "CNAME"
+target = "@"
+
+[[zone.record]]
+subdomain = "api"
+type = "AAAA"
+target = "2001:db8::10"
+`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plan := commands.NewPlanDNSCommand(client, false)
+	if prompt, _ := plan.NextPrompt(); prompt == nil || prompt.Default != "dns.toml" {
+		t.Fatalf("Unexpected file prompt %+v", prompt)
+	}
+	if err := plan.SetInput("file", path); err != nil {
+		t.Fatalf("SetInput failed: %v", err)
+	}
+	output, err := plan.Execute()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	normalized := strings.Join(strings.Fields(output), " ")
+	for _, want := range []string{"Zone plan for example.com", "+ api IN AAAA 2001:db8::10",
+		"~ @ IN A 203.0.113.20 -> @ IN A 203.0.113.21", "- @ IN MX 1 mx1.mail.ovh.net.",
+		"1 to add, 1 to change, 1 to remove."} {
+		if !strings.Contains(normalized, want) {
+			t.Errorf("Expected %q in plan, got %q", want, output)
+		}
+	}
+
+	apply := commands.NewApplyDNSCommand(client, true)
+	if err := apply.SetInput("file", path); err != nil {
+		t.Fatalf("SetInput failed: %v", err)
+	}
+	if prompt, _ := apply.NextPrompt(); prompt == nil ||
+		prompt.Label != "Apply to 1 zone(s): 1 to add, 1 to change, 0 to remove?" {
+		t.Fatalf("Expected the MX record to be ignored, got %+v", prompt)
+	}
+	output, err = apply.Execute()
+	if err != nil || output != "Applied 1 addition(s), 1 change(s) and 0 removal(s) to example.com." {
+		t.Fatalf("Unexpected apply result %q (err %v)", output, err)
+	}
+
+	zone := srv.Fixtures().Zones["example.com"]
+	if zone.Refreshes != 1 {
+		t.Errorf("Expected the zone to be refreshed once, got %d", zone.Refreshes)
+	}
+	if record := zone.Records["5003"]; record.Target != "203.0.113.21" {
+		t.Errorf("Expected the A record to be updated in place, got %+v", record)
+	}
+	records, err := commands.LoadZoneRecords(client, "example.com")
+	if err != nil || len(records) != 7 {
+		t.Errorf("Expected the MX record to be kept and api to be added, got %+v (err %v)", records, err)
+	}
+
+	again := commands.NewPlanDNSCommand(client, true)
+	if err := again.SetInput("file", path); err != nil {
+		t.Fatalf("SetInput failed: %v", err)
+	}
+	if output, err := again.Execute(); err != nil || !strings.Contains(output, "no changes") {
+		t.Errorf("Expected no changes after apply, got %q (err %v)", output, err)
+	}
+}
+
+func TestDNSRecordsPartialApply(t *testing.T) {
+	srv := ovhfake.NewServer()
+	defer srv.Close()
+	client := newClient(t, srv)
+	path := filepath.Join(t.TempDir(), "dns.toml")
+	err := os.WriteFile(path, []byte(`
+[[zone]]
+name = "example.com"
+
+[[zone.record]]
+type = "A"
+target = "203.0.113.21"
+
+[[zone.record]]
+subdomain = "api"
+type = "AAAA"
+target = "2001:db8::10"
+`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv.Fail(http.MethodPost, "/domain/zone/example.com/record", http.StatusBadRequest, 1)
+	apply := commands.NewApplyDNSCommand(client, true)
+	if err := apply.SetInput("file", path); err != nil {
+		t.Fatalf("SetInput failed: %v", err)
+	}
+	_, err = apply.Execute()
+	if err == nil || !strings.Contains(err.Error(), "applied 1 of 2 change(s) to example.com") {
+		t.Fatalf("Expected the partial apply to be reported, got %v", err)
+	}
+
+	zone := srv.Fixtures().Zones["example.com"]
+	if record := zone.Records["5003"]; record.Target != "203.0.113.21" {
+		t.Errorf("Expected the A record to be updated, got %+v", record)
+	}
+	if zone.Refreshes != 1 {
+		t.Errorf("Expected the changed zone to be refreshed, got %d refreshes", zone.Refreshes)
+	}
+}
+
+func TestDynDNS(t *testing.T) {
+	srv := ovhfake.NewServer()
+	defer srv.Close()
+	client := newClient(t, srv)
+
+	addresses := []net.IP{net.ParseIP("203.0.113.20"), net.ParseIP("2001:db8::20")}
+	detect := func(ctx context.Context) ([]net.IP, error) { return addresses, nil }
+	writes := func() []string {
+		var writes []string
+		for _, req := range srv.Requests() {
+			if !strings.HasPrefix(req, "GET ") {
+				writes = append(writes, req)
+			}
+		}
+		return writes
+	}
+
+	updater, err := dyndns.NewUpdater(client, config.DynDNSConfig{
+		Records: []config.DynDNSRecord{
+			{Zone: "example.com", Type: "A"},
+			{Zone: "example.com", SubDomain: "office", Type: "A", TTL: 60},
+			{Zone: "example.com", SubDomain: "office", Type: "AAAA", TTL: 60},
+		},
+	}, logger.NewLogger(), dyndns.WithDetector(detect))
+	if err != nil {
+		t.Fatalf("NewUpdater failed: %v", err)
+	}
+
+	if err := updater.Check(context.Background()); err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	zone := srv.Fixtures().Zones["example.com"]
+	if zone.Refreshes != 1 || len(zone.Records) != 8 {
+		t.Fatalf("Expected the office records to be added in one refresh, got %d refreshes and %+v",
+			zone.Refreshes, zone.Records)
+	}
+	if record := zone.Records["5003"]; record.Target != "203.0.113.20" {
+		t.Errorf("Expected the apex record to be left alone, got %+v", record)
+	}
+
+	srv.Reset()
+	if err := updater.Check(context.Background()); err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if len(writes()) != 0 {
+		t.Errorf("Expected no update while the address is unchanged, got %v", writes())
+	}
+
+	addresses = []net.IP{net.ParseIP("198.51.100.7"), net.ParseIP("2001:db8::20")}
+	if err := updater.Check(context.Background()); err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if got := writes(); len(got) != 3 {
+		t.Errorf("Expected the two A records to be updated and the zone refreshed, got %v", got)
+	}
+	records, err := commands.LoadZoneRecords(client, "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, record := range records {
+		if record.Type == "A" && record.Target != "198.51.100.7" {
+			t.Errorf("Expected A records to follow the address, got %+v", record)
+		}
+		if record.Type == "AAAA" && (record.Target != "2001:db8::20" || record.TTL != 60) {
+			t.Errorf("Expected the AAAA record to be kept, got %+v", record)
+		}
+	}
+
+	dynHost, err := dyndns.NewUpdater(client, config.DynDNSConfig{
+		Method:    config.DynDNSDynHost,
+		IPCommand: "echo 'address: 192.0.2.44'",
+		Records:   []config.DynDNSRecord{{Zone: "example.org", SubDomain: "home", Type: "A"}},
+	}, logger.NewLogger())
+	if err != nil {
+		t.Fatalf("NewUpdater failed: %v", err)
+	}
+	if err := dynHost.Check(context.Background()); err != nil {
+		t.Fatalf("DynHost check failed: %v", err)
+	}
+	hosts := srv.Fixtures().Zones["example.org"].DynHosts
+	if len(hosts) != 1 || hosts["1"].IP != "192.0.2.44" || hosts["1"].SubDomain != "home" {
+		t.Errorf("Expected the DynHost record to be created, got %+v", hosts)
+	}
+
+	if _, err := dyndns.NewUpdater(client, config.DynDNSConfig{
+		Records: []config.DynDNSRecord{{Zone: "example.com", Type: "A"}},
+	}, logger.NewLogger()); err == nil {
+		t.Error("Expected an updater without address source to be refused")
+	}
+	failing, _ := dyndns.NewUpdater(client, config.DynDNSConfig{
+		IPCommand: "echo no address here",
+		Records:   []config.DynDNSRecord{{Zone: "example.com", Type: "A"}},
+	}, logger.NewLogger())
+	if err := failing.Check(context.Background()); err == nil {
+		t.Error("Expected a command without address to fail")
+	}
+}
+
+func TestIPAddresses(t *testing.T) {
+	srv := ovhfake.NewServer()
+	defer srv.Close()
+	client := newClient(t, srv)
+
+	t.Run("grouped by type and service", func(t *testing.T) {
+		groups, err := commands.NewIPOverviewCommand(client).Groups()
+		if err != nil {
+			t.Fatalf("Groups failed: %v", err)
+		}
+
+		var titles []string
+		for _, group := range groups {
+			titles = append(titles, group.Title)
+		}
+		want := []string{"Dedicated IPs", "Failover IPs", "VPS IPs"}
+		if strings.Join(titles, ",") != strings.Join(want, ",") {
+			t.Errorf("Expected groups %v, got %v", want, titles)
+		}
+	})
+
+	t.Run("block details show reverses", func(t *testing.T) {
+		output, err := commands.NewIPCommand(client, "198.51.100.8/29").Execute()
+		if err != nil {
+			t.Fatalf("IPCommand failed: %v", err)
+		}
+		for _, want := range []string{"Failover IP", "ns1001.ip-203-0-113.eu", "198.51.100.9", "www.example.com."} {
+			if !strings.Contains(output, want) {
+				t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+			}
+		}
+	})
+
+	t.Run("set and delete reverse", func(t *testing.T) {
+		if _, err := client.SetIPReverse("198.51.100.8/29", "198.51.100.10", "mail.example.com."); err != nil {
+			t.Fatalf("SetIPReverse failed: %v", err)
+		}
+		reverse, err := client.GetIPReverse("198.51.100.8/29", "198.51.100.10")
+		if err != nil || reverse.Reverse != "mail.example.com." {
+			t.Fatalf("Expected new reverse, got %+v (err %v)", reverse, err)
+		}
+
+		if err := client.DeleteIPReverse("198.51.100.8/29", "198.51.100.10"); err != nil {
+			t.Fatalf("DeleteIPReverse failed: %v", err)
+		}
+		ips, err := client.ListIPReverses("198.51.100.8/29")
+		if err != nil || len(ips) != 1 {
+			t.Errorf("Expected one remaining reverse, got %v (err %v)", ips, err)
+		}
+	})
+}
+
+func TestMoveFailoverIP(t *testing.T) {
+	srv := ovhfake.NewServer()
+	defer srv.Close()
+	client := newClient(t, srv)
+
+	t.Run("eligible destinations", func(t *testing.T) {
+		cmd := commands.NewMoveIPCommand(client, "198.51.100.8/29")
+		prompt, err := cmd.NextPrompt()
+		if err != nil {
+			t.Fatalf("NextPrompt failed: %v", err)
+		}
+		want := []string{
+			"ns1002.ip-203-0-113.eu (dedicated server)",
+			"vps-0a1b2c3d.vps.ovh.net (VPS)",
+		}
+		if strings.Join(prompt.Choices, ",") != strings.Join(want, ",") {
+			t.Errorf("Expected destinations %v, got %v", want, prompt.Choices)
+		}
+		if err := cmd.SetInput("to", "ns1001.ip-203-0-113.eu"); err == nil {
+			t.Error("Expected current server to be rejected as destination")
+		}
+	})
+
+	t.Run("move and poll task", func(t *testing.T) {
+		cmd := commands.NewMoveIPCommand(client, "198.51.100.8/29")
+		if err := cmd.SetInput("to", "ns1002.ip-203-0-113.eu (dedicated server)"); err != nil {
+			t.Fatalf("SetInput failed: %v", err)
+		}
+		if _, err := cmd.ExecuteWithOptions(commands.WithPollInterval(time.Millisecond)); err != nil {
+			t.Fatalf("Move failed: %v", err)
+		}
+
+		info, err := client.GetIPInfo("198.51.100.8/29")
+		if err != nil || info.GetRoutedService() != "ns1002.ip-203-0-113.eu" {
+			t.Errorf("Expected block routed to ns1002, got %+v (err %v)", info, err)
+		}
+	})
+
+	t.Run("only failover blocks", func(t *testing.T) {
+		_, err := commands.NewMoveIPCommand(client, "203.0.113.10/32").NextPrompt()
+		if err == nil {
+			t.Error("Expected dedicated IP move to be refused")
+		}
+	})
+}
+
+func TestEdgeFirewall(t *testing.T) {
+	srv := ovhfake.NewServer()
+	defer srv.Close()
+	client := newClient(t, srv)
+	fast := commands.WithPollInterval(time.Millisecond)
+
+	t.Run("rules by sequence", func(t *testing.T) {
+		output, err := commands.NewFirewallCommand(client, "203.0.113.10/32").Execute()
+		if err != nil {
+			t.Fatalf("FirewallCommand failed: %v", err)
+		}
+		ssh := strings.Index(output, "permit tcp from 198.51.100.0/24 to port 22")
+		deny := strings.Index(output, "deny ipv4 from any")
+		if ssh < 0 || deny < ssh {
+			t.Errorf("Expected rules in sequence order, got:\n%s", output)
+		}
+	})
+
+	t.Run("add rule creates firewall", func(t *testing.T) {
+		cmd := commands.NewAddFirewallRuleCommand(client, "198.51.100.8/29")
+		inputs := [][2]string{
+			{"ip", "198.51.100.9"},
+			{"sequence", "0"},
+			{"action", "permit"},
+			{"protocol", "udp"},
+			{"source", "192.0.2.1"},
+			{"destination_port", "53"},
+			{"source_port", ""},
+		}
+		for _, in := range inputs {
+			if err := cmd.SetInput(in[0], in[1]); err != nil {
+				t.Fatalf("SetInput(%s) failed: %v", in[0], err)
+			}
+		}
+		if _, err := cmd.ExecuteWithOptions(fast); err != nil {
+			t.Fatalf("Add rule failed: %v", err)
+		}
+
+		rule, err := client.GetFirewallRule("198.51.100.8/29", "198.51.100.9", 0)
+		if err != nil || rule.Source != "192.0.2.1/32" || rule.DestinationPort != "eq 53" {
+			t.Errorf("Expected created rule, got %+v (err %v)", rule, err)
+		}
+	})
+
+	t.Run("add rule with tcp options", func(t *testing.T) {
+		cmd := commands.NewAddFirewallRuleCommand(client, "198.51.100.8/29")
+		inputs := [][2]string{
+			{"ip", "198.51.100.9"},
+			{"sequence", "1"},
+			{"action", "permit"},
+			{"protocol", "tcp"},
+			{"source", ""},
+			{"destination_port", "443"},
+			{"source_port", ""},
+			{"tcp_option", "established"},
+		}
+		for _, in := range inputs {
+			if err := cmd.SetInput(in[0], in[1]); err != nil {
+				t.Fatalf("SetInput(%s) failed: %v", in[0], err)
+			}
+		}
+		if _, err := cmd.ExecuteWithOptions(fast); err != nil {
+			t.Fatalf("Add rule failed: %v", err)
+		}
+
+		rule, err := client.GetFirewallRule("198.51.100.8/29", "198.51.100.9", 1)
+		if err != nil || rule.TCPOption != "established" {
+			t.Errorf("Expected established TCP option, got %+v (err %v)", rule, err)
+		}
+
+		_, err = client.CreateFirewallRule("198.51.100.8/29", "198.51.100.9", api.FirewallRuleCreate{
+			Sequence:  2,
+			Action:    "deny",
+			Protocol:  "tcp",
+			TCPOption: &api.FirewallOptionTCP{Fragments: true},
+		})
+		if err != nil {
+			t.Fatalf("CreateFirewallRule failed: %v", err)
+		}
+		rule, err = client.GetFirewallRule("198.51.100.8/29", "198.51.100.9", 2)
+		if err != nil || rule.Fragments == nil || !*rule.Fragments {
+			t.Errorf("Expected fragments rule, got %+v (err %v)", rule, err)
+		}
+	})
+
+	t.Run("plan and apply rules file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "firewall.toml")
+		rules := `enabled = false
+
+[[rule]]
+sequence = 0
+action = "permit"
+protocol = "tcp"
+source = "198.51.100.0/24"
+destination_port = 22
+
+[[rule]]
+sequence = 2
+action = "permit"
+protocol = "icmp"
+`
+		if err := os.WriteFile(path, []byte(rules), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		plan := commands.NewPlanFirewallCommand(client, "203.0.113.10/32")
+		if err := plan.SetInput("file", path); err != nil {
+			t.Fatalf("SetInput failed: %v", err)
+		}
+		output, err := plan.Execute()
+		if err != nil {
+			t.Fatalf("Plan failed: %v", err)
+		}
+		for _, want := range []string{"~ firewall disabled", "+ rule 2", "- rule 1", "- rule 19", "1 to add, 0 to change, 2 to remove"} {
+			if !strings.Contains(output, want) {
+				t.Errorf("Expected plan to contain %q, got:\n%s", want, output)
+			}
+		}
+
+		apply := commands.NewApplyFirewallCommand(client, "203.0.113.10/32")
+		if err := apply.SetInput("file", path); err != nil {
+			t.Fatalf("SetInput failed: %v", err)
+		}
+		if _, err := apply.ExecuteWithOptions(fast); err != nil {
+			t.Fatalf("Apply failed: %v", err)
+		}
+
+		output, err = plan.Execute()
+		if err != nil || !strings.Contains(output, "no changes") {
+			t.Errorf("Expected no changes after apply, got %q (err %v)", output, err)
+		}
+	})
+
+	t.Run("apply keeps the confirmed plan", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "firewall.toml")
+		rules := "[[rule]]\nsequence = 0\naction = \"permit\"\nprotocol = \"tcp\"\n" +
+			"source = \"198.51.100.0/24\"\ndestination_port = 22\n\n" +
+			"[[rule]]\nsequence = 2\naction = \"permit\"\nprotocol = \"icmp\"\n"
+		confirmed := rules + "\n[[rule]]\nsequence = 3\naction = \"deny\"\nprotocol = \"udp\"\n"
+		if err := os.WriteFile(path, []byte(confirmed), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		apply := commands.NewApplyFirewallCommand(client, "203.0.113.10/32")
+		if err := apply.SetInput("file", path); err != nil {
+			t.Fatalf("SetInput failed: %v", err)
+		}
+		prompt, err := apply.NextPrompt()
+		if err != nil || prompt == nil || !strings.Contains(prompt.Label, "1 to add") {
+			t.Fatalf("Expected confirmation of one addition, got %+v (err %v)", prompt, err)
+		}
+
+		// The file changes between the confirmation and the apply
+		if err := os.WriteFile(path, []byte(rules), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := apply.SetInput("confirm", "yes"); err != nil {
+			t.Fatalf("SetInput failed: %v", err)
+		}
+		if _, err := apply.ExecuteWithOptions(fast); err != nil {
+			t.Fatalf("Apply failed: %v", err)
+		}
+
+		if rule, err := client.GetFirewallRule("203.0.113.10/32", "203.0.113.10", 3); err != nil || rule.Protocol != "udp" {
+			t.Errorf("Expected the confirmed rule 3 to be added, got %+v (err %v)", rule, err)
+		}
+	})
+}
+
+func TestMitigationAndAttackHistory(t *testing.T) {
+	srv := ovhfake.NewServer()
+	defer srv.Close()
+	client := newClient(t, srv)
+
+	t.Run("mitigation modes and profiles", func(t *testing.T) {
+		output, err := commands.NewMitigationCommand(client, "198.51.100.8/29").Execute()
+		if err != nil {
+			t.Fatalf("MitigationCommand failed: %v", err)
+		}
+		for _, want := range []string{"Permanent (forced)", "Auto mitigation lasts 1h 0m"} {
+			if !strings.Contains(output, want) {
+				t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+			}
+		}
+
+		output, err = commands.NewMitigationCommand(client, "203.0.113.10/32").Execute()
+		if err != nil || !strings.Contains(output, "Automatic (attack detected)") {
+			t.Errorf("Expected automatic mitigation, got %q (err %v)", output, err)
+		}
+	})
+
+	t.Run("history shows unblock availability", func(t *testing.T) {
+		output, err := commands.NewAttackHistoryCommand(client, "198.51.100.8/29").Execute()
+		if err != nil {
+			t.Fatalf("AttackHistoryCommand failed: %v", err)
+		}
+		for _, want := range []string{
+			"blocked, unblock available",
+			"unblock possible in 1h 30m",
+			"SSH brute force",
+		} {
+			if !strings.Contains(output, want) {
+				t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+			}
+		}
+	})
+
+	t.Run("unblock offers only eligible addresses", func(t *testing.T) {
+		cmd := commands.NewUnblockIPCommand(client, "198.51.100.8/29")
+		prompt, err := cmd.NextPrompt()
+		if err != nil {
+			t.Fatalf("NextPrompt failed: %v", err)
+		}
+		if len(prompt.Choices) != 1 || prompt.Choices[0] != "198.51.100.10 (anti-hack)" {
+			t.Errorf("Expected only 198.51.100.10, got %v", prompt.Choices)
+		}
+		if err := cmd.SetInput("address", "198.51.100.11"); err == nil {
+			t.Error("Expected address still in its block period to be refused")
+		}
+
+		if err := cmd.SetInput("address", prompt.Choices[0]); err != nil {
+			t.Fatalf("SetInput failed: %v", err)
+		}
+		if _, err := cmd.Execute(); err != nil {
+			t.Fatalf("Unblock failed: %v", err)
+		}
+		entry, err := client.GetAntihack("198.51.100.8/29", "198.51.100.10")
+		if err != nil || entry.State != api.IPBlockedStateUnblocking {
+			t.Errorf("Expected unblocking state, got %+v (err %v)", entry, err)
+		}
+	})
+
+	t.Run("spam unblock", func(t *testing.T) {
+		cmd := commands.NewUnblockIPCommand(client, "192.0.2.44/32")
+		if err := cmd.SetInput("address", "192.0.2.44"); err != nil {
+			t.Fatalf("SetInput failed: %v", err)
+		}
+		if _, err := cmd.Execute(); err != nil {
+			t.Fatalf("Unblock failed: %v", err)
+		}
+	})
+}
+
+func TestInvalidSignature(t *testing.T) {
+	srv := ovhfake.NewServer()
+	defer srv.Close()
+
+	cfg := srv.AccountConfig()
+	cfg.AppSecret = "wrong-secret"
+	client, err := api.NewClient(cfg, logger.NewLogger())
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	_, err = client.GetAccountInfo()
+	var apiErr *api.APIError
+	if !errors.As(err, &apiErr) || apiErr.Type != api.ErrorTypeAuth {
+		t.Fatalf("Expected auth error, got %v", err)
+	}
+}
+
+func TestClockDrift(t *testing.T) {
+	srv := ovhfake.NewServer(ovhfake.WithClockOffset(-10 * time.Minute))
+	defer srv.Close()
+	client := newClient(t, srv)
+
+	if _, err := client.GetAccountInfo(); err != nil {
+		t.Fatalf("Expected startup time delta to be applied, got %v", err)
+	}
+
+	// Clock jumps while the client is running
+	srv.Reset()
+	srv.SetClockOffset(5 * time.Minute)
+	if _, err := client.GetAccountInfo(); err != nil {
+		t.Fatalf("Expected request to succeed after resync, got %v", err)
+	}
+
+	timeRequests := 0
+	for _, req := range srv.Requests() {
+		if req == "GET /auth/time" {
+			timeRequests++
+		}
+	}
+	if timeRequests == 0 {
+		t.Error("Expected the client to query /auth/time again")
+	}
+}
+
+func TestBadCredentialsWithClockOffset(t *testing.T) {
+	srv := ovhfake.NewServer(ovhfake.WithClockOffset(40 * time.Second))
+	defer srv.Close()
+
+	account := srv.AccountConfig()
+	account.AppSecret = "wrong-secret"
+	client, err := api.NewClient(account, logger.NewLogger(), api.WithRetry(fastRetry))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	_, err = client.GetAccountInfo()
+	var apiErr *api.APIError
+	if !errors.As(err, &apiErr) || apiErr.Type != api.ErrorTypeAuth {
+		t.Fatalf("Expected auth error, got %v", err)
+	}
+	if apiErr.ClockSkew != 0 || strings.Contains(apiErr.UserError(), "clock") {
+		t.Errorf("Expected a credential error, not clock drift: %q", apiErr.UserError())
+	}
+}
+
+func TestInjectedErrors(t *testing.T) {
+	srv := ovhfake.NewServer()
+	defer srv.Close()
+	client := newClient(t, srv)
+
+	t.Run("unauthorized", func(t *testing.T) {
+		srv.Reset()
+		srv.Fail(http.MethodGet, "/me", http.StatusUnauthorized, 1)
+
+		_, err := client.GetAccountInfo()
+		var apiErr *api.APIError
+		if !errors.As(err, &apiErr) || apiErr.Type != api.ErrorTypeAuth {
+			t.Fatalf("Expected auth error, got %v", err)
+		}
+	})
+
+	t.Run("rate limited then recovered", func(t *testing.T) {
+		srv.Reset()
+		srv.Fail(http.MethodGet, "/me", http.StatusTooManyRequests, 2)
+
+		if _, err := client.GetAccountInfo(); err != nil {
+			t.Fatalf("Expected retry to succeed, got %v", err)
+		}
+
+		attempts := 0
+		for _, req := range srv.Requests() {
+			if req == "GET /me" {
+				attempts++
+			}
+		}
+		if attempts != 3 {
+			t.Errorf("Expected 3 attempts, got %d", attempts)
+		}
+	})
+
+	t.Run("service unavailable", func(t *testing.T) {
+		srv.Reset()
+		srv.Fail("*", "/dedicated/server", http.StatusServiceUnavailable, 0)
+
+		_, err := client.ListDedicatedServers()
+		var apiErr *api.APIError
+		if !errors.As(err, &apiErr) || apiErr.Type != api.ErrorTypeAPI {
+			t.Fatalf("Expected API error, got %v", err)
+		}
+		if !strings.Contains(apiErr.UserError(), "503") {
+			t.Errorf("Expected status in user error, got %q", apiErr.UserError())
+		}
+	})
+}
+
+func TestCustomEndpointFromConfig(t *testing.T) {
+	srv := ovhfake.NewServer(ovhfake.WithTLS())
+	defer srv.Close()
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	if err := srv.WriteCACert(caFile); err != nil {
+		t.Fatalf("WriteCACert failed: %v", err)
+	}
+
+	writeConfig := func(caLine string) string {
+		path := filepath.Join(dir, "config.toml")
+		content := fmt.Sprintf(`[general]
+default_account = "fake"
+log_level = "error"
+log_file = "none"
+
+[accounts.fake]
+name = "Fake"
+endpoint = %q
+app_key = %q
+app_secret = %q
+consumer_key = %q
+%s
+
+[keybindings]
+quit = ["q"]
+help = ["F1"]
+`, srv.Endpoint(), ovhfake.AppKey, ovhfake.AppSecret, ovhfake.ConsumerKey, caLine)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		return path
+	}
+
+	t.Run("trusted CA bundle", func(t *testing.T) {
+		cfg, err := config.LoadConfig(writeConfig(fmt.Sprintf("ca_file = %q", caFile)))
+		if err != nil {
+			t.Fatalf("LoadConfig failed: %v", err)
+		}
+
+		account := cfg.Accounts["fake"]
+		client, err := api.NewClient(&account, logger.NewLogger())
+		if err != nil {
+			t.Fatalf("NewClient failed: %v", err)
+		}
+		if _, err := client.GetAccountInfo(); err != nil {
+			t.Fatalf("GetAccountInfo failed: %v", err)
+		}
+	})
+
+	t.Run("untrusted certificate", func(t *testing.T) {
+		cfg, err := config.LoadConfig(writeConfig(""))
+		if err != nil {
+			t.Fatalf("LoadConfig failed: %v", err)
+		}
+
+		account := cfg.Accounts["fake"]
+		client, err := api.NewClient(&account, logger.NewLogger())
+		if err != nil {
+			t.Fatalf("NewClient failed: %v", err)
+		}
+		if _, err := client.GetAccountInfo(); err == nil {
+			t.Fatal("Expected TLS verification error but got nil")
+		}
+	})
+}
+
+func TestOAuth2ServiceAccount(t *testing.T) {
+	// Tokens shorter than the oauth2 expiry margin are refreshed on every call
+	srv := ovhfake.NewServer(
+		ovhfake.WithOAuth2Client("svc-client", "svc-secret"),
+		ovhfake.WithTokenLifetime(5*time.Second),
+	)
+	defer srv.Close()
+
+	t.Run("token acquisition and refresh", func(t *testing.T) {
+		client, err := api.NewClient(srv.OAuth2AccountConfig(), logger.NewLogger())
+		if err != nil {
+			t.Fatalf("NewClient failed: %v", err)
+		}
+
+		for i := 0; i < 2; i++ {
+			if _, err := client.GetAccountInfo(); err != nil {
+				t.Fatalf("GetAccountInfo failed: %v", err)
+			}
+		}
+
+		tokenRequests := 0
+		for _, req := range srv.Requests() {
+			if req == "POST "+ovhfake.TokenPath {
+				tokenRequests++
+			}
+		}
+		if tokenRequests < 3 {
+			t.Errorf("Expected a token per request, got %d token requests", tokenRequests)
+		}
+	})
+
+	t.Run("invalid client secret", func(t *testing.T) {
+		cfg := srv.OAuth2AccountConfig()
+		cfg.ClientSecret = "wrong"
+
+		_, err := api.NewClient(cfg, logger.NewLogger())
+		var apiErr *api.APIError
+		if !errors.As(err, &apiErr) || apiErr.Type != api.ErrorTypeAuth {
+			t.Fatalf("Expected auth error, got %v", err)
+		}
+		if !strings.Contains(apiErr.UserError(), "client_id") {
+			t.Errorf("Expected OAuth2 hint in user error, got %q", apiErr.UserError())
+		}
+	})
+}
@@ -0,0 +1,358 @@
+// internal/ovhfake/email.go
+package ovhfake
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"ovh-terminal/internal/api"
+)
+
+const gib = int64(1) << 30
+
+// EmailDomain is a fake MX Plan domain with its resources. Mailing lists
+// are keyed by name, redirections by ID and responders by account.
+type EmailDomain struct {
+	api.EmailDomain
+	Accounts     map[string]*EmailAccount        `json:"-"`
+	Redirections map[string]api.EmailRedirection `json:"-"`
+	MailingLists map[string]api.EmailMailingList `json:"-"`
+	Responders   map[string]api.EmailResponder   `json:"-"`
+}
+
+// EmailAccount is a fake mailbox. The password is kept so tests can check
+// it was set, and is never served.
+type EmailAccount struct {
+	api.EmailAccount
+	Password string         `json:"-"`
+	Usage    api.EmailUsage `json:"-"`
+}
+
+// defaultEmailDomains returns the email service of example.com, with two
+// mailboxes, a newsletter and an out-of-office reply
+func defaultEmailDomains(now time.Time) map[string]*EmailDomain {
+	measured := now.UTC().Truncate(time.Hour)
+	away := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 7)
+
+	return map[string]*EmailDomain{
+		"example.com": {
+			EmailDomain: api.EmailDomain{
+				Domain:             "example.com",
+				Offer:              "MXPLAN",
+				Status:             "ok",
+				AllowedAccountSize: []int64{gib, 5 * gib},
+				CreationDate:       timePtr(time.Date(2021, 3, 2, 10, 0, 0, 0, time.UTC)),
+			},
+			Accounts: map[string]*EmailAccount{
+				"contact": {
+					EmailAccount: api.EmailAccount{
+						AccountName: "contact",
+						Domain:      "example.com",
+						Email:       "contact@example.com",
+						Description: "Public contact",
+						Size:        5 * gib,
+						State:       "ok",
+					},
+					Usage: api.EmailUsage{Quota: 1288490189, EmailCount: 4213, Date: &measured},
+				},
+				"billing": {
+					EmailAccount: api.EmailAccount{
+						AccountName: "billing",
+						Domain:      "example.com",
+						Email:       "billing@example.com",
+						Size:        gib,
+						State:       "ok",
+					},
+					Usage: api.EmailUsage{Quota: 52428800, EmailCount: 310, Date: &measured},
+				},
+			},
+			Redirections: map[string]api.EmailRedirection{
+				"1001": {ID: "1001", From: "info@example.com", To: "contact@example.com"},
+				"1002": {ID: "1002", From: "sales@example.com", To: "alice@example.net"},
+			},
+			MailingLists: map[string]api.EmailMailingList{
+				"newsletter": {
+					ID:            1,
+					Name:          "newsletter",
+					Language:      "en",
+					OwnerEmail:    "contact@example.com",
+					ReplyTo:       "contact@example.com",
+					NbSubscribers: 248,
+				},
+			},
+			Responders: map[string]api.EmailResponder{
+				"contact": {
+					Account: "contact",
+					Content: "Thanks for your message. We are away this week and will reply on our return.",
+					Copy:    true,
+					CopyTo:  "billing@example.com",
+					From:    timePtr(away),
+					To:      timePtr(away.AddDate(0, 0, 7)),
+				},
+			},
+		},
+	}
+}
+
+// emailDomain returns the email domain of the request, writing a 404 when
+// it is unknown. Callers must hold s.mu.
+func (s *Server) emailDomain(w http.ResponseWriter, r *http.Request) (*EmailDomain, bool) {
+	name := r.PathValue("domain")
+	domain, ok := s.fixtures.EmailDomains[name]
+	if !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+name+") does not exist")
+		return nil, false
+	}
+	return domain, true
+}
+
+// newEmailTask registers a task for a change that the fake applies right
+// away. Callers must hold s.mu.
+func (s *Server) newEmailTask(action, domain, name string) api.EmailTask {
+	t := s.newTask(action, nil)
+	return api.EmailTask{
+		ID:     int64(t.ID),
+		Action: action,
+		Domain: domain,
+		Name:   name,
+		Date:   timePtr(t.StartDate),
+	}
+}
+
+func (s *Server) listEmailDomains(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, sortedKeys(s.fixtures.EmailDomains))
+}
+
+func (s *Server) getEmailDomain(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if domain, ok := s.emailDomain(w, r); ok {
+		writeJSON(w, http.StatusOK, domain.EmailDomain)
+	}
+}
+
+func (s *Server) listEmailAccounts(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if domain, ok := s.emailDomain(w, r); ok {
+		writeJSON(w, http.StatusOK, sortedKeys(domain.Accounts))
+	}
+}
+
+func (s *Server) getEmailAccount(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if domain, ok := s.emailDomain(w, r); ok {
+		if account, ok := domain.account(w, r); ok {
+			writeJSON(w, http.StatusOK, account.EmailAccount)
+		}
+	}
+}
+
+func (s *Server) getEmailAccountUsage(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if domain, ok := s.emailDomain(w, r); ok {
+		if account, ok := domain.account(w, r); ok {
+			writeJSON(w, http.StatusOK, account.Usage)
+		}
+	}
+}
+
+// account returns the mailbox of the request, writing a 404 when it is
+// unknown
+func (d *EmailDomain) account(w http.ResponseWriter, r *http.Request) (*EmailAccount, bool) {
+	name := r.PathValue("account")
+	account, ok := d.Accounts[name]
+	if !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+name+") does not exist")
+		return nil, false
+	}
+	return account, true
+}
+
+func (s *Server) createEmailAccount(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	domain, ok := s.emailDomain(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		AccountName string `json:"accountName"`
+		Password    string `json:"password"`
+		Size        int64  `json:"size"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.AccountName == "" || req.Password == "" {
+		writeError(w, http.StatusBadRequest, "accountName and password are required")
+		return
+	}
+	if _, exists := domain.Accounts[req.AccountName]; exists {
+		writeError(w, http.StatusConflict, "Account "+req.AccountName+" already exists")
+		return
+	}
+	allowed := false
+	for _, size := range domain.AllowedAccountSize {
+		allowed = allowed || size == req.Size
+	}
+	if !allowed {
+		writeError(w, http.StatusBadRequest, "Invalid size: "+strconv.FormatInt(req.Size, 10))
+		return
+	}
+
+	if domain.Accounts == nil {
+		domain.Accounts = make(map[string]*EmailAccount)
+	}
+	domain.Accounts[req.AccountName] = &EmailAccount{
+		EmailAccount: api.EmailAccount{
+			AccountName: req.AccountName,
+			Domain:      domain.Domain,
+			Email:       req.AccountName + "@" + domain.Domain,
+			Size:        req.Size,
+			State:       "ok",
+		},
+		Password: req.Password,
+		Usage:    api.EmailUsage{Date: timePtr(time.Now().UTC().Truncate(time.Second))},
+	}
+	writeJSON(w, http.StatusOK, s.newEmailTask("addAccount", domain.Domain, req.AccountName))
+}
+
+func (s *Server) deleteEmailAccount(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	domain, ok := s.emailDomain(w, r)
+	if !ok {
+		return
+	}
+	account, ok := domain.account(w, r)
+	if !ok {
+		return
+	}
+
+	delete(domain.Accounts, account.AccountName)
+	delete(domain.Responders, account.AccountName)
+	writeJSON(w, http.StatusOK, s.newEmailTask("delAccount", domain.Domain, account.AccountName))
+}
+
+func (s *Server) changeEmailPassword(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	domain, ok := s.emailDomain(w, r)
+	if !ok {
+		return
+	}
+	account, ok := domain.account(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Password string `json:"password"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Password == "" {
+		writeError(w, http.StatusBadRequest, "password is required")
+		return
+	}
+
+	account.Password = req.Password
+	writeJSON(w, http.StatusOK, s.newEmailTask("changePassword", domain.Domain, account.AccountName))
+}
+
+func (s *Server) listEmailRedirections(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if domain, ok := s.emailDomain(w, r); ok {
+		writeJSON(w, http.StatusOK, sortedKeys(domain.Redirections))
+	}
+}
+
+func (s *Server) getEmailRedirection(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if domain, ok := s.emailDomain(w, r); ok {
+		writeFixture(w, domain.Redirections, r.PathValue("id"))
+	}
+}
+
+func (s *Server) createEmailRedirection(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	domain, ok := s.emailDomain(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		From      string `json:"from"`
+		To        string `json:"to"`
+		LocalCopy bool   `json:"localCopy"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.From == "" || req.To == "" {
+		writeError(w, http.StatusBadRequest, "from and to are required")
+		return
+	}
+	for _, redirection := range domain.Redirections {
+		if redirection.From == req.From && redirection.To == req.To {
+			writeError(w, http.StatusConflict, "Redirection already exists")
+			return
+		}
+	}
+
+	if domain.Redirections == nil {
+		domain.Redirections = make(map[string]api.EmailRedirection)
+	}
+	id := int64(1001)
+	for _, existing := range sortedIDs(domain.Redirections) {
+		if existing >= id {
+			id = existing + 1
+		}
+	}
+	key := strconv.FormatInt(id, 10)
+	domain.Redirections[key] = api.EmailRedirection{ID: key, From: req.From, To: req.To}
+	writeJSON(w, http.StatusOK, s.newEmailTask("addRedirection", domain.Domain, req.From))
+}
+
+func (s *Server) listEmailMailingLists(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if domain, ok := s.emailDomain(w, r); ok {
+		writeJSON(w, http.StatusOK, sortedKeys(domain.MailingLists))
+	}
+}
+
+func (s *Server) getEmailMailingList(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if domain, ok := s.emailDomain(w, r); ok {
+		writeFixture(w, domain.MailingLists, r.PathValue("name"))
+	}
+}
+
+func (s *Server) listEmailResponders(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if domain, ok := s.emailDomain(w, r); ok {
+		writeJSON(w, http.StatusOK, sortedKeys(domain.Responders))
+	}
+}
+
+func (s *Server) getEmailResponder(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if domain, ok := s.emailDomain(w, r); ok {
+		writeFixture(w, domain.Responders, r.PathValue("account"))
+	}
+}
@@ -0,0 +1,297 @@
+// internal/ovhfake/firewall.go
+package ovhfake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"sort"
+	"strconv"
+	"strings"
+
+	"ovh-terminal/internal/api"
+)
+
+// Firewall is the fake edge firewall of an address. Pending states
+// settle on the next read, like a task advancing.
+type Firewall struct {
+	api.FirewallIP
+	Rules map[int]api.FirewallRule
+}
+
+// firewallState is the state a firewall reports while a change is pending
+const firewallState = "enableFirewallPending"
+
+// firewallFor returns the firewall of an address in a block, writing a
+// 404 when either is missing. Callers must hold s.mu.
+func (s *Server) firewallFor(w http.ResponseWriter, r *http.Request) (*Firewall, bool) {
+	block, ip := r.PathValue("block"), r.PathValue("ip")
+	if _, ok := s.fixtures.IPs[block]; !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+block+") does not exist")
+		return nil, false
+	}
+	firewall, ok := s.fixtures.Firewalls[ip]
+	if !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+ip+") does not exist")
+		return nil, false
+	}
+	return firewall, true
+}
+
+// settle completes pending rule removals. Callers must hold s.mu.
+func (f *Firewall) settle() {
+	for sequence, rule := range f.Rules {
+		if rule.State == api.FirewallRuleRemovalPending {
+			delete(f.Rules, sequence)
+		}
+	}
+}
+
+func (s *Server) listFirewalls(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	block := r.PathValue("block")
+	if _, ok := s.fixtures.IPs[block]; !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+block+") does not exist")
+		return
+	}
+
+	ips := []string{}
+	for _, ip := range sortedKeys(s.fixtures.Firewalls) {
+		if inBlock(block, ip) {
+			ips = append(ips, ip)
+		}
+	}
+	writeJSON(w, http.StatusOK, ips)
+}
+
+func (s *Server) createFirewall(w http.ResponseWriter, r *http.Request) {
+	var payload struct {
+		IPOnFirewall string `json:"ipOnFirewall"`
+	}
+	if !readJSON(w, r, &payload) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	block := r.PathValue("block")
+	if _, ok := s.fixtures.IPs[block]; !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+block+") does not exist")
+		return
+	}
+	if !inBlock(block, payload.IPOnFirewall) {
+		writeError(w, http.StatusBadRequest, payload.IPOnFirewall+" is not part of "+block)
+		return
+	}
+	if _, exists := s.fixtures.Firewalls[payload.IPOnFirewall]; exists {
+		writeError(w, http.StatusConflict, "This IP is already on the firewall")
+		return
+	}
+
+	if s.fixtures.Firewalls == nil {
+		s.fixtures.Firewalls = make(map[string]*Firewall)
+	}
+	firewall := &Firewall{
+		FirewallIP: api.FirewallIP{IPOnFirewall: payload.IPOnFirewall, State: firewallState},
+		Rules:      make(map[int]api.FirewallRule),
+	}
+	s.fixtures.Firewalls[payload.IPOnFirewall] = firewall
+	writeJSON(w, http.StatusOK, firewall.FirewallIP)
+}
+
+func (s *Server) getFirewall(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	firewall, ok := s.firewallFor(w, r)
+	if !ok {
+		return
+	}
+
+	current := firewall.FirewallIP
+	firewall.State = api.FirewallRuleOK
+	writeJSON(w, http.StatusOK, current)
+}
+
+func (s *Server) updateFirewall(w http.ResponseWriter, r *http.Request) {
+	var payload struct {
+		Enabled bool `json:"enabled"`
+	}
+	if !readJSON(w, r, &payload) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	firewall, ok := s.firewallFor(w, r)
+	if !ok {
+		return
+	}
+	firewall.Enabled = payload.Enabled
+	firewall.State = firewallState
+	writeJSON(w, http.StatusOK, nil)
+}
+
+func (s *Server) listFirewallRules(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	firewall, ok := s.firewallFor(w, r)
+	if !ok {
+		return
+	}
+
+	sequences := make([]int, 0, len(firewall.Rules))
+	for sequence := range firewall.Rules {
+		sequences = append(sequences, sequence)
+	}
+	sort.Ints(sequences)
+	firewall.settle()
+	writeJSON(w, http.StatusOK, sequences)
+}
+
+func (s *Server) getFirewallRule(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	firewall, ok := s.firewallFor(w, r)
+	if !ok {
+		return
+	}
+
+	sequence, _ := strconv.Atoi(r.PathValue("sequence"))
+	rule, ok := firewall.Rules[sequence]
+	if !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+r.PathValue("sequence")+") does not exist")
+		return
+	}
+	if rule.State == api.FirewallRuleCreationPending {
+		settled := rule
+		settled.State = api.FirewallRuleOK
+		firewall.Rules[sequence] = settled
+	}
+	writeJSON(w, http.StatusOK, rule)
+}
+
+// firewallRuleCreate is the rule creation body the API expects. It is
+// declared apart from api.FirewallRuleCreate so a client sending another
+// shape fails here as it would on the real API.
+type firewallRuleCreate struct {
+	Sequence        int    `json:"sequence"`
+	Action          string `json:"action"`
+	Protocol        string `json:"protocol"`
+	Source          string `json:"source"`
+	SourcePort      int    `json:"sourcePort"`
+	DestinationPort int    `json:"destinationPort"`
+	TCPOption       *struct {
+		Option    string `json:"option"`
+		Fragments bool   `json:"fragments"`
+	} `json:"tcpOption"`
+}
+
+func (s *Server) createFirewallRule(w http.ResponseWriter, r *http.Request) {
+	var payload firewallRuleCreate
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&payload); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	if payload.TCPOption != nil && payload.Protocol != "tcp" {
+		writeError(w, http.StatusBadRequest, "tcpOption requires the tcp protocol")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	firewall, ok := s.firewallFor(w, r)
+	if !ok {
+		return
+	}
+	if payload.Sequence < 0 || payload.Sequence > 19 {
+		writeError(w, http.StatusBadRequest, "Invalid sequence")
+		return
+	}
+	if _, exists := firewall.Rules[payload.Sequence]; exists {
+		writeError(w, http.StatusConflict, "A rule with this sequence already exists")
+		return
+	}
+
+	rule := firewallRule(payload)
+	firewall.Rules[payload.Sequence] = rule
+	writeJSON(w, http.StatusOK, rule)
+}
+
+func (s *Server) deleteFirewallRule(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	firewall, ok := s.firewallFor(w, r)
+	if !ok {
+		return
+	}
+
+	sequence, _ := strconv.Atoi(r.PathValue("sequence"))
+	rule, ok := firewall.Rules[sequence]
+	if !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+r.PathValue("sequence")+") does not exist")
+		return
+	}
+	rule.State = api.FirewallRuleRemovalPending
+	firewall.Rules[sequence] = rule
+	writeJSON(w, http.StatusOK, rule)
+}
+
+// firewallRule renders a creation payload the way the API reports rules
+func firewallRule(payload firewallRuleCreate) api.FirewallRule {
+	rule := api.FirewallRule{
+		Sequence:    payload.Sequence,
+		Action:      payload.Action,
+		Protocol:    payload.Protocol,
+		Source:      "any",
+		Destination: "any",
+		State:       api.FirewallRuleCreationPending,
+	}
+	if payload.Source != "" {
+		rule.Source = payload.Source
+	}
+	if payload.SourcePort != 0 {
+		rule.SourcePort = fmt.Sprintf("eq %d", payload.SourcePort)
+	}
+	if payload.DestinationPort != 0 {
+		rule.DestinationPort = fmt.Sprintf("eq %d", payload.DestinationPort)
+	}
+	if payload.TCPOption != nil {
+		rule.TCPOption = payload.TCPOption.Option
+		if payload.TCPOption.Fragments {
+			fragments := true
+			rule.Fragments = &fragments
+		}
+	}
+
+	parts := []string{rule.Action, rule.Protocol, rule.Source}
+	if rule.SourcePort != "" {
+		parts = append(parts, rule.SourcePort)
+	}
+	parts = append(parts, rule.Destination)
+	if rule.DestinationPort != "" {
+		parts = append(parts, rule.DestinationPort)
+	}
+	rule.Rule = strings.Join(parts, " ")
+	return rule
+}
+
+// settledRule renders a seeded rule that is already active
+func settledRule(payload firewallRuleCreate) api.FirewallRule {
+	rule := firewallRule(payload)
+	rule.State = api.FirewallRuleOK
+	return rule
+}
+
+// inBlock checks if an address belongs to a block
+func inBlock(block, ip string) bool {
+	prefix, err := netip.ParsePrefix(block)
+	if err != nil {
+		return false
+	}
+	addr, err := netip.ParseAddr(ip)
+	return err == nil && prefix.Contains(addr)
+}
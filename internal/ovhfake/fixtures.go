@@ -0,0 +1,495 @@
+// internal/ovhfake/fixtures.go
+package ovhfake
+
+import (
+	"sort"
+	"time"
+
+	"ovh-terminal/internal/api"
+)
+
+// CloudProject is a fake Public Cloud project with its resources, which
+// are served by their own endpoints
+type CloudProject struct {
+	api.CloudProject
+	Flavors    []api.CloudFlavor               `json:"-"`
+	Instances  map[string]api.CloudInstance    `json:"-"`
+	Volumes    map[string]api.CloudVolume      `json:"-"`
+	Snapshots  map[string]api.CloudSnapshot    `json:"-"`
+	Networks   map[string]api.CloudNetwork     `json:"-"`
+	SSHKeys    map[string]api.CloudSSHKey      `json:"-"`
+	Kube       map[string]api.KubeCluster      `json:"-"`
+	NodePools  map[string][]api.KubeNodePool   `json:"-"`
+	Regions    []string                        `json:"-"`
+	Available  []api.CloudRegion               `json:"-"`
+	Quotas     []api.CloudQuota                `json:"-"`
+	Containers map[string]api.StorageContainer `json:"-"`
+	Users      map[string]api.CloudUser        `json:"-"`
+	S3Keys     map[string][]api.S3Credentials  `json:"-"`
+	Usage      *api.CloudUsage                 `json:"-"`
+	Forecast   *api.CloudUsage                 `json:"-"`
+
+	// pending holds the transitional status of instances whose action
+	// only shows from the next read
+	pending map[string]string
+}
+
+// Fixtures holds the data served by the fake API
+type Fixtures struct {
+	Account            api.AccountInfo
+	Servers            map[string]api.ServerInfo
+	VPS                map[string]api.VPSInfo
+	Domains            map[string]*Domain
+	Zones              map[string]*Zone
+	IPs                map[string]api.IPInfo
+	Reverses           map[string]map[string]api.IPReverse
+	Firewalls          map[string]*Firewall
+	Mitigations        map[string]api.IPMitigation
+	MitigationProfiles map[string]api.IPMitigationProfile
+	Antihack           map[string]api.IPAntihack
+	Spam               map[string]api.IPSpam
+	CloudProjects      map[string]*CloudProject
+	Bills              map[string]*Bill
+	Tickets            map[string]*Ticket
+	Hostings           map[string]*Hosting
+	EmailDomains       map[string]*EmailDomain
+	Services           map[string]*api.ServiceInfos
+}
+
+// DefaultFixtures returns a small, deterministic account
+func DefaultFixtures() *Fixtures {
+	return &Fixtures{
+		Account: api.AccountInfo{
+			Email:        "jane.doe@example.com",
+			FirstName:    "Jane",
+			Name:         "Doe",
+			Currency:     &api.Currency{Code: "EUR", Symbol: "€"},
+			Language:     "en_GB",
+			Organisation: "Example Ltd",
+			City:         "Roubaix",
+			Country:      "FR",
+			CustomerCode: "1234-5678-90",
+			NicHandle:    "dj12345-ovh",
+			State:        "complete",
+			KYCValidated: true,
+		},
+		Servers: map[string]api.ServerInfo{
+			"ns1001.ip-203-0-113.eu": {
+				Name:            "ns1001.ip-203-0-113.eu",
+				IP:              "203.0.113.10",
+				State:           api.ServerStateActive,
+				PowerState:      "poweron",
+				Datacenter:      "rbx8",
+				CommercialRange: "advance",
+				Reverse:         "web1.example.com.",
+				Region:          "eu-west-rbx",
+				OS:              "debian12_64",
+				IAM:             &api.IAMInfo{DisplayName: "web1"},
+			},
+			"ns1002.ip-203-0-113.eu": {
+				Name:            "ns1002.ip-203-0-113.eu",
+				IP:              "203.0.113.20",
+				State:           api.ServerStateActive,
+				PowerState:      "poweron",
+				Datacenter:      "gra3",
+				CommercialRange: "rise",
+				Region:          "eu-west-gra",
+				OS:              "ubuntu2404-server_64",
+			},
+		},
+		VPS: map[string]api.VPSInfo{
+			"vps-0a1b2c3d.vps.ovh.net": {
+				Name:        "vps-0a1b2c3d.vps.ovh.net",
+				DisplayName: "staging",
+				State:       "running",
+				Zone:        "Region OpenStack: os-gra7",
+				OfferType:   "ssd",
+				MemoryLimit: 4096,
+				VCore:       2,
+				Model: api.VPSModelInfo{
+					Name:   "vps-value-1-4-80",
+					Offer:  "VPS vps2020-value-1-4-80",
+					Memory: 4096,
+					Disk:   80,
+					VCore:  2,
+				},
+			},
+		},
+		Domains: defaultDomains(),
+		Zones:   defaultZones(),
+		IPs: map[string]api.IPInfo{
+			"203.0.113.10/32": {
+				IP:       "203.0.113.10/32",
+				Type:     api.IPTypeDedicated,
+				RoutedTo: &api.IPRoutedTo{ServiceName: "ns1001.ip-203-0-113.eu"},
+			},
+			"198.51.100.8/29": {
+				IP:          "198.51.100.8/29",
+				Type:        api.IPTypeFailover,
+				Description: "web failover",
+				RoutedTo:    &api.IPRoutedTo{ServiceName: "ns1001.ip-203-0-113.eu"},
+			},
+			"192.0.2.44/32": {
+				IP:       "192.0.2.44/32",
+				Type:     api.IPTypeVPS,
+				RoutedTo: &api.IPRoutedTo{ServiceName: "vps-0a1b2c3d.vps.ovh.net"},
+			},
+		},
+		Reverses: map[string]map[string]api.IPReverse{
+			"203.0.113.10/32": {
+				"203.0.113.10": {IPReverse: "203.0.113.10", Reverse: "web1.example.com."},
+			},
+			"198.51.100.8/29": {
+				"198.51.100.9": {IPReverse: "198.51.100.9", Reverse: "www.example.com."},
+			},
+		},
+		Firewalls: map[string]*Firewall{
+			"203.0.113.10": {
+				FirewallIP: api.FirewallIP{
+					IPOnFirewall: "203.0.113.10",
+					Enabled:      true,
+					State:        api.FirewallRuleOK,
+				},
+				Rules: map[int]api.FirewallRule{
+					0: settledRule(firewallRuleCreate{
+						Sequence:        0,
+						Action:          "permit",
+						Protocol:        "tcp",
+						Source:          "198.51.100.0/24",
+						DestinationPort: 22,
+					}),
+					1: settledRule(firewallRuleCreate{
+						Sequence:        1,
+						Action:          "permit",
+						Protocol:        "tcp",
+						DestinationPort: 443,
+					}),
+					19: settledRule(firewallRuleCreate{
+						Sequence: 19,
+						Action:   "deny",
+						Protocol: "ipv4",
+					}),
+				},
+			},
+		},
+		Mitigations: map[string]api.IPMitigation{
+			"198.51.100.9": {IPOnMitigation: "198.51.100.9", Permanent: true, State: "ok"},
+			"203.0.113.10": {IPOnMitigation: "203.0.113.10", Auto: true, State: "ok"},
+		},
+		MitigationProfiles: map[string]api.IPMitigationProfile{
+			"198.51.100.9": {IPMitigationProfile: "198.51.100.9", AutoMitigationTimeOut: 60, State: "ok"},
+		},
+		Antihack: map[string]api.IPAntihack{
+			"198.51.100.10": {
+				IPBlocked: "198.51.100.10",
+				Logs:      "SSH brute force towards 192.0.2.77",
+				State:     api.IPBlockedStateBlocked,
+			},
+			"198.51.100.11": {
+				IPBlocked: "198.51.100.11",
+				State:     api.IPBlockedStateBlocked,
+				Time:      5400,
+			},
+		},
+		Spam: map[string]api.IPSpam{
+			"192.0.2.44": {
+				IPSpamming: "192.0.2.44",
+				Date:       timePtr(time.Date(2026, 9, 30, 14, 5, 0, 0, time.UTC)),
+				State:      api.IPSpamStateBlocked,
+			},
+		},
+		CloudProjects: map[string]*CloudProject{
+			"5c0e1f2a3b4c4d5e8f9a0b1c2d3e4f5a": {
+				CloudProject: api.CloudProject{
+					ProjectID:    "5c0e1f2a3b4c4d5e8f9a0b1c2d3e4f5a",
+					Description:  "production",
+					Status:       "ok",
+					PlanCode:     "project.2018",
+					CreationDate: timePtr(time.Date(2023, 4, 1, 9, 0, 0, 0, time.UTC)),
+				},
+				Flavors: []api.CloudFlavor{
+					{
+						ID:        "f-b2-7-gra11",
+						Name:      "b2-7",
+						Region:    "GRA11",
+						VCPUs:     2,
+						RAM:       7000,
+						Disk:      50,
+						Available: true,
+					},
+					{
+						ID:        "f-d2-2-gra11",
+						Name:      "d2-2",
+						Region:    "GRA11",
+						VCPUs:     1,
+						RAM:       2000,
+						Disk:      25,
+						Available: true,
+					},
+				},
+				Instances: map[string]api.CloudInstance{
+					"i-web-1": {
+						ID:       "i-web-1",
+						Name:     "web-1",
+						Status:   "ACTIVE",
+						Region:   "GRA11",
+						FlavorID: "f-b2-7-gra11",
+						ImageID:  "img-debian12",
+						Created:  timePtr(time.Date(2024, 2, 12, 10, 30, 0, 0, time.UTC)),
+						IPAddresses: []api.CloudIPAddress{
+							{IP: "51.68.10.20", Type: "public", Version: 4},
+							{IP: "10.0.0.12", Type: "private", Version: 4, NetworkID: "pn-123_0"},
+						},
+					},
+					"i-worker-1": {
+						ID:       "i-worker-1",
+						Name:     "worker-1",
+						Status:   "SHUTOFF",
+						Region:   "GRA11",
+						FlavorID: "f-d2-2-gra11",
+						ImageID:  "img-debian12",
+						Created:  timePtr(time.Date(2024, 5, 3, 8, 0, 0, 0, time.UTC)),
+					},
+				},
+				Volumes: map[string]api.CloudVolume{
+					"v-data": {
+						ID:           "v-data",
+						Name:         "data",
+						Size:         100,
+						Region:       "GRA11",
+						Status:       "in-use",
+						Type:         "classic",
+						AttachedTo:   []string{"i-web-1"},
+						CreationDate: timePtr(time.Date(2024, 2, 12, 11, 0, 0, 0, time.UTC)),
+					},
+				},
+				Snapshots: map[string]api.CloudSnapshot{
+					"s-web-1-weekly": {
+						ID:           "s-web-1-weekly",
+						Name:         "web-1 weekly",
+						Region:       "GRA11",
+						Status:       "active",
+						Size:         3.4,
+						MinDisk:      50,
+						Type:         "linux",
+						Visibility:   "private",
+						CreationDate: timePtr(time.Date(2026, 10, 11, 3, 0, 0, 0, time.UTC)),
+					},
+				},
+				Networks: map[string]api.CloudNetwork{
+					"pn-123_0": {
+						ID:      "pn-123_0",
+						Name:    "backend",
+						Status:  "ACTIVE",
+						Type:    "private",
+						VlanID:  0,
+						Regions: []api.CloudNetworkRegion{{Region: "GRA11", Status: "ACTIVE"}},
+					},
+				},
+				SSHKeys: map[string]api.CloudSSHKey{
+					"k-jane": {
+						ID:          "k-jane",
+						Name:        "jane@laptop",
+						PublicKey:   "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIFakeKeyForTestsOnly jane@laptop",
+						Fingerprint: "SHA256:0fakefingerprint",
+						Regions:     []string{"GRA11"},
+					},
+				},
+				Kube: map[string]api.KubeCluster{
+					"kube-prod": {
+						ID:                  "kube-prod",
+						Name:                "prod-cluster",
+						Region:              "GRA7",
+						Version:             "1.30",
+						Status:              "READY",
+						URL:                 "https://abc123.c1.gra7.k8s.ovh.net",
+						UpdatePolicy:        "ALWAYS_UPDATE",
+						IsUpToDate:          true,
+						NextUpgradeVersions: []string{"1.31"},
+						CreatedAt:           timePtr(time.Date(2025, 3, 4, 9, 0, 0, 0, time.UTC)),
+					},
+				},
+				NodePools: map[string][]api.KubeNodePool{
+					"kube-prod": {
+						{
+							ID: "np-general", Name: "general", Flavor: "b3-8", Status: "READY",
+							DesiredNodes: 3, CurrentNodes: 3, AvailableNodes: 3, UpToDateNodes: 3,
+							MinNodes: 3, MaxNodes: 6, Autoscale: true,
+						},
+						{
+							ID: "np-batch", Name: "batch", Flavor: "c3-16", Status: "READY",
+							DesiredNodes: 2, CurrentNodes: 2, AvailableNodes: 2, UpToDateNodes: 2,
+							MinNodes: 2, MaxNodes: 2,
+						},
+					},
+				},
+				Regions: []string{"GRA", "GRA11", "GRA7", "SBG"},
+				Available: []api.CloudRegion{
+					{Name: "BHS5", ContinentCode: "NA", DatacenterLocation: "BHS"},
+					{Name: "WAW1", ContinentCode: "EU", DatacenterLocation: "WAW"},
+				},
+				Quotas: []api.CloudQuota{
+					{
+						Region: "GRA11",
+						Instance: &api.CloudInstanceQuota{
+							MaxCores: 20, UsedCores: 18,
+							MaxInstances: 20, UsedInstances: 2,
+							MaxRAM: 40000, UsedRAM: 9000,
+						},
+						Volume: &api.CloudVolumeQuota{
+							MaxGigabytes: 10000, UsedGigabytes: 100,
+							MaxVolumeCount: 100, VolumeCount: 1,
+						},
+					},
+					{
+						Region: "GRA7",
+						Instance: &api.CloudInstanceQuota{
+							MaxCores: 20, UsedCores: 10,
+							MaxInstances: 20, UsedInstances: 5,
+							MaxRAM: 40000, UsedRAM: 20000,
+						},
+					},
+				},
+				Containers: map[string]api.StorageContainer{
+					"GRA/backups": {
+						Name:         "backups",
+						Region:       "GRA",
+						OwnerID:      4242,
+						ObjectsCount: 2,
+						ObjectsSize:  3221225472,
+						CreatedAt:    timePtr(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)),
+						Objects: []api.StorageObject{
+							{Key: "db/2026-10-17.sql.gz", Size: 1073741824, StorageClass: "STANDARD",
+								LastModified: timePtr(time.Date(2026, 10, 17, 2, 0, 0, 0, time.UTC))},
+							{Key: "db/2026-10-18.sql.gz", Size: 2147483648, StorageClass: "STANDARD",
+								LastModified: timePtr(time.Date(2026, 10, 18, 2, 0, 0, 0, time.UTC))},
+						},
+					},
+					"SBG/assets": {
+						Name:      "assets",
+						Region:    "SBG",
+						OwnerID:   4242,
+						CreatedAt: timePtr(time.Date(2025, 9, 9, 12, 0, 0, 0, time.UTC)),
+					},
+				},
+				Users: map[string]api.CloudUser{
+					"4242": {
+						ID:           4242,
+						Username:     "user-s3backup",
+						Description:  "backup writer",
+						Status:       "ok",
+						Roles:        []api.CloudUserRole{{Name: "objectstore_operator"}},
+						CreationDate: timePtr(time.Date(2025, 6, 1, 11, 0, 0, 0, time.UTC)),
+					},
+				},
+				S3Keys: map[string][]api.S3Credentials{
+					"4242": {
+						{
+							Access:   "AKIA0BACKUP0001",
+							Secret:   "s3cr3t-backup-0001",
+							TenantID: "5c0e1f2a3b4c4d5e8f9a0b1c2d3e4f5a",
+							UserID:   "4242",
+						},
+					},
+				},
+				Usage: &api.CloudUsage{
+					HourlyUsage: &api.CloudHourlyUsage{
+						Instance: []api.CloudUsageEntry{
+							{Region: "GRA11", Reference: "d2-2", TotalPrice: 1.84,
+								Quantity: &api.CloudUsageQuantity{Unit: "Hour", Value: 184}},
+						},
+						InstanceBandwidth: []api.CloudUsageEntry{
+							{Region: "GRA11", Reference: "bandwidth", TotalPrice: 0.45,
+								Quantity: &api.CloudUsageQuantity{Unit: "GiB", Value: 45}},
+						},
+						Storage: []api.CloudUsageEntry{
+							{Region: "GRA", Reference: "storage", TotalPrice: 0.32,
+								Quantity: &api.CloudUsageQuantity{Unit: "GiBh", Value: 32000}},
+						},
+						Volume: []api.CloudUsageEntry{
+							{Region: "GRA11", Reference: "classic", TotalPrice: 2.4,
+								Quantity: &api.CloudUsageQuantity{Unit: "GiBh", Value: 43200}},
+						},
+						Snapshot: []api.CloudUsageEntry{
+							{Region: "GRA11", Reference: "snapshot", TotalPrice: 0.05,
+								Quantity: &api.CloudUsageQuantity{Unit: "GiBh", Value: 2448}},
+						},
+					},
+					MonthlyUsage: &api.CloudMonthlyUsage{
+						Instance: []api.CloudUsageEntry{
+							{Region: "GRA11", Reference: "b2-7", TotalPrice: 26},
+						},
+					},
+					Period: api.CloudUsagePeriod{
+						From: timePtr(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)),
+						To:   timePtr(time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)),
+					},
+					LastUpdate: timePtr(time.Date(2026, 10, 18, 6, 0, 0, 0, time.UTC)),
+				},
+				Forecast: &api.CloudUsage{
+					HourlyUsage: &api.CloudHourlyUsage{
+						Instance: []api.CloudUsageEntry{
+							{Region: "GRA11", Reference: "d2-2", TotalPrice: 3.17,
+								Quantity: &api.CloudUsageQuantity{Unit: "Hour", Value: 317}},
+						},
+						InstanceBandwidth: []api.CloudUsageEntry{
+							{Region: "GRA11", Reference: "bandwidth", TotalPrice: 0.78,
+								Quantity: &api.CloudUsageQuantity{Unit: "GiB", Value: 78}},
+						},
+						Storage: []api.CloudUsageEntry{
+							{Region: "GRA", Reference: "storage", TotalPrice: 0.55,
+								Quantity: &api.CloudUsageQuantity{Unit: "GiBh", Value: 55000}},
+						},
+						Volume: []api.CloudUsageEntry{
+							{Region: "GRA11", Reference: "classic", TotalPrice: 4.13,
+								Quantity: &api.CloudUsageQuantity{Unit: "GiBh", Value: 74400}},
+						},
+						Snapshot: []api.CloudUsageEntry{
+							{Region: "GRA11", Reference: "snapshot", TotalPrice: 0.09,
+								Quantity: &api.CloudUsageQuantity{Unit: "GiBh", Value: 4216}},
+						},
+					},
+					MonthlyUsage: &api.CloudMonthlyUsage{
+						Instance: []api.CloudUsageEntry{
+							{Region: "GRA11", Reference: "b2-7", TotalPrice: 26},
+						},
+					},
+					Period: api.CloudUsagePeriod{
+						From: timePtr(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)),
+						To:   timePtr(time.Date(2026, 10, 31, 23, 59, 59, 0, time.UTC)),
+					},
+					LastUpdate: timePtr(time.Date(2026, 10, 18, 6, 0, 0, 0, time.UTC)),
+				},
+			},
+			"9a8b7c6d5e4f40312a1b0c9d8e7f6a5b": {
+				CloudProject: api.CloudProject{
+					ProjectID:    "9a8b7c6d5e4f40312a1b0c9d8e7f6a5b",
+					Description:  "analytics",
+					Status:       "ok",
+					PlanCode:     "project.2018",
+					CreationDate: timePtr(time.Date(2025, 1, 20, 9, 0, 0, 0, time.UTC)),
+				},
+			},
+		},
+		Bills:        defaultBills(time.Now()),
+		Tickets:      defaultTickets(),
+		Hostings:     defaultHostings(time.Now()),
+		EmailDomains: defaultEmailDomains(time.Now()),
+		Services:     defaultServices(),
+	}
+}
+
+// timePtr returns a pointer to a fixture timestamp
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+// sortedKeys returns the keys of a fixture map in a stable order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,407 @@
+// internal/ovhfake/handlers.go
+package ovhfake
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"ovh-terminal/internal/api"
+)
+
+// registerRoutes wires the fake API endpoints
+func (s *Server) registerRoutes() {
+	s.handle("GET /auth/time", s.getAuthTime)
+	s.handle("GET /me", s.getMe)
+	s.handle("GET /me/bill", s.listBills)
+	s.handle("GET /me/bill/{bill}", s.getBill)
+	s.handle("GET /me/bill/{bill}/details", s.listBillDetails)
+	s.handle("GET /me/bill/{bill}/details/{detail}", s.getBillDetail)
+	s.handle("GET /me/bill/{bill}/payment", s.getBillPayment)
+	s.handle("GET /support/tickets", s.listTickets)
+	s.handle("GET /support/tickets/{ticket}", s.getTicket)
+	s.handle("GET /support/tickets/{ticket}/messages", s.listTicketMessages)
+	s.handle("POST /support/tickets/{ticket}/reply", s.replyTicket)
+	s.handle("POST /support/tickets/{ticket}/close", s.closeTicket)
+	s.handle("POST /support/tickets/{ticket}/reopen", s.reopenTicket)
+	s.handle("GET /dedicated/server", s.listServers)
+	s.handle("GET /dedicated/server/{name}", s.getServer)
+	s.handle("GET /vps", s.listVPS)
+	s.handle("GET /vps/{name}", s.getVPS)
+	s.handle("GET /domain", s.listDomains)
+	s.handle("GET /domain/{domain}", s.getDomain)
+	s.handle("PUT /domain/{domain}", s.updateDomain)
+	s.handle("GET /domain/{domain}/nameServer", s.listDomainNameServers)
+	s.handle("GET /domain/{domain}/nameServer/{id}", s.getDomainNameServer)
+	s.handle("POST /domain/{domain}/nameServers/update", s.updateDomainNameServers)
+	s.handle("GET /domain/{domain}/dsRecord", s.listDomainDSRecords)
+	s.handle("POST /domain/{domain}/dsRecord", s.updateDomainDSRecords)
+	s.handle("GET /domain/{domain}/dsRecord/{id}", s.getDomainDSRecord)
+	s.handle("GET /domain/{domain}/task/{id}", s.getDomainTask)
+	s.handle("GET /domain/zone/{zone}", s.getZone)
+	s.handle("POST /domain/zone/{zone}/refresh", s.refreshZone)
+	s.handle("GET /domain/zone/{zone}/dnssec", s.getZoneDNSSEC)
+	s.handle("POST /domain/zone/{zone}/dnssec", s.setZoneDNSSEC(api.DNSSECEnabled))
+	s.handle("DELETE /domain/zone/{zone}/dnssec", s.setZoneDNSSEC(api.DNSSECDisabled))
+	s.handle("GET /domain/zone/{zone}/record", s.listZoneRecords)
+	s.handle("POST /domain/zone/{zone}/record", s.createZoneRecord)
+	s.handle("GET /domain/zone/{zone}/record/{id}", s.getZoneRecord)
+	s.handle("PUT /domain/zone/{zone}/record/{id}", s.updateZoneRecord)
+	s.handle("DELETE /domain/zone/{zone}/record/{id}", s.deleteZoneRecord)
+	s.handle("GET /domain/zone/{zone}/dynHost/record", s.listDynHostRecords)
+	s.handle("POST /domain/zone/{zone}/dynHost/record", s.createDynHostRecord)
+	s.handle("GET /domain/zone/{zone}/dynHost/record/{id}", s.getDynHostRecord)
+	s.handle("PUT /domain/zone/{zone}/dynHost/record/{id}", s.updateDynHostRecord)
+	s.handle("GET /domain/zone/{zone}/export", s.exportZone)
+	s.handle("POST /domain/zone/{zone}/import", s.importZone)
+	s.handle("GET /domain/zone/{zone}/task/{id}", s.getZoneTask)
+	s.handle("GET /domain/zone/{zone}/redirection", s.listZoneRedirections)
+	s.handle("POST /domain/zone/{zone}/redirection", s.createZoneRedirection)
+	s.handle("GET /domain/zone/{zone}/redirection/{id}", s.getZoneRedirection)
+	s.handle("DELETE /domain/zone/{zone}/redirection/{id}", s.deleteZoneRedirection)
+	s.handle("GET /hosting/web", s.listHostings)
+	s.handle("GET /hosting/web/{name}", s.getHosting)
+	s.handle("GET /hosting/web/{name}/ovhConfig", s.listHostingConfigs)
+	s.handle("GET /hosting/web/{name}/ovhConfig/{id}", s.getHostingConfig)
+	s.handle("GET /hosting/web/{name}/attachedDomain", s.listHostingDomains)
+	s.handle("GET /hosting/web/{name}/attachedDomain/{domain}", s.getHostingDomain)
+	s.handle("GET /hosting/web/{name}/user", s.listHostingUsers)
+	s.handle("GET /hosting/web/{name}/user/{login}", s.getHostingUser)
+	s.handle("GET /hosting/web/{name}/database", s.listHostingDatabases)
+	s.handle("GET /hosting/web/{name}/database/{database}", s.getHostingDatabase)
+	s.handle("GET /hosting/web/{name}/database/{database}/dump", s.listDumps)
+	s.handle("POST /hosting/web/{name}/database/{database}/dump", s.createDump)
+	s.handle("GET /hosting/web/{name}/database/{database}/dump/{id}", s.getDump)
+	s.handle("POST /hosting/web/{name}/database/{database}/dump/{id}/restore", s.restoreDump)
+	s.handle("GET /hosting/web/{name}/tasks/{id}", s.getHostingTask)
+	s.handle("GET /hosting/web/{name}/cron", s.listHostingCrons)
+	s.handle("GET /hosting/web/{name}/cron/{id}", s.getHostingCron)
+	s.handle("GET /hosting/web/{name}/ssl", s.getHostingSSL)
+	s.handle("GET /hosting/web/{name}/ssl/domains", s.listHostingSSLDomains)
+	s.handle("GET /email/domain", s.listEmailDomains)
+	s.handle("GET /email/domain/{domain}", s.getEmailDomain)
+	s.handle("GET /email/domain/{domain}/account", s.listEmailAccounts)
+	s.handle("POST /email/domain/{domain}/account", s.createEmailAccount)
+	s.handle("GET /email/domain/{domain}/account/{account}", s.getEmailAccount)
+	s.handle("DELETE /email/domain/{domain}/account/{account}", s.deleteEmailAccount)
+	s.handle("GET /email/domain/{domain}/account/{account}/usage", s.getEmailAccountUsage)
+	s.handle("POST /email/domain/{domain}/account/{account}/changePassword", s.changeEmailPassword)
+	s.handle("GET /email/domain/{domain}/redirection", s.listEmailRedirections)
+	s.handle("POST /email/domain/{domain}/redirection", s.createEmailRedirection)
+	s.handle("GET /email/domain/{domain}/redirection/{id}", s.getEmailRedirection)
+	s.handle("GET /email/domain/{domain}/mailingList", s.listEmailMailingLists)
+	s.handle("GET /email/domain/{domain}/mailingList/{name}", s.getEmailMailingList)
+	s.handle("GET /email/domain/{domain}/responder", s.listEmailResponders)
+	s.handle("GET /email/domain/{domain}/responder/{account}", s.getEmailResponder)
+	s.handle("GET /dedicated/server/{name}/serviceInfos", s.serviceInfosHandler("server"))
+	s.handle("PUT /dedicated/server/{name}/serviceInfos", s.updateServiceInfosHandler("server"))
+	s.handle("GET /vps/{name}/serviceInfos", s.serviceInfosHandler("vps"))
+	s.handle("PUT /vps/{name}/serviceInfos", s.updateServiceInfosHandler("vps"))
+	s.handle("GET /domain/{name}/serviceInfos", s.serviceInfosHandler("domain"))
+	s.handle("PUT /domain/{name}/serviceInfos", s.updateServiceInfosHandler("domain"))
+	s.handle("GET /hosting/web/{name}/serviceInfos", s.serviceInfosHandler("hosting"))
+	s.handle("PUT /hosting/web/{name}/serviceInfos", s.updateServiceInfosHandler("hosting"))
+	s.handle("GET /ip", s.listIPs)
+	s.handle("GET /ip/{block}", s.getIP)
+	s.handle("GET /ip/{block}/reverse", s.listReverses)
+	s.handle("POST /ip/{block}/reverse", s.createReverse)
+	s.handle("GET /ip/{block}/reverse/{ip}", s.getReverse)
+	s.handle("DELETE /ip/{block}/reverse/{ip}", s.deleteReverse)
+	s.handle("GET /ip/{block}/move", s.getMoveDestinations)
+	s.handle("POST /ip/{block}/move", s.moveIP)
+	s.handle("GET /ip/{block}/task/{id}", s.getIPTask)
+	s.handle("GET /ip/{block}/firewall", s.listFirewalls)
+	s.handle("POST /ip/{block}/firewall", s.createFirewall)
+	s.handle("GET /ip/{block}/firewall/{ip}", s.getFirewall)
+	s.handle("PUT /ip/{block}/firewall/{ip}", s.updateFirewall)
+	s.handle("GET /ip/{block}/firewall/{ip}/rule", s.listFirewallRules)
+	s.handle("POST /ip/{block}/firewall/{ip}/rule", s.createFirewallRule)
+	s.handle("GET /ip/{block}/firewall/{ip}/rule/{sequence}", s.getFirewallRule)
+	s.handle("DELETE /ip/{block}/firewall/{ip}/rule/{sequence}", s.deleteFirewallRule)
+	s.handle("GET /ip/{block}/mitigation", s.listMitigations)
+	s.handle("GET /ip/{block}/mitigation/{ip}", s.getMitigation)
+	s.handle("GET /ip/{block}/mitigationProfiles", s.listMitigationProfiles)
+	s.handle("GET /ip/{block}/mitigationProfiles/{ip}", s.getMitigationProfile)
+	s.handle("GET /ip/{block}/antihack", s.listAntihack)
+	s.handle("GET /ip/{block}/antihack/{ip}", s.getAntihack)
+	s.handle("POST /ip/{block}/antihack/{ip}/unblock", s.unblockAntihack)
+	s.handle("GET /ip/{block}/spam", s.listSpam)
+	s.handle("GET /ip/{block}/spam/{ip}", s.getSpam)
+	s.handle("POST /ip/{block}/spam/{ip}/unblock", s.unblockSpam)
+	s.handle("GET /cloud/project", s.listCloudProjects)
+	s.handle("GET /cloud/project/{id}", s.getCloudProject)
+	s.handle("GET /cloud/project/{id}/flavor", s.listCloudFlavors)
+	s.handle("GET /cloud/project/{id}/instance", s.listCloudInstances)
+	s.handle("GET /cloud/project/{id}/instance/{instance}", s.getCloudInstance)
+	s.handle("POST /cloud/project/{id}/instance/{instance}/start",
+		s.cloudInstanceAction("POWERING_ON", api.CloudInstanceShutoff))
+	s.handle("POST /cloud/project/{id}/instance/{instance}/stop",
+		s.cloudInstanceAction("POWERING_OFF", api.CloudInstanceActive))
+	s.handle("POST /cloud/project/{id}/instance/{instance}/reboot",
+		s.cloudInstanceAction("REBOOT", api.CloudInstanceActive, api.CloudInstanceShutoff))
+	s.handle("POST /cloud/project/{id}/instance/{instance}/shelve",
+		s.cloudInstanceAction("SHELVING", api.CloudInstanceActive, api.CloudInstanceShutoff))
+	s.handle("POST /cloud/project/{id}/instance/{instance}/unshelve",
+		s.cloudInstanceAction("UNSHELVING", api.CloudInstanceShelved, api.CloudInstanceShelvedOffloaded))
+	s.handle("POST /cloud/project/{id}/instance/{instance}/rescueMode", s.setCloudInstanceRescue)
+	s.handle("POST /cloud/project/{id}/instance/{instance}/snapshot", s.createCloudInstanceSnapshot)
+	s.handle("GET /cloud/project/{id}/volume", s.listCloudVolumes)
+	s.handle("GET /cloud/project/{id}/volume/{volume}", s.getCloudVolume)
+	s.handle("GET /cloud/project/{id}/snapshot", s.listCloudSnapshots)
+	s.handle("GET /cloud/project/{id}/snapshot/{snapshot}", s.getCloudSnapshot)
+	s.handle("GET /cloud/project/{id}/network/private", s.listCloudNetworks)
+	s.handle("GET /cloud/project/{id}/network/private/{network}", s.getCloudNetwork)
+	s.handle("GET /cloud/project/{id}/sshkey", s.listCloudSSHKeys)
+	s.handle("GET /cloud/project/{id}/sshkey/{key}", s.getCloudSSHKey)
+	s.handle("GET /cloud/project/{id}/kube", s.listKubeClusters)
+	s.handle("GET /cloud/project/{id}/kube/{kube}", s.getKubeCluster)
+	s.handle("GET /cloud/project/{id}/kube/{kube}/nodepool", s.listKubeNodePools)
+	s.handle("POST /cloud/project/{id}/kube/{kube}/kubeconfig", s.getKubeconfig)
+	s.handle("GET /cloud/project/{id}/region", s.listCloudRegions)
+	s.handle("GET /cloud/project/{id}/region/{region}", s.getCloudRegion)
+	s.handle("GET /cloud/project/{id}/regionAvailable", s.listAvailableCloudRegions)
+	s.handle("GET /cloud/project/{id}/quota", s.listCloudQuotas)
+	s.handle("GET /cloud/project/{id}/region/{region}/storage", s.listStorageContainers)
+	s.handle("GET /cloud/project/{id}/region/{region}/storage/{name}", s.getStorageContainer)
+	s.handle("GET /cloud/project/{id}/user", s.listCloudUsers)
+	s.handle("GET /cloud/project/{id}/user/{user}", s.getCloudUser)
+	s.handle("GET /cloud/project/{id}/user/{user}/s3Credentials", s.listS3Credentials)
+	s.handle("POST /cloud/project/{id}/user/{user}/s3Credentials", s.createS3Credentials)
+	s.handle("POST /cloud/project/{id}/user/{user}/s3Credentials/{access}/secret", s.getS3Secret)
+	s.handle("DELETE /cloud/project/{id}/user/{user}/s3Credentials/{access}", s.deleteS3Credentials)
+	s.handle("GET /cloud/project/{id}/usage/current",
+		s.cloudUsage(func(p *CloudProject) *api.CloudUsage { return p.Usage }))
+	s.handle("GET /cloud/project/{id}/usage/forecast",
+		s.cloudUsage(func(p *CloudProject) *api.CloudUsage { return p.Forecast }))
+}
+
+// ZonePath prefixes DNS zone routes. They are served by their own mux, as
+// /domain/zone/{zone} overlaps with /domain/{domain} sub-resources.
+const ZonePath = "/domain/zone/"
+
+// handle registers a route below the API base path
+func (s *Server) handle(pattern string, handler http.HandlerFunc) {
+	method, path, _ := strings.Cut(pattern, " ")
+	mux := s.mux
+	if strings.HasPrefix(path, ZonePath) {
+		mux = s.zoneMux
+	}
+	mux.HandleFunc(method+" "+BasePath+path, handler)
+}
+
+// getAuthTime returns the server time as a unix timestamp
+func (s *Server) getAuthTime(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.now().Unix())
+}
+
+func (s *Server) getMe(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, s.fixtures.Account)
+}
+
+func (s *Server) listServers(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, sortedKeys(s.fixtures.Servers))
+}
+
+func (s *Server) getServer(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeFixture(w, s.fixtures.Servers, r.PathValue("name"))
+}
+
+func (s *Server) listVPS(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, sortedKeys(s.fixtures.VPS))
+}
+
+func (s *Server) getVPS(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeFixture(w, s.fixtures.VPS, r.PathValue("name"))
+}
+
+func (s *Server) listIPs(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, sortedKeys(s.fixtures.IPs))
+}
+
+func (s *Server) getIP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeFixture(w, s.fixtures.IPs, r.PathValue("block"))
+}
+
+func (s *Server) listReverses(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	block := r.PathValue("block")
+	if _, ok := s.fixtures.IPs[block]; !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+block+") does not exist")
+		return
+	}
+	writeJSON(w, http.StatusOK, sortedKeys(s.fixtures.Reverses[block]))
+}
+
+func (s *Server) getReverse(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeFixture(w, s.fixtures.Reverses[r.PathValue("block")], r.PathValue("ip"))
+}
+
+func (s *Server) createReverse(w http.ResponseWriter, r *http.Request) {
+	var reverse api.IPReverse
+	if !readJSON(w, r, &reverse) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	block := r.PathValue("block")
+	if _, ok := s.fixtures.IPs[block]; !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+block+") does not exist")
+		return
+	}
+	if s.fixtures.Reverses == nil {
+		s.fixtures.Reverses = make(map[string]map[string]api.IPReverse)
+	}
+	if s.fixtures.Reverses[block] == nil {
+		s.fixtures.Reverses[block] = make(map[string]api.IPReverse)
+	}
+	s.fixtures.Reverses[block][reverse.IPReverse] = reverse
+	writeJSON(w, http.StatusOK, reverse)
+}
+
+func (s *Server) deleteReverse(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	block, ip := r.PathValue("block"), r.PathValue("ip")
+	if _, ok := s.fixtures.Reverses[block][ip]; !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+ip+") does not exist")
+		return
+	}
+	delete(s.fixtures.Reverses[block], ip)
+	writeJSON(w, http.StatusOK, nil)
+}
+
+// moveDestinations lists the services a failover block can move to.
+// Callers must hold s.mu.
+func (s *Server) moveDestinations(block string) map[string][]api.IPDestination {
+	destinations := map[string][]api.IPDestination{
+		"dedicatedServer": {},
+		"vps":             {},
+	}
+
+	info := s.fixtures.IPs[block]
+	if info.Type != api.IPTypeFailover {
+		return destinations
+	}
+	for _, name := range sortedKeys(s.fixtures.Servers) {
+		if name != info.GetRoutedService() {
+			destinations["dedicatedServer"] = append(destinations["dedicatedServer"],
+				api.IPDestination{Service: name})
+		}
+	}
+	for _, name := range sortedKeys(s.fixtures.VPS) {
+		if name != info.GetRoutedService() {
+			destinations["vps"] = append(destinations["vps"], api.IPDestination{Service: name})
+		}
+	}
+	return destinations
+}
+
+func (s *Server) getMoveDestinations(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	block := r.PathValue("block")
+	if _, ok := s.fixtures.IPs[block]; !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+block+") does not exist")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.moveDestinations(block))
+}
+
+func (s *Server) moveIP(w http.ResponseWriter, r *http.Request) {
+	var payload struct {
+		To      string `json:"to"`
+		Nexthop string `json:"nexthop"`
+	}
+	if !readJSON(w, r, &payload) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	block := r.PathValue("block")
+	if _, ok := s.fixtures.IPs[block]; !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+block+") does not exist")
+		return
+	}
+
+	eligible := false
+	for _, destinations := range s.moveDestinations(block) {
+		for _, destination := range destinations {
+			eligible = eligible || destination.Service == payload.To
+		}
+	}
+	if !eligible {
+		writeError(w, http.StatusBadRequest, payload.To+" is not an eligible destination")
+		return
+	}
+
+	t := s.newTask("genericMoveFloatingIp", func() {
+		info := s.fixtures.IPs[block]
+		info.RoutedTo = &api.IPRoutedTo{ServiceName: payload.To}
+		s.fixtures.IPs[block] = info
+	})
+	writeJSON(w, http.StatusOK, t)
+}
+
+func (s *Server) getIPTask(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, _ := strconv.Atoi(r.PathValue("id"))
+	t, ok := s.tasks[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+r.PathValue("id")+") does not exist")
+		return
+	}
+	t.advance()
+	writeJSON(w, http.StatusOK, t)
+}
+
+func (s *Server) listCloudProjects(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, sortedKeys(s.fixtures.CloudProjects))
+}
+
+func (s *Server) getCloudProject(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeFixture(w, s.fixtures.CloudProjects, r.PathValue("id"))
+}
+
+// readJSON decodes a request body, answering 400 when it is invalid
+func readJSON(w http.ResponseWriter, r *http.Request, value interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(value); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// writeFixture writes a single fixture entry or a 404 if it is unknown
+func writeFixture[V any](w http.ResponseWriter, fixtures map[string]V, key string) {
+	value, ok := fixtures[key]
+	if !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+key+") does not exist")
+		return
+	}
+	writeJSON(w, http.StatusOK, value)
+}
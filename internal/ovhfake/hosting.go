@@ -0,0 +1,455 @@
+// internal/ovhfake/hosting.go
+package ovhfake
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"ovh-terminal/internal/api"
+)
+
+// Hosting is a fake web hosting plan with its resources. Configurations
+// and cron jobs are keyed by their numeric ID.
+type Hosting struct {
+	api.Hosting
+	Configs    map[string]api.HostingConfig    `json:"-"`
+	Domains    map[string]api.HostingDomain    `json:"-"`
+	Users      map[string]api.HostingUser      `json:"-"`
+	Databases  map[string]*api.HostingDatabase `json:"-"`
+	Crons      map[string]api.HostingCron      `json:"-"`
+	Dumps      map[string]*api.HostingDump     `json:"-"`
+	SSL        *api.HostingSSL                 `json:"-"`
+	SSLDomains []string                        `json:"-"`
+}
+
+// defaultHostings returns a personal plan serving example.com, with last
+// night's backup of its database
+func defaultHostings(now time.Time) map[string]*Hosting {
+	const home = "/homez.2021/examplec"
+	backup := time.Date(now.Year(), now.Month(), now.Day(), 3, 0, 0, 0, time.UTC)
+
+	return map[string]*Hosting{
+		"example.com": {
+			Hosting: api.Hosting{
+				ServiceName:     "example.com",
+				DisplayName:     "example.com",
+				Offer:           "perso2014",
+				Cluster:         "cluster021",
+				ClusterIP:       "203.0.113.21",
+				Datacenter:      "gra1",
+				OperatingSystem: "linux",
+				Home:            home,
+				PrimaryLogin:    "examplec",
+				State:           "active",
+				QuotaSize:       &api.UnitValue{Unit: "GB", Value: 100},
+				QuotaUsed:       &api.UnitValue{Unit: "MB", Value: 2458},
+			},
+			Configs: map[string]api.HostingConfig{
+				"1": {
+					ID:            1,
+					EngineName:    "php",
+					EngineVersion: "8.2",
+					Environment:   "production",
+					Container:     "stable64",
+					HTTPFirewall:  "none",
+					Status:        "created",
+				},
+			},
+			Domains: map[string]api.HostingDomain{
+				"example.com": {
+					Domain: "example.com", Path: "./www", SSL: true,
+					CDN: "none", Firewall: "none", OwnLog: "none", Status: "created",
+				},
+				"www.example.com": {
+					Domain: "www.example.com", Path: "./www", SSL: true,
+					CDN: "none", Firewall: "none", OwnLog: "none", Status: "created",
+				},
+				"blog.example.com": {
+					Domain: "blog.example.com", Path: "./blog",
+					CDN: "none", Firewall: "active", OwnLog: "active", Status: "created",
+				},
+			},
+			Users: map[string]api.HostingUser{
+				"examplec": {
+					Login: "examplec", Home: home, SSHState: "active",
+					State: "rw", PrimaryAccount: true, Status: "ok",
+				},
+				"examplec-deploy": {
+					Login: "examplec-deploy", Home: home + "/www", SSHState: "sftponly",
+					State: "rw", Status: "ok",
+				},
+			},
+			Databases: map[string]*api.HostingDatabase{
+				"examplecdb": {
+					Name: "examplecdb", User: "examplecdb", Server: "examplecdb.mysql.db",
+					Port: 3306, Type: "mysql", Version: "8.0", Mode: "w",
+					State: "activated", Status: "ok",
+					QuotaSize: &api.UnitValue{Unit: "MB", Value: 400},
+					QuotaUsed: &api.UnitValue{Unit: "MB", Value: 37.8},
+				},
+			},
+			Crons: map[string]api.HostingCron{
+				"1": {
+					ID: 1, Description: "Nightly cleanup", Command: "www/cron/cleanup.php",
+					Frequency: "0 3 * * *", Language: "php8.2", State: "enabled", Status: "ok",
+					Email: "admin@example.com",
+				},
+				"2": {
+					ID: 2, Description: "Sitemap", Command: "www/bin/sitemap.sh",
+					Frequency: "30 * * * *", Language: "other", State: "disabled", Status: "ok",
+				},
+			},
+			Dumps: map[string]*api.HostingDump{
+				"1": {
+					ID: 1, DatabaseName: "examplecdb", Type: api.DumpYesterday,
+					Status: "created", CreationDate: timePtr(backup),
+				},
+			},
+			SSL: &api.HostingSSL{
+				Provider:    "LETSENCRYPT",
+				Type:        "DV",
+				Status:      "created",
+				Regenerable: true,
+			},
+			SSLDomains: []string{"example.com", "www.example.com"},
+		},
+	}
+}
+
+// sortedIDs returns the numeric keys of a fixture map in order
+func sortedIDs[V any](m map[string]V) []int64 {
+	ids := make([]int64, 0, len(m))
+	for key := range m {
+		id, _ := strconv.ParseInt(key, 10, 64)
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// hosting returns the hosting plan of the request, writing a 404 when it
+// is unknown. Callers must hold s.mu.
+func (s *Server) hosting(w http.ResponseWriter, r *http.Request) (*Hosting, bool) {
+	name := r.PathValue("name")
+	hosting, ok := s.fixtures.Hostings[name]
+	if !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+name+") does not exist")
+		return nil, false
+	}
+	return hosting, true
+}
+
+func (s *Server) listHostings(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, sortedKeys(s.fixtures.Hostings))
+}
+
+func (s *Server) getHosting(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if hosting, ok := s.hosting(w, r); ok {
+		writeJSON(w, http.StatusOK, hosting.Hosting)
+	}
+}
+
+func (s *Server) listHostingConfigs(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if hosting, ok := s.hosting(w, r); ok {
+		writeJSON(w, http.StatusOK, sortedIDs(hosting.Configs))
+	}
+}
+
+func (s *Server) getHostingConfig(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if hosting, ok := s.hosting(w, r); ok {
+		writeFixture(w, hosting.Configs, r.PathValue("id"))
+	}
+}
+
+func (s *Server) listHostingDomains(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if hosting, ok := s.hosting(w, r); ok {
+		writeJSON(w, http.StatusOK, sortedKeys(hosting.Domains))
+	}
+}
+
+func (s *Server) getHostingDomain(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if hosting, ok := s.hosting(w, r); ok {
+		writeFixture(w, hosting.Domains, r.PathValue("domain"))
+	}
+}
+
+func (s *Server) listHostingUsers(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if hosting, ok := s.hosting(w, r); ok {
+		writeJSON(w, http.StatusOK, sortedKeys(hosting.Users))
+	}
+}
+
+func (s *Server) getHostingUser(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if hosting, ok := s.hosting(w, r); ok {
+		writeFixture(w, hosting.Users, r.PathValue("login"))
+	}
+}
+
+func (s *Server) listHostingDatabases(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if hosting, ok := s.hosting(w, r); ok {
+		writeJSON(w, http.StatusOK, sortedKeys(hosting.Databases))
+	}
+}
+
+func (s *Server) getHostingDatabase(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if hosting, ok := s.hosting(w, r); ok {
+		writeFixture(w, hosting.Databases, r.PathValue("database"))
+	}
+}
+
+func (s *Server) listHostingCrons(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if hosting, ok := s.hosting(w, r); ok {
+		writeJSON(w, http.StatusOK, sortedIDs(hosting.Crons))
+	}
+}
+
+func (s *Server) getHostingCron(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if hosting, ok := s.hosting(w, r); ok {
+		writeFixture(w, hosting.Crons, r.PathValue("id"))
+	}
+}
+
+// getHostingSSL answers 404 for plans without a certificate, like the
+// real API
+func (s *Server) getHostingSSL(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	hosting, ok := s.hosting(w, r)
+	if !ok {
+		return
+	}
+	if hosting.SSL == nil {
+		writeError(w, http.StatusNotFound, "This hosting has no SSL certificate")
+		return
+	}
+	writeJSON(w, http.StatusOK, hosting.SSL)
+}
+
+func (s *Server) listHostingSSLDomains(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	hosting, ok := s.hosting(w, r)
+	if !ok {
+		return
+	}
+	if hosting.SSL == nil {
+		writeError(w, http.StatusNotFound, "This hosting has no SSL certificate")
+		return
+	}
+	writeJSON(w, http.StatusOK, hosting.SSLDomains)
+}
+
+// DumpPath serves database dumps. Like the real links, they need no
+// signature.
+const DumpPath = "/dumps/"
+
+// hostingTask is the view of a task returned by the hosting API
+type hostingTask struct {
+	ID        int        `json:"id"`
+	Function  string     `json:"function"`
+	Status    string     `json:"status"`
+	StartDate time.Time  `json:"startDate"`
+	DoneDate  *time.Time `json:"doneDate"`
+}
+
+// newHostingTask registers a task and returns its hosting view. Callers
+// must hold s.mu.
+func (s *Server) newHostingTask(function string, onDone func()) hostingTask {
+	t := s.newTask(function, onDone)
+	return hostingTask{ID: t.ID, Function: t.Function, Status: t.Status, StartDate: t.StartDate}
+}
+
+// hostingDatabase returns the hosting plan and database of the request,
+// writing a 404 when either is unknown. Callers must hold s.mu.
+func (s *Server) hostingDatabase(w http.ResponseWriter, r *http.Request) (*Hosting, string, bool) {
+	hosting, ok := s.hosting(w, r)
+	if !ok {
+		return nil, "", false
+	}
+	name := r.PathValue("database")
+	if _, ok := hosting.Databases[name]; !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+name+") does not exist")
+		return nil, "", false
+	}
+	return hosting, name, true
+}
+
+// dump returns the dump of the request if it belongs to the database
+func (h *Hosting) dump(database, id string) (*api.HostingDump, bool) {
+	dump, ok := h.Dumps[id]
+	if !ok || dump.DatabaseName != database {
+		return nil, false
+	}
+	return dump, true
+}
+
+func (s *Server) listDumps(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	hosting, database, ok := s.hostingDatabase(w, r)
+	if !ok {
+		return
+	}
+
+	ids := []int64{}
+	for _, id := range sortedIDs(hosting.Dumps) {
+		if hosting.Dumps[strconv.FormatInt(id, 10)].DatabaseName == database {
+			ids = append(ids, id)
+		}
+	}
+	writeJSON(w, http.StatusOK, ids)
+}
+
+// createDump adds the dump once its task completes
+func (s *Server) createDump(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	hosting, database, ok := s.hostingDatabase(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Date string `json:"date"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	switch req.Date {
+	case api.DumpNow, api.DumpYesterday, api.DumpLastWeek:
+	default:
+		writeError(w, http.StatusBadRequest, "Invalid date: "+req.Date)
+		return
+	}
+
+	t := s.newHostingTask("database/dump", func() {
+		if hosting.Dumps == nil {
+			hosting.Dumps = make(map[string]*api.HostingDump)
+		}
+		id := int64(len(hosting.Dumps) + 1)
+		for hosting.Dumps[strconv.FormatInt(id, 10)] != nil {
+			id++
+		}
+		hosting.Dumps[strconv.FormatInt(id, 10)] = &api.HostingDump{
+			ID:           id,
+			DatabaseName: database,
+			Type:         req.Date,
+			Status:       "created",
+			CreationDate: timePtr(time.Now().UTC().Truncate(time.Second)),
+		}
+	})
+	writeJSON(w, http.StatusOK, t)
+}
+
+// getDump fills in the download link of a dump
+func (s *Server) getDump(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	hosting, database, ok := s.hostingDatabase(w, r)
+	if !ok {
+		return
+	}
+	dump, ok := hosting.dump(database, r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+r.PathValue("id")+") does not exist")
+		return
+	}
+
+	result := *dump
+	result.URL = fmt.Sprintf("%s%s%s/%s/%d.sql.gz", serverURL(r), DumpPath, hosting.ServiceName, database, dump.ID)
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) restoreDump(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	hosting, database, ok := s.hostingDatabase(w, r)
+	if !ok {
+		return
+	}
+	if _, ok := hosting.dump(database, r.PathValue("id")); !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+r.PathValue("id")+") does not exist")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.newHostingTask("database/import", nil))
+}
+
+// getHostingTask advances a task of the hosting API
+func (s *Server) getHostingTask(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.hosting(w, r); !ok {
+		return
+	}
+	id, _ := strconv.Atoi(r.PathValue("id"))
+	t, ok := s.tasks[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+r.PathValue("id")+") does not exist")
+		return
+	}
+	t.advance()
+	writeJSON(w, http.StatusOK, hostingTask{
+		ID: t.ID, Function: t.Function, Status: t.Status, StartDate: t.StartDate, DoneDate: t.DoneDate,
+	})
+}
+
+// downloadDump serves a gzipped placeholder SQL dump
+func (s *Server) downloadDump(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, DumpPath), "/")
+	if len(parts) != 3 {
+		http.NotFound(w, r)
+		return
+	}
+	hosting, ok := s.fixtures.Hostings[parts[0]]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	dump, ok := hosting.dump(parts[1], strings.TrimSuffix(parts[2], ".sql.gz"))
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	fmt.Fprintf(zw, "-- MySQL dump of %s (#%d, %s)\nCREATE TABLE posts (id INT PRIMARY KEY);\n",
+		dump.DatabaseName, dump.ID, dump.Type)
+	zw.Close()
+
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Write(buf.Bytes())
+}
@@ -0,0 +1,150 @@
+// internal/ovhfake/mitigation.go
+package ovhfake
+
+import (
+	"net/http"
+
+	"ovh-terminal/internal/api"
+)
+
+// addressesInBlock lists the keys of a per-address fixture map that belong
+// to the block of the request, writing a 404 for unknown blocks. Callers
+// must hold s.mu.
+func addressesInBlock[V any](s *Server, w http.ResponseWriter, r *http.Request, m map[string]V) ([]string, bool) {
+	block := r.PathValue("block")
+	if _, ok := s.fixtures.IPs[block]; !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+block+") does not exist")
+		return nil, false
+	}
+
+	ips := []string{}
+	for _, ip := range sortedKeys(m) {
+		if inBlock(block, ip) {
+			ips = append(ips, ip)
+		}
+	}
+	return ips, true
+}
+
+// addressFixture writes the entry of an address if it belongs to the block
+// of the request. Callers must hold s.mu.
+func addressFixture[V any](w http.ResponseWriter, r *http.Request, m map[string]V) {
+	ip := r.PathValue("ip")
+	if !inBlock(r.PathValue("block"), ip) {
+		writeError(w, http.StatusNotFound, "The requested object ("+ip+") does not exist")
+		return
+	}
+	writeFixture(w, m, ip)
+}
+
+func (s *Server) listMitigations(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ips, ok := addressesInBlock(s, w, r, s.fixtures.Mitigations); ok {
+		writeJSON(w, http.StatusOK, ips)
+	}
+}
+
+func (s *Server) getMitigation(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	addressFixture(w, r, s.fixtures.Mitigations)
+}
+
+func (s *Server) listMitigationProfiles(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ips, ok := addressesInBlock(s, w, r, s.fixtures.MitigationProfiles); ok {
+		writeJSON(w, http.StatusOK, ips)
+	}
+}
+
+func (s *Server) getMitigationProfile(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	addressFixture(w, r, s.fixtures.MitigationProfiles)
+}
+
+func (s *Server) listAntihack(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ips, ok := addressesInBlock(s, w, r, s.fixtures.Antihack)
+	if !ok {
+		return
+	}
+
+	state := r.URL.Query().Get("state")
+	filtered := []string{}
+	for _, ip := range ips {
+		if state == "" || s.fixtures.Antihack[ip].State == state {
+			filtered = append(filtered, ip)
+		}
+	}
+	writeJSON(w, http.StatusOK, filtered)
+}
+
+func (s *Server) getAntihack(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	addressFixture(w, r, s.fixtures.Antihack)
+}
+
+func (s *Server) unblockAntihack(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ip := r.PathValue("ip")
+	entry, ok := s.fixtures.Antihack[ip]
+	if !ok || !inBlock(r.PathValue("block"), ip) {
+		writeError(w, http.StatusNotFound, "The requested object ("+ip+") does not exist")
+		return
+	}
+	if entry.State != api.IPBlockedStateBlocked || entry.Time > 0 {
+		writeError(w, http.StatusBadRequest, "This IP cannot be unblocked yet")
+		return
+	}
+	entry.State = api.IPBlockedStateUnblocking
+	s.fixtures.Antihack[ip] = entry
+	writeJSON(w, http.StatusOK, nil)
+}
+
+func (s *Server) listSpam(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ips, ok := addressesInBlock(s, w, r, s.fixtures.Spam)
+	if !ok {
+		return
+	}
+
+	state := r.URL.Query().Get("state")
+	filtered := []string{}
+	for _, ip := range ips {
+		if state == "" || s.fixtures.Spam[ip].State == state {
+			filtered = append(filtered, ip)
+		}
+	}
+	writeJSON(w, http.StatusOK, filtered)
+}
+
+func (s *Server) getSpam(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	addressFixture(w, r, s.fixtures.Spam)
+}
+
+func (s *Server) unblockSpam(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ip := r.PathValue("ip")
+	entry, ok := s.fixtures.Spam[ip]
+	if !ok || !inBlock(r.PathValue("block"), ip) {
+		writeError(w, http.StatusNotFound, "The requested object ("+ip+") does not exist")
+		return
+	}
+	if entry.State != api.IPSpamStateBlocked || entry.Time > 0 {
+		writeError(w, http.StatusBadRequest, "This IP cannot be unblocked yet")
+		return
+	}
+	entry.State = api.IPBlockedStateUnblocking
+	s.fixtures.Spam[ip] = entry
+	writeJSON(w, http.StatusOK, entry)
+}
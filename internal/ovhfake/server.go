@@ -0,0 +1,438 @@
+// internal/ovhfake/server.go
+
+// Package ovhfake provides an in-process stand-in for the OVH API, used to
+// exercise the client end to end without network access
+package ovhfake
+
+import (
+	"crypto/sha1"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"ovh-terminal/internal/config"
+)
+
+// Default credentials accepted by the fake server
+const (
+	AppKey      = "fake-app-key"
+	AppSecret   = "fake-app-secret"
+	ConsumerKey = "fake-consumer-key"
+)
+
+// BasePath is the API version prefix served by the fake server
+const BasePath = "/1.0"
+
+// TokenPath is the OAuth2 token endpoint, served outside the API prefix
+const TokenPath = "/auth/oauth2/token"
+
+// signatureWindow is the maximum accepted distance between a request
+// timestamp and the server clock
+const signatureWindow = time.Minute
+
+// Server is a fake OVH API backed by httptest
+type Server struct {
+	srv      *httptest.Server
+	mux      *http.ServeMux
+	zoneMux  *http.ServeMux
+	mu       sync.Mutex
+	fixtures *Fixtures
+	failures []*failure
+	requests []string
+	offset   time.Duration
+	tls      bool
+
+	appKey      string
+	appSecret   string
+	consumerKey string
+
+	clientID      string
+	clientSecret  string
+	tokenLifetime time.Duration
+	tokens        map[string]time.Time
+	tokenSeq      int
+
+	tasks   map[int]*task
+	taskSeq int
+}
+
+// failure is an injected error response
+type failure struct {
+	method    string
+	path      string
+	status    int
+	remaining int
+}
+
+// Option configures a fake server
+type Option func(*Server)
+
+// WithFixtures replaces the default seeded data
+func WithFixtures(fixtures *Fixtures) Option {
+	return func(s *Server) {
+		s.fixtures = fixtures
+	}
+}
+
+// WithClockOffset shifts the server clock relative to the local clock
+func WithClockOffset(offset time.Duration) Option {
+	return func(s *Server) {
+		s.offset = offset
+	}
+}
+
+// WithTLS serves the API over https with a self-signed certificate
+func WithTLS() Option {
+	return func(s *Server) {
+		s.tls = true
+	}
+}
+
+// WithCredentials sets the application and consumer keys the server accepts
+func WithCredentials(appKey, appSecret, consumerKey string) Option {
+	return func(s *Server) {
+		s.appKey = appKey
+		s.appSecret = appSecret
+		s.consumerKey = consumerKey
+	}
+}
+
+// WithOAuth2Client enables OAuth2 client credentials for a service account
+func WithOAuth2Client(clientID, clientSecret string) Option {
+	return func(s *Server) {
+		s.clientID = clientID
+		s.clientSecret = clientSecret
+	}
+}
+
+// WithTokenLifetime sets the validity of issued OAuth2 access tokens
+func WithTokenLifetime(lifetime time.Duration) Option {
+	return func(s *Server) {
+		s.tokenLifetime = lifetime
+	}
+}
+
+// NewServer starts a fake OVH API server
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		mux:           http.NewServeMux(),
+		zoneMux:       http.NewServeMux(),
+		fixtures:      DefaultFixtures(),
+		appKey:        AppKey,
+		appSecret:     AppSecret,
+		consumerKey:   ConsumerKey,
+		tokenLifetime: time.Hour,
+		tokens:        make(map[string]time.Time),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.registerRoutes()
+	if s.tls {
+		s.srv = httptest.NewTLSServer(s)
+	} else {
+		s.srv = httptest.NewServer(s)
+	}
+
+	return s
+}
+
+// Close shuts the server down
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// URL returns the root URL of the server
+func (s *Server) URL() string {
+	return s.srv.URL
+}
+
+// Endpoint returns the API endpoint URL to configure clients with
+func (s *Server) Endpoint() string {
+	return s.srv.URL + BasePath
+}
+
+// WriteCACert writes the server certificate as a PEM bundle, for use as
+// an account ca_file. Only valid for servers started WithTLS.
+func (s *Server) WriteCACert(path string) error {
+	cert := s.srv.Certificate()
+	if cert == nil {
+		return fmt.Errorf("server is not using TLS")
+	}
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	return os.WriteFile(path, data, 0o600)
+}
+
+// AccountConfig returns an account configuration pointing at the server
+func (s *Server) AccountConfig() *config.AccountConfig {
+	return &config.AccountConfig{
+		Name:        "Fake Account",
+		Endpoint:    s.Endpoint(),
+		AppKey:      s.appKey,
+		AppSecret:   s.appSecret,
+		ConsumerKey: s.consumerKey,
+	}
+}
+
+// OAuth2AccountConfig returns an account configuration using the OAuth2
+// service account enabled WithOAuth2Client
+func (s *Server) OAuth2AccountConfig() *config.AccountConfig {
+	return &config.AccountConfig{
+		Name:         "Fake Service Account",
+		Endpoint:     s.Endpoint(),
+		Auth:         config.AuthOAuth2,
+		ClientID:     s.clientID,
+		ClientSecret: s.clientSecret,
+		TokenURL:     s.srv.URL + TokenPath,
+	}
+}
+
+// Fixtures returns the seeded data, tests may adjust it before issuing
+// requests
+func (s *Server) Fixtures() *Fixtures {
+	return s.fixtures
+}
+
+// SetClockOffset changes the server clock offset at runtime
+func (s *Server) SetClockOffset(offset time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.offset = offset
+}
+
+// Fail makes the next times requests matching method and path fail with
+// status. A path of "*" matches every request, times <= 0 fails forever.
+func (s *Server) Fail(method, path string, status, times int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, &failure{
+		method:    method,
+		path:      path,
+		status:    status,
+		remaining: times,
+	})
+}
+
+// Reset clears injected failures and the request log
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = nil
+	s.requests = nil
+}
+
+// Requests returns the requests served so far as "METHOD /path"
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+// now returns the server clock
+func (s *Server) now() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return time.Now().Add(s.offset)
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, BasePath)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, r.Method+" "+path)
+	s.mu.Unlock()
+
+	if status, ok := s.injectedFailure(r.Method, path); ok {
+		writeError(w, status, http.StatusText(status))
+		return
+	}
+
+	if r.URL.Path == TokenPath {
+		s.issueToken(w, r)
+		return
+	}
+
+	if strings.HasPrefix(r.URL.Path, DownloadPath) {
+		s.downloadBill(w, r)
+		return
+	}
+
+	if strings.HasPrefix(r.URL.Path, DumpPath) {
+		s.downloadDump(w, r)
+		return
+	}
+
+	if path != "/auth/time" {
+		verify := s.verifySignature
+		if strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+			verify = s.verifyToken
+		}
+		if status, msg := verify(r); status != http.StatusOK {
+			writeError(w, status, msg)
+			return
+		}
+	}
+
+	if strings.HasPrefix(path, ZonePath) {
+		s.zoneMux.ServeHTTP(w, r)
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// injectedFailure returns the status of a matching injected failure
+func (s *Server) injectedFailure(method, path string) (int, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, f := range s.failures {
+		if f.method != method && f.method != "*" {
+			continue
+		}
+		if f.path != path && f.path != "*" {
+			continue
+		}
+
+		if f.remaining > 0 {
+			f.remaining--
+			if f.remaining == 0 {
+				s.failures = append(s.failures[:i], s.failures[i+1:]...)
+			}
+		}
+		return f.status, true
+	}
+	return 0, false
+}
+
+// verifySignature checks the OVH request signature headers
+func (s *Server) verifySignature(r *http.Request) (int, string) {
+	if r.Header.Get("X-Ovh-Application") != s.appKey {
+		return http.StatusForbidden, "Invalid application key"
+	}
+	if r.Header.Get("X-Ovh-Consumer") != s.consumerKey {
+		return http.StatusForbidden, "Invalid credential"
+	}
+
+	timestamp, err := strconv.ParseInt(r.Header.Get("X-Ovh-Timestamp"), 10, 64)
+	if err != nil {
+		return http.StatusBadRequest, "Missing or invalid timestamp"
+	}
+	drift := s.now().Sub(time.Unix(timestamp, 0))
+	if drift > signatureWindow || drift < -signatureWindow {
+		return http.StatusUnauthorized, "Invalid timestamp: request time is too far from server time"
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return http.StatusBadRequest, "Unreadable request body"
+	}
+	r.Body = io.NopCloser(strings.NewReader(string(body)))
+
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	target := fmt.Sprintf("%s://%s%s", scheme, r.Host, r.URL.RequestURI())
+
+	h := sha1.New()
+	h.Write([]byte(fmt.Sprintf("%s+%s+%s+%s+%s+%d",
+		s.appSecret, s.consumerKey, r.Method, target, body, timestamp)))
+	expected := fmt.Sprintf("$1$%x", h.Sum(nil))
+
+	if r.Header.Get("X-Ovh-Signature") != expected {
+		return http.StatusUnauthorized, "Invalid signature"
+	}
+
+	return http.StatusOK, ""
+}
+
+// issueToken implements the OAuth2 client credentials grant
+func (s *Server) issueToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientID = r.PostFormValue("client_id")
+		clientSecret = r.PostFormValue("client_secret")
+	}
+
+	if r.PostFormValue("grant_type") != "client_credentials" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+		return
+	}
+	if s.clientID == "" || clientID != s.clientID || clientSecret != s.clientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	s.mu.Lock()
+	s.tokenSeq++
+	token := fmt.Sprintf("fake-access-token-%d", s.tokenSeq)
+	s.tokens[token] = time.Now().Add(s.tokenLifetime)
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": token,
+		"token_type":   "Bearer",
+		"expires_in":   int(s.tokenLifetime.Seconds()),
+		"scope":        "all",
+	})
+}
+
+// verifyToken checks an OAuth2 bearer token
+func (s *Server) verifyToken(r *http.Request) (int, string) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+	s.mu.Lock()
+	expiry, ok := s.tokens[token]
+	s.mu.Unlock()
+
+	if !ok {
+		return http.StatusUnauthorized, "Invalid access token"
+	}
+	if time.Now().After(expiry) {
+		return http.StatusUnauthorized, "The access token has expired"
+	}
+	return http.StatusOK, ""
+}
+
+// writeJSON encodes a value as a JSON response
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+// writeError writes an OVH style error response
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"class":   errorClasses[status],
+		"message": message,
+	})
+}
+
+// errorClasses maps status codes to OVH error classes
+var errorClasses = map[int]string{
+	http.StatusBadRequest:          "Client::BadRequest",
+	http.StatusUnauthorized:        "Client::Unauthorized",
+	http.StatusForbidden:           "Client::Forbidden",
+	http.StatusNotFound:            "Client::NotFound",
+	http.StatusConflict:            "Client::Conflict",
+	http.StatusTooManyRequests:     "Client::TooManyRequests",
+	http.StatusInternalServerError: "Server::InternalServerError",
+	http.StatusServiceUnavailable:  "Server::ServiceUnavailable",
+}
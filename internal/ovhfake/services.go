@@ -0,0 +1,92 @@
+// internal/ovhfake/services.go
+package ovhfake
+
+import (
+	"net/http"
+
+	"ovh-terminal/internal/api"
+)
+
+// serviceInfos is a fixture subscription
+func serviceInfos(id int64, name, creation, expiration string, renew api.ServiceRenew) *api.ServiceInfos {
+	return &api.ServiceInfos{
+		ServiceID:             id,
+		Domain:                name,
+		Status:                "ok",
+		Creation:              creation,
+		Expiration:            expiration,
+		RenewalType:           "automaticV2016",
+		Renew:                 &renew,
+		CanDeleteAtExpiration: true,
+		PossibleRenewPeriod:   []int{1, 3, 6, 12},
+	}
+}
+
+// defaultServices returns the subscriptions of the fixture services,
+// keyed by service type and name
+func defaultServices() map[string]*api.ServiceInfos {
+	server := serviceInfos(1001, "ns1001.ip-203-0-113.eu", "2024-01-31", "2027-01-31",
+		api.ServiceRenew{Automatic: true, Period: 12})
+	server.EngagedUpTo = "2027-01-31"
+
+	hosting := serviceInfos(5001, "example.com", "2022-02-01", "2027-02-01",
+		api.ServiceRenew{Automatic: true, Forced: true, Period: 12})
+	hosting.RenewalType = "automaticForcedProduct"
+
+	return map[string]*api.ServiceInfos{
+		"server/ns1001.ip-203-0-113.eu": server,
+		"server/ns1002.ip-203-0-113.eu": serviceInfos(1002, "ns1002.ip-203-0-113.eu", "2025-06-30", "2026-11-30",
+			api.ServiceRenew{Automatic: true, Period: 1}),
+		"vps/vps-0a1b2c3d.vps.ovh.net": serviceInfos(2001, "vps-0a1b2c3d.vps.ovh.net", "2024-10-29", "2026-10-29",
+			api.ServiceRenew{}),
+		"domain/example.com": serviceInfos(3001, "example.com", "2019-03-14", "2027-03-14",
+			api.ServiceRenew{Automatic: true, Period: 12}),
+		"domain/example.org": serviceInfos(3002, "example.org", "2021-11-02", "2026-11-02",
+			api.ServiceRenew{DeleteAtExpiration: true}),
+		"hosting/example.com": hosting,
+	}
+}
+
+// serviceInfosHandler serves the subscription of a service of kind
+func (s *Server) serviceInfosHandler(kind string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		writeFixture(w, s.fixtures.Services, kind+"/"+r.PathValue("name"))
+	}
+}
+
+// updateServiceInfosHandler changes the renewal of a service of kind
+func (s *Server) updateServiceInfosHandler(kind string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		name := r.PathValue("name")
+		infos, ok := s.fixtures.Services[kind+"/"+name]
+		if !ok {
+			writeError(w, http.StatusNotFound, "The requested object ("+name+") does not exist")
+			return
+		}
+
+		var req struct {
+			Renew *api.ServiceRenew `json:"renew"`
+		}
+		if !readJSON(w, r, &req) {
+			return
+		}
+		if req.Renew != nil {
+			if infos.Renew != nil && infos.Renew.Forced && !req.Renew.Automatic {
+				writeError(w, http.StatusForbidden, "Automatic renewal is forced for this service")
+				return
+			}
+			if req.Renew.DeleteAtExpiration && !infos.CanDeleteAtExpiration {
+				writeError(w, http.StatusBadRequest, "This service cannot be deleted at expiration")
+				return
+			}
+			renew := *req.Renew
+			infos.Renew = &renew
+		}
+		writeJSON(w, http.StatusOK, nil)
+	}
+}
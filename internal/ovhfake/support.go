@@ -0,0 +1,206 @@
+// internal/ovhfake/support.go
+package ovhfake
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"ovh-terminal/internal/api"
+)
+
+// Ticket is a fake support ticket with its messages, oldest first
+type Ticket struct {
+	api.SupportTicket
+	Messages []api.SupportMessage `json:"-"`
+}
+
+// supportMessage is a fixture message of a ticket
+func supportMessage(ticketID, messageID int64, from string, at time.Time, body string) api.SupportMessage {
+	return api.SupportMessage{
+		MessageID:    messageID,
+		TicketID:     ticketID,
+		From:         from,
+		Body:         body,
+		CreationDate: timePtr(at),
+		UpdateDate:   timePtr(at),
+	}
+}
+
+// defaultTickets returns an open ticket awaiting an answer and a closed one
+func defaultTickets() map[string]*Ticket {
+	opened := time.Date(2026, 10, 10, 9, 12, 0, 0, time.UTC)
+	billed := time.Date(2026, 9, 3, 14, 30, 0, 0, time.UTC)
+
+	return map[string]*Ticket{
+		"9120001": {
+			SupportTicket: api.SupportTicket{
+				TicketID:        9120001,
+				TicketNumber:    4821357,
+				Subject:         "Server unreachable after reboot",
+				State:           api.TicketOpen,
+				Product:         "dedicated",
+				Category:        "incident",
+				Type:            "genericRequest",
+				ServiceName:     "ns1001.ip-203-0-113.eu",
+				LastMessageFrom: api.MessageFromSupport,
+				CanBeClosed:     true,
+				CreationDate:    timePtr(opened),
+				UpdateDate:      timePtr(opened.Add(26 * time.Hour)),
+			},
+			Messages: []api.SupportMessage{
+				supportMessage(9120001, 1, api.MessageFromCustomer, opened,
+					"Hello,\n\nns1001 does not answer to ping since a reboot this morning. "+
+						"The IPMI console shows the BIOS screen.\n\nRegards,\nJane"),
+				supportMessage(9120001, 2, api.MessageFromSupport, opened.Add(2*time.Hour),
+					"Hello,\n\nA technician replaced a faulty memory module. "+
+						"The server booted on its disk again.\n\nBest regards,\nOVHcloud support"),
+				supportMessage(9120001, 3, api.MessageFromSupport, opened.Add(26*time.Hour),
+					"Can you confirm the server works as expected so that we close this request?"),
+			},
+		},
+		"9110457": {
+			SupportTicket: api.SupportTicket{
+				TicketID:        9110457,
+				TicketNumber:    4809921,
+				Subject:         "VAT number missing on invoice",
+				State:           api.TicketClosed,
+				Product:         "dedicated-billing",
+				Category:        "billing",
+				Type:            "genericRequest",
+				LastMessageFrom: api.MessageFromSupport,
+				CreationDate:    timePtr(billed),
+				UpdateDate:      timePtr(billed.Add(24 * time.Hour)),
+			},
+			Messages: []api.SupportMessage{
+				supportMessage(9110457, 1, api.MessageFromCustomer, billed,
+					"Our VAT number is missing on the last invoice, can you issue a corrected one?"),
+				supportMessage(9110457, 2, api.MessageFromSupport, billed.Add(24*time.Hour),
+					"The invoice was corrected and is available in your control panel."),
+			},
+		},
+	}
+}
+
+// ticket returns the ticket of the request, writing a 404 when it is
+// unknown. Callers must hold s.mu.
+func (s *Server) ticket(w http.ResponseWriter, r *http.Request) (*Ticket, bool) {
+	id := r.PathValue("ticket")
+	ticket, ok := s.fixtures.Tickets[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "The requested object ("+id+") does not exist")
+		return nil, false
+	}
+	return ticket, true
+}
+
+// addTicketMessage appends a customer message and updates the ticket.
+// Callers must hold s.mu.
+func (s *Server) addTicketMessage(ticket *Ticket, body string) {
+	now := time.Now().Add(s.offset).UTC()
+	ticket.Messages = append(ticket.Messages, supportMessage(ticket.TicketID,
+		int64(len(ticket.Messages)+1), api.MessageFromCustomer, now, body))
+	ticket.LastMessageFrom = api.MessageFromCustomer
+	ticket.UpdateDate = timePtr(now)
+}
+
+// readTicketBody decodes the message of a reply or reopening, answering
+// 400 when it is empty
+func readTicketBody(w http.ResponseWriter, r *http.Request) (string, bool) {
+	var req struct {
+		Body string `json:"body"`
+	}
+	if !readJSON(w, r, &req) {
+		return "", false
+	}
+	if strings.TrimSpace(req.Body) == "" {
+		writeError(w, http.StatusBadRequest, "Invalid body: a message is required")
+		return "", false
+	}
+	return req.Body, true
+}
+
+func (s *Server) listTickets(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make([]int64, 0, len(s.fixtures.Tickets))
+	for _, key := range sortedKeys(s.fixtures.Tickets) {
+		id, _ := strconv.ParseInt(key, 10, 64)
+		ids = append(ids, id)
+	}
+	writeJSON(w, http.StatusOK, ids)
+}
+
+func (s *Server) getTicket(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ticket, ok := s.ticket(w, r); ok {
+		writeJSON(w, http.StatusOK, ticket)
+	}
+}
+
+func (s *Server) listTicketMessages(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ticket, ok := s.ticket(w, r); ok {
+		writeJSON(w, http.StatusOK, ticket.Messages)
+	}
+}
+
+func (s *Server) replyTicket(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ticket, ok := s.ticket(w, r)
+	if !ok {
+		return
+	}
+	if ticket.State != api.TicketOpen {
+		writeError(w, http.StatusBadRequest, "This ticket is closed")
+		return
+	}
+	body, ok := readTicketBody(w, r)
+	if !ok {
+		return
+	}
+	s.addTicketMessage(ticket, body)
+	writeJSON(w, http.StatusOK, nil)
+}
+
+func (s *Server) closeTicket(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ticket, ok := s.ticket(w, r)
+	if !ok {
+		return
+	}
+	if ticket.State != api.TicketOpen || !ticket.CanBeClosed {
+		writeError(w, http.StatusBadRequest, "This ticket cannot be closed")
+		return
+	}
+	ticket.State = api.TicketClosed
+	ticket.UpdateDate = timePtr(time.Now().Add(s.offset).UTC())
+	writeJSON(w, http.StatusOK, nil)
+}
+
+func (s *Server) reopenTicket(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ticket, ok := s.ticket(w, r)
+	if !ok {
+		return
+	}
+	if ticket.State != api.TicketClosed {
+		writeError(w, http.StatusBadRequest, "This ticket is not closed")
+		return
+	}
+	body, ok := readTicketBody(w, r)
+	if !ok {
+		return
+	}
+	ticket.State = api.TicketOpen
+	ticket.CanBeClosed = true
+	s.addTicketMessage(ticket, body)
+	writeJSON(w, http.StatusOK, nil)
+}
@@ -0,0 +1,56 @@
+// internal/ovhfake/tasks.go
+package ovhfake
+
+import "time"
+
+// taskSteps are the statuses a fake task goes through, one per poll
+var taskSteps = []string{"todo", "doing", "done"}
+
+// task is an asynchronous operation that completes after a few polls
+type task struct {
+	ID        int        `json:"taskId"`
+	Function  string     `json:"function"`
+	Status    string     `json:"status"`
+	Comment   string     `json:"comment"`
+	StartDate time.Time  `json:"startDate"`
+	DoneDate  *time.Time `json:"doneDate"`
+
+	step   int
+	onDone func()
+}
+
+// newTask registers a task, onDone applies its effect on completion.
+// Callers must hold s.mu.
+func (s *Server) newTask(function string, onDone func()) *task {
+	if s.tasks == nil {
+		s.tasks = make(map[int]*task)
+	}
+	s.taskSeq++
+
+	t := &task{
+		ID:        s.taskSeq,
+		Function:  function,
+		Status:    taskSteps[0],
+		StartDate: time.Now().UTC(),
+		onDone:    onDone,
+	}
+	s.tasks[t.ID] = t
+	return t
+}
+
+// advance moves the task one step forward. Callers must hold s.mu.
+func (t *task) advance() {
+	if t.step >= len(taskSteps)-1 {
+		return
+	}
+
+	t.step++
+	t.Status = taskSteps[t.step]
+	if t.step == len(taskSteps)-1 {
+		done := time.Now().UTC()
+		t.DoneDate = &done
+		if t.onDone != nil {
+			t.onDone()
+		}
+	}
+}
@@ -3,6 +3,12 @@
 // Package common provides shared functionality for the UI
 package common
 
+import (
+	"ovh-terminal/internal/commands"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
 // MessageType represents different types of UI messages
 type MessageType int
 
@@ -35,3 +41,37 @@ type NavigationMessage struct {
 	Direction NavigationDirection
 	Pane      string
 }
+
+// CommandProgressMsg reports progress of a command running in the
+// background. Next waits for the following update.
+type CommandProgressMsg struct {
+	Title    string
+	Progress commands.CommandProgress
+	Next     tea.Cmd
+}
+
+// CommandPromptMsg delivers the next prompt of an interactive command,
+// computed in the background since prompts may call the API. Prompt is nil
+// once all input is collected. Submitted is the prompt whose value was
+// passed to the command, nil for the first one; InputErr rejects that value.
+type CommandPromptMsg struct {
+	Title     string
+	Command   commands.InteractiveCommand
+	Prompt    *commands.Prompt
+	Err       error
+	Submitted *Prompt
+	InputErr  error
+}
+
+// CommandResultMsg delivers the result of a background command
+type CommandResultMsg struct {
+	Title  string
+	Result commands.CommandResult
+}
+
+// EditorFinishedMsg reports that the external editor opened for a prompt
+// exited. Path is the file holding the composed text.
+type EditorFinishedMsg struct {
+	Path string
+	Err  error
+}
@@ -0,0 +1,123 @@
+// internal/ui/common/prompt.go
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	"ovh-terminal/internal/commands"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// PromptSubmitFunc handles the value entered at a prompt. A returned
+// error is shown and the prompt stays open.
+type PromptSubmitFunc func(model UIModel, value string) (tea.Cmd, error)
+
+// Prompt is an active input request, shown in place of the status bar
+type Prompt struct {
+	Spec   commands.Prompt
+	Input  textinput.Model
+	Cursor int
+	Error  string
+	Submit PromptSubmitFunc
+
+	// Pending is set while a submitted value is checked in the background
+	Pending bool
+}
+
+// NewPrompt creates a prompt for spec
+func NewPrompt(spec commands.Prompt, submit PromptSubmitFunc) *Prompt {
+	input := textinput.New()
+	input.Prompt = ""
+	input.Placeholder = spec.Default
+	if spec.Kind == commands.PromptSecret {
+		input.EchoMode = textinput.EchoPassword
+		input.EchoCharacter = '•'
+	}
+	input.Cursor.SetMode(cursor.CursorStatic)
+	input.Focus()
+
+	p := &Prompt{
+		Spec:   spec,
+		Input:  input,
+		Submit: submit,
+	}
+
+	// Start choice prompts on the default value
+	for i, choice := range spec.Choices {
+		if choice == spec.Default {
+			p.Cursor = i
+		}
+	}
+	return p
+}
+
+// Value returns the value the prompt would submit
+func (p *Prompt) Value() string {
+	switch p.Spec.Kind {
+	case commands.PromptChoice:
+		if p.Cursor < len(p.Spec.Choices) {
+			return p.Spec.Choices[p.Cursor]
+		}
+		return ""
+	default:
+		if value := p.Input.Value(); value != "" {
+			return value
+		}
+		return p.Spec.Default
+	}
+}
+
+// View renders the prompt line
+func (p *Prompt) View() string {
+	var view string
+	switch p.Spec.Kind {
+	case commands.PromptConfirm:
+		view = fmt.Sprintf("%s [y/N]", p.Spec.Label)
+	case commands.PromptChoice:
+		view = fmt.Sprintf("%s: %s  (↑/↓ select • Enter confirm • Esc cancel)",
+			p.Spec.Label, p.Value())
+	case commands.PromptEditor:
+		view = fmt.Sprintf("%s: %s  (Enter on an empty line opens $EDITOR)",
+			p.Spec.Label, p.Input.View())
+	default:
+		view = fmt.Sprintf("%s: %s", p.Spec.Label, p.Input.View())
+	}
+
+	if p.Pending {
+		view += "  …"
+	} else if p.Error != "" {
+		view += "  ✗ " + p.Error
+	}
+	return view
+}
+
+// ChoicesView renders the choices of a choice prompt, scrolled to keep
+// the cursor within height lines
+func (p *Prompt) ChoicesView(height int) string {
+	if p.Spec.Kind != commands.PromptChoice {
+		return ""
+	}
+
+	start := 0
+	if height > 0 && p.Cursor >= height {
+		start = p.Cursor - height + 1
+	}
+
+	var lines []string
+	lines = append(lines, p.Spec.Label, "")
+	for i := start; i < len(p.Spec.Choices); i++ {
+		if height > 0 && len(lines)-2 >= height {
+			break
+		}
+		marker := "  "
+		if i == p.Cursor {
+			marker = "> "
+		}
+		lines = append(lines, marker+p.Spec.Choices[i])
+	}
+	return strings.Join(lines, "\n")
+}
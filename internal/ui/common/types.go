@@ -57,6 +57,12 @@ type MenuItem interface {
 	GetIndent() int
 	IsSelectable() bool
 	WithExpanded(bool) list.Item
+
+	// GetKey returns a key identifying the item across menu rebuilds
+	GetKey() string
+
+	// GetResource returns the kind and ID of the resource the item shows
+	GetResource() (kind, id string)
 }
 
 // UIState represents the current state of the UI
@@ -112,6 +118,10 @@ type UIModel interface {
 
 	// Help functionality
 	ToggleHelp()
+
+	// Prompt functionality
+	GetPrompt() *Prompt
+	SetPrompt(*Prompt)
 }
 
 // UpdateType represents different types of UI updates
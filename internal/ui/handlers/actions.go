@@ -0,0 +1,313 @@
+// internal/ui/handlers/actions.go
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"ovh-terminal/internal/api"
+	"ovh-terminal/internal/commands"
+	"ovh-terminal/internal/logger"
+	"ovh-terminal/internal/ui/common"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ResourceHandler creates the detail command of a menu resource
+type ResourceHandler func(*api.Client, string) commands.Command
+
+// ResourceAction is an operation offered for a menu resource
+type ResourceAction struct {
+	Title string
+	New   ResourceHandler
+}
+
+// progressSetter is implemented by commands that report progress
+type progressSetter interface {
+	SetProgressReporter(commands.ProgressReporter)
+}
+
+// channelReporter forwards command progress to the UI, dropping
+// updates when the UI falls behind
+type channelReporter chan commands.CommandProgress
+
+// ReportProgress implements commands.ProgressReporter
+func (r channelReporter) ReportProgress(progress commands.CommandProgress) {
+	select {
+	case r <- progress:
+	default:
+	}
+}
+
+// handleActions offers the actions available for the selected resource
+func handleActions(model common.UIModel) (tea.Model, tea.Cmd) {
+	if model.GetActivePane() != "menu" {
+		return model, nil
+	}
+
+	item, ok := model.GetList().SelectedItem().(common.MenuItem)
+	if !ok {
+		return model, nil
+	}
+
+	kind, id := item.GetResource()
+	actions := actionRegistry[kind]
+	if len(actions) == 0 {
+		model.SetStatusMessage(fmt.Sprintf("No actions available for %s", item.Title()))
+		return model, nil
+	}
+
+	titles := make([]string, len(actions))
+	for i, action := range actions {
+		titles[i] = action.Title
+	}
+
+	logger.Log.Debug("Offering actions", "kind", kind, "id", id, "count", len(actions))
+
+	spec := commands.Prompt{
+		Key:     "action",
+		Label:   fmt.Sprintf("Action for %s", item.Title()),
+		Kind:    commands.PromptChoice,
+		Choices: titles,
+	}
+	model.SetPrompt(common.NewPrompt(spec, func(m common.UIModel, value string) (tea.Cmd, error) {
+		for _, action := range actions {
+			if action.Title == value {
+				title := fmt.Sprintf("%s (%s)", action.Title, item.Title())
+				return StartCommand(m, title, action.New(m.GetAPIClient(), id)), nil
+			}
+		}
+		return nil, fmt.Errorf("unknown action %q", value)
+	}))
+
+	return model, nil
+}
+
+// StartCommand collects the input of interactive commands through
+// prompts, then runs the command in the background. Prompts and input
+// checks may call the API, so they run in the background too and the
+// next step arrives as a CommandPromptMsg.
+func StartCommand(model common.UIModel, title string, cmd commands.Command) tea.Cmd {
+	model.SetPrompt(nil)
+
+	interactive, ok := cmd.(commands.InteractiveCommand)
+	if !ok {
+		return runCommand(model, title, cmd)
+	}
+
+	model.SetStatusMessage(fmt.Sprintf("Preparing: %s...", title))
+	return func() tea.Msg {
+		spec, err := interactive.NextPrompt()
+		return common.CommandPromptMsg{Title: title, Command: interactive, Prompt: spec, Err: err}
+	}
+}
+
+// HandleCommandPrompt shows the next prompt of an interactive command, or
+// runs it once all input is collected
+func HandleCommandPrompt(model common.UIModel, msg common.CommandPromptMsg) tea.Cmd {
+	// The prompt was canceled while its value was being checked
+	if msg.Submitted != nil && model.GetPrompt() != msg.Submitted {
+		return nil
+	}
+
+	if msg.InputErr != nil {
+		msg.Submitted.Pending = false
+		msg.Submitted.Error = msg.InputErr.Error()
+		return nil
+	}
+
+	model.SetPrompt(nil)
+	if msg.Err != nil {
+		model.SetStatusMessage(fmt.Sprintf("Error: %v", msg.Err))
+		model.SetContent(errorContent(msg.Err))
+		return nil
+	}
+	if msg.Prompt == nil {
+		return runCommand(model, msg.Title, msg.Command)
+	}
+
+	model.SetPrompt(commandPrompt(msg.Title, msg.Command, *msg.Prompt))
+	return nil
+}
+
+// commandPrompt asks for one input of an interactive command. The value
+// is checked and the next prompt computed in the background.
+func commandPrompt(title string, cmd commands.InteractiveCommand, spec commands.Prompt) *common.Prompt {
+	prompt := common.NewPrompt(spec, nil)
+	prompt.Submit = func(m common.UIModel, value string) (tea.Cmd, error) {
+		if spec.Kind == commands.PromptConfirm && !isConfirmed(value) {
+			m.SetPrompt(nil)
+			m.SetStatusMessage(fmt.Sprintf("Canceled: %s", title))
+			return nil, nil
+		}
+
+		prompt.Pending = true
+		prompt.Error = ""
+		return func() tea.Msg {
+			msg := common.CommandPromptMsg{Title: title, Command: cmd, Submitted: prompt}
+			if err := cmd.SetInput(spec.Key, value); err != nil {
+				msg.InputErr = err
+				return msg
+			}
+			msg.Prompt, msg.Err = cmd.NextPrompt()
+			return msg
+		}, nil
+	}
+	return prompt
+}
+
+// runCommand executes a command in the background
+func runCommand(model common.UIModel, title string, cmd commands.Command) tea.Cmd {
+	progress := make(channelReporter, 16)
+	if setter, ok := cmd.(progressSetter); ok {
+		setter.SetProgressReporter(progress)
+	}
+
+	results, err := cmd.ExecuteAsync(context.Background())
+	if err != nil {
+		model.SetStatusMessage(fmt.Sprintf("Error: %v", err))
+		model.SetContent(errorContent(err))
+		return nil
+	}
+
+	logger.Log.Info("Running command", "title", title)
+	model.SetStatusMessage(fmt.Sprintf("Running: %s...", title))
+	return waitForCommand(title, progress, results)
+}
+
+// waitForCommand delivers the next progress update or the result
+func waitForCommand(
+	title string,
+	progress <-chan commands.CommandProgress,
+	results <-chan commands.CommandResult,
+) tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case update := <-progress:
+			return common.CommandProgressMsg{
+				Title:    title,
+				Progress: update,
+				Next:     waitForCommand(title, progress, results),
+			}
+		case result, ok := <-results:
+			if !ok {
+				result = commands.CommandResult{
+					Error: errors.New("command ended without a result"),
+					State: commands.StateFailed,
+				}
+			}
+			return common.CommandResultMsg{Title: title, Result: result}
+		}
+	}
+}
+
+// HandleCommandProgress shows the progress of a background command
+func HandleCommandProgress(model common.UIModel, msg common.CommandProgressMsg) tea.Cmd {
+	status := fmt.Sprintf("%s: %s", msg.Title, msg.Progress.Message)
+	if msg.Progress.TotalSteps > 0 {
+		status = fmt.Sprintf("%s [%d/%d]: %s", msg.Title,
+			msg.Progress.Step, msg.Progress.TotalSteps, msg.Progress.Message)
+	}
+	model.SetStatusMessage(status)
+	return msg.Next
+}
+
+// HandleCommandResult shows the result of a background command and
+// refreshes the menu to reflect any change it made
+func HandleCommandResult(model common.UIModel, msg common.CommandResultMsg) {
+	if err := msg.Result.Error; err != nil {
+		logger.Log.Error("Command failed", "title", msg.Title, "error", err)
+		model.SetStatusMessage(fmt.Sprintf("Error: %v", err))
+		model.SetContent(errorContent(err))
+		return
+	}
+
+	logger.Log.Info("Command completed",
+		"title", msg.Title,
+		"duration", msg.Result.Duration.String())
+	model.SetStatusMessage(fmt.Sprintf("Completed: %s", msg.Title))
+	model.SetContent(msg.Result.Output)
+	model.UpdateMenuItems()
+	ensureLayoutManager(model).Update()
+}
+
+// HandlePromptKey routes key presses to the active prompt
+func HandlePromptKey(model common.UIModel, msg tea.KeyMsg) tea.Cmd {
+	prompt := model.GetPrompt()
+	if prompt == nil {
+		return nil
+	}
+
+	key := msg.String()
+	if key == "esc" || key == "ctrl+c" {
+		model.SetPrompt(nil)
+		model.SetStatusMessage("Canceled")
+		return nil
+	}
+	if prompt.Pending {
+		return nil
+	}
+
+	switch prompt.Spec.Kind {
+	case commands.PromptConfirm:
+		switch key {
+		case "y", "Y":
+			return submitPrompt(model, prompt, "yes")
+		case "n", "N", "enter":
+			return submitPrompt(model, prompt, "no")
+		}
+		return nil
+
+	case commands.PromptChoice:
+		switch key {
+		case "up", "k":
+			if prompt.Cursor > 0 {
+				prompt.Cursor--
+			}
+		case "down", "j":
+			if prompt.Cursor < len(prompt.Spec.Choices)-1 {
+				prompt.Cursor++
+			}
+		case "home", "g":
+			prompt.Cursor = 0
+		case "end", "G":
+			prompt.Cursor = len(prompt.Spec.Choices) - 1
+		case "enter":
+			return submitPrompt(model, prompt, prompt.Value())
+		}
+		return nil
+
+	default:
+		if key == "enter" {
+			if prompt.Spec.Kind == commands.PromptEditor && prompt.Input.Value() == "" {
+				return openEditor(model, prompt)
+			}
+			return submitPrompt(model, prompt, prompt.Value())
+		}
+		var cmd tea.Cmd
+		prompt.Input, cmd = prompt.Input.Update(msg)
+		prompt.Error = ""
+		return cmd
+	}
+}
+
+// submitPrompt passes a value to the prompt, keeping it open on errors
+func submitPrompt(model common.UIModel, prompt *common.Prompt, value string) tea.Cmd {
+	cmd, err := prompt.Submit(model, value)
+	if err != nil {
+		prompt.Error = err.Error()
+		return nil
+	}
+	return cmd
+}
+
+// isConfirmed reports whether a confirmation answer is positive
+func isConfirmed(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
@@ -2,6 +2,7 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 
 	"ovh-terminal/internal/api"
@@ -9,6 +10,8 @@ import (
 	"ovh-terminal/internal/logger"
 	"ovh-terminal/internal/ui/common"
 	"ovh-terminal/internal/ui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
 )
 
 // CommandHandler is a function type that creates commands
@@ -24,8 +27,10 @@ var commandRegistry = map[string]CommandHandler{
 	},
 }
 
-// HandleCommand processes a selected menu item and executes any associated command
-func HandleCommand(model common.UIModel, item common.MenuItem) error {
+// HandleCommand processes a selected menu item and executes any associated
+// command. Commands needing input or running in the background return a
+// tea.Cmd delivering their result.
+func HandleCommand(model common.UIModel, item common.MenuItem) (tea.Cmd, error) {
 	logger.Log.Debug("Handling command",
 		"title", item.Title(),
 		"type", item.GetType(),
@@ -34,17 +39,17 @@ func HandleCommand(model common.UIModel, item common.MenuItem) error {
 	switch item.GetType() {
 	case common.TypeHeader:
 		if item.GetIndent() == 0 {
-			return handleTopLevelHeader(model, item)
+			return nil, handleTopLevelHeader(model, item)
 		}
-		return handleNestedHeader(model, item)
+		return nil, handleNestedHeader(model, item)
 	case common.TypeTreeItem, common.TypeTreeLastItem:
 		return handleTreeCommand(model, item)
 	case common.TypeNormal:
 		if item.Title() == "Exit" {
-			return nil
+			return nil, nil
 		}
 	}
-	return nil
+	return nil, nil
 }
 
 // handleTopLevelHeader handles main menu headers (indent level 0)
@@ -56,7 +61,7 @@ func handleTopLevelHeader(model common.UIModel, item common.MenuItem) error {
 	// Get current list
 	list := model.GetList()
 	currentIndex := list.Index()
-	headerTitle := item.Title()
+	headerKey := item.GetKey()
 
 	// Toggle current item
 	model.ToggleItemExpanded(currentIndex)
@@ -87,9 +92,9 @@ func handleTopLevelHeader(model common.UIModel, item common.MenuItem) error {
 	items = list.Items()
 	for i, menuItem := range items {
 		if mi, ok := menuItem.(common.MenuItem); ok {
-			if mi.Title() == headerTitle {
+			if mi.GetIndent() == 0 && mi.GetKey() == headerKey {
 				logger.Log.Debug("Found header in new structure",
-					"title", headerTitle,
+					"title", mi.Title(),
 					"newIndex", i)
 				list.Select(i)
 				break
@@ -112,7 +117,7 @@ func handleNestedHeader(model common.UIModel, item common.MenuItem) error {
 	// Get current list
 	list := model.GetList()
 	currentIndex := list.Index()
-	headerTitle := item.Title()
+	headerKey := item.GetKey()
 
 	// Toggle only this nested header
 	model.ToggleItemExpanded(currentIndex)
@@ -124,9 +129,9 @@ func handleNestedHeader(model common.UIModel, item common.MenuItem) error {
 	items := list.Items()
 	for i, menuItem := range items {
 		if mi, ok := menuItem.(common.MenuItem); ok {
-			if mi.Title() == headerTitle {
+			if mi.GetIndent() == item.GetIndent() && mi.GetKey() == headerKey {
 				logger.Log.Debug("Found nested header in new structure",
-					"title", headerTitle,
+					"title", mi.Title(),
 					"newIndex", i)
 				list.Select(i)
 				break
@@ -141,20 +146,33 @@ func handleNestedHeader(model common.UIModel, item common.MenuItem) error {
 }
 
 // handleTreeCommand handles actions for regular tree items
-func handleTreeCommand(model common.UIModel, item common.MenuItem) error {
-	handler, exists := commandRegistry[item.Title()]
-	if !exists {
+func handleTreeCommand(model common.UIModel, item common.MenuItem) (tea.Cmd, error) {
+	var cmd commands.Command
+	if handler, exists := commandRegistry[item.Title()]; exists {
+		cmd = handler(model.GetAPIClient())
+	} else if kind, id := item.GetResource(); kind != "" {
+		if handler, exists := resourceRegistry[kind]; exists {
+			cmd = handler(model.GetAPIClient(), id)
+		}
+	}
+
+	if cmd == nil {
 		model.SetStatusMessage(fmt.Sprintf("Selected: %s", item.Title()))
-		return nil
+		return nil, nil
 	}
 
-	// Create and execute command
-	cmd := handler(model.GetAPIClient())
+	// Actions may need input and can take a while, run them in background
+	if _, interactive := cmd.(commands.InteractiveCommand); interactive ||
+		cmd.GetType() != commands.TypeInfo {
+		return StartCommand(model, item.Title(), cmd), nil
+	}
+
+	// Execute informational commands directly
 	output, err := cmd.Execute()
 	if err != nil {
 		model.SetStatusMessage(fmt.Sprintf("Error: %v", err))
-		model.SetContent(fmt.Sprintf("Failed to execute command: %v", err))
-		return err
+		model.SetContent(errorContent(err))
+		return nil, err
 	}
 
 	// Update UI with command output
@@ -167,5 +185,16 @@ func handleTreeCommand(model common.UIModel, item common.MenuItem) error {
 	// Update border colors to reflect the active pane
 	styles.UpdateBorderStyles(model.GetActivePane())
 
-	return nil
+	return nil, nil
+}
+
+// errorContent formats a command error for the content pane, including
+// the user-friendly explanation of API errors
+func errorContent(err error) string {
+	content := fmt.Sprintf("Failed to execute command: %v", err)
+	var apiErr *api.APIError
+	if errors.As(err, &apiErr) {
+		content += "\n\n" + apiErr.UserError()
+	}
+	return content
 }
@@ -0,0 +1,81 @@
+// internal/ui/handlers/editor.go
+package handlers
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"ovh-terminal/internal/logger"
+	"ovh-terminal/internal/ui/common"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// defaultEditor is used when neither $VISUAL nor $EDITOR is set
+const defaultEditor = "vi"
+
+// editorCommand returns the editor and its arguments, from $VISUAL or
+// $EDITOR
+func editorCommand() []string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(name)); len(fields) > 0 {
+			return fields
+		}
+	}
+	return []string{defaultEditor}
+}
+
+// openEditor suspends the UI to compose the value of an editor prompt in
+// a private temporary file, starting from the prompt default
+func openEditor(model common.UIModel, prompt *common.Prompt) tea.Cmd {
+	file, err := os.CreateTemp("", "ovh-terminal-*.txt")
+	if err != nil {
+		prompt.Error = fmt.Sprintf("cannot create a file to edit: %v", err)
+		return nil
+	}
+	path := file.Name()
+	_, err = file.WriteString(prompt.Spec.Default)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		prompt.Error = fmt.Sprintf("cannot create a file to edit: %v", err)
+		return nil
+	}
+
+	args := editorCommand()
+	logger.Log.Debug("Opening editor", "editor", args[0])
+	model.SetStatusMessage(fmt.Sprintf("Editing %s in %s...", prompt.Spec.Label, args[0]))
+
+	cmd := exec.Command(args[0], append(args[1:], path)...)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return common.EditorFinishedMsg{Path: path, Err: err}
+	})
+}
+
+// HandleEditorFinished submits the text composed in the editor to the
+// open prompt. The prompt stays open when the editor failed or the text
+// is rejected.
+func HandleEditorFinished(model common.UIModel, msg common.EditorFinishedMsg) tea.Cmd {
+	defer os.Remove(msg.Path)
+
+	prompt := model.GetPrompt()
+	if prompt == nil {
+		return nil
+	}
+	if msg.Err != nil {
+		logger.Log.Error("Editor failed", "error", msg.Err)
+		prompt.Error = fmt.Sprintf("editor failed: %v", msg.Err)
+		return nil
+	}
+
+	data, err := os.ReadFile(msg.Path)
+	if err != nil {
+		prompt.Error = fmt.Sprintf("cannot read the edited text: %v", err)
+		return nil
+	}
+	return submitPrompt(model, prompt, string(data))
+}
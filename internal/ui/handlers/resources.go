@@ -0,0 +1,494 @@
+// internal/ui/handlers/resources.go
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"ovh-terminal/internal/api"
+	"ovh-terminal/internal/commands"
+)
+
+// Resource kinds linked to menu items
+const (
+	ResourceIPOverview = "ip-overview"
+	ResourceIP         = "ip"
+
+	ResourceInvoices = "invoices"
+	ResourceBill     = "bill"
+	ResourceSpending = "spending"
+
+	ResourceRenewals = "renewals"
+	ResourceRenewal  = "renewal"
+
+	ResourceDomains        = "domains"
+	ResourceDomain         = "domain"
+	ResourceWebRedirection = "web-redirection"
+
+	ResourceHostings         = "hostings"
+	ResourceHosting          = "hosting"
+	ResourceHostingDomain    = "hosting-domain"
+	ResourceHostingUser      = "hosting-user"
+	ResourceHostingDatabases = "hosting-databases"
+	ResourceHostingDatabase  = "hosting-database"
+	ResourceHostingCron      = "hosting-cron"
+	ResourceHostingSSL       = "hosting-ssl"
+
+	ResourceEmailDomains     = "email-domains"
+	ResourceEmailDomain      = "email-domain"
+	ResourceEmailAccount     = "email-account"
+	ResourceEmailRedirection = "email-redirection"
+	ResourceEmailMailingList = "email-mailinglist"
+	ResourceEmailResponder   = "email-responder"
+
+	ResourceSupportTickets = "support-tickets"
+	ResourceSupportTicket  = "support-ticket"
+
+	ResourceCloudProjects  = "cloud-projects"
+	ResourceCloudProject   = "cloud-project"
+	ResourceCloudUsage     = "cloud-usage"
+	ResourceCloudQuota     = "cloud-quota"
+	ResourceCloudInstance  = "cloud-instance"
+	ResourceCloudVolume    = "cloud-volume"
+	ResourceCloudSnapshot  = "cloud-snapshot"
+	ResourceCloudNetwork   = "cloud-network"
+	ResourceCloudSSHKey    = "cloud-sshkey"
+	ResourceCloudKube      = "cloud-kube"
+	ResourceCloudContainer = "cloud-container"
+	ResourceCloudUser      = "cloud-user"
+)
+
+// ProjectResourceID identifies a resource inside a cloud project
+func ProjectResourceID(projectID, id string) string {
+	return projectID + "/" + id
+}
+
+// splitProjectResourceID reverses ProjectResourceID
+func splitProjectResourceID(id string) (projectID, resourceID string) {
+	projectID, resourceID, _ = strings.Cut(id, "/")
+	return projectID, resourceID
+}
+
+// cloudResource returns the detail handler of a project resource kind
+func cloudResource(kind string) ResourceHandler {
+	return func(client *api.Client, id string) commands.Command {
+		projectID, resourceID := splitProjectResourceID(id)
+		return commands.NewCloudResourceCommand(client, kind, projectID, resourceID)
+	}
+}
+
+// instanceAction returns the action handler of an instance lifecycle action
+func instanceAction(kind string) ResourceHandler {
+	return func(client *api.Client, id string) commands.Command {
+		projectID, instanceID := splitProjectResourceID(id)
+		return commands.NewCloudInstanceActionCommand(client, projectID, instanceID, kind)
+	}
+}
+
+// WebRedirectionID identifies a web redirection of a zone
+func WebRedirectionID(zone string, id int64) string {
+	return zone + "/" + strconv.FormatInt(id, 10)
+}
+
+// splitWebRedirectionID reverses WebRedirectionID. Invalid IDs map to 0,
+// which the API reports as unknown.
+func splitWebRedirectionID(id string) (zone string, redirectionID int64) {
+	zone, value, _ := strings.Cut(id, "/")
+	redirectionID, _ = strconv.ParseInt(value, 10, 64)
+	return zone, redirectionID
+}
+
+// HostingResourceID identifies a resource inside a hosting plan
+func HostingResourceID(service, id string) string {
+	return service + "/" + id
+}
+
+// hostingResource returns the detail handler of a hosting resource kind
+func hostingResource(kind string) ResourceHandler {
+	return func(client *api.Client, id string) commands.Command {
+		service, resourceID, _ := strings.Cut(id, "/")
+		return commands.NewHostingResourceCommand(client, kind, service, resourceID)
+	}
+}
+
+// EmailResourceID identifies a resource inside an email domain
+func EmailResourceID(domain, id string) string {
+	return domain + "/" + id
+}
+
+// emailResource returns the detail handler of an email resource kind
+func emailResource(kind string) ResourceHandler {
+	return func(client *api.Client, id string) commands.Command {
+		domain, resourceID, _ := strings.Cut(id, "/")
+		return commands.NewEmailResourceCommand(client, kind, domain, resourceID)
+	}
+}
+
+// ticketAction returns the action handler of a support ticket action
+func ticketAction(kind string) ResourceHandler {
+	return func(client *api.Client, id string) commands.Command {
+		return commands.NewSupportTicketActionCommand(client, parseTicketID(id), kind)
+	}
+}
+
+// parseTicketID reads the ID of a support ticket menu item. Invalid IDs
+// map to 0, which the API reports as unknown.
+func parseTicketID(id string) int64 {
+	ticketID, _ := strconv.ParseInt(id, 10, 64)
+	return ticketID
+}
+
+// resourceRegistry maps resource kinds to their detail commands
+var resourceRegistry = map[string]ResourceHandler{
+	ResourceIPOverview: func(client *api.Client, _ string) commands.Command {
+		return commands.NewIPOverviewCommand(client)
+	},
+	ResourceIP: func(client *api.Client, block string) commands.Command {
+		return commands.NewIPCommand(client, block)
+	},
+	ResourceInvoices: func(client *api.Client, _ string) commands.Command {
+		return commands.NewInvoicesCommand(client)
+	},
+	ResourceBill: func(client *api.Client, billID string) commands.Command {
+		return commands.NewBillCommand(client, billID)
+	},
+	ResourceSpending: func(client *api.Client, period string) commands.Command {
+		return commands.NewSpendingCommand(client, period)
+	},
+	ResourceRenewals: func(client *api.Client, order string) commands.Command {
+		return commands.NewRenewalsCommand(client, order)
+	},
+	ResourceRenewal: func(client *api.Client, id string) commands.Command {
+		kind, name, _ := strings.Cut(id, "/")
+		return commands.NewServiceRenewalCommand(client, kind, name)
+	},
+	ResourceDomains: func(client *api.Client, _ string) commands.Command {
+		return commands.NewDomainsCommand(client)
+	},
+	ResourceDomain: func(client *api.Client, domain string) commands.Command {
+		return commands.NewDomainCommand(client, domain)
+	},
+	ResourceWebRedirection: func(client *api.Client, id string) commands.Command {
+		zone, redirectionID := splitWebRedirectionID(id)
+		return commands.NewWebRedirectionCommand(client, zone, redirectionID)
+	},
+	ResourceHostings: func(client *api.Client, _ string) commands.Command {
+		return commands.NewHostingsCommand(client)
+	},
+	ResourceHosting: func(client *api.Client, service string) commands.Command {
+		return commands.NewHostingCommand(client, service)
+	},
+	ResourceHostingDatabases: func(client *api.Client, service string) commands.Command {
+		return commands.NewHostingDatabasesCommand(client, service)
+	},
+	ResourceHostingDomain:   hostingResource(commands.HostingResourceDomain),
+	ResourceHostingUser:     hostingResource(commands.HostingResourceUser),
+	ResourceHostingDatabase: hostingResource(commands.HostingResourceDatabase),
+	ResourceHostingCron:     hostingResource(commands.HostingResourceCron),
+	ResourceHostingSSL:      hostingResource(commands.HostingResourceSSL),
+	ResourceEmailDomains: func(client *api.Client, _ string) commands.Command {
+		return commands.NewEmailDomainsCommand(client)
+	},
+	ResourceEmailDomain: func(client *api.Client, domain string) commands.Command {
+		return commands.NewEmailDomainCommand(client, domain)
+	},
+	ResourceEmailAccount:     emailResource(commands.EmailResourceAccount),
+	ResourceEmailRedirection: emailResource(commands.EmailResourceRedirection),
+	ResourceEmailMailingList: emailResource(commands.EmailResourceMailingList),
+	ResourceEmailResponder:   emailResource(commands.EmailResourceResponder),
+	ResourceSupportTickets: func(client *api.Client, _ string) commands.Command {
+		return commands.NewSupportTicketsCommand(client)
+	},
+	ResourceSupportTicket: func(client *api.Client, id string) commands.Command {
+		return commands.NewSupportTicketCommand(client, parseTicketID(id))
+	},
+	ResourceCloudProjects: func(client *api.Client, _ string) commands.Command {
+		return commands.NewCloudProjectsCommand(client)
+	},
+	ResourceCloudProject: func(client *api.Client, projectID string) commands.Command {
+		return commands.NewCloudProjectCommand(client, projectID)
+	},
+	ResourceCloudUsage: func(client *api.Client, projectID string) commands.Command {
+		return commands.NewCloudUsageCommand(client, projectID)
+	},
+	ResourceCloudQuota: func(client *api.Client, projectID string) commands.Command {
+		return commands.NewCloudQuotaCommand(client, projectID)
+	},
+	ResourceCloudInstance:  cloudResource(commands.CloudResourceInstance),
+	ResourceCloudVolume:    cloudResource(commands.CloudResourceVolume),
+	ResourceCloudSnapshot:  cloudResource(commands.CloudResourceSnapshot),
+	ResourceCloudNetwork:   cloudResource(commands.CloudResourceNetwork),
+	ResourceCloudSSHKey:    cloudResource(commands.CloudResourceSSHKey),
+	ResourceCloudKube:      cloudResource(commands.CloudResourceKube),
+	ResourceCloudContainer: cloudResource(commands.CloudResourceContainer),
+	ResourceCloudUser:      cloudResource(commands.CloudResourceUser),
+}
+
+// actionRegistry maps resource kinds to the actions offered for them
+var actionRegistry = map[string][]ResourceAction{
+	ResourceIP: {
+		{
+			Title: "Set reverse DNS",
+			New: func(client *api.Client, block string) commands.Command {
+				return commands.NewSetReverseCommand(client, block)
+			},
+		},
+		{
+			Title: "Delete reverse DNS",
+			New: func(client *api.Client, block string) commands.Command {
+				return commands.NewDeleteReverseCommand(client, block)
+			},
+		},
+		{
+			Title: "Move failover IP",
+			New: func(client *api.Client, block string) commands.Command {
+				return commands.NewMoveIPCommand(client, block)
+			},
+		},
+		{
+			Title: "Show firewall",
+			New: func(client *api.Client, block string) commands.Command {
+				return commands.NewFirewallCommand(client, block)
+			},
+		},
+		{
+			Title: "Enable firewall",
+			New: func(client *api.Client, block string) commands.Command {
+				return commands.NewSetFirewallCommand(client, block, true)
+			},
+		},
+		{
+			Title: "Disable firewall",
+			New: func(client *api.Client, block string) commands.Command {
+				return commands.NewSetFirewallCommand(client, block, false)
+			},
+		},
+		{
+			Title: "Add firewall rule",
+			New: func(client *api.Client, block string) commands.Command {
+				return commands.NewAddFirewallRuleCommand(client, block)
+			},
+		},
+		{
+			Title: "Remove firewall rule",
+			New: func(client *api.Client, block string) commands.Command {
+				return commands.NewRemoveFirewallRuleCommand(client, block)
+			},
+		},
+		{
+			Title: "Plan firewall rules file",
+			New: func(client *api.Client, block string) commands.Command {
+				return commands.NewPlanFirewallCommand(client, block)
+			},
+		},
+		{
+			Title: "Apply firewall rules file",
+			New: func(client *api.Client, block string) commands.Command {
+				return commands.NewApplyFirewallCommand(client, block)
+			},
+		},
+		{
+			Title: "Show DDoS mitigation",
+			New: func(client *api.Client, block string) commands.Command {
+				return commands.NewMitigationCommand(client, block)
+			},
+		},
+		{
+			Title: "Show attack history",
+			New: func(client *api.Client, block string) commands.Command {
+				return commands.NewAttackHistoryCommand(client, block)
+			},
+		},
+		{
+			Title: "Unblock address",
+			New: func(client *api.Client, block string) commands.Command {
+				return commands.NewUnblockIPCommand(client, block)
+			},
+		},
+	},
+	ResourceBill: {
+		{
+			Title: "Download invoice (PDF)",
+			New: func(client *api.Client, billID string) commands.Command {
+				return commands.NewDownloadBillCommand(client, billID, commands.BillPDF)
+			},
+		},
+		{
+			Title: "Download invoice (HTML)",
+			New: func(client *api.Client, billID string) commands.Command {
+				return commands.NewDownloadBillCommand(client, billID, commands.BillHTML)
+			},
+		},
+	},
+	ResourceRenewal: {
+		{
+			Title: "Toggle automatic renewal",
+			New: func(client *api.Client, id string) commands.Command {
+				kind, name, _ := strings.Cut(id, "/")
+				return commands.NewAutoRenewCommand(client, kind, name)
+			},
+		},
+	},
+	ResourceDomain: {
+		{
+			Title: "Change nameservers",
+			New: func(client *api.Client, domain string) commands.Command {
+				return commands.NewNameServersCommand(client, domain)
+			},
+		},
+		{
+			Title: "Enable or disable DNSSEC",
+			New: func(client *api.Client, domain string) commands.Command {
+				return commands.NewDNSSECCommand(client, domain)
+			},
+		},
+		{
+			Title: "Toggle transfer lock",
+			New: func(client *api.Client, domain string) commands.Command {
+				return commands.NewTransferLockCommand(client, domain)
+			},
+		},
+		{
+			Title: "Add web redirection",
+			New: func(client *api.Client, zone string) commands.Command {
+				return commands.NewAddWebRedirectionCommand(client, zone)
+			},
+		},
+		{
+			Title: "Export zone file",
+			New: func(client *api.Client, zone string) commands.Command {
+				return commands.NewExportZoneCommand(client, zone)
+			},
+		},
+		{
+			Title: "Compare with zone file",
+			New: func(client *api.Client, zone string) commands.Command {
+				return commands.NewDiffZoneCommand(client, zone)
+			},
+		},
+		{
+			Title: "Import zone file",
+			New: func(client *api.Client, zone string) commands.Command {
+				return commands.NewImportZoneCommand(client, zone)
+			},
+		},
+	},
+	ResourceWebRedirection: {
+		{
+			Title: "Delete web redirection",
+			New: func(client *api.Client, id string) commands.Command {
+				zone, redirectionID := splitWebRedirectionID(id)
+				return commands.NewDeleteWebRedirectionCommand(client, zone, redirectionID)
+			},
+		},
+	},
+	ResourceHostingDatabase: {
+		{
+			Title: "Create dump",
+			New: func(client *api.Client, id string) commands.Command {
+				service, database, _ := strings.Cut(id, "/")
+				return commands.NewCreateDumpCommand(client, service, database)
+			},
+		},
+		{
+			Title: "Download dump",
+			New: func(client *api.Client, id string) commands.Command {
+				service, database, _ := strings.Cut(id, "/")
+				return commands.NewDownloadDumpCommand(client, service, database)
+			},
+		},
+		{
+			Title: "Restore from dump",
+			New: func(client *api.Client, id string) commands.Command {
+				service, database, _ := strings.Cut(id, "/")
+				return commands.NewRestoreDumpCommand(client, service, database)
+			},
+		},
+	},
+	ResourceEmailDomain: {
+		{
+			Title: "Create mailbox",
+			New: func(client *api.Client, domain string) commands.Command {
+				return commands.NewCreateMailboxCommand(client, domain)
+			},
+		},
+		{
+			Title: "Add redirection",
+			New: func(client *api.Client, domain string) commands.Command {
+				return commands.NewAddRedirectionCommand(client, domain)
+			},
+		},
+	},
+	ResourceEmailAccount: {
+		{
+			Title: "Change password",
+			New: func(client *api.Client, id string) commands.Command {
+				domain, account, _ := strings.Cut(id, "/")
+				return commands.NewMailboxPasswordCommand(client, domain, account)
+			},
+		},
+		{
+			Title: "Delete mailbox",
+			New: func(client *api.Client, id string) commands.Command {
+				domain, account, _ := strings.Cut(id, "/")
+				return commands.NewDeleteMailboxCommand(client, domain, account)
+			},
+		},
+	},
+	ResourceSupportTicket: {
+		{Title: "Reply", New: ticketAction(commands.TicketReply)},
+		{Title: "Close ticket", New: ticketAction(commands.TicketClose)},
+		{Title: "Reopen ticket", New: ticketAction(commands.TicketReopen)},
+	},
+	ResourceCloudUsage: {
+		{
+			Title: "Export usage to CSV",
+			New: func(client *api.Client, projectID string) commands.Command {
+				return commands.NewExportCloudUsageCommand(client, projectID)
+			},
+		},
+	},
+	ResourceCloudInstance: {
+		{Title: "Start instance", New: instanceAction(commands.InstanceStart)},
+		{Title: "Stop instance", New: instanceAction(commands.InstanceStop)},
+		{Title: "Soft reboot", New: instanceAction(commands.InstanceSoftReboot)},
+		{Title: "Hard reboot", New: instanceAction(commands.InstanceHardReboot)},
+		{Title: "Shelve instance", New: instanceAction(commands.InstanceShelve)},
+		{Title: "Unshelve instance", New: instanceAction(commands.InstanceUnshelve)},
+		{Title: "Boot in rescue mode", New: instanceAction(commands.InstanceRescue)},
+		{Title: "Leave rescue mode", New: instanceAction(commands.InstanceUnrescue)},
+		{Title: "Create snapshot", New: instanceAction(commands.InstanceSnapshot)},
+	},
+	ResourceCloudKube: {
+		{
+			Title: "Download kubeconfig",
+			New: func(client *api.Client, id string) commands.Command {
+				projectID, kubeID := splitProjectResourceID(id)
+				return commands.NewKubeconfigCommand(client, projectID, kubeID)
+			},
+		},
+	},
+	ResourceCloudUser: {
+		{
+			Title: "Generate S3 credentials",
+			New: func(client *api.Client, id string) commands.Command {
+				projectID, userID := splitProjectResourceID(id)
+				return commands.NewCreateS3CredentialsCommand(client, projectID, userID)
+			},
+		},
+		{
+			Title: "Show S3 configuration",
+			New: func(client *api.Client, id string) commands.Command {
+				projectID, userID := splitProjectResourceID(id)
+				return commands.NewS3ConfigCommand(client, projectID, userID)
+			},
+		},
+		{
+			Title: "Revoke S3 credentials",
+			New: func(client *api.Client, id string) commands.Command {
+				projectID, userID := splitProjectResourceID(id)
+				return commands.NewDeleteS3CredentialsCommand(client, projectID, userID)
+			},
+		},
+	},
+}
@@ -20,6 +20,7 @@ var KeyMap = map[string]KeyHandler{
 	"f1":     handleHelp,
 	"tab":    handlePaneToggle,
 	"enter":  handleEnter,
+	"a":      handleActions,
 	// "up":     handleUpNav,
 	// "k":      handleUpNav,
 	// "down":   handleDownNav,
@@ -83,7 +84,8 @@ func handleEnter(model common.UIModel) (tea.Model, tea.Cmd) {
 			"isMenuItem", ok,
 			"type", menuItem.GetType())
 
-		if err := HandleCommand(model, menuItem); err != nil {
+		cmd, err := HandleCommand(model, menuItem)
+		if err != nil {
 			logger.Log.Error("Error handling command",
 				"error", err,
 				"item", menuItem.Title())
@@ -92,7 +94,7 @@ func handleEnter(model common.UIModel) (tea.Model, tea.Cmd) {
 
 		// Update layout after command execution
 		ensureLayoutManager(model).Update()
-		return model, nil
+		return model, cmd
 	}
 
 	return model, nil
@@ -59,6 +59,12 @@ func GetHelpContent(width, height int) string {
 		section("Menu Actions"),
 		shortcut("Enter", "Select menu item / Toggle section"),
 		shortcut("←/→", "Collapse/Expand section"),
+		shortcut("a", "Actions for the selected resource"),
+		"",
+		section("Prompts"),
+		shortcut("Enter", "Confirm input or selection"),
+		shortcut("y/n", "Answer a confirmation"),
+		shortcut("Esc", "Cancel"),
 		"",
 		section("General"),
 		shortcut("F1", "Toggle this help screen"),
@@ -16,12 +16,15 @@ import (
 
 // ListItem represents a single item in the menu
 type ListItem struct {
-	text       string
-	desc       string
-	itemType   common.ItemType
-	expanded   bool
-	indent     int
-	selectable bool
+	text         string
+	desc         string
+	itemType     common.ItemType
+	expanded     bool
+	indent       int
+	selectable   bool
+	key          string
+	resourceKind string
+	resourceID   string
 }
 
 // MenuItemOption is a function type for applying options to a ListItem
@@ -43,6 +46,18 @@ func (i *ListItem) GetType() common.ItemType { return i.itemType }
 func (i *ListItem) IsExpanded() bool         { return i.expanded }
 func (i *ListItem) GetIndent() int           { return i.indent }
 func (i *ListItem) IsSelectable() bool       { return i.selectable }
+func (i *ListItem) GetResource() (string, string) {
+	return i.resourceKind, i.resourceID
+}
+
+// GetKey returns the item key, defaulting to its title
+func (i *ListItem) GetKey() string {
+	if i.key != "" {
+		return i.key
+	}
+	return i.text
+}
+
 func (i *ListItem) WithExpanded(expanded bool) list.Item {
 	newItem := *i
 	newItem.expanded = expanded
@@ -77,6 +92,21 @@ func WithSelectable(selectable bool) MenuItemOption {
 	}
 }
 
+// WithKey sets a key identifying the item when titles are not unique
+func WithKey(key string) MenuItemOption {
+	return func(i *ListItem) {
+		i.key = key
+	}
+}
+
+// WithResource links the item to an API resource for details and actions
+func WithResource(kind, id string) MenuItemOption {
+	return func(i *ListItem) {
+		i.resourceKind = kind
+		i.resourceID = id
+	}
+}
+
 // NewListItem creates a new ListItem with options
 func NewListItem(text string, itemType common.ItemType, opts ...MenuItemOption) *ListItem {
 	item := &ListItem{
@@ -260,7 +290,9 @@ func CreateBaseMenuItems() []list.Item {
 	items := []list.Item{
 		NewListItem("Account Information", common.TypeHeader),
 		NewListItem("Bare Metal Cloud", common.TypeHeader),
+		NewListItem("Public Cloud", common.TypeHeader),
 		NewListItem("Web Cloud", common.TypeHeader),
+		NewListItem("Support", common.TypeHeader),
 		NewListItem("Exit", common.TypeNormal,
 			WithDesc("Exit the application")),
 	}
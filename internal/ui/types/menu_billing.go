@@ -0,0 +1,67 @@
+// internal/ui/types/menu_billing.go
+package types
+
+import (
+	"ovh-terminal/internal/commands"
+	"ovh-terminal/internal/ui/common"
+	"ovh-terminal/internal/ui/handlers"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// invoiceMenuItems builds the Invoices section, with the spending
+// summaries followed by the bills of the last twelve months, newest first
+func (m *Model) invoiceMenuItems(currentItems []list.Item) []list.Item {
+	const key = "account/invoices"
+
+	expanded := isExpanded(currentItems, key)
+	items := []list.Item{
+		NewListItem("Invoices", common.TypeHeader,
+			WithDesc("Bills, payments and spending"),
+			WithIndent(1),
+			WithKey(key),
+			WithExpanded(expanded)),
+	}
+	if !expanded {
+		return items
+	}
+
+	invoices, err := commands.NewInvoicesCommand(m.apiClient).Invoices()
+	if err != nil {
+		return append(items,
+			NewListItem("Error loading invoices", common.TypeTreeLastItem,
+				WithDesc(err.Error()),
+				WithIndent(2)))
+	}
+
+	yearlyType := common.TypeTreeItem
+	if len(invoices) == 0 {
+		yearlyType = common.TypeTreeLastItem
+	}
+	items = append(items,
+		NewListItem("Overview", common.TypeTreeItem,
+			WithDesc("Invoices of the last twelve months"),
+			WithIndent(2),
+			WithKey(key+"/overview"),
+			WithResource(handlers.ResourceInvoices, "")),
+		NewListItem("Monthly spending", common.TypeTreeItem,
+			WithDesc("Spending per month and service"),
+			WithIndent(2),
+			WithKey(key+"/monthly"),
+			WithResource(handlers.ResourceSpending, commands.SpendingMonthly)),
+		NewListItem("Yearly spending", yearlyType,
+			WithDesc("Spending per year and service"),
+			WithIndent(2),
+			WithKey(key+"/yearly"),
+			WithResource(handlers.ResourceSpending, commands.SpendingYearly)))
+
+	for i, invoice := range invoices {
+		items = append(items,
+			NewListItem(invoice.Bill.BillID, treeItemType(i, len(invoices)),
+				WithDesc(invoice.Summary()),
+				WithIndent(2),
+				WithKey(key+"/"+invoice.Bill.BillID),
+				WithResource(handlers.ResourceBill, invoice.Bill.BillID)))
+	}
+	return items
+}
@@ -0,0 +1,360 @@
+// internal/ui/types/menu_cloud.go
+package types
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"ovh-terminal/internal/api"
+	"ovh-terminal/internal/commands"
+	"ovh-terminal/internal/logger"
+	"ovh-terminal/internal/ui/common"
+	"ovh-terminal/internal/ui/handlers"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// cloudEntry is a project resource shown as a menu item
+type cloudEntry struct {
+	id    string
+	title string
+	desc  string
+}
+
+// cloudSection is a list of project resources of one kind
+type cloudSection struct {
+	name  string
+	title string
+	kind  string
+	load  func(client *api.Client, projectID string) ([]cloudEntry, error)
+}
+
+// cloudSections are the resource lists shown below each project
+var cloudSections = []cloudSection{
+	{
+		name:  "instances",
+		title: "Instances",
+		kind:  handlers.ResourceCloudInstance,
+		load:  loadCloudInstances,
+	},
+	{
+		name:  "volumes",
+		title: "Volumes",
+		kind:  handlers.ResourceCloudVolume,
+		load:  loadCloudVolumes,
+	},
+	{
+		name:  "snapshots",
+		title: "Snapshots",
+		kind:  handlers.ResourceCloudSnapshot,
+		load:  loadCloudSnapshots,
+	},
+	{
+		name:  "networks",
+		title: "Networks",
+		kind:  handlers.ResourceCloudNetwork,
+		load:  loadCloudNetworks,
+	},
+	{
+		name:  "sshkeys",
+		title: "SSH keys",
+		kind:  handlers.ResourceCloudSSHKey,
+		load:  loadCloudSSHKeys,
+	},
+	{
+		name:  "kube",
+		title: "Kubernetes",
+		kind:  handlers.ResourceCloudKube,
+		load:  loadKubeClusters,
+	},
+	{
+		name:  "storage",
+		title: "Object storage",
+		kind:  handlers.ResourceCloudContainer,
+		load:  loadStorageContainers,
+	},
+	{
+		name:  "users",
+		title: "Users",
+		kind:  handlers.ResourceCloudUser,
+		load:  loadCloudUsers,
+	},
+}
+
+// cloudMenuItems builds the Public Cloud section, with projects sorted by
+// description and their resources grouped by kind
+func (m *Model) cloudMenuItems(currentItems []list.Item) []list.Item {
+	const key = "public-cloud"
+
+	projects, err := commands.NewCloudProjectsCommand(m.apiClient).Projects()
+	if err != nil {
+		return []list.Item{
+			NewListItem("Error loading cloud projects", common.TypeTreeLastItem,
+				WithDesc(err.Error()),
+				WithIndent(1)),
+		}
+	}
+
+	overviewType := common.TypeTreeItem
+	if len(projects) == 0 {
+		overviewType = common.TypeTreeLastItem
+	}
+	items := []list.Item{
+		NewListItem("Overview", overviewType,
+			WithDesc("All Public Cloud projects"),
+			WithIndent(1),
+			WithKey(key+"/overview"),
+			WithResource(handlers.ResourceCloudProjects, "")),
+	}
+
+	for _, project := range projects {
+		projectKey := key + "/" + project.ProjectID
+		expanded := isExpanded(currentItems, projectKey)
+		items = append(items,
+			NewListItem(project.GetDisplayName(), common.TypeHeader,
+				WithDesc(project.ProjectID),
+				WithIndent(1),
+				WithKey(projectKey),
+				WithExpanded(expanded),
+				WithResource(handlers.ResourceCloudProject, project.ProjectID)))
+
+		if expanded {
+			items = append(items, m.cloudProjectItems(currentItems, projectKey, project.ProjectID)...)
+		}
+	}
+
+	return items
+}
+
+// cloudProjectItems builds the overview and resource sections of a project
+func (m *Model) cloudProjectItems(currentItems []list.Item, projectKey, projectID string) []list.Item {
+	items := []list.Item{
+		NewListItem("Overview", common.TypeTreeItem,
+			WithDesc("Project details and resource counts"),
+			WithIndent(2),
+			WithKey(projectKey+"/overview"),
+			WithResource(handlers.ResourceCloudProject, projectID)),
+		NewListItem("Usage & costs", common.TypeTreeItem,
+			WithDesc("Current month and forecast"),
+			WithIndent(2),
+			WithKey(projectKey+"/usage"),
+			WithResource(handlers.ResourceCloudUsage, projectID)),
+		NewListItem("Quota & regions", common.TypeTreeItem,
+			WithDesc("Quota usage, regions and flavors"),
+			WithIndent(2),
+			WithKey(projectKey+"/quota"),
+			WithResource(handlers.ResourceCloudQuota, projectID)),
+	}
+
+	for _, section := range cloudSections {
+		sectionKey := projectKey + "/" + section.name
+		expanded := isExpanded(currentItems, sectionKey)
+		items = append(items,
+			NewListItem(section.title, common.TypeHeader,
+				WithDesc(section.title),
+				WithIndent(2),
+				WithKey(sectionKey),
+				WithExpanded(expanded)))
+
+		if !expanded {
+			continue
+		}
+
+		entries, err := section.load(m.apiClient, projectID)
+		if err != nil {
+			items = append(items,
+				NewListItem("Error loading "+strings.ToLower(section.title), common.TypeTreeLastItem,
+					WithDesc(err.Error()),
+					WithIndent(3)))
+			continue
+		}
+		if len(entries) == 0 {
+			items = append(items,
+				NewListItem("None", common.TypeTreeLastItem,
+					WithDesc("No "+strings.ToLower(section.title)),
+					WithIndent(3)))
+			continue
+		}
+
+		sort.Slice(entries, func(i, j int) bool { return entries[i].title < entries[j].title })
+		for i, entry := range entries {
+			items = append(items,
+				NewListItem(entry.title, treeItemType(i, len(entries)),
+					WithDesc(entry.desc),
+					WithIndent(3),
+					WithKey(sectionKey+"/"+entry.id),
+					WithResource(section.kind, handlers.ProjectResourceID(projectID, entry.id))))
+		}
+	}
+
+	return items
+}
+
+// loadCloudInstances lists instances with their flavor, region, status
+// and public addresses
+func loadCloudInstances(client *api.Client, projectID string) ([]cloudEntry, error) {
+	instances, err := client.ListCloudInstances(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	flavors := make(map[string]string)
+	if list, err := client.ListCloudFlavors(projectID); err != nil {
+		logger.Log.Error("Failed to list flavors", "project", projectID, "error", err)
+	} else {
+		for _, flavor := range list {
+			flavors[flavor.ID] = flavor.Name
+		}
+	}
+
+	entries := make([]cloudEntry, len(instances))
+	for i, instance := range instances {
+		entries[i] = cloudEntry{
+			id:    instance.ID,
+			title: instance.Name,
+			desc:  commands.CloudInstanceSummary(instance, flavors[instance.FlavorID]),
+		}
+	}
+	return entries, nil
+}
+
+// loadCloudVolumes lists volumes with their size, region and status
+func loadCloudVolumes(client *api.Client, projectID string) ([]cloudEntry, error) {
+	volumes, err := client.ListCloudVolumes(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]cloudEntry, len(volumes))
+	for i, volume := range volumes {
+		entries[i] = cloudEntry{
+			id:    volume.ID,
+			title: volume.Name,
+			desc:  fmt.Sprintf("%d GB · %s · %s", volume.Size, volume.Region, volume.Status),
+		}
+	}
+	return entries, nil
+}
+
+// loadCloudSnapshots lists snapshots with their size, region and status
+func loadCloudSnapshots(client *api.Client, projectID string) ([]cloudEntry, error) {
+	snapshots, err := client.ListCloudSnapshots(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]cloudEntry, len(snapshots))
+	for i, snapshot := range snapshots {
+		entries[i] = cloudEntry{
+			id:    snapshot.ID,
+			title: snapshot.Name,
+			desc:  fmt.Sprintf("%.1f GB · %s · %s", snapshot.Size, snapshot.Region, snapshot.Status),
+		}
+	}
+	return entries, nil
+}
+
+// loadCloudNetworks lists private networks with their VLAN and status
+func loadCloudNetworks(client *api.Client, projectID string) ([]cloudEntry, error) {
+	networks, err := client.ListCloudNetworks(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]cloudEntry, len(networks))
+	for i, network := range networks {
+		entries[i] = cloudEntry{
+			id:    network.ID,
+			title: network.Name,
+			desc:  fmt.Sprintf("VLAN %d · %s", network.VlanID, network.Status),
+		}
+	}
+	return entries, nil
+}
+
+// loadCloudSSHKeys lists SSH keys with their fingerprint
+func loadCloudSSHKeys(client *api.Client, projectID string) ([]cloudEntry, error) {
+	keys, err := client.ListCloudSSHKeys(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]cloudEntry, len(keys))
+	for i, key := range keys {
+		entries[i] = cloudEntry{id: key.ID, title: key.Name, desc: key.Fingerprint}
+	}
+	return entries, nil
+}
+
+// loadKubeClusters lists Managed Kubernetes clusters with their version,
+// region, status and node counts
+func loadKubeClusters(client *api.Client, projectID string) ([]cloudEntry, error) {
+	ids, err := client.ListKubeClusters(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]cloudEntry, 0, len(ids))
+	for _, id := range ids {
+		cluster, err := client.GetKubeCluster(projectID, id)
+		if err != nil {
+			return nil, err
+		}
+		pools, err := client.ListKubeNodePools(projectID, id)
+		if err != nil {
+			logger.Log.Error("Failed to list node pools", "cluster", id, "error", err)
+		}
+		entries = append(entries, cloudEntry{
+			id:    id,
+			title: cluster.Name,
+			desc:  commands.KubeClusterSummary(cluster, pools),
+		})
+	}
+	return entries, nil
+}
+
+// loadStorageContainers lists the S3 containers of every project region
+// with their object counts and sizes
+func loadStorageContainers(client *api.Client, projectID string) ([]cloudEntry, error) {
+	regions, err := client.ListCloudRegions(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []cloudEntry
+	for _, region := range regions {
+		containers, err := client.ListStorageContainers(projectID, region)
+		if err != nil {
+			logger.Log.Error("Failed to list containers", "region", region, "error", err)
+			continue
+		}
+		for _, container := range containers {
+			entries = append(entries, cloudEntry{
+				id:    commands.ContainerID(container.Region, container.Name),
+				title: container.Name,
+				desc:  commands.ContainerSummary(container),
+			})
+		}
+	}
+	return entries, nil
+}
+
+// loadCloudUsers lists project users with their status and roles
+func loadCloudUsers(client *api.Client, projectID string) ([]cloudEntry, error) {
+	users, err := client.ListCloudUsers(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]cloudEntry, len(users))
+	for i, user := range users {
+		entries[i] = cloudEntry{
+			id:    strconv.Itoa(user.ID),
+			title: user.Username,
+			desc:  commands.CloudUserSummary(user),
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,126 @@
+// internal/ui/types/menu_domain.go
+package types
+
+import (
+	"strconv"
+
+	"ovh-terminal/internal/api"
+	"ovh-terminal/internal/commands"
+	"ovh-terminal/internal/ui/common"
+	"ovh-terminal/internal/ui/handlers"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// domainMenuItems builds the Domain names section, with each domain
+// expanding to its settings and web redirections
+func (m *Model) domainMenuItems(currentItems []list.Item) []list.Item {
+	const key = "web/domain"
+
+	expanded := isExpanded(currentItems, key)
+	items := []list.Item{
+		NewListItem("Domain names", common.TypeHeader,
+			WithDesc("View and manage domain names"),
+			WithIndent(1),
+			WithKey(key),
+			WithExpanded(expanded)),
+	}
+	if !expanded {
+		return items
+	}
+
+	domains, err := commands.NewDomainsCommand(m.apiClient).Domains()
+	if err != nil {
+		return append(items,
+			NewListItem("Error loading domain names", common.TypeTreeLastItem,
+				WithDesc(err.Error()),
+				WithIndent(2)))
+	}
+
+	overviewType := common.TypeTreeItem
+	if len(domains) == 0 {
+		overviewType = common.TypeTreeLastItem
+	}
+	items = append(items,
+		NewListItem("Overview", overviewType,
+			WithDesc("All domain names"),
+			WithIndent(2),
+			WithKey(key+"/overview"),
+			WithResource(handlers.ResourceDomains, "")))
+
+	for _, domain := range domains {
+		domainKey := key + "/" + domain.Domain
+		expanded := isExpanded(currentItems, domainKey)
+		items = append(items,
+			NewListItem(domain.Domain, common.TypeHeader,
+				WithDesc(commands.DomainSummary(domain)),
+				WithIndent(2),
+				WithKey(domainKey),
+				WithExpanded(expanded),
+				WithResource(handlers.ResourceDomain, domain.Domain)))
+
+		if expanded {
+			items = append(items, m.domainItems(currentItems, domainKey, domain)...)
+		}
+	}
+
+	return items
+}
+
+// domainItems builds the settings and web redirections of a domain. Web
+// redirections need the zone, so they are only listed with OVHcloud DNS.
+func (m *Model) domainItems(currentItems []list.Item, domainKey string, domain *api.DomainInfo) []list.Item {
+	hosted := domain.NameServerType != api.NameServerExternal
+	settingsType := common.TypeTreeItem
+	if !hosted {
+		settingsType = common.TypeTreeLastItem
+	}
+	items := []list.Item{
+		NewListItem("Settings", settingsType,
+			WithDesc("Nameservers, DNSSEC and transfer lock"),
+			WithIndent(3),
+			WithKey(domainKey+"/settings"),
+			WithResource(handlers.ResourceDomain, domain.Domain)),
+	}
+	if !hosted {
+		return items
+	}
+
+	sectionKey := domainKey + "/redirections"
+	expanded := isExpanded(currentItems, sectionKey)
+	items = append(items,
+		NewListItem("Web redirections", common.TypeHeader,
+			WithDesc("HTTP redirections of the zone"),
+			WithIndent(3),
+			WithKey(sectionKey),
+			WithExpanded(expanded)))
+	if !expanded {
+		return items
+	}
+
+	redirections, err := commands.LoadWebRedirections(m.apiClient, domain.Domain)
+	if err != nil {
+		return append(items,
+			NewListItem("Error loading web redirections", common.TypeTreeLastItem,
+				WithDesc(err.Error()),
+				WithIndent(4)))
+	}
+	if len(redirections) == 0 {
+		return append(items,
+			NewListItem("None", common.TypeTreeLastItem,
+				WithDesc("No web redirections"),
+				WithIndent(4)))
+	}
+
+	for i, redirection := range redirections {
+		id := handlers.WebRedirectionID(domain.Domain, redirection.ID)
+		items = append(items,
+			NewListItem(redirection.Host(), treeItemType(i, len(redirections)),
+				WithDesc(commands.WebRedirectionSummary(redirection)),
+				WithIndent(4),
+				WithKey(sectionKey+"/"+strconv.FormatInt(redirection.ID, 10)),
+				WithResource(handlers.ResourceWebRedirection, id)))
+	}
+
+	return items
+}
@@ -0,0 +1,251 @@
+// internal/ui/types/menu_email.go
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"ovh-terminal/internal/api"
+	"ovh-terminal/internal/commands"
+	"ovh-terminal/internal/ui/common"
+	"ovh-terminal/internal/ui/handlers"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// emailEntry is an email domain resource shown as a menu item
+type emailEntry struct {
+	id    string
+	title string
+	desc  string
+}
+
+// emailSection is a list of email domain resources of one kind
+type emailSection struct {
+	name  string
+	title string
+	kind  string
+	load  func(client *api.Client, domain string) ([]emailEntry, error)
+}
+
+// emailSections are the resource lists shown below each email domain
+var emailSections = []emailSection{
+	{
+		name:  "accounts",
+		title: "Mailboxes",
+		kind:  handlers.ResourceEmailAccount,
+		load:  loadEmailAccounts,
+	},
+	{
+		name:  "redirections",
+		title: "Redirections",
+		kind:  handlers.ResourceEmailRedirection,
+		load:  loadEmailRedirections,
+	},
+	{
+		name:  "mailinglists",
+		title: "Mailing lists",
+		kind:  handlers.ResourceEmailMailingList,
+		load:  loadEmailMailingLists,
+	},
+	{
+		name:  "responders",
+		title: "Responders",
+		kind:  handlers.ResourceEmailResponder,
+		load:  loadEmailResponders,
+	},
+}
+
+// emailMenuItems builds the Emails section, with each domain expanding to
+// its overview and resource lists
+func (m *Model) emailMenuItems(currentItems []list.Item) []list.Item {
+	const key = "web/email"
+
+	expanded := isExpanded(currentItems, key)
+	items := []list.Item{
+		NewListItem("Emails", common.TypeHeader,
+			WithDesc("Email domains and mailboxes"),
+			WithIndent(1),
+			WithKey(key),
+			WithExpanded(expanded)),
+	}
+	if !expanded {
+		return items
+	}
+
+	domains, err := commands.NewEmailDomainsCommand(m.apiClient).Domains()
+	if err != nil {
+		return append(items,
+			NewListItem("Error loading email domains", common.TypeTreeLastItem,
+				WithDesc(err.Error()),
+				WithIndent(2)))
+	}
+
+	overviewType := common.TypeTreeItem
+	if len(domains) == 0 {
+		overviewType = common.TypeTreeLastItem
+	}
+	items = append(items,
+		NewListItem("Overview", overviewType,
+			WithDesc("All email domains"),
+			WithIndent(2),
+			WithKey(key+"/overview"),
+			WithResource(handlers.ResourceEmailDomains, "")))
+
+	for _, domain := range domains {
+		domainKey := key + "/" + domain.Domain
+		expanded := isExpanded(currentItems, domainKey)
+		items = append(items,
+			NewListItem(domain.Domain, common.TypeHeader,
+				WithDesc(fmt.Sprintf("%s · %s", domain.Offer, domain.Status)),
+				WithIndent(2),
+				WithKey(domainKey),
+				WithExpanded(expanded),
+				WithResource(handlers.ResourceEmailDomain, domain.Domain)))
+
+		if expanded {
+			items = append(items, m.emailItems(currentItems, domainKey, domain.Domain)...)
+		}
+	}
+
+	return items
+}
+
+// emailItems builds the overview and resource sections of an email domain
+func (m *Model) emailItems(currentItems []list.Item, domainKey, domain string) []list.Item {
+	items := []list.Item{
+		NewListItem("Overview", common.TypeTreeItem,
+			WithDesc("Offer and resource counts"),
+			WithIndent(3),
+			WithKey(domainKey+"/overview"),
+			WithResource(handlers.ResourceEmailDomain, domain)),
+	}
+
+	for _, section := range emailSections {
+		sectionKey := domainKey + "/" + section.name
+		expanded := isExpanded(currentItems, sectionKey)
+		items = append(items,
+			NewListItem(section.title, common.TypeHeader,
+				WithDesc(section.title),
+				WithIndent(3),
+				WithKey(sectionKey),
+				WithExpanded(expanded)))
+
+		if !expanded {
+			continue
+		}
+
+		entries, err := section.load(m.apiClient, domain)
+		if err != nil {
+			items = append(items,
+				NewListItem("Error loading "+strings.ToLower(section.title), common.TypeTreeLastItem,
+					WithDesc(err.Error()),
+					WithIndent(4)))
+			continue
+		}
+		if len(entries) == 0 {
+			items = append(items,
+				NewListItem("None", common.TypeTreeLastItem,
+					WithDesc("No "+strings.ToLower(section.title)),
+					WithIndent(4)))
+			continue
+		}
+
+		for i, entry := range entries {
+			items = append(items,
+				NewListItem(entry.title, treeItemType(i, len(entries)),
+					WithDesc(entry.desc),
+					WithIndent(4),
+					WithKey(sectionKey+"/"+entry.id),
+					WithResource(section.kind, handlers.EmailResourceID(domain, entry.id))))
+		}
+	}
+
+	return items
+}
+
+// loadEmailAccounts lists mailboxes with their quota usage. Usage is
+// optional, a mailbox being created has none yet.
+func loadEmailAccounts(client *api.Client, domain string) ([]emailEntry, error) {
+	names, err := client.ListEmailAccounts(domain)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]emailEntry, 0, len(names))
+	for _, name := range names {
+		account, err := client.GetEmailAccount(domain, name)
+		if err != nil {
+			return nil, err
+		}
+		usage, _ := client.GetEmailAccountUsage(domain, name)
+		entries = append(entries, emailEntry{
+			id:    name,
+			title: account.Email,
+			desc:  commands.EmailAccountSummary(account, usage),
+		})
+	}
+	return entries, nil
+}
+
+// loadEmailRedirections lists redirections by source address
+func loadEmailRedirections(client *api.Client, domain string) ([]emailEntry, error) {
+	ids, err := client.ListEmailRedirections(domain)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]emailEntry, 0, len(ids))
+	for _, id := range ids {
+		redirection, err := client.GetEmailRedirection(domain, id)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, emailEntry{
+			id:    id,
+			title: redirection.From,
+			desc:  "→ " + redirection.To,
+		})
+	}
+	return entries, nil
+}
+
+// loadEmailMailingLists lists mailing lists with their subscriber count
+func loadEmailMailingLists(client *api.Client, domain string) ([]emailEntry, error) {
+	names, err := client.ListEmailMailingLists(domain)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]emailEntry, 0, len(names))
+	for _, name := range names {
+		mailingList, err := client.GetEmailMailingList(domain, name)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, emailEntry{
+			id:    name,
+			title: name + "@" + domain,
+			desc:  commands.EmailMailingListSummary(mailingList),
+		})
+	}
+	return entries, nil
+}
+
+// loadEmailResponders lists automatic replies by account
+func loadEmailResponders(client *api.Client, domain string) ([]emailEntry, error) {
+	accounts, err := client.ListEmailResponders(domain)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]emailEntry, 0, len(accounts))
+	for _, account := range accounts {
+		responder, err := client.GetEmailResponder(domain, account)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, emailEntry{
+			id:    account,
+			title: account + "@" + domain,
+			desc:  commands.EmailResponderSummary(responder),
+		})
+	}
+	return entries, nil
+}
@@ -0,0 +1,266 @@
+// internal/ui/types/menu_hosting.go
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"ovh-terminal/internal/api"
+	"ovh-terminal/internal/commands"
+	"ovh-terminal/internal/ui/common"
+	"ovh-terminal/internal/ui/handlers"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// hostingEntry is a hosting resource shown as a menu item
+type hostingEntry struct {
+	id    string
+	title string
+	desc  string
+}
+
+// hostingSection is a list of hosting resources of one kind. When list is
+// set, the section header shows all resources of the kind.
+type hostingSection struct {
+	name  string
+	title string
+	kind  string
+	list  string
+	load  func(client *api.Client, service string) ([]hostingEntry, error)
+}
+
+// hostingSections are the resource lists shown below each hosting plan
+var hostingSections = []hostingSection{
+	{
+		name:  "domains",
+		title: "Domains",
+		kind:  handlers.ResourceHostingDomain,
+		load:  loadHostingDomains,
+	},
+	{
+		name:  "users",
+		title: "FTP/SSH users",
+		kind:  handlers.ResourceHostingUser,
+		load:  loadHostingUsers,
+	},
+	{
+		name:  "databases",
+		title: "Databases",
+		kind:  handlers.ResourceHostingDatabase,
+		list:  handlers.ResourceHostingDatabases,
+		load:  loadHostingDatabases,
+	},
+	{
+		name:  "cron",
+		title: "Cron jobs",
+		kind:  handlers.ResourceHostingCron,
+		load:  loadHostingCrons,
+	},
+}
+
+// hostingMenuItems builds the Hosting plans section, with each plan
+// expanding to its overview, certificate and resource lists
+func (m *Model) hostingMenuItems(currentItems []list.Item) []list.Item {
+	const key = "web/hosting"
+
+	expanded := isExpanded(currentItems, key)
+	items := []list.Item{
+		NewListItem("Hosting plans", common.TypeHeader,
+			WithDesc("Web hosting plans"),
+			WithIndent(1),
+			WithKey(key),
+			WithExpanded(expanded)),
+	}
+	if !expanded {
+		return items
+	}
+
+	hostings, err := commands.NewHostingsCommand(m.apiClient).Hostings()
+	if err != nil {
+		return append(items,
+			NewListItem("Error loading hosting plans", common.TypeTreeLastItem,
+				WithDesc(err.Error()),
+				WithIndent(2)))
+	}
+
+	overviewType := common.TypeTreeItem
+	if len(hostings) == 0 {
+		overviewType = common.TypeTreeLastItem
+	}
+	items = append(items,
+		NewListItem("Overview", overviewType,
+			WithDesc("All hosting plans"),
+			WithIndent(2),
+			WithKey(key+"/overview"),
+			WithResource(handlers.ResourceHostings, "")))
+
+	for _, hosting := range hostings {
+		hostingKey := key + "/" + hosting.ServiceName
+		expanded := isExpanded(currentItems, hostingKey)
+		items = append(items,
+			NewListItem(hosting.GetDisplayName(), common.TypeHeader,
+				WithDesc(commands.HostingSummary(hosting)),
+				WithIndent(2),
+				WithKey(hostingKey),
+				WithExpanded(expanded),
+				WithResource(handlers.ResourceHosting, hosting.ServiceName)))
+
+		if expanded {
+			items = append(items, m.hostingItems(currentItems, hostingKey, hosting.ServiceName)...)
+		}
+	}
+
+	return items
+}
+
+// hostingItems builds the overview, certificate and resource sections of a
+// hosting plan
+func (m *Model) hostingItems(currentItems []list.Item, hostingKey, service string) []list.Item {
+	items := []list.Item{
+		NewListItem("Overview", common.TypeTreeItem,
+			WithDesc("Plan details and resource counts"),
+			WithIndent(3),
+			WithKey(hostingKey+"/overview"),
+			WithResource(handlers.ResourceHosting, service)),
+		NewListItem("SSL certificate", common.TypeTreeItem,
+			WithDesc("Certificate status"),
+			WithIndent(3),
+			WithKey(hostingKey+"/ssl"),
+			WithResource(handlers.ResourceHostingSSL, handlers.HostingResourceID(service, ""))),
+	}
+
+	for _, section := range hostingSections {
+		sectionKey := hostingKey + "/" + section.name
+		expanded := isExpanded(currentItems, sectionKey)
+		opts := []MenuItemOption{
+			WithDesc(section.title),
+			WithIndent(3),
+			WithKey(sectionKey),
+			WithExpanded(expanded),
+		}
+		if section.list != "" {
+			opts = append(opts, WithResource(section.list, service))
+		}
+		items = append(items, NewListItem(section.title, common.TypeHeader, opts...))
+
+		if !expanded {
+			continue
+		}
+
+		entries, err := section.load(m.apiClient, service)
+		if err != nil {
+			items = append(items,
+				NewListItem("Error loading "+strings.ToLower(section.title), common.TypeTreeLastItem,
+					WithDesc(err.Error()),
+					WithIndent(4)))
+			continue
+		}
+		if len(entries) == 0 {
+			items = append(items,
+				NewListItem("None", common.TypeTreeLastItem,
+					WithDesc("No "+strings.ToLower(section.title)),
+					WithIndent(4)))
+			continue
+		}
+
+		for i, entry := range entries {
+			items = append(items,
+				NewListItem(entry.title, treeItemType(i, len(entries)),
+					WithDesc(entry.desc),
+					WithIndent(4),
+					WithKey(sectionKey+"/"+entry.id),
+					WithResource(section.kind, handlers.HostingResourceID(service, entry.id))))
+		}
+	}
+
+	return items
+}
+
+// loadHostingDomains lists attached domains with their folder
+func loadHostingDomains(client *api.Client, service string) ([]hostingEntry, error) {
+	names, err := client.ListHostingDomains(service)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]hostingEntry, 0, len(names))
+	for _, name := range names {
+		domain, err := client.GetHostingDomain(service, name)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, hostingEntry{
+			id:    name,
+			title: name,
+			desc:  commands.HostingDomainSummary(domain),
+		})
+	}
+	return entries, nil
+}
+
+// loadHostingUsers lists FTP/SSH users with their home and SSH access
+func loadHostingUsers(client *api.Client, service string) ([]hostingEntry, error) {
+	logins, err := client.ListHostingUsers(service)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]hostingEntry, 0, len(logins))
+	for _, login := range logins {
+		user, err := client.GetHostingUser(service, login)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, hostingEntry{
+			id:    login,
+			title: login,
+			desc:  commands.HostingUserSummary(user),
+		})
+	}
+	return entries, nil
+}
+
+// loadHostingDatabases lists databases with their engine and size
+func loadHostingDatabases(client *api.Client, service string) ([]hostingEntry, error) {
+	names, err := client.ListHostingDatabases(service)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]hostingEntry, 0, len(names))
+	for _, name := range names {
+		database, err := client.GetHostingDatabase(service, name)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, hostingEntry{
+			id:    name,
+			title: name,
+			desc:  commands.HostingDatabaseSummary(database),
+		})
+	}
+	return entries, nil
+}
+
+// loadHostingCrons lists scheduled tasks by description
+func loadHostingCrons(client *api.Client, service string) ([]hostingEntry, error) {
+	ids, err := client.ListHostingCrons(service)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]hostingEntry, 0, len(ids))
+	for _, id := range ids {
+		cron, err := client.GetHostingCron(service, id)
+		if err != nil {
+			return nil, err
+		}
+		title := cron.Description
+		if title == "" {
+			title = fmt.Sprintf("Cron job %d", cron.ID)
+		}
+		entries = append(entries, hostingEntry{
+			id:    fmt.Sprint(cron.ID),
+			title: title,
+			desc:  commands.HostingCronSummary(cron),
+		})
+	}
+	return entries, nil
+}
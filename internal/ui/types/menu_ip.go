@@ -0,0 +1,101 @@
+// internal/ui/types/menu_ip.go
+package types
+
+import (
+	"ovh-terminal/internal/commands"
+	"ovh-terminal/internal/ui/common"
+	"ovh-terminal/internal/ui/handlers"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// findItem returns the current menu item with key, or nil
+func findItem(items []list.Item, key string) *ListItem {
+	for _, item := range items {
+		if listItem, ok := item.(*ListItem); ok && listItem.GetKey() == key {
+			return listItem
+		}
+	}
+	return nil
+}
+
+// isExpanded reports whether the menu item with key is expanded
+func isExpanded(items []list.Item, key string) bool {
+	item := findItem(items, key)
+	return item != nil && item.IsExpanded()
+}
+
+// treeItemType returns the tree item type of entry i out of count
+func treeItemType(i, count int) common.ItemType {
+	if i == count-1 {
+		return common.TypeTreeLastItem
+	}
+	return common.TypeTreeItem
+}
+
+// ipMenuItems builds the IP addresses section, with blocks grouped by
+// type and sorted by the service they are routed to
+func (m *Model) ipMenuItems(currentItems []list.Item) []list.Item {
+	const key = "bare-metal/ip"
+
+	expanded := isExpanded(currentItems, key)
+	items := []list.Item{
+		NewListItem("IP addresses", common.TypeHeader,
+			WithDesc("IP blocks and reverse DNS"),
+			WithIndent(1),
+			WithKey(key),
+			WithExpanded(expanded)),
+	}
+	if !expanded {
+		return items
+	}
+
+	groups, err := commands.NewIPOverviewCommand(m.apiClient).Groups()
+	if err != nil {
+		return append(items,
+			NewListItem("Error loading IP addresses", common.TypeTreeLastItem,
+				WithDesc(err.Error()),
+				WithIndent(2)))
+	}
+
+	overviewType := common.TypeTreeItem
+	if len(groups) == 0 {
+		overviewType = common.TypeTreeLastItem
+	}
+	items = append(items,
+		NewListItem("Overview", overviewType,
+			WithDesc("All IP blocks by type and service"),
+			WithIndent(2),
+			WithKey(key+"/overview"),
+			WithResource(handlers.ResourceIPOverview, "")))
+
+	for _, group := range groups {
+		groupKey := key + "/" + string(group.Type)
+		groupExpanded := isExpanded(currentItems, groupKey)
+		items = append(items,
+			NewListItem(group.Title, common.TypeHeader,
+				WithDesc(group.Title),
+				WithIndent(2),
+				WithKey(groupKey),
+				WithExpanded(groupExpanded)))
+
+		if !groupExpanded {
+			continue
+		}
+
+		for i, block := range group.Blocks {
+			desc := block.GetRoutedService()
+			if desc == "" {
+				desc = "Not routed"
+			}
+			items = append(items,
+				NewListItem(block.IP, treeItemType(i, len(group.Blocks)),
+					WithDesc(desc),
+					WithIndent(3),
+					WithKey(groupKey+"/"+block.IP),
+					WithResource(handlers.ResourceIP, block.IP)))
+		}
+	}
+
+	return items
+}
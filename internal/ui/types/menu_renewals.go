@@ -0,0 +1,69 @@
+// internal/ui/types/menu_renewals.go
+package types
+
+import (
+	"strings"
+	"time"
+
+	"ovh-terminal/internal/commands"
+	"ovh-terminal/internal/ui/common"
+	"ovh-terminal/internal/ui/handlers"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// renewalMenuItems builds the Renewals section: the dashboard in both
+// orders, then every service, soonest expiration first
+func (m *Model) renewalMenuItems(currentItems []list.Item) []list.Item {
+	const key = "account/renewals"
+
+	expanded := isExpanded(currentItems, key)
+	items := []list.Item{
+		NewListItem("Renewals", common.TypeHeader,
+			WithDesc("Expiration and renewal of services"),
+			WithIndent(1),
+			WithKey(key),
+			WithExpanded(expanded)),
+	}
+	if !expanded {
+		return items
+	}
+
+	renewals, unavailable := commands.NewRenewalsCommand(m.apiClient, commands.RenewalsByDate).Renewals()
+
+	items = append(items,
+		NewListItem("By expiration date", common.TypeTreeItem,
+			WithDesc("All services, soonest expiration first"),
+			WithIndent(2),
+			WithKey(key+"/date"),
+			WithResource(handlers.ResourceRenewals, commands.RenewalsByDate)),
+		NewListItem("By service type", common.TypeTreeItem,
+			WithDesc("Servers, VPS, domains and hosting plans"),
+			WithIndent(2),
+			WithKey(key+"/type"),
+			WithResource(handlers.ResourceRenewals, commands.RenewalsByType)))
+	if len(unavailable) > 0 {
+		items = append(items,
+			NewListItem("Some services could not be loaded", common.TypeTreeItem,
+				WithDesc(strings.Join(unavailable, ", ")),
+				WithIndent(2)))
+	}
+
+	if len(renewals) == 0 {
+		return append(items,
+			NewListItem("None", common.TypeTreeLastItem,
+				WithDesc("No services with a subscription"),
+				WithIndent(2)))
+	}
+
+	now := time.Now()
+	for i, renewal := range renewals {
+		items = append(items,
+			NewListItem(renewal.Name, treeItemType(i, len(renewals)),
+				WithDesc(renewal.Summary(now)),
+				WithIndent(2),
+				WithKey(key+"/"+renewal.ID()),
+				WithResource(handlers.ResourceRenewal, renewal.ID())))
+	}
+	return items
+}
@@ -0,0 +1,50 @@
+// internal/ui/types/menu_support.go
+package types
+
+import (
+	"strconv"
+
+	"ovh-terminal/internal/commands"
+	"ovh-terminal/internal/ui/common"
+	"ovh-terminal/internal/ui/handlers"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// supportMenuItems builds the Support section, with open tickets first
+// and the most recently updated first
+func (m *Model) supportMenuItems() []list.Item {
+	const key = "support"
+
+	tickets, err := commands.NewSupportTicketsCommand(m.apiClient).Tickets()
+	if err != nil {
+		return []list.Item{
+			NewListItem("Error loading support tickets", common.TypeTreeLastItem,
+				WithDesc(err.Error()),
+				WithIndent(1)),
+		}
+	}
+
+	overviewType := common.TypeTreeItem
+	if len(tickets) == 0 {
+		overviewType = common.TypeTreeLastItem
+	}
+	items := []list.Item{
+		NewListItem("Overview", overviewType,
+			WithDesc("All support tickets"),
+			WithIndent(1),
+			WithKey(key+"/overview"),
+			WithResource(handlers.ResourceSupportTickets, "")),
+	}
+
+	for i, ticket := range tickets {
+		id := strconv.FormatInt(ticket.TicketID, 10)
+		items = append(items,
+			NewListItem(commands.TicketTitle(ticket), treeItemType(i, len(tickets)),
+				WithDesc(commands.TicketSummary(ticket)),
+				WithIndent(1),
+				WithKey(key+"/"+id),
+				WithResource(handlers.ResourceSupportTicket, id)))
+	}
+	return items
+}
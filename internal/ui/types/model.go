@@ -38,6 +38,9 @@ type Model struct {
 	Height     int
 
 	ShowHelp bool
+
+	// Active input prompt, nil when none
+	prompt *common.Prompt
 }
 
 // Ensure Model implements common.UIModel
@@ -121,30 +124,19 @@ func (m *Model) SetViewport(vp *viewport.Model) {
 	m.Viewport = *vp
 }
 
+func (m *Model) GetPrompt() *common.Prompt {
+	return m.prompt
+}
+
+func (m *Model) SetPrompt(prompt *common.Prompt) {
+	m.prompt = prompt
+}
+
 // UpdateMenuItems refreshes all menu items while preserving states
 func (m *Model) UpdateMenuItems() {
 	var updatedItems []list.Item
 	currentItems := m.List.Items()
 
-	// Helper to add child items for a header
-	addChildItems := func(items []*ListItem) {
-		for i, item := range items {
-			itemType := common.TypeTreeItem
-			if i == len(items)-1 {
-				itemType = common.TypeTreeLastItem
-			}
-
-			newItem := NewListItem(
-				item.Title(),
-				itemType,
-				WithDesc(item.Description()),
-				WithIndent(item.GetIndent()),
-				WithSelectable(item.IsSelectable()),
-			)
-			updatedItems = append(updatedItems, newItem)
-		}
-	}
-
 	// Build new list preserving expanded states
 	for _, item := range currentItems {
 		curr, ok := item.(*ListItem)
@@ -158,14 +150,15 @@ func (m *Model) UpdateMenuItems() {
 			if curr.GetType() == common.TypeHeader && curr.IsExpanded() {
 				switch curr.Title() {
 				case "Account Information":
-					addChildItems([]*ListItem{
+					updatedItems = append(updatedItems,
 						NewListItem("My information", common.TypeTreeItem,
 							WithDesc("View and manage my current information"),
 							WithIndent(1)),
-						NewListItem("API information", common.TypeTreeLastItem,
+						NewListItem("API information", common.TypeTreeItem,
 							WithDesc("Information about applications and credentials"),
-							WithIndent(1)),
-					})
+							WithIndent(1)))
+					updatedItems = append(updatedItems, m.invoiceMenuItems(currentItems)...)
+					updatedItems = append(updatedItems, m.renewalMenuItems(currentItems)...)
 
 				case "Bare Metal Cloud":
 					// Find current states
@@ -299,15 +292,19 @@ func (m *Model) UpdateMenuItems() {
 						}
 					}
 
+					// Add IP addresses grouped by type
+					updatedItems = append(updatedItems, m.ipMenuItems(currentItems)...)
+
+				case "Public Cloud":
+					updatedItems = append(updatedItems, m.cloudMenuItems(currentItems)...)
+
 				case "Web Cloud":
-					addChildItems([]*ListItem{
-						NewListItem("Domain names", common.TypeTreeItem,
-							WithDesc("View and manage domain names"),
-							WithIndent(1)),
-						NewListItem("Hosting plans", common.TypeTreeLastItem,
-							WithDesc(""),
-							WithIndent(1)),
-					})
+					updatedItems = append(updatedItems, m.domainMenuItems(currentItems)...)
+					updatedItems = append(updatedItems, m.hostingMenuItems(currentItems)...)
+					updatedItems = append(updatedItems, m.emailMenuItems(currentItems)...)
+
+				case "Support":
+					updatedItems = append(updatedItems, m.supportMenuItems()...)
 				}
 			}
 		}
@@ -331,6 +328,11 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		// An open prompt takes all key presses
+		if m.prompt != nil {
+			return m, handlers.HandlePromptKey(m, msg)
+		}
+
 		_, cmd := handlers.HandleKeyMsg(m, msg)
 		if cmd != nil {
 			cmds = append(cmds, cmd)
@@ -338,6 +340,19 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.WindowSizeMsg:
 		handlers.HandleWindowSizeMsg(m, msg)
+
+	case common.CommandProgressMsg:
+		return m, handlers.HandleCommandProgress(m, msg)
+
+	case common.CommandPromptMsg:
+		return m, handlers.HandleCommandPrompt(m, msg)
+
+	case common.CommandResultMsg:
+		handlers.HandleCommandResult(m, msg)
+		return m, nil
+
+	case common.EditorFinishedMsg:
+		return m, handlers.HandleEditorFinished(m, msg)
 	}
 
 	// Update active component
@@ -366,6 +381,13 @@ func (m *Model) View() string {
 	menuView := styles.MenuStyle.Render(m.List.View())
 	contentView := styles.ContentStyle.Render(m.Viewport.View())
 
+	// Choice prompts list their choices in the content pane
+	if m.prompt != nil && m.prompt.Spec.Kind == commands.PromptChoice {
+		choices := m.Viewport
+		choices.SetContent(m.prompt.ChoicesView(m.Viewport.Height - 2))
+		contentView = styles.ContentStyle.Render(choices.View())
+	}
+
 	// Combine menu and content horizontally
 	mainView := lipgloss.JoinHorizontal(
 		lipgloss.Top,
@@ -375,9 +397,11 @@ func (m *Model) View() string {
 
 	// Get status text based on current state
 	statusText := m.StatusMessage
-	if statusText == "" {
+	if m.prompt != nil {
+		statusText = m.prompt.View()
+	} else if statusText == "" {
 		if m.GetActivePane() == "menu" {
-			statusText = "↑/k up • ↓/j down • g/G top/bottom • ? help"
+			statusText = "↑/k up • ↓/j down • g/G top/bottom • a actions • ? help"
 		} else {
 			statusText = "↑/k up • ↓/j down • g/G top/bottom • Tab to menu"
 		}
@@ -1,12 +1,14 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
 
 	"ovh-terminal/internal/api"
+	"ovh-terminal/internal/commands"
 	"ovh-terminal/internal/config"
 	"ovh-terminal/internal/logger"
 	"ovh-terminal/internal/ui"
@@ -22,6 +24,8 @@ const (
 // AppConfig holds application configuration and components
 type AppConfig struct {
 	ConfigPath string
+	ReplayDir  string
+	RecordDir  string
 	Config     *config.Config
 	Logger     *logger.Logger
 	APIClient  *api.Client
@@ -47,8 +51,12 @@ func initLogger(cfg *config.Config) (*logger.Logger, error) {
 }
 
 // initAPIClient initializes the OVH API client
-func initAPIClient(cfg *config.AccountConfig, log *logger.Logger) (*api.Client, error) {
-	client, err := api.NewClient(cfg, log)
+func initAPIClient(
+	cfg *config.AccountConfig,
+	log *logger.Logger,
+	opts ...api.ClientOption,
+) (*api.Client, error) {
+	client, err := api.NewClient(cfg, log, opts...)
 	if err != nil {
 		log.Error("Failed to create API client", "error", err)
 		return nil, err
@@ -78,6 +86,7 @@ func printError(msg string, details ...string) {
 func printHelp(configPath string) {
 	fmt.Fprintln(os.Stderr, "\nTo set up OVH API access:")
 	fmt.Fprintf(os.Stderr, "1. Get your API credentials from https://api.ovh.com/createToken/\n")
+	fmt.Fprintln(os.Stderr, "   or create an IAM service account and set auth = \"oauth2\"")
 	fmt.Fprintf(os.Stderr, "2. Update %s with your credentials\n", configPath)
 	fmt.Fprintf(os.Stderr, "3. Ensure you have the following API rights:\n")
 	fmt.Fprintln(os.Stderr, "   • GET /me")
@@ -92,33 +101,60 @@ func setupConfig() (*AppConfig, error) {
 	app := &AppConfig{}
 
 	// Parse command line flags
+	flag.Usage = usage
 	flag.StringVar(&app.ConfigPath, "config", "config.toml", "path to config file")
+	flag.StringVar(&app.ReplayDir, "replay", "", "replay API responses from a cassette directory")
+	flag.StringVar(&app.RecordDir, "record", "", "record API responses to a cassette directory")
 	flag.Parse()
 
+	if app.ReplayDir != "" && app.RecordDir != "" {
+		return app, fmt.Errorf("-replay and -record cannot be used together")
+	}
+
 	// Load configuration
 	cfg, err := config.LoadConfig(app.ConfigPath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("configuration file not found: %s", app.ConfigPath)
+			return app, fmt.Errorf("configuration file not found: %s", app.ConfigPath)
 		}
-		return nil, fmt.Errorf("invalid configuration: %w", err)
+		return app, fmt.Errorf("invalid configuration: %w", err)
 	}
 	app.Config = cfg
+	commands.SetQuotaWarning(cfg.UI.QuotaWarning)
 
 	// Initialize logger
 	log, err := initLogger(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("logging setup failed: %w", err)
+		return app, fmt.Errorf("logging setup failed: %w", err)
 	}
 	app.Logger = log
 
 	// Initialize API client
+	var opts []api.ClientOption
+	switch {
+	case app.ReplayDir != "":
+		replayer, err := api.NewReplayer(app.ReplayDir)
+		if err != nil {
+			return app, fmt.Errorf("replay setup failed: %w", err)
+		}
+		log.Info("Replaying API responses", "dir", app.ReplayDir)
+		opts = append(opts, api.WithTransport(replayer))
+	case app.RecordDir != "":
+		log.Info("Recording API responses", "dir", app.RecordDir)
+		opts = append(opts, api.WithRecorder(app.RecordDir))
+	}
+
 	account := cfg.Accounts[cfg.General.DefaultAccount]
-	client, err := initAPIClient(&account, log)
+	client, err := initAPIClient(&account, log, opts...)
 	if err != nil {
-		printError(err.Error(), "API client setup failed")
+		details := []string{"API client setup failed"}
+		var apiErr *api.APIError
+		if errors.As(err, &apiErr) {
+			details = append(details, apiErr.UserError())
+		}
+		printError(err.Error(), details...)
 		printHelp(app.ConfigPath)
-		return nil, err
+		return app, err
 	}
 	app.APIClient = client
 
@@ -146,6 +182,16 @@ func main() {
 		return
 	}
 
+	// Run a command instead of the UI when one is given
+	if args := flag.Args(); len(args) > 0 {
+		if err := runSubcommand(app, args); err != nil {
+			app.Logger.Error("Command failed", "command", args[0], "error", err)
+			printError(err.Error())
+			exitCode = exitError
+		}
+		return
+	}
+
 	app.Logger.Info("Starting OVH Terminal Client")
 
 	// Initialize and run UI